@@ -14,7 +14,9 @@ import (
 	uiplugin "github.com/rhobs/observability-operator/pkg/apis/uiplugin/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	rsexport "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	appsv1 "k8s.io/api/apps/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"open-cluster-management.io/addon-framework/pkg/agent"
 	addonutils "open-cluster-management.io/addon-framework/pkg/utils"
@@ -52,14 +54,21 @@ func HealthProber(getter addonutils.AddOnDeploymentConfigGetter, logger logr.Log
 	probeFields = append(probeFields, getMetricsProbeFields()...)
 	probeFields = append(probeFields, getLogsProbeFields()...)
 	probeFields = append(probeFields, getTracesProbeFields()...)
+	probeFields = append(probeFields, getEventsProbeFields()...)
+	probeFields = append(probeFields, getProfilingProbeFields()...)
+	probeFields = append(probeFields, getNetworkFlowsProbeFields()...)
+	probeFields = append(probeFields, getRightSizingProbeFields()...)
 	probeFields = append(probeFields, getAnalyticsProbeFields()...)
+	probeFields = append(probeFields, getObsAPIProbeFields()...)
 	probeFields = append(probeFields, getTLSProfileProbeFields()...)
 	return &agent.HealthProber{
 		Type: agent.HealthProberTypeWork,
 		WorkProber: &agent.WorkHealthProber{
 			ProbeFields: probeFields,
 			HealthChecker: func(fields []agent.FieldResult, mc *v1.ManagedCluster, mcao *addonapiv1beta1.ManagedClusterAddOn) error {
-				if err := healthChecker(getter, fields, mc, mcao); err != nil {
+				err := healthChecker(getter, fields, mc, mcao)
+				recordAvailability(mc.Name, err)
+				if err != nil {
 					logger.V(1).Info("Health check failed for managed cluster", "clusterName", mc.Name, "error", err.Error())
 					return fmt.Errorf("healthChecker failed: %w", err)
 				}
@@ -182,6 +191,31 @@ func getMetricsProbeFields() []agent.ProbeField {
 	}
 }
 
+// getRightSizingProbeFields reports whether the VerticalPodAutoscaler CRD is
+// established, the right-sizing export waits for it to export VerticalPodAutoscalers.
+func getRightSizingProbeFields() []agent.ProbeField {
+	return []agent.ProbeField{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:    apiextensionsv1.GroupName,
+				Resource: crdResourceName,
+				Name:     rsexport.VPACRDName,
+			},
+			ProbeRules: []workv1.FeedbackRule{
+				{
+					Type: workv1.JSONPathsType,
+					JsonPaths: []workv1.JsonPath{
+						{
+							Name: addoncfg.IsEstablishedFeedbackName,
+							Path: addoncfg.IsEstablishedFeedbackPath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func getLogsProbeFields() []agent.ProbeField {
 	return []agent.ProbeField{
 		{
@@ -230,6 +264,77 @@ func getTracesProbeFields() []agent.ProbeField {
 	}
 }
 
+func getEventsProbeFields() []agent.ProbeField {
+	return []agent.ProbeField{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:     otelv1alpha1.GroupVersion.Group,
+				Resource:  addoncfg.OpenTelemetryCollectorsResource,
+				Name:      addoncfg.SpokeEventsCollectorName,
+				Namespace: addoncfg.SpokeOTELColNamespace,
+			},
+			ProbeRules: []workv1.FeedbackRule{
+				{
+					Type: workv1.JSONPathsType,
+					JsonPaths: []workv1.JsonPath{
+						{
+							Name: addoncfg.OtelColProbeKey,
+							Path: addoncfg.OtelColProbePath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getProfilingProbeFields() []agent.ProbeField {
+	return []agent.ProbeField{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:     appsv1.GroupName,
+				Resource:  addoncfg.DaemonSetsResource,
+				Name:      addoncfg.SpokeProfilingAgentName,
+				Namespace: addoncfg.SpokeProfilingAgentNamespace,
+			},
+			ProbeRules: []workv1.FeedbackRule{
+				{
+					Type: workv1.JSONPathsType,
+					JsonPaths: []workv1.JsonPath{
+						{
+							Name: addoncfg.ProfilingAgentProbeKey,
+							Path: addoncfg.ProfilingAgentProbePath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getNetworkFlowsProbeFields() []agent.ProbeField {
+	return []agent.ProbeField{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:    addoncfg.FlowCollectorsGroup,
+				Resource: addoncfg.FlowCollectorsResource,
+				Name:     addoncfg.SpokeFlowCollectorName,
+			},
+			ProbeRules: []workv1.FeedbackRule{
+				{
+					Type: workv1.JSONPathsType,
+					JsonPaths: []workv1.JsonPath{
+						{
+							Name: addoncfg.FlowCollectorProbeKey,
+							Path: addoncfg.FlowCollectorProbePath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func getAnalyticsProbeFields() []agent.ProbeField {
 	return []agent.ProbeField{
 		{
@@ -253,6 +358,30 @@ func getAnalyticsProbeFields() []agent.ProbeField {
 	}
 }
 
+func getObsAPIProbeFields() []agent.ProbeField {
+	return []agent.ProbeField{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:     appsv1.GroupName,
+				Resource:  addoncfg.DeploymentsResource,
+				Name:      addoncfg.HubObsAPIName,
+				Namespace: addoncfg.InstallNamespace,
+			},
+			ProbeRules: []workv1.FeedbackRule{
+				{
+					Type: workv1.JSONPathsType,
+					JsonPaths: []workv1.JsonPath{
+						{
+							Name: addoncfg.ObsAPIProbeKey,
+							Path: addoncfg.ObsAPIProbePath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func getTLSProfileProbeFields() []agent.ProbeField {
 	return []agent.ProbeField{
 		{
@@ -289,7 +418,8 @@ func ManifestConfigs() []workv1.ManifestConfigOption {
 	// prometheusagents and scrapeconfigs CRDs use ReadOnly: the Work Agent never creates or
 	// updates them (the endpoint operator owns them), but still reports isEstablished and
 	// timestamp feedback so the hub can compute the prometheus-operator restart annotation.
-	crdNames := []string{scrapeConfigCRDName, prometheusAgentCRDName}
+	// The verticalpodautoscalers CRD is ReadOnly too, its feedback gates the right-sizing export.
+	crdNames := []string{scrapeConfigCRDName, prometheusAgentCRDName, rsexport.VPACRDName}
 	manifestConfigs := make([]workv1.ManifestConfigOption, len(crdNames))
 	for i, crdName := range crdNames {
 		manifestConfigs[i] = workv1.ManifestConfigOption{
@@ -389,18 +519,34 @@ func healthChecker(getter addonutils.AddOnDeploymentConfigGetter, fields []agent
 		return fmt.Errorf("failed to build addon options: %w", err)
 	}
 
+	recordInfo(mc.Name, fields)
+
+	// Every component is checked so that its health gets reported, the first
+	// failure is returned.
 	isOpenShiftVendor := common.IsOpenShiftVendor(mc)
-	if err := checkMetrics(fields, opts, isOpenShiftVendor); err != nil {
-		return err
-	}
-	if err := checkLogging(fields, opts); err != nil {
-		return err
-	}
-	if err := checkTracing(fields, opts); err != nil {
-		return err
-	}
+	metricsErr := checkMetrics(fields, opts, isOpenShiftVendor)
+	recordComponentHealth(mc.Name, prometheusAgentComponent, opts.Platform.Metrics.CollectionEnabled || opts.UserWorkloads.Metrics.CollectionEnabled, metricsErr)
+	logsErr := checkLogging(fields, opts)
+	recordComponentHealth(mc.Name, clusterLogForwarderComponent, opts.Platform.Logs.CollectionEnabled || opts.UserWorkloads.Logs.CollectionEnabled, logsErr)
+	tracesErr := checkTracing(fields, opts)
+	recordComponentHealth(mc.Name, openTelemetryCollectorComponent, opts.UserWorkloads.Traces.CollectionEnabled, tracesErr)
+	eventsErr := checkEvents(fields, opts, isOpenShiftVendor)
+	recordComponentHealth(mc.Name, eventsCollectorComponent, opts.Platform.Events.CollectionEnabled && isOpenShiftVendor, eventsErr)
+	profilingDeployed := isOpenShiftVendor && !common.IsHubCluster(mc)
+	profilingErr := checkProfiling(fields, opts, profilingDeployed)
+	recordComponentHealth(mc.Name, profilingAgentComponent, opts.UserWorkloads.Profiles.CollectionEnabled && profilingDeployed, profilingErr)
+	networkFlowsErr := checkNetworkFlows(fields, opts, isOpenShiftVendor)
+	recordComponentHealth(mc.Name, flowCollectorComponent, opts.Platform.NetworkFlows.CollectionEnabled && isOpenShiftVendor, networkFlowsErr)
+	var uiPluginErr, obsAPIErr error
 	if common.IsHubCluster(mc) {
-		if err := checkMetricsUIPlugin(fields, opts); err != nil {
+		uiPluginErr = checkMetricsUIPlugin(fields, opts)
+		recordComponentHealth(mc.Name, uiPluginComponent, opts.Platform.Metrics.UI.Enabled, uiPluginErr)
+		obsAPIErr = checkObsAPI(fields, opts)
+		recordComponentHealth(mc.Name, obsAPIComponent, opts.HubObsAPI.Enabled, obsAPIErr)
+	}
+
+	for _, err := range []error{metricsErr, logsErr, tracesErr, eventsErr, profilingErr, networkFlowsErr, uiPluginErr, obsAPIErr} {
+		if err != nil {
 			return err
 		}
 	}
@@ -502,36 +648,134 @@ func checkTracing(fields []agent.FieldResult, opts Options) error {
 		return nil
 	}
 
+	return checkOTELCol(fields, addoncfg.SpokeOTELColName)
+}
+
+// checkEvents checks the events collector, which is only deployed on
+// OpenShift clusters.
+func checkEvents(fields []agent.FieldResult, opts Options, isOCP bool) error {
+	if !opts.Platform.Events.CollectionEnabled || !isOCP {
+		return nil
+	}
+
+	return checkOTELCol(fields, addoncfg.SpokeEventsCollectorName)
+}
+
+// checkOTELCol checks that the OpenTelemetryCollector with the given name has
+// at least one replica.
+func checkOTELCol(fields []agent.FieldResult, name string) error {
 	foundOtelCol := false
 	for _, field := range fields {
 		identifier := field.ResourceIdentifier
-		switch identifier.Resource {
-		case addoncfg.OpenTelemetryCollectorsResource:
-			if len(field.FeedbackResult.Values) == 0 {
-				return fmt.Errorf("%w for %s with key %s/%s", errMissingFeedbackValues, identifier.Resource, identifier.Namespace, identifier.Name)
+		if identifier.Resource != addoncfg.OpenTelemetryCollectorsResource || identifier.Name != name {
+			continue
+		}
+		if len(field.FeedbackResult.Values) == 0 {
+			return fmt.Errorf("%w for %s with key %s/%s", errMissingFeedbackValues, identifier.Resource, identifier.Namespace, identifier.Name)
+		}
+		for _, value := range field.FeedbackResult.Values {
+			if value.Name != addoncfg.OtelColProbeKey {
+				return fmt.Errorf("%w: %s with key %s/%s unknown probe keys %s", errUnknownProbeKey, identifier.Resource, identifier.Namespace, identifier.Name, value.Name)
 			}
-			for _, value := range field.FeedbackResult.Values {
-				if value.Name != addoncfg.OtelColProbeKey {
-					return fmt.Errorf("%w: %s with key %s/%s unknown probe keys %s", errUnknownProbeKey, identifier.Resource, identifier.Namespace, identifier.Name, value.Name)
-				}
 
-				if value.Value.Integer == nil {
-					return fmt.Errorf("%w: %s with key %s/%s", errProbeValueIsNil, identifier.Resource, identifier.Namespace, identifier.Name)
-				}
+			if value.Value.Integer == nil {
+				return fmt.Errorf("%w: %s with key %s/%s", errProbeValueIsNil, identifier.Resource, identifier.Namespace, identifier.Name)
+			}
 
-				if *value.Value.Integer < 1 {
-					return fmt.Errorf("%w: %s replicas is %d for %s/%s", errProbeConditionNotSatisfied, identifier.Resource, *value.Value.Integer, identifier.Namespace, identifier.Name)
-				}
-				// otel collector passes the health check
+			if *value.Value.Integer < 1 {
+				return fmt.Errorf("%w: %s replicas is %d for %s/%s", errProbeConditionNotSatisfied, identifier.Resource, *value.Value.Integer, identifier.Namespace, identifier.Name)
 			}
-			foundOtelCol = true
+			// otel collector passes the health check
 		}
+		foundOtelCol = true
 	}
 
 	if !foundOtelCol {
-		return fmt.Errorf("%w: %s", errMissingFields, addoncfg.OpenTelemetryCollectorsResource)
+		return fmt.Errorf("%w: %s with name %s", errMissingFields, addoncfg.OpenTelemetryCollectorsResource, name)
+	}
+
+	return nil
+}
+
+// checkProfiling checks that the profiling agent runs on at least one node. The
+// agent is only deployed on OpenShift managed clusters.
+func checkProfiling(fields []agent.FieldResult, opts Options, deployed bool) error {
+	if !opts.UserWorkloads.Profiles.CollectionEnabled || !deployed {
+		return nil
+	}
+	return checkProbe(fields, addoncfg.DaemonSetsResource, addoncfg.SpokeProfilingAgentName, addoncfg.ProfilingAgentProbeKey, atLeastOne("ready pods"))
+}
+
+// checkNetworkFlows checks that the FlowCollector is ready. It is only
+// deployed on OpenShift clusters.
+func checkNetworkFlows(fields []agent.FieldResult, opts Options, isOCP bool) error {
+	if !opts.Platform.NetworkFlows.CollectionEnabled || !isOCP {
+		return nil
+	}
+	return checkProbe(fields, addoncfg.FlowCollectorsResource, addoncfg.SpokeFlowCollectorName, addoncfg.FlowCollectorProbeKey, conditionTrue)
+}
+
+// checkObsAPI checks that a replica of the observability API of the hub passes
+// its readiness probe.
+func checkObsAPI(fields []agent.FieldResult, opts Options) error {
+	if !opts.HubObsAPI.Enabled {
+		return nil
 	}
+	return checkProbe(fields, addoncfg.DeploymentsResource, addoncfg.HubObsAPIName, addoncfg.ObsAPIProbeKey, atLeastOne("ready replicas"))
+}
 
+// checkProbe checks that the resource with the given name reports only the
+// given probe key, and that its values satisfy the predicate.
+func checkProbe(fields []agent.FieldResult, resource, name, probeKey string, predicate func(workv1.FieldValue) error) error {
+	found := false
+	for _, field := range fields {
+		identifier := field.ResourceIdentifier
+		if identifier.Resource != resource || identifier.Name != name {
+			continue
+		}
+		if len(field.FeedbackResult.Values) == 0 {
+			return fmt.Errorf("%w for %s with key %s/%s", errMissingFeedbackValues, identifier.Resource, identifier.Namespace, identifier.Name)
+		}
+		for _, value := range field.FeedbackResult.Values {
+			if value.Name != probeKey {
+				return fmt.Errorf("%w: %s with key %s/%s unknown probe keys %s", errUnknownProbeKey, identifier.Resource, identifier.Namespace, identifier.Name, value.Name)
+			}
+			if err := predicate(value.Value); err != nil {
+				return fmt.Errorf("%w for %s with key %s/%s", err, identifier.Resource, identifier.Namespace, identifier.Name)
+			}
+		}
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s with name %s", errMissingFields, resource, name)
+	}
+
+	return nil
+}
+
+// atLeastOne returns a probe predicate requiring the integer count of what to
+// be at least one.
+func atLeastOne(what string) func(workv1.FieldValue) error {
+	return func(value workv1.FieldValue) error {
+		if value.Integer == nil {
+			return errProbeValueIsNil
+		}
+		if *value.Integer < 1 {
+			return fmt.Errorf("%w: %s is %d", errProbeConditionNotSatisfied, what, *value.Integer)
+		}
+		return nil
+	}
+}
+
+// conditionTrue is a probe predicate requiring the status of a condition to be True.
+func conditionTrue(value workv1.FieldValue) error {
+	if value.String == nil {
+		return errProbeValueIsNil
+	}
+	if *value.String != "True" {
+		return fmt.Errorf("%w: status condition type is %s", errProbeConditionNotSatisfied, *value.String)
+	}
 	return nil
 }
 
@@ -275,6 +275,203 @@ func Test_AgentHealthProber_OTELCol(t *testing.T) {
 	}
 }
 
+func Test_AgentHealthProber_EventsCollector(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedCluster.Labels = map[string]string{"vendor": "OpenShift"}
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+	aodc := newAddonDeploymentConfig()
+	addEventsCustomizedVariables(aodc)
+	addAODCConfigReference(managedClusterAddOn, aodc)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addonapiv1beta1.Install(scheme))
+
+	for _, tc := range []struct {
+		name          string
+		collectorName string
+		replicas      int64
+		expectedErr   error
+	}{
+		{
+			name:          "healthy",
+			collectorName: addoncfg.SpokeEventsCollectorName,
+			replicas:      1,
+		},
+		{
+			name:          "unhealthy",
+			collectorName: addoncfg.SpokeEventsCollectorName,
+			replicas:      0,
+			expectedErr:   errProbeConditionNotSatisfied,
+		},
+		{
+			name:          "only the traces collector",
+			collectorName: addoncfg.SpokeOTELColName,
+			replicas:      1,
+			expectedErr:   errMissingFields,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			healthProber := HealthProber(newTestGetter(aodc), logr.Discard())
+			err := healthProber.WorkProber.HealthChecker([]agent.FieldResult{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{
+						Group:     otelv1alpha1.GroupVersion.Group,
+						Resource:  addoncfg.OpenTelemetryCollectorsResource,
+						Name:      tc.collectorName,
+						Namespace: addoncfg.SpokeOTELColNamespace,
+					},
+					FeedbackResult: workv1.StatusFeedbackResult{
+						Values: []workv1.FeedbackValue{
+							{
+								Name: addoncfg.OtelColProbeKey,
+								Value: workv1.FieldValue{
+									Type:    workv1.Integer,
+									Integer: &tc.replicas,
+								},
+							},
+						},
+					},
+				},
+			}, managedCluster, managedClusterAddOn)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_AgentHealthProber_ProfilingAgent(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedCluster.Labels = map[string]string{"vendor": "OpenShift"}
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+	aodc := newAddonDeploymentConfig()
+	addProfilingCustomizedVariables(aodc)
+	addAODCConfigReference(managedClusterAddOn, aodc)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addonapiv1beta1.Install(scheme))
+
+	for _, tc := range []struct {
+		name        string
+		resource    string
+		numberReady int64
+		expectedErr error
+	}{
+		{
+			name:        "healthy",
+			resource:    addoncfg.DaemonSetsResource,
+			numberReady: 3,
+		},
+		{
+			name:        "unhealthy",
+			resource:    addoncfg.DaemonSetsResource,
+			numberReady: 0,
+			expectedErr: errProbeConditionNotSatisfied,
+		},
+		{
+			name:        "missing daemonset",
+			resource:    "deployments",
+			numberReady: 1,
+			expectedErr: errMissingFields,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			healthProber := HealthProber(newTestGetter(aodc), logr.Discard())
+			err := healthProber.WorkProber.HealthChecker([]agent.FieldResult{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{
+						Group:     "apps",
+						Resource:  tc.resource,
+						Name:      addoncfg.SpokeProfilingAgentName,
+						Namespace: addoncfg.SpokeProfilingAgentNamespace,
+					},
+					FeedbackResult: workv1.StatusFeedbackResult{
+						Values: []workv1.FeedbackValue{
+							{
+								Name: addoncfg.ProfilingAgentProbeKey,
+								Value: workv1.FieldValue{
+									Type:    workv1.Integer,
+									Integer: &tc.numberReady,
+								},
+							},
+						},
+					},
+				},
+			}, managedCluster, managedClusterAddOn)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_AgentHealthProber_FlowCollector(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedCluster.Labels = map[string]string{"vendor": "OpenShift"}
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+	aodc := newAddonDeploymentConfig()
+	addNetworkFlowsCustomizedVariables(aodc)
+	addAODCConfigReference(managedClusterAddOn, aodc)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addonapiv1beta1.Install(scheme))
+
+	for _, tc := range []struct {
+		name        string
+		resource    string
+		status      string
+		expectedErr error
+	}{
+		{
+			name:     "healthy",
+			resource: addoncfg.FlowCollectorsResource,
+			status:   "True",
+		},
+		{
+			name:        "unhealthy",
+			resource:    addoncfg.FlowCollectorsResource,
+			status:      "False",
+			expectedErr: errProbeConditionNotSatisfied,
+		},
+		{
+			name:        "missing flowcollector",
+			resource:    addoncfg.ClusterLogForwardersResource,
+			status:      "True",
+			expectedErr: errMissingFields,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			healthProber := HealthProber(newTestGetter(aodc), logr.Discard())
+			err := healthProber.WorkProber.HealthChecker([]agent.FieldResult{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{
+						Group:    addoncfg.FlowCollectorsGroup,
+						Resource: tc.resource,
+						Name:     addoncfg.SpokeFlowCollectorName,
+					},
+					FeedbackResult: workv1.StatusFeedbackResult{
+						Values: []workv1.FeedbackValue{
+							{
+								Name: addoncfg.FlowCollectorProbeKey,
+								Value: workv1.FieldValue{
+									Type:   workv1.String,
+									String: &tc.status,
+								},
+							},
+						},
+					},
+				},
+			}, managedCluster, managedClusterAddOn)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func Test_AgentHealthProber_UIPlugin(t *testing.T) {
 	managedCluster := addontesting.NewManagedCluster("cluster-1")
 	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
@@ -366,6 +563,72 @@ func Test_AgentHealthProber_UIPlugin(t *testing.T) {
 	}
 }
 
+func Test_AgentHealthProber_ObsAPI(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("local-cluster")
+	managedCluster.Labels = map[string]string{clusterlifecycleconstants.SelfManagedClusterLabelKey: "true"}
+	managedClusterAddOn := addontesting.NewAddon("test", "local-cluster")
+	aodc := newAddonDeploymentConfig()
+	addObsAPICustomizedVariables(aodc)
+	addAODCConfigReference(managedClusterAddOn, aodc)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addonapiv1beta1.Install(scheme))
+
+	for _, tc := range []struct {
+		name          string
+		resource      string
+		readyReplicas int64
+		expectedErr   error
+	}{
+		{
+			name:          "healthy",
+			resource:      addoncfg.DeploymentsResource,
+			readyReplicas: 2,
+		},
+		{
+			name:          "unhealthy",
+			resource:      addoncfg.DeploymentsResource,
+			readyReplicas: 0,
+			expectedErr:   errProbeConditionNotSatisfied,
+		},
+		{
+			name:          "missing deployment",
+			resource:      addoncfg.DaemonSetsResource,
+			readyReplicas: 1,
+			expectedErr:   errMissingFields,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			healthProber := HealthProber(newTestGetter(aodc), logr.Discard())
+			err := healthProber.WorkProber.HealthChecker([]agent.FieldResult{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{
+						Group:     "apps",
+						Resource:  tc.resource,
+						Name:      addoncfg.HubObsAPIName,
+						Namespace: addoncfg.InstallNamespace,
+					},
+					FeedbackResult: workv1.StatusFeedbackResult{
+						Values: []workv1.FeedbackValue{
+							{
+								Name: addoncfg.ObsAPIProbeKey,
+								Value: workv1.FieldValue{
+									Type:    workv1.Integer,
+									Integer: &tc.readyReplicas,
+								},
+							},
+						},
+					},
+				},
+			}, managedCluster, managedClusterAddOn)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func Test_AgentHealthProber_MissingResources(t *testing.T) {
 	managedCluster := addontesting.NewManagedCluster("cluster-1")
 	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
@@ -625,6 +888,46 @@ func addTracingCustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig)
 	}...)
 }
 
+func addEventsCustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig) {
+	aodc.Spec.CustomizedVariables = append(aodc.Spec.CustomizedVariables, []addonapiv1beta1.CustomizedVariable{
+		{
+			Name:  KeyPlatformEventsCollection,
+			Value: string(OpenTelemetryCollectorV1beta1),
+		},
+		{
+			Name:  KeyPlatformEventsEndpoint,
+			Value: "https://otlp.example.com:4317",
+		},
+	}...)
+}
+
+func addProfilingCustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig) {
+	aodc.Spec.CustomizedVariables = append(aodc.Spec.CustomizedVariables, []addonapiv1beta1.CustomizedVariable{
+		{
+			Name:  KeyUserWorkloadProfilesCollection,
+			Value: string(DaemonSetV1),
+		},
+	}...)
+}
+
+func addObsAPICustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig) {
+	aodc.Spec.CustomizedVariables = append(aodc.Spec.CustomizedVariables, []addonapiv1beta1.CustomizedVariable{
+		{
+			Name:  KeyHubObservatoriumAPIHostname,
+			Value: "observatorium-api.example.com",
+		},
+	}...)
+}
+
+func addNetworkFlowsCustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig) {
+	aodc.Spec.CustomizedVariables = append(aodc.Spec.CustomizedVariables, []addonapiv1beta1.CustomizedVariable{
+		{
+			Name:  KeyPlatformNetworkFlowsCollection,
+			Value: string(FlowCollectorV1beta2),
+		},
+	}...)
+}
+
 func addUIPluginCustomizedVariables(aodc *addonapiv1beta1.AddOnDeploymentConfig) {
 	aodc.Spec.CustomizedVariables = append(aodc.Spec.CustomizedVariables, []addonapiv1beta1.CustomizedVariable{
 		{
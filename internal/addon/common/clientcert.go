@@ -0,0 +1,191 @@
+package common
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/openshift/library-go/pkg/crypto"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	clientCertLifetime    = 365 * 24 * time.Hour
+	clientCertRenewBefore = 30 * 24 * time.Hour
+)
+
+var errInvalidClientCA = errors.New("invalid client CA of the hub")
+
+// EnsureClientCert returns the client certificate of the managed cluster
+// stored in the named secret, issued by the client CA of the hub. The name of
+// the cluster is its common name and DNS name, and the groups are both its
+// organizations, read by the Kubernetes authenticators, and its organizational
+// units, read by the observability API. The spoke can't choose the identity it
+// is authenticated with, like it could with a header or a label.
+//
+// The certificate is kept in the install namespace, where the CA is, owned by
+// its ManagedCluster, and issued again when it is about to expire, was not
+// signed by the current CA or doesn't have the groups. The time it is to be
+// issued again is annotated, for the cluster to be reconciled by then.
+func EnsureClientCert(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, name string, groups []string) (*corev1.Secret, error) {
+	caSecret := &corev1.Secret{}
+	if err := k8s.Get(ctx, types.NamespacedName{Name: addoncfg.ClientCASecretName, Namespace: addoncfg.InstallNamespace}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s in namespace %s: %w", addoncfg.ClientCASecretName, addoncfg.InstallNamespace, err)
+	}
+	ca, err := crypto.GetCAFromBytes(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidClientCA, err.Error())
+	}
+
+	clusterName := mcAddon.Namespace
+	subject := crypto.UserToSubject(&user.DefaultInfo{Name: clusterName, Groups: groups})
+	subject.OrganizationalUnit = subject.Organization
+
+	key := ClientCertKey(clusterName, name)
+	secret := &corev1.Secret{}
+	err = k8s.Get(ctx, key, secret)
+	switch {
+	case err == nil:
+		if isClientCertValid(secret, ca, clusterName, subject.Organization) {
+			return secret, nil
+		}
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+	default:
+		return nil, err
+	}
+
+	cluster := &clusterv1.ManagedCluster{}
+	if err := k8s.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get the managed cluster %s: %w", clusterName, err)
+	}
+
+	publicKey, privateKey, err := crypto.NewKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	template := crypto.NewClientCertificateTemplateForDuration(subject, clientCertLifetime, time.Now)
+	template.DNSNames = []string{clusterName}
+	cert, err := ca.SignCertificate(template, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue the client certificate %s of cluster %s: %w", name, clusterName, err)
+	}
+	certPEM, err := crypto.EncodeCertificates(cert)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := crypto.EncodeKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := controllerutil.SetOwnerReference(cluster, secret, k8s.Scheme()); err != nil {
+		return nil, err
+	}
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, addoncfg.ClientCertClusterLabelKey, clusterName)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, addoncfg.ClientCertRenewAnnotationKey, cert.NotAfter.Add(-clientCertRenewBefore).UTC().Format(time.RFC3339))
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+	if secret.ResourceVersion == "" {
+		err = k8s.Create(ctx, secret)
+	} else {
+		err = k8s.Update(ctx, secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store the client certificate %s of cluster %s: %w", name, clusterName, err)
+	}
+
+	return secret, nil
+}
+
+// ClientCertKey returns the key of the named client certificate of the cluster.
+func ClientCertKey(clusterName, name string) types.NamespacedName {
+	return types.NamespacedName{Name: name + "-" + clusterName, Namespace: addoncfg.InstallNamespace}
+}
+
+// ClientCertRenewTime returns the earliest time a client certificate of the
+// cluster is to be issued again, or the zero time when it has none.
+func ClientCertRenewTime(ctx context.Context, k8s client.Client, clusterName string) (time.Time, error) {
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := k8s.List(ctx, secrets, client.InNamespace(addoncfg.InstallNamespace), client.MatchingLabels{addoncfg.ClientCertClusterLabelKey: clusterName}); err != nil {
+		return time.Time{}, err
+	}
+
+	var renewTime time.Time
+	for _, secret := range secrets.Items {
+		t, err := time.Parse(time.RFC3339, secret.Annotations[addoncfg.ClientCertRenewAnnotationKey])
+		if err != nil {
+			continue
+		}
+		if renewTime.IsZero() || t.Before(renewTime) {
+			renewTime = t
+		}
+	}
+	return renewTime, nil
+}
+
+// isClientCertValid returns true when the certificate of the secret was
+// issued for the cluster and the groups by the CA and is not about to expire.
+func isClientCertValid(secret *corev1.Secret, ca *crypto.CA, clusterName string, groups []string) bool {
+	cfg, err := crypto.GetTLSCertificateConfigFromBytes(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil || len(cfg.Certs) == 0 {
+		return false
+	}
+	cert := cfg.Certs[0]
+	if cert.Subject.CommonName != clusterName || time.Until(cert.NotAfter) < clientCertRenewBefore {
+		return false
+	}
+	if !slices.Equal(sorted(cert.Subject.Organization), sorted(groups)) || !slices.Equal(sorted(cert.Subject.OrganizationalUnit), sorted(groups)) {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	for _, c := range ca.Config.Certs {
+		roots.AddCert(c)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil
+}
+
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	sort.Strings(s)
+	return s
+}
+
+// ClientCertPredicate filters the client certificates of the install namespace.
+func ClientCertPredicate() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		if obj.GetNamespace() != addoncfg.InstallNamespace {
+			return false
+		}
+		_, ok := obj.GetLabels()[addoncfg.ClientCertClusterLabelKey]
+		return ok
+	})
+}
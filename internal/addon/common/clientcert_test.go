@@ -0,0 +1,90 @@
+package common
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/openshift/library-go/pkg/crypto"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureClientCert(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, addonapiv1beta1.Install(scheme))
+	require.NoError(t, clusterv1.Install(scheme))
+
+	ca, err := crypto.MakeSelfSignedCAConfig("observability-client-ca-certificate", time.Hour*24*365)
+	require.NoError(t, err)
+	certPEM, keyPEM, err := ca.GetPEMBytes()
+	require.NoError(t, err)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: addoncfg.ClientCASecretName, Namespace: addoncfg.InstallNamespace},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	mcAddon := addontesting.NewAddon("multicluster-observability-addon", "cluster-1")
+	cluster := addontesting.NewManagedCluster("cluster-1")
+	cluster.UID = types.UID("cluster-uid")
+	k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcAddon, cluster, caSecret).Build()
+
+	renewTime, err := ClientCertRenewTime(t.Context(), k8s, "cluster-1")
+	require.NoError(t, err)
+	require.True(t, renewTime.IsZero())
+
+	parse := func(secret *corev1.Secret) *x509.Certificate {
+		block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		return cert
+	}
+
+	secret, err := EnsureClientCert(t.Context(), k8s, mcAddon, "client-cert", []string{"writers", "set-a"})
+	require.NoError(t, err)
+	cert := parse(secret)
+	require.Equal(t, "cluster-1", cert.Subject.CommonName)
+	require.Equal(t, []string{"cluster-1"}, cert.DNSNames)
+	require.ElementsMatch(t, []string{"writers", "set-a"}, cert.Subject.Organization)
+	require.ElementsMatch(t, []string{"writers", "set-a"}, cert.Subject.OrganizationalUnit)
+	require.Equal(t, addoncfg.InstallNamespace, secret.Namespace)
+	require.Equal(t, "cluster-1", secret.Labels[addoncfg.ClientCertClusterLabelKey])
+	require.Len(t, secret.OwnerReferences, 1)
+	require.Equal(t, cluster.UID, secret.OwnerReferences[0].UID)
+
+	// The cluster is to be reconciled before the certificate expires.
+	renewTime, err = ClientCertRenewTime(t.Context(), k8s, "cluster-1")
+	require.NoError(t, err)
+	require.Equal(t, cert.NotAfter.Add(-clientCertRenewBefore).Truncate(time.Second), renewTime.Truncate(time.Second))
+
+	// The certificate is reused while it is valid, whatever the order of the
+	// groups.
+	again, err := EnsureClientCert(t.Context(), k8s, mcAddon, "client-cert", []string{"set-a", "writers"})
+	require.NoError(t, err)
+	require.Equal(t, secret.Data, again.Data)
+
+	// It is issued again when the groups change.
+	moved, err := EnsureClientCert(t.Context(), k8s, mcAddon, "client-cert", []string{"writers", "set-b"})
+	require.NoError(t, err)
+	require.NotEqual(t, secret.Data, moved.Data)
+	require.ElementsMatch(t, []string{"writers", "set-b"}, parse(moved).Subject.OrganizationalUnit)
+
+	stored := &corev1.Secret{}
+	require.NoError(t, k8s.Get(t.Context(), ClientCertKey("cluster-1", "client-cert"), stored))
+	require.Equal(t, moved.Data, stored.Data)
+}
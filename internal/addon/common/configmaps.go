@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,3 +48,15 @@ func GetConfigMaps(ctx context.Context, k8s client.Client, configResourceNamespa
 
 	return configMaps, nil
 }
+
+// SplitList splits a comma or newline separated list of a ConfigMap value,
+// dropping blank items.
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
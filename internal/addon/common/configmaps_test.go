@@ -89,3 +89,8 @@ func TestGetConfigMaps(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitList(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, SplitList(" a,b\n\nc , "))
+	require.Nil(t, SplitList(""))
+}
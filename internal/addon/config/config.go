@@ -13,11 +13,17 @@ const (
 
 	DefaultContextTimeout = 10 * time.Second
 
-	McoaChartDir    = "manifests/charts/mcoa"
-	MetricsChartDir = "manifests/charts/mcoa/charts/metrics"
-	LoggingChartDir = "manifests/charts/mcoa/charts/logging"
-	TracingChartDir = "manifests/charts/mcoa/charts/tracing"
-	COOChartDir     = "manifests/charts/mcoa/charts/coo"
+	McoaChartDir        = "manifests/charts/mcoa"
+	MetricsChartDir     = "manifests/charts/mcoa/charts/metrics"
+	LoggingChartDir     = "manifests/charts/mcoa/charts/logging"
+	TracingChartDir     = "manifests/charts/mcoa/charts/tracing"
+	EventsChartDir      = "manifests/charts/mcoa/charts/events"
+	ProfilingChartDir   = "manifests/charts/mcoa/charts/profiling"
+	NetFlowsChartDir    = "manifests/charts/mcoa/charts/netflows"
+	LokiStackChartDir   = "manifests/charts/mcoa/charts/lokistack"
+	OTelGatewayChartDir = "manifests/charts/mcoa/charts/otel-gateway"
+	ObsAPIChartDir      = "manifests/charts/mcoa/charts/obs-api"
+	COOChartDir         = "manifests/charts/mcoa/charts/coo"
 
 	AddonDeploymentConfigResource = "addondeploymentconfigs"
 
@@ -42,6 +48,25 @@ const (
 	SpokeOTELColNamespace           = "mcoa-opentelemetry"
 	OtelColProbeKey                 = "replicas"
 	OtelColProbePath                = ".spec.replicas"
+	SpokeEventsCollectorName        = "mcoa-events"
+
+	ConfigMapsResource           = "configmaps"
+	DaemonSetsResource           = "daemonsets"
+	SpokeProfilingAgentName      = "mcoa-profiling-agent"
+	SpokeProfilingAgentNamespace = "mcoa-profiling"
+	ProfilingAgentProbeKey       = "numberReady"
+	ProfilingAgentProbePath      = ".status.numberReady"
+
+	FlowCollectorsGroup    = "flows.netobserv.io"
+	FlowCollectorsResource = "flowcollectors"
+	SpokeFlowCollectorName = "cluster"
+	FlowCollectorProbeKey  = "isReady"
+	FlowCollectorProbePath = ".status.conditions[?(@.type==\"Ready\")].status"
+
+	DeploymentsResource = "deployments"
+	HubObsAPIName       = "mcoa-observability-observatorium-api"
+	ObsAPIProbeKey      = "readyReplicas"
+	ObsAPIProbePath     = ".status.readyReplicas"
 
 	UiPluginsResource = "uiplugins"
 	UipProbeKey       = "isAvailable"
@@ -49,6 +74,10 @@ const (
 
 	DefaultStackPrefix = "mcoa-default"
 
+	// ClientCASecretName is the client CA of the observability API of the hub,
+	// issuing the client certificates of the managed clusters.
+	ClientCASecretName = "observability-client-ca-certs"
+
 	// Label keys
 	PlacementRefNameLabelKey      = "placement-ref-name"
 	PlacementRefNamespaceLabelKey = "placement-ref-namespace"
@@ -58,6 +87,36 @@ const (
 	BackupLabelKey                = "cluster.open-cluster-management.io/backup"
 	BackupLabelValue              = ""
 	PlacementAnnotationKey        = "observability.open-cluster-management.io/placements"
+	// UserDashboardLabelKey marks the ConfigMaps of the install namespace holding user dashboards.
+	UserDashboardLabelKey = "observability.open-cluster-management.io/dashboard"
+	// UserDashboardSourceLabelKey is set on user dashboards to the name of their ConfigMap.
+	UserDashboardSourceLabelKey = "observability.open-cluster-management.io/dashboard-configmap"
+	// ProfilingConfigLabelKey marks the ConfigMap, referenced by the addon
+	// configs, holding the configuration of the profiling agent.
+	ProfilingConfigLabelKey = "observability.open-cluster-management.io/profiling"
+	// MetricsTenantLabelKey marks the ConfigMaps of the install namespace mapping
+	// user workload metrics to a tenant of the hub.
+	MetricsTenantLabelKey = "observability.open-cluster-management.io/metrics-tenant"
+	// TracingPolicyLabelKey marks the ConfigMaps of the install namespace holding
+	// the sampling and span metrics policies of the user workload traces.
+	TracingPolicyLabelKey = "observability.open-cluster-management.io/tracing-policy"
+	// LokiStackConfigLabelKey marks the ConfigMap, referenced by the addon
+	// configs, holding the configuration of the LokiStack of the hub.
+	LokiStackConfigLabelKey = "observability.open-cluster-management.io/lokistack"
+	// ObsAPIConfigLabelKey marks the ConfigMap, referenced by the addon configs,
+	// holding the configuration of the observability API of the hub, and the
+	// Secrets holding the OIDC client secrets of its tenants.
+	ObsAPIConfigLabelKey = "observability.open-cluster-management.io/observatorium-api"
+	// ClientCertClusterLabelKey is set on the client certificates of the install
+	// namespace to the name of their managed cluster.
+	ClientCertClusterLabelKey = "observability.open-cluster-management.io/client-cert-cluster"
+	// ClientCertRenewAnnotationKey is set on the client certificates to the
+	// time they are issued again, before they expire.
+	ClientCertRenewAnnotationKey = "observability.open-cluster-management.io/client-cert-renew-after"
+	// TeamProjectLabelKey marks the ConfigMaps of the install namespace configuring a team Perses project.
+	TeamProjectLabelKey = "observability.open-cluster-management.io/perses-project"
+	// TeamProjectSourceLabelKey is set on the resources of a team project to the name of its ConfigMap.
+	TeamProjectSourceLabelKey = "observability.open-cluster-management.io/perses-project-configmap"
 
 	ClusterClaimClusterID        = "id.k8s.io"
 	ManagedClusterLabelClusterID = "clusterID"
@@ -88,8 +147,15 @@ const (
 )
 
 var (
-	ErrInvalidMetricsHubHostname  = errors.New("invalid metrics hub hostname")
-	ErrInvalidProxyURL            = errors.New("invalid proxy URL")
-	ErrInvalidSubscriptionChannel = errors.New("current version of the cluster-observability-operator installed doesn't match the supported MCOA version")
-	ErrInvalidPort                = errors.New("invalid port")
+	ErrInvalidMetricsHubHostname      = errors.New("invalid metrics hub hostname")
+	ErrInvalidProxyURL                = errors.New("invalid proxy URL")
+	ErrInvalidSubscriptionChannel     = errors.New("current version of the cluster-observability-operator installed doesn't match the supported MCOA version")
+	ErrInvalidPort                    = errors.New("invalid port")
+	ErrInvalidEventsEndpoint          = errors.New("invalid events endpoint")
+	ErrInvalidEventsExporter          = errors.New("invalid events exporter")
+	ErrInvalidOTelGatewayHostname     = errors.New("invalid otel gateway hostname")
+	ErrInvalidHubLokiStack            = errors.New("the LokiStack of the hub requires the otel gateway")
+	ErrInvalidObsAPIHostname          = errors.New("invalid observatorium api hostname")
+	ErrInvalidUserWorkloadMetricsOTLP = errors.New("user workload OTLP metrics require the collection of user workload metrics and traces")
+	ErrInvalidVirtDashboards          = errors.New("invalid virtualization dashboards value")
 )
@@ -10,11 +10,22 @@ import (
 	rshandlers "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/handlers"
 	chandlers "github.com/stolostron/multicluster-observability-addon/internal/coo/handlers"
 	cmanifests "github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	ehandlers "github.com/stolostron/multicluster-observability-addon/internal/events/handlers"
+	emanifests "github.com/stolostron/multicluster-observability-addon/internal/events/manifests"
 	lhandlers "github.com/stolostron/multicluster-observability-addon/internal/logging/handlers"
 	lmanifests "github.com/stolostron/multicluster-observability-addon/internal/logging/manifests"
+	lshandlers "github.com/stolostron/multicluster-observability-addon/internal/lokistack/handlers"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
 	mhandlers "github.com/stolostron/multicluster-observability-addon/internal/metrics/handlers"
 	mmanifests "github.com/stolostron/multicluster-observability-addon/internal/metrics/manifests"
+	nfhandlers "github.com/stolostron/multicluster-observability-addon/internal/netflows/handlers"
+	nfmanifests "github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	ohandlers "github.com/stolostron/multicluster-observability-addon/internal/obsapi/handlers"
 	omanifests "github.com/stolostron/multicluster-observability-addon/internal/obsapi/manifests"
+	gwhandlers "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/handlers"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	phandlers "github.com/stolostron/multicluster-observability-addon/internal/profiling/handlers"
+	pmanifests "github.com/stolostron/multicluster-observability-addon/internal/profiling/manifests"
 	thandlers "github.com/stolostron/multicluster-observability-addon/internal/tracing/handlers"
 	tmanifests "github.com/stolostron/multicluster-observability-addon/internal/tracing/manifests"
 	"open-cluster-management.io/addon-framework/pkg/addonfactory"
@@ -29,14 +40,19 @@ type GlobalValues struct {
 }
 
 type HelmChartValues struct {
-	Enabled     bool                          `json:"enabled"`
-	Global      *GlobalValues                 `json:"global,omitempty"`
-	Metrics     *mmanifests.MetricsValues     `json:"metrics,omitempty"`
-	Logging     *lmanifests.LoggingValues     `json:"logging,omitempty"`
-	Tracing     *tmanifests.TracingValues     `json:"tracing,omitempty"`
-	COO         *cmanifests.COOValues         `json:"coo,omitempty"`
-	RightSizing *rshandlers.RightSizingValues `json:"rightSizing,omitempty"`
-	ObsAPI      *omanifests.ObsAPIValues      `json:"obs-api,omitempty"`
+	Enabled     bool                           `json:"enabled"`
+	Global      *GlobalValues                  `json:"global,omitempty"`
+	Metrics     *mmanifests.MetricsValues      `json:"metrics,omitempty"`
+	Logging     *lmanifests.LoggingValues      `json:"logging,omitempty"`
+	Tracing     *tmanifests.TracingValues      `json:"tracing,omitempty"`
+	Events      *emanifests.EventsValues       `json:"events,omitempty"`
+	Profiling   *pmanifests.ProfilingValues    `json:"profiling,omitempty"`
+	NetFlows    *nfmanifests.NetFlowsValues    `json:"netflows,omitempty"`
+	COO         *cmanifests.COOValues          `json:"coo,omitempty"`
+	RightSizing *rshandlers.RightSizingValues  `json:"rightSizing,omitempty"`
+	ObsAPI      *omanifests.ObsAPIValues       `json:"obs-api,omitempty"`
+	OTelGateway *gwmanifests.OTelGatewayValues `json:"otel-gateway,omitempty"`
+	LokiStack   *lsmanifests.LokiStackValues   `json:"lokistack,omitempty"`
 }
 
 func GetValuesFunc(ctx context.Context, k8s client.Client, getter addonutils.AddOnDeploymentConfigGetter, logger logr.Logger) addonfactory.GetValuesFunc {
@@ -75,12 +91,43 @@ func GetValuesFunc(ctx context.Context, k8s client.Client, getter addonutils.Add
 			return nil, fmt.Errorf("failed to get logging values: %w", err)
 		}
 
-		userValues.Tracing, err = getTracingValues(ctx, k8s, cluster, mcAddon, opts)
+		userValues.Tracing, err = getTracingValues(ctx, k8s, logger, cluster, mcAddon, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get tracing values: %w", err)
 		}
 
-		userValues.COO, err = getCOOValues(ctx, k8s, logger, cluster, opts)
+		userValues.OTelGateway, err = getOTelGatewayValues(ctx, k8s, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get otel gateway values: %w", err)
+		}
+
+		userValues.LokiStack, err = getLokiStackValues(ctx, k8s, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lokistack values: %w", err)
+		}
+
+		userValues.Events, err = getEventsValues(ctx, k8s, logger, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events values: %w", err)
+		}
+		if userValues.Events != nil {
+			// The OpenTelemetry operator is installed by the tracing chart when traces are collected
+			// and by the gateway chart on the hub. All of them follow the same Subscription channel.
+			userValues.Events.InstallOperator = userValues.Tracing == nil && userValues.OTelGateway == nil
+			userValues.Events.SubscriptionChannel = opts.UserWorkloads.Traces.SubscriptionChannel
+		}
+
+		userValues.Profiling, err = getProfilingValues(ctx, k8s, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get profiling values: %w", err)
+		}
+
+		userValues.NetFlows, err = getNetFlowsValues(ctx, k8s, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network flows values: %w", err)
+		}
+
+		userValues.COO, err = getCOOValues(ctx, k8s, logger, cluster, mcAddon, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -90,9 +137,10 @@ func GetValuesFunc(ctx context.Context, k8s client.Client, getter addonutils.Add
 			return nil, fmt.Errorf("failed to get right-sizing values: %w", err)
 		}
 
-		// WIP: Temporary solution to enable obs-api and will require to delete the mcoa pod to take effect.
-		obsAPIEnabled := aodc.Annotations["mcoa-obs-api"] == "true"
-		userValues.ObsAPI = omanifests.BuildValues(common.IsHubCluster(cluster), obsAPIEnabled)
+		userValues.ObsAPI, err = getObsAPIValues(ctx, k8s, logger, cluster, mcAddon, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get obs-api values: %w", err)
+		}
 
 		// WIP: Temporary solution to enable thanos-operator and will require to delete the mcoa pod to take effect.
 		if userValues.Metrics != nil {
@@ -136,7 +184,7 @@ func getLoggingValues(ctx context.Context, k8s client.Client, cluster *clusterv1
 		return nil, nil
 	}
 
-	loggingOpts, err := lhandlers.BuildOptions(ctx, k8s, mcAddon, opts.Platform.Logs, opts.UserWorkloads.Logs, common.IsHubCluster(cluster))
+	loggingOpts, err := lhandlers.BuildOptions(ctx, k8s, mcAddon, opts.Platform.Logs, opts.UserWorkloads.Logs, opts.OTelGateway, common.IsHubCluster(cluster), opts.Platform.Metrics.CollectionEnabled)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +192,7 @@ func getLoggingValues(ctx context.Context, k8s client.Client, cluster *clusterv1
 	return lmanifests.BuildValues(loggingOpts)
 }
 
-func getTracingValues(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*tmanifests.TracingValues, error) {
+func getTracingValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*tmanifests.TracingValues, error) {
 	if common.IsHubCluster(cluster) || !opts.UserWorkloads.Traces.CollectionEnabled {
 		return nil, nil
 	}
@@ -153,7 +201,7 @@ func getTracingValues(ctx context.Context, k8s client.Client, cluster *clusterv1
 		return nil, nil
 	}
 
-	tracingOpts, err := thandlers.BuildOptions(ctx, k8s, mcAddon, opts.UserWorkloads.Traces)
+	tracingOpts, err := thandlers.BuildOptions(ctx, k8s, logger, cluster, mcAddon, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +214,107 @@ func getTracingValues(ctx context.Context, k8s client.Client, cluster *clusterv1
 	return &tracing, nil
 }
 
-func getCOOValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, opts addon.Options) (*cmanifests.COOValues, error) {
+// getOTelGatewayValues returns the values of the gateway receiving the traces
+// and logs of the managed clusters. It is only deployed on the hub.
+func getOTelGatewayValues(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*gwmanifests.OTelGatewayValues, error) {
+	if !opts.OTelGateway.Enabled || !common.IsHubCluster(cluster) {
+		return nil, nil
+	}
+
+	gatewayOpts, err := gwhandlers.BuildOptions(ctx, k8s, mcAddon, opts.OTelGateway, opts.HubLokiStack, opts.Registries)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := gwmanifests.BuildValues(gatewayOpts)
+	if err != nil {
+		return nil, err
+	}
+	values.SubscriptionChannel = opts.UserWorkloads.Traces.SubscriptionChannel
+
+	return values, nil
+}
+
+// getLokiStackValues returns the values of the LokiStack receiving the logs
+// of the managed clusters. It is only deployed on the hub.
+func getLokiStackValues(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*lsmanifests.LokiStackValues, error) {
+	if !opts.HubLokiStack.Enabled || !common.IsHubCluster(cluster) {
+		return nil, nil
+	}
+
+	channel := opts.Platform.Logs.SubscriptionChannel
+	if channel == "" {
+		channel = opts.UserWorkloads.Logs.SubscriptionChannel
+	}
+	lokiStackOpts, err := lshandlers.BuildOptions(ctx, k8s, mcAddon, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return lsmanifests.BuildValues(lokiStackOpts), nil
+}
+
+func getEventsValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*emanifests.EventsValues, error) {
+	if !opts.Platform.Events.CollectionEnabled {
+		return nil, nil
+	}
+
+	if !common.IsOpenShiftVendor(cluster) {
+		logger.Info("skipping the events collector, it is only deployed on OpenShift clusters")
+		return nil, nil
+	}
+
+	eventsOpts, err := ehandlers.BuildOptions(ctx, k8s, cluster, mcAddon, opts.Platform.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := emanifests.BuildValues(eventsOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &events, nil
+}
+
+func getProfilingValues(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*pmanifests.ProfilingValues, error) {
+	if common.IsHubCluster(cluster) || !opts.UserWorkloads.Profiles.CollectionEnabled {
+		return nil, nil
+	}
+
+	if !common.IsOpenShiftVendor(cluster) {
+		return nil, nil
+	}
+
+	profilingOpts, err := phandlers.BuildOptions(ctx, k8s, cluster, mcAddon, opts.UserWorkloads.Profiles, opts.Registries)
+	if err != nil {
+		return nil, err
+	}
+
+	profiling, err := pmanifests.BuildValues(profilingOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiling, nil
+}
+
+// getNetFlowsValues returns the values of the FlowCollector of OpenShift
+// clusters. Its metrics are federated when platform metrics are collected.
+func getNetFlowsValues(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*nfmanifests.NetFlowsValues, error) {
+	if !opts.Platform.NetworkFlows.CollectionEnabled || !common.IsOpenShiftVendor(cluster) {
+		return nil, nil
+	}
+
+	netflowsOpts, err := nfhandlers.BuildOptions(ctx, k8s, mcAddon, opts.Platform.NetworkFlows, opts.Platform.Metrics.CollectionEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return nfmanifests.BuildValues(netflowsOpts)
+}
+
+func getCOOValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*cmanifests.COOValues, error) {
 	if !common.IsOpenShiftVendor(cluster) {
 		return nil, nil
 	}
@@ -178,7 +326,47 @@ func getCOOValues(ctx context.Context, k8s client.Client, logger logr.Logger, cl
 
 	hasCardinalityRules := chandlers.HasCardinalityRules(ctx, k8s, common.IsHubCluster(cluster))
 
-	return cmanifests.BuildValues(opts, installCOO, common.IsHubCluster(cluster), hasCardinalityRules), nil
+	hasVirtualizationClusters, err := chandlers.HasVirtualizationClusters(ctx, k8s, common.IsHubCluster(cluster))
+	if err != nil {
+		return nil, err
+	}
+
+	userDashboards := chandlers.GetUserDashboards(ctx, k8s, logger, common.IsHubCluster(cluster))
+
+	lokiStack := chandlers.GetLokiStackOutput(ctx, k8s, logger, mcAddon, opts.HubLokiStack, common.IsHubCluster(cluster))
+
+	tracingOutput := chandlers.GetTracingOutput(ctx, k8s, logger, mcAddon, common.IsHubCluster(cluster))
+
+	profilingOutput := chandlers.GetProfilingOutput(ctx, k8s, logger, mcAddon, common.IsHubCluster(cluster))
+
+	teams := chandlers.GetTeamProjects(ctx, k8s, logger, common.IsHubCluster(cluster), opts.Registries)
+	return cmanifests.BuildValues(cmanifests.Options{
+		Addon:                     opts,
+		InstallCOO:                installCOO,
+		IsHub:                     common.IsHubCluster(cluster),
+		HasCardinalityRules:       hasCardinalityRules,
+		HasVirtualizationClusters: hasVirtualizationClusters,
+		UserDashboards:            userDashboards,
+		LokiStack:                 lokiStack,
+		Tracing:                   tracingOutput,
+		Profiling:                 profilingOutput,
+		Teams:                     teams,
+	}), nil
+}
+
+// getObsAPIValues returns the values of the observability API of the hub,
+// which authorizes the access to the metrics, logs and traces of the tenants.
+func getObsAPIValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, opts addon.Options) (*omanifests.ObsAPIValues, error) {
+	if !opts.HubObsAPI.Enabled || !common.IsHubCluster(cluster) {
+		return nil, nil
+	}
+
+	obsAPIOpts, err := ohandlers.BuildOptions(ctx, k8s, logger, mcAddon, opts.HubObsAPI.Endpoint.Hostname(), opts.Registries)
+	if err != nil {
+		return nil, err
+	}
+
+	return omanifests.BuildValues(obsAPIOpts)
 }
 
 func getRightSizingValues(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, opts addon.Options) (*rshandlers.RightSizingValues, error) {
@@ -4,18 +4,29 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	uiplugin "github.com/rhobs/observability-operator/pkg/apis/uiplugin/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	rsexport "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
+	rshandlers "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/handlers"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	nfmanifests "github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	tmanifests "github.com/stolostron/multicluster-observability-addon/internal/tracing/manifests"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	"open-cluster-management.io/addon-framework/pkg/addonfactory"
@@ -32,11 +43,16 @@ var (
 	_ = loggingv1.AddToScheme(scheme.Scheme)
 	_ = operatorsv1.AddToScheme(scheme.Scheme)
 	_ = operatorsv1alpha1.AddToScheme(scheme.Scheme)
+	_ = otelv1beta1.AddToScheme(scheme.Scheme)
 	_ = prometheusv1.AddToScheme(scheme.Scheme)
+	_ = cooprometheusv1.AddToScheme(scheme.Scheme)
 	_ = cooprometheusv1alpha1.AddToScheme(scheme.Scheme)
 	_ = addonapiv1beta1.Install(scheme.Scheme)
 	_ = apiextensionsv1.AddToScheme(scheme.Scheme)
 	_ = uiplugin.AddToScheme(scheme.Scheme)
+	_ = rsexport.AddToScheme(scheme.Scheme)
+	_ = nfmanifests.AddToScheme(scheme.Scheme)
+	_ = lsmanifests.AddToScheme(scheme.Scheme)
 )
 
 func newTestGetter(aodc *addonapiv1beta1.AddOnDeploymentConfig) addonutils.AddOnDeploymentConfigGetter {
@@ -286,3 +302,187 @@ func TestRSOnlyBothDisabled_ManifestsNotEmpty(t *testing.T) {
 		}
 	}
 }
+
+// TestRSExport_ManifestsDecode ensures the exported VerticalPodAutoscalers and
+// reports render to objects that the addon scheme can decode.
+func TestRSExport_ManifestsDecode(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	values, err := rshandlers.BuildValues(rshandlers.Options{
+		IsOpenShiftVendor:    true,
+		NamespaceRightSizing: rshandlers.ComponentOptions{Enabled: true},
+		Export: rshandlers.ExportOptions{
+			VPACRD:  true,
+			Formats: []string{rightsizing.ExportFormatVPA, rightsizing.ExportFormatReport},
+			Recommendations: []rsexport.Recommendation{
+				{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURecommendation: 0.25, MemoryRecommendation: 64 * 1024 * 1024},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	agentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.McoaChartDir).
+		WithGetValuesFuncs(func(*clusterv1.ManagedCluster, *addonapiv1beta1.ManagedClusterAddOn) (addonfactory.Values, error) {
+			return addonfactory.JsonStructToValues(HelmChartValues{Enabled: true, RightSizing: values})
+		}).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := agentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+
+	var vpa *rsexport.VerticalPodAutoscaler
+	var report *corev1.ConfigMap
+	var crd *apiextensionsv1.CustomResourceDefinition
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *apiextensionsv1.CustomResourceDefinition:
+			crd = o
+		case *rsexport.VerticalPodAutoscaler:
+			vpa = o
+		case *corev1.ConfigMap:
+			if o.Name == rightsizing.ReportConfigMapName {
+				report = o
+			}
+		}
+	}
+	require.NotNil(t, crd)
+	require.Equal(t, rsexport.VPACRDName, crd.Name)
+	require.NotNil(t, vpa)
+	require.Equal(t, "shop", vpa.Namespace)
+	require.Equal(t, "Off", *vpa.Spec.UpdatePolicy.UpdateMode)
+	require.JSONEq(t, `[{"name":"nginx","cpu":"250m","memory":"64Mi"}]`, vpa.Annotations[rsexport.RecommendationAnnotation])
+	require.NotNil(t, report)
+	require.Contains(t, report.Data["recommendations.csv"], "shop,web,deployment,nginx")
+}
+
+// TestRSRules_NonOCPTarget ensures right-sizing rules of non-OpenShift clusters are
+// rendered for the addon's Prometheus server instead of openshift-monitoring.
+func TestRSRules_NonOCPTarget(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	for _, tc := range []struct {
+		name              string
+		isOpenShiftVendor bool
+		expectedNamespace string
+		expectedCOORule   bool
+	}{
+		{name: "openshift", isOpenShiftVendor: true, expectedNamespace: "openshift-monitoring"},
+		{name: "non-openshift", isOpenShiftVendor: false, expectedNamespace: "open-cluster-management-agent-addon", expectedCOORule: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := rshandlers.BuildValues(rshandlers.Options{
+				IsOpenShiftVendor: tc.isOpenShiftVendor,
+				NamespaceRightSizing: rshandlers.ComponentOptions{
+					Enabled:         true,
+					PrometheusRules: []*prometheusv1.PrometheusRule{{ObjectMeta: metav1.ObjectMeta{Name: rightsizing.NamespacePrometheusRuleName}}},
+				},
+			})
+			require.NoError(t, err)
+
+			agentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.McoaChartDir).
+				WithGetValuesFuncs(func(*clusterv1.ManagedCluster, *addonapiv1beta1.ManagedClusterAddOn) (addonfactory.Values, error) {
+					return addonfactory.JsonStructToValues(HelmChartValues{Enabled: true, RightSizing: values})
+				}).
+				WithAgentRegistrationOption(&agent.RegistrationOption{}).
+				WithScheme(scheme.Scheme).
+				BuildHelmAgentAddon()
+			require.NoError(t, err)
+
+			objects, err := agentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+			require.NoError(t, err)
+
+			found := false
+			for _, obj := range objects {
+				switch o := obj.(type) {
+				case *prometheusv1.PrometheusRule:
+					require.False(t, tc.expectedCOORule, "unexpected monitoring.coreos.com rule")
+					require.Equal(t, tc.expectedNamespace, o.Namespace)
+					found = true
+				case *cooprometheusv1.PrometheusRule:
+					require.True(t, tc.expectedCOORule, "unexpected monitoring.rhobs rule")
+					require.Equal(t, tc.expectedNamespace, o.Namespace)
+					found = true
+				}
+			}
+			require.True(t, found, "right-sizing PrometheusRule not rendered")
+		})
+	}
+}
+
+// TestNetFlowsScrapeConfig_PlatformCollector ensures the network flows
+// ScrapeConfig is selected by the platform PrometheusAgent.
+func TestNetFlowsScrapeConfig_PlatformCollector(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	fc := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{}}}
+	fc.SetGroupVersionKind(nfmanifests.FlowCollectorGVK)
+	values, err := nfmanifests.BuildValues(nfmanifests.Options{
+		FlowCollector: fc,
+		ScrapeConfig:  nfmanifests.GenerateScrapeConfig(),
+	})
+	require.NoError(t, err)
+
+	agentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.McoaChartDir).
+		WithGetValuesFuncs(func(*clusterv1.ManagedCluster, *addonapiv1beta1.ManagedClusterAddOn) (addonfactory.Values, error) {
+			return addonfactory.JsonStructToValues(HelmChartValues{Enabled: true, NetFlows: values})
+		}).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := agentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+
+	var sc *cooprometheusv1alpha1.ScrapeConfig
+	for _, obj := range objects {
+		if o, ok := obj.(*cooprometheusv1alpha1.ScrapeConfig); ok && o.Name == nfmanifests.ScrapeConfigName {
+			sc = o
+		}
+	}
+	require.NotNil(t, sc)
+	require.True(t, labels.SelectorFromSet(mconfig.PlatformPrometheusMatchLabels).Matches(labels.Set(sc.Labels)))
+	require.Equal(t, mconfig.ScrapeClassCfgName, *sc.Spec.ScrapeClassName)
+	require.Contains(t, sc.Spec.Params["match[]"], `{__name__="netobserv_namespace_ingress_bytes_total"}`)
+}
+
+// TestSpanMetricsScrapeConfig_UserWorkloadCollector ensures the span metrics
+// ScrapeConfig is selected by the user workloads PrometheusAgent.
+func TestSpanMetricsScrapeConfig_UserWorkloadCollector(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	values, err := tmanifests.BuildValues(tmanifests.Options{
+		OpenTelemetryCollector: &otelv1beta1.OpenTelemetryCollector{},
+		ScrapeConfig:           tmanifests.GenerateScrapeConfig(),
+	})
+	require.NoError(t, err)
+
+	agentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.McoaChartDir).
+		WithGetValuesFuncs(func(*clusterv1.ManagedCluster, *addonapiv1beta1.ManagedClusterAddOn) (addonfactory.Values, error) {
+			return addonfactory.JsonStructToValues(HelmChartValues{Enabled: true, Tracing: &values})
+		}).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := agentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+
+	var rendered *cooprometheusv1alpha1.ScrapeConfig
+	for _, obj := range objects {
+		if o, ok := obj.(*cooprometheusv1alpha1.ScrapeConfig); ok && o.Name == tmanifests.ScrapeConfigName {
+			rendered = o
+		}
+	}
+	require.NotNil(t, rendered)
+	require.True(t, labels.SelectorFromSet(mconfig.UserWorkloadPrometheusMatchLabels).Matches(labels.Set(rendered.Labels)))
+	require.Equal(t, cooprometheusv1alpha1.Target("mcoa-instance-collector.mcoa-opentelemetry.svc:8889"), rendered.Spec.StaticConfigs[0].Targets[0])
+}
@@ -2,18 +2,21 @@ package addon
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 	"strings"
 
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 )
 
 const (
 	// Operator Subscription Channels
 	KeyOpenShiftLoggingChannel = "openshiftLoggingChannel"
+	KeyOpenTelemetryChannel    = "openTelemetryChannel"
 
 	// Platform Observability Keys
 	KeyPlatformMetricsCollection         = "platformMetricsCollection"
@@ -23,18 +26,34 @@ const (
 	KeyPlatformVirtualizationRightSizing = "platformVirtualizationRightSizing"
 	KeyRightSizingDelegated              = "rightSizingDelegated"
 	KeyMetricsHubHostname                = "metricsHubHostname"
+	KeyMetricsExemplars                  = "metricsExemplars"
+	KeyMetricsNativeHistograms           = "metricsNativeHistograms"
+	KeyOTelGatewayHostname               = "otelGatewayHostname"
+	KeyHubLokiStack                      = "hubLokiStack"
+	KeyHubObservatoriumAPIHostname       = "hubObservatoriumAPIHostname"
 	KeyNodeExporterHostPort              = "nodeExporterHostPort"
 	KeyNodeExporterInternalPort          = "nodeExporterInternalPort"
 	KeyPlatformMetricsAlerts             = "platformMetricsAlerts"
+	KeyPlatformEventsCollection          = "platformEventsCollection"
+	KeyPlatformEventsEndpoint            = "platformEventsEndpoint"
+	KeyPlatformEventsExporter            = "platformEventsExporter"
+	KeyPlatformEventsSecret              = "platformEventsSecret"
+	KeyPlatformNetworkFlowsCollection    = "platformNetworkFlowsCollection"
 
 	// User Workloads Observability Keys
-	KeyUserWorkloadMetricsCollection = "userWorkloadMetricsCollection"
-	KeyUserWorkloadLogsCollection    = "userWorkloadLogsCollection"
-	KeyUserWorkloadTracesCollection  = "userWorkloadTracesCollection"
-	KeyUserWorkloadInstrumentation   = "userWorkloadInstrumentation"
-	KeyUserWorkloadMetricsAlerts     = "userWorkloadMetricsAlerts"
+	KeyUserWorkloadMetricsCollection  = "userWorkloadMetricsCollection"
+	KeyUserWorkloadLogsCollection     = "userWorkloadLogsCollection"
+	KeyUserWorkloadTracesCollection   = "userWorkloadTracesCollection"
+	KeyUserWorkloadInstrumentation    = "userWorkloadInstrumentation"
+	KeyUserWorkloadProfilesCollection = "userWorkloadProfilesCollection"
+	KeyUserWorkloadProfilesEBPF       = "userWorkloadProfilesEBPF"
+	KeyUserWorkloadMetricsAlerts      = "userWorkloadMetricsAlerts"
+	KeyUserWorkloadMetricsOTLP        = "userWorkloadMetricsOTLP"
 
 	KeyPlatformMetricsUI = "platformMetricsUI"
+	// KeyPlatformVirtualizationDashboards forces the virtualization dashboards "enabled" or "disabled".
+	// When absent, they are deployed if any managed cluster runs OpenShift Virtualization.
+	KeyPlatformVirtualizationDashboards = "platformVirtualizationDashboards"
 )
 
 type CollectionKind string
@@ -43,6 +62,9 @@ const (
 	ClusterLogForwarderV1         CollectionKind = "clusterlogforwarders.v1.observability.openshift.io"
 	OpenTelemetryCollectorV1beta1 CollectionKind = "opentelemetrycollectors.v1beta1.opentelemetry.io"
 	PrometheusAgentV1alpha1       CollectionKind = "prometheusagents.v1alpha1.monitoring.rhobs"
+	DaemonSetV1                   CollectionKind = "daemonsets.v1.apps"
+	FlowCollectorV1beta2          CollectionKind = "flowcollectors.v1beta2.flows.netobserv.io"
+	LokiStackV1                   CollectionKind = "lokistacks.v1.loki.grafana.com"
 )
 
 type InstrumentationKind string
@@ -51,6 +73,16 @@ const (
 	InstrumentationV1alpha1 InstrumentationKind = "instrumentations.v1alpha1.opentelemetry.io"
 )
 
+type EventsExporter string
+
+const (
+	// EventsExporterOTLP sends the events to an OTLP gRPC endpoint.
+	EventsExporterOTLP EventsExporter = "otlp"
+	// EventsExporterLoki sends the events to the OTLP HTTP endpoint of Loki,
+	// e.g. https://loki.example.com/otlp.
+	EventsExporterLoki EventsExporter = "loki"
+)
+
 type UIKind string
 
 const (
@@ -63,6 +95,16 @@ type MetricsOptions struct {
 	UI                MetricsUIOptions
 	NodeExporter      NodeExporterOptions
 	AlertsEnabled     bool
+	// ExemplarsEnabled and NativeHistogramsEnabled make the Prometheus
+	// agents and servers of the managed clusters collect exemplars and native
+	// histograms and send them to the hub. They apply to the platform and user
+	// workloads metrics.
+	ExemplarsEnabled        bool
+	NativeHistogramsEnabled bool
+	// OTLPEnabled makes the collector of the user workload traces also
+	// receive OTLP metrics and write them to the hub. It only applies to the
+	// user workloads.
+	OTLPEnabled bool
 }
 
 type NodeExporterOptions struct {
@@ -79,16 +121,40 @@ type LogsOptions struct {
 	SubscriptionChannel string
 }
 
+type EventsOptions struct {
+	CollectionEnabled bool
+	Endpoint          string
+	Exporter          EventsExporter
+	// Secret is the name of the secret holding the tls.crt, tls.key and
+	// ca.crt used to connect to the endpoint, if any.
+	Secret string
+}
+
+type NetworkFlowsOptions struct {
+	CollectionEnabled bool
+}
+
 type TracesOptions struct {
 	CollectionEnabled      bool
 	InstrumentationEnabled bool
-	SubscriptionChannel    string
+	// SubscriptionChannel is the channel of the OpenTelemetry operator
+	// Subscription shared by the tracing, events and gateway charts.
+	SubscriptionChannel string
+}
+
+type ProfilesOptions struct {
+	CollectionEnabled bool
+	// EBPFEnabled allows the eBPF mode of the profiling agent, which runs
+	// privileged in the host PID namespace.
+	EBPFEnabled bool
 }
 
 type PlatformOptions struct {
 	Enabled          bool
 	Metrics          MetricsOptions
 	Logs             LogsOptions
+	Events           EventsOptions
+	NetworkFlows     NetworkFlowsOptions
 	AnalyticsOptions AnalyticsOptions
 }
 
@@ -104,14 +170,41 @@ type AnalyticsOptions struct {
 }
 
 type UserWorkloadOptions struct {
-	Enabled bool
-	Metrics MetricsOptions
-	Logs    LogsOptions
-	Traces  TracesOptions
+	Enabled  bool
+	Metrics  MetricsOptions
+	Logs     LogsOptions
+	Traces   TracesOptions
+	Profiles ProfilesOptions
 }
 
 type MetricsUIOptions struct {
 	Enabled bool
+	// VirtualizationDashboards overrides the fleet detection of OpenShift Virtualization
+	// when set; nil means auto-detect.
+	VirtualizationDashboards *bool
+}
+
+// OTelGatewayOptions configures the OpenTelemetry collector gateway deployed
+// on the hub. When enabled, the traces and logs of the managed clusters are
+// sent to the gateway over mTLS instead of to the exporters of their templates.
+type OTelGatewayOptions struct {
+	Enabled  bool
+	Endpoint url.URL
+}
+
+// HubLokiStackOptions configures the LokiStack provisioned on the hub. When
+// enabled, the logs of the managed clusters are forwarded to it through the
+// gateway instead of to the outputs of their ClusterLogForwarder template.
+type HubLokiStackOptions struct {
+	Enabled bool
+}
+
+// HubObsAPIOptions configures the observability API deployed on the hub in
+// front of the metrics, logs and traces stores. The managed clusters write the
+// metrics of the tenants of the hub to its Endpoint.
+type HubObsAPIOptions struct {
+	Enabled  bool
+	Endpoint url.URL
 }
 
 type ProxyConfig struct {
@@ -127,6 +220,9 @@ type Options struct {
 	NodeSelector          map[string]string
 	ResourceReqs          []addonapiv1beta1.ContainerResourceRequirements
 	ProxyConfig           ProxyConfig
+	OTelGateway           OTelGatewayOptions
+	HubLokiStack          HubLokiStackOptions
+	HubObsAPI             HubObsAPIOptions
 	Registries            []addonapiv1beta1.ImageMirror
 	ThanosOperatorEnabled bool
 }
@@ -136,6 +232,16 @@ func (o Options) validate() error {
 		return err
 	}
 
+	if err := o.validateEvents(); err != nil {
+		return err
+	}
+
+	// The managed clusters send their logs to the LokiStack of the hub through
+	// the gateway, which authenticates them.
+	if o.HubLokiStack.Enabled && !o.OTelGateway.Enabled {
+		return addoncfg.ErrInvalidHubLokiStack
+	}
+
 	return nil
 }
 
@@ -148,9 +254,55 @@ func (o Options) validateMetrics() error {
 		return addoncfg.ErrInvalidMetricsHubHostname
 	}
 
+	// The OTLP metrics are received by the collector of the traces and go
+	// through the allow-list of the user workload metrics.
+	if o.UserWorkloads.Metrics.OTLPEnabled && (!o.UserWorkloads.Metrics.CollectionEnabled || !o.UserWorkloads.Traces.CollectionEnabled) {
+		return addoncfg.ErrInvalidUserWorkloadMetricsOTLP
+	}
+
 	return nil
 }
 
+func (o Options) validateEvents() error {
+	if !o.Platform.Events.CollectionEnabled {
+		return nil
+	}
+
+	if o.Platform.Events.Endpoint == "" {
+		return addoncfg.ErrInvalidEventsEndpoint
+	}
+
+	switch o.Platform.Events.Exporter {
+	case EventsExporterOTLP:
+	case EventsExporterLoki:
+		// The OTLP HTTP exporter needs the scheme of the endpoint.
+		if !IsURL(o.Platform.Events.Endpoint) {
+			return fmt.Errorf("%w: %q, the loki exporter needs a URL", addoncfg.ErrInvalidEventsEndpoint, o.Platform.Events.Endpoint)
+		}
+	default:
+		return fmt.Errorf("%w: %q", addoncfg.ErrInvalidEventsExporter, o.Platform.Events.Exporter)
+	}
+
+	return nil
+}
+
+// IsURL reports whether the endpoint is a URL with a host.
+func IsURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// IsHostPort reports whether the endpoint is a bare host:port, the form
+// expected by the OTLP gRPC exporters.
+func IsHostPort(endpoint string) bool {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil || host == "" {
+		return false
+	}
+	_, err = strconv.ParseUint(port, 10, 16)
+	return err == nil
+}
+
 // BuildOptions parses an AddOnDeploymentConfig into addon Options.
 func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Options, error) {
 	var opts Options
@@ -198,6 +350,8 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 		case KeyOpenShiftLoggingChannel:
 			opts.Platform.Logs.SubscriptionChannel = keyvalue.Value
 			opts.UserWorkloads.Logs.SubscriptionChannel = keyvalue.Value
+		case KeyOpenTelemetryChannel:
+			opts.UserWorkloads.Traces.SubscriptionChannel = keyvalue.Value
 		// Platform Observability Options
 		case KeyMetricsHubHostname:
 			val := keyvalue.Value
@@ -218,6 +372,48 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 			}
 
 			opts.Platform.Metrics.HubEndpoint = *url
+		case KeyMetricsExemplars:
+			if keyvalue.Value == "enabled" {
+				opts.Platform.Metrics.ExemplarsEnabled = true
+			}
+		case KeyMetricsNativeHistograms:
+			if keyvalue.Value == "enabled" {
+				opts.Platform.Metrics.NativeHistogramsEnabled = true
+			}
+		case KeyOTelGatewayHostname:
+			val := keyvalue.Value
+			if !strings.HasPrefix(val, "http") {
+				val = "https://" + val
+			}
+			url, err := url.Parse(val)
+			if err != nil {
+				return opts, fmt.Errorf("%w: %s", addoncfg.ErrInvalidOTelGatewayHostname, err.Error())
+			}
+			if strings.TrimSpace(url.Host) == "" || url.Host == ":" || strings.HasPrefix(url.Host, ":") {
+				return opts, fmt.Errorf("%w: invalid hostname format '%s'", addoncfg.ErrInvalidOTelGatewayHostname, url.Host)
+			}
+
+			opts.OTelGateway.Enabled = true
+			opts.OTelGateway.Endpoint = *url
+		case KeyHubLokiStack:
+			if keyvalue.Value == string(LokiStackV1) {
+				opts.HubLokiStack.Enabled = true
+			}
+		case KeyHubObservatoriumAPIHostname:
+			val := keyvalue.Value
+			if !strings.HasPrefix(val, "http") {
+				val = "https://" + val
+			}
+			url, err := url.Parse(val)
+			if err != nil {
+				return opts, fmt.Errorf("%w: %s", addoncfg.ErrInvalidObsAPIHostname, err.Error())
+			}
+			if strings.TrimSpace(url.Host) == "" || url.Host == ":" || strings.HasPrefix(url.Host, ":") {
+				return opts, fmt.Errorf("%w: invalid hostname format '%s'", addoncfg.ErrInvalidObsAPIHostname, url.Host)
+			}
+
+			opts.HubObsAPI.Enabled = true
+			opts.HubObsAPI.Endpoint = *url
 		case KeyPlatformMetricsAlerts:
 			if keyvalue.Value == "enabled" {
 				opts.Platform.Metrics.AlertsEnabled = true
@@ -226,6 +422,10 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 			if keyvalue.Value == "enabled" {
 				opts.UserWorkloads.Metrics.AlertsEnabled = true
 			}
+		case KeyUserWorkloadMetricsOTLP:
+			if keyvalue.Value == "enabled" {
+				opts.UserWorkloads.Metrics.OTLPEnabled = true
+			}
 		case KeyNodeExporterHostPort:
 			port, err := parsePort(keyvalue.Name, keyvalue.Value)
 			if err != nil {
@@ -248,6 +448,25 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 				opts.Platform.Enabled = true
 				opts.Platform.Logs.CollectionEnabled = true
 			}
+		case KeyPlatformEventsCollection:
+			if keyvalue.Value == string(OpenTelemetryCollectorV1beta1) {
+				opts.Platform.Enabled = true
+				opts.Platform.Events.CollectionEnabled = true
+			}
+		case KeyPlatformEventsEndpoint:
+			if !IsURL(keyvalue.Value) && !IsHostPort(keyvalue.Value) {
+				return opts, fmt.Errorf("%w: %q", addoncfg.ErrInvalidEventsEndpoint, keyvalue.Value)
+			}
+			opts.Platform.Events.Endpoint = keyvalue.Value
+		case KeyPlatformEventsExporter:
+			opts.Platform.Events.Exporter = EventsExporter(keyvalue.Value)
+		case KeyPlatformEventsSecret:
+			opts.Platform.Events.Secret = keyvalue.Value
+		case KeyPlatformNetworkFlowsCollection:
+			if keyvalue.Value == string(FlowCollectorV1beta2) {
+				opts.Platform.Enabled = true
+				opts.Platform.NetworkFlows.CollectionEnabled = true
+			}
 		case KeyPlatformIncidentDetection:
 			if keyvalue.Value == string(UIPluginV1alpha1) {
 				opts.Platform.Enabled = true
@@ -288,6 +507,15 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 				opts.UserWorkloads.Enabled = true
 				opts.UserWorkloads.Traces.CollectionEnabled = true
 			}
+		case KeyUserWorkloadProfilesCollection:
+			if keyvalue.Value == string(DaemonSetV1) {
+				opts.UserWorkloads.Enabled = true
+				opts.UserWorkloads.Profiles.CollectionEnabled = true
+			}
+		case KeyUserWorkloadProfilesEBPF:
+			if keyvalue.Value == "true" {
+				opts.UserWorkloads.Profiles.EBPFEnabled = true
+			}
 		case KeyUserWorkloadInstrumentation:
 			if keyvalue.Value == string(InstrumentationV1alpha1) {
 				opts.UserWorkloads.Enabled = true
@@ -298,6 +526,15 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 			if keyvalue.Value == string(UIPluginV1alpha1) {
 				opts.Platform.Metrics.UI.Enabled = true
 			}
+		case KeyPlatformVirtualizationDashboards:
+			switch keyvalue.Value {
+			case "enabled":
+				opts.Platform.Metrics.UI.VirtualizationDashboards = ptr.To(true)
+			case "disabled":
+				opts.Platform.Metrics.UI.VirtualizationDashboards = ptr.To(false)
+			default:
+				return opts, fmt.Errorf("%w: %q, expected \"enabled\" or \"disabled\"", addoncfg.ErrInvalidVirtDashboards, keyvalue.Value)
+			}
 		}
 	}
 
@@ -326,6 +563,10 @@ func BuildOptions(addOnDeployment *addonapiv1beta1.AddOnDeploymentConfig) (Optio
 		opts.Platform.Metrics.UI.Enabled = false
 	}
 
+	if opts.Platform.Events.CollectionEnabled && opts.Platform.Events.Exporter == "" {
+		opts.Platform.Events.Exporter = EventsExporterOTLP
+	}
+
 	return opts, opts.validate()
 }
 
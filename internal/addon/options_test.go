@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 )
 
@@ -135,6 +136,40 @@ func TestBuildOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "exemplars and native histograms",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformMetricsCollection, Value: string(PrometheusAgentV1alpha1)},
+						{Name: KeyMetricsHubHostname, Value: "https://metrics.example.com"},
+						{Name: KeyMetricsExemplars, Value: "enabled"},
+						{Name: KeyMetricsNativeHistograms, Value: "enabled"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Metrics: MetricsOptions{
+						CollectionEnabled: true,
+						HubEndpoint: url.URL{
+							Scheme: "https",
+							Host:   "metrics.example.com",
+							Path:   "api/metrics/v1/default/api/v1/receive",
+						},
+						ExemplarsEnabled:        true,
+						NativeHistogramsEnabled: true,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "invalid metrics hub hostname",
 			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
@@ -187,6 +222,7 @@ func TestBuildOptions(t *testing.T) {
 					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
 						{Name: KeyUserWorkloadTracesCollection, Value: string(OpenTelemetryCollectorV1beta1)},
 						{Name: KeyUserWorkloadInstrumentation, Value: string(InstrumentationV1alpha1)},
+						{Name: KeyOpenTelemetryChannel, Value: "stable-0.140"},
 					},
 				},
 			},
@@ -205,10 +241,339 @@ func TestBuildOptions(t *testing.T) {
 					Traces: TracesOptions{
 						CollectionEnabled:      true,
 						InstrumentationEnabled: true,
+						SubscriptionChannel:    "stable-0.140",
+					},
+				},
+			},
+		},
+		{
+			name: "valid profiles",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyUserWorkloadProfilesCollection, Value: string(DaemonSetV1)},
+						{Name: KeyUserWorkloadProfilesEBPF, Value: "true"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+				UserWorkloads: UserWorkloadOptions{
+					Enabled: true,
+					Profiles: ProfilesOptions{
+						CollectionEnabled: true,
+						EBPFEnabled:       true,
+					},
+				},
+			},
+		},
+		{
+			name: "valid network flows",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformNetworkFlowsCollection, Value: string(FlowCollectorV1beta2)},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					NetworkFlows: NetworkFlowsOptions{
+						CollectionEnabled: true,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "valid otel gateway hostname",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyOTelGatewayHostname, Value: "otel-gateway.example.com"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+				OTelGateway: OTelGatewayOptions{
+					Enabled: true,
+					Endpoint: url.URL{
+						Scheme: "https",
+						Host:   "otel-gateway.example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "valid hub lokistack",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformLogsCollection, Value: string(ClusterLogForwarderV1)},
+						{Name: KeyHubLokiStack, Value: string(LokiStackV1)},
+						{Name: KeyOTelGatewayHostname, Value: "otel-gateway.example.com"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Logs: LogsOptions{
+						CollectionEnabled: true,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+				HubLokiStack: HubLokiStackOptions{
+					Enabled: true,
+				},
+				OTelGateway: OTelGatewayOptions{
+					Enabled: true,
+					Endpoint: url.URL{
+						Scheme: "https",
+						Host:   "otel-gateway.example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "hub lokistack without gateway",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformLogsCollection, Value: string(ClusterLogForwarderV1)},
+						{Name: KeyHubLokiStack, Value: string(LokiStackV1)},
+					},
+				},
+			},
+			expectedErrMsg: "the LokiStack of the hub requires the otel gateway",
+		},
+		{
+			name: "valid hub observatorium api",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformLogsCollection, Value: string(ClusterLogForwarderV1)},
+						{Name: KeyHubObservatoriumAPIHostname, Value: "observatorium-api.example.com"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Logs: LogsOptions{
+						CollectionEnabled: true,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+				HubObsAPI: HubObsAPIOptions{
+					Enabled: true,
+					Endpoint: url.URL{
+						Scheme: "https",
+						Host:   "observatorium-api.example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid otel gateway hostname",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyOTelGatewayHostname, Value: "://invalid-url"},
+					},
+				},
+			},
+			expectedErrMsg: "invalid otel gateway hostname: invalid hostname format ':'",
+		},
+		{
+			name: "invalid hub observatorium api hostname",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyHubObservatoriumAPIHostname, Value: "://invalid-url"},
+					},
+				},
+			},
+			expectedErrMsg: "invalid observatorium api hostname: invalid hostname format ':'",
+		},
+		{
+			name: "valid events",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+						{Name: KeyPlatformEventsEndpoint, Value: "https://loki.example.com/otlp"},
+						{Name: KeyPlatformEventsExporter, Value: string(EventsExporterLoki)},
+						{Name: KeyPlatformEventsSecret, Value: "events-tls"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Events: EventsOptions{
+						CollectionEnabled: true,
+						Endpoint:          "https://loki.example.com/otlp",
+						Exporter:          EventsExporterLoki,
+						Secret:            "events-tls",
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
 					},
 				},
 			},
 		},
+		{
+			name: "events exporter defaults to otlp",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+						{Name: KeyPlatformEventsEndpoint, Value: "https://otlp.example.com:4317"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Events: EventsOptions{
+						CollectionEnabled: true,
+						Endpoint:          "https://otlp.example.com:4317",
+						Exporter:          EventsExporterOTLP,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "events endpoint as a bare host and port",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+						{Name: KeyPlatformEventsEndpoint, Value: "otlp.example.com:4317"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Events: EventsOptions{
+						CollectionEnabled: true,
+						Endpoint:          "otlp.example.com:4317",
+						Exporter:          EventsExporterOTLP,
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "loki events exporter without a URL",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+						{Name: KeyPlatformEventsEndpoint, Value: "loki.example.com:443"},
+						{Name: KeyPlatformEventsExporter, Value: string(EventsExporterLoki)},
+					},
+				},
+			},
+			expectedErrMsg: `invalid events endpoint: "loki.example.com:443", the loki exporter needs a URL`,
+		},
+		{
+			name: "events without endpoint",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+					},
+				},
+			},
+			expectedErrMsg: "invalid events endpoint",
+		},
+		{
+			name: "invalid events endpoint",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsEndpoint, Value: "otlp.example.com"},
+					},
+				},
+			},
+			expectedErrMsg: "invalid events endpoint",
+		},
+		{
+			name: "invalid events exporter",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformEventsCollection, Value: string(OpenTelemetryCollectorV1beta1)},
+						{Name: KeyPlatformEventsEndpoint, Value: "https://otlp.example.com:4317"},
+						{Name: KeyPlatformEventsExporter, Value: "kafka"},
+					},
+				},
+			},
+			expectedErrMsg: `invalid events exporter: "kafka"`,
+		},
+		{
+			name: "otlp metrics without traces collection",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyMetricsHubHostname, Value: "metrics.example.com"},
+						{Name: KeyUserWorkloadMetricsCollection, Value: string(PrometheusAgentV1alpha1)},
+						{Name: KeyUserWorkloadMetricsOTLP, Value: "enabled"},
+					},
+				},
+			},
+			expectedErrMsg: "user workload OTLP metrics require the collection of user workload metrics and traces",
+		},
 		{
 			name: "valid incident detection",
 			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
@@ -233,6 +598,43 @@ func TestBuildOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualization dashboards disabled explicitly",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformVirtualizationDashboards, Value: "disabled"},
+					},
+				},
+			},
+			expectedOpts: Options{
+				Platform: PlatformOptions{
+					Enabled: true,
+					Metrics: MetricsOptions{
+						UI: MetricsUIOptions{
+							VirtualizationDashboards: ptr.To(false),
+						},
+					},
+					AnalyticsOptions: AnalyticsOptions{
+						RightSizing: RightSizingOptions{
+							NamespaceEnabled:      true,
+							VirtualizationEnabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid virtualization dashboards value",
+			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
+				Spec: addonapiv1beta1.AddOnDeploymentConfigSpec{
+					CustomizedVariables: []addonapiv1beta1.CustomizedVariable{
+						{Name: KeyPlatformVirtualizationDashboards, Value: "true"},
+					},
+				},
+			},
+			expectedErrMsg: `invalid virtualization dashboards value: "true", expected "enabled" or "disabled"`,
+		},
 		{
 			name: "right-sizing enabled explicitly",
 			addOnDeploy: &addonapiv1beta1.AddOnDeploymentConfig{
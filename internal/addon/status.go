@@ -0,0 +1,101 @@
+package addon
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The components whose health is reported by the addon status metrics.
+const (
+	prometheusAgentComponent        = "PrometheusAgent"
+	clusterLogForwarderComponent    = "ClusterLogForwarder"
+	openTelemetryCollectorComponent = "OpenTelemetryCollector"
+	eventsCollectorComponent        = "EventsCollector"
+	profilingAgentComponent         = "ProfilingAgent"
+	flowCollectorComponent          = "FlowCollector"
+	uiPluginComponent               = "UIPlugin"
+	obsAPIComponent                 = "ObservatoriumAPI"
+
+	// The values of the prometheus_operator label of the addon info metric.
+	prometheusOperatorCOO         = "coo"
+	prometheusOperatorSelfManaged = "self-managed"
+)
+
+// The addon status metrics are exposed on the HTTPS metrics endpoint of the
+// addon manager, port 8084, which the hub ServiceMonitor of the metrics chart
+// scrapes. The managed cluster is carried by the managed_cluster label: the
+// cluster label is overwritten with the hub name when the hub platform metrics
+// are forwarded to the hub Thanos.
+var (
+	addonAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "acm_observability_addon_available",
+		Help: "Whether the last health check of the observability addon of the cluster succeeded.",
+	}, []string{"managed_cluster"})
+
+	addonComponentHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "acm_observability_addon_component_healthy",
+		Help: "Whether a collector or UI component deployed by the observability addon on the cluster is healthy. Only enabled components are reported.",
+	}, []string{"managed_cluster", "component"})
+
+	addonInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "acm_observability_addon_info",
+		Help: "The prometheus-operator running the metrics collectors of the cluster, either deployed by COO or by the addon, and the TLS profile of the cluster.",
+	}, []string{"managed_cluster", "prometheus_operator", "prometheus_operator_version", "tls_profile"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(addonAvailable, addonComponentHealthy, addonInfo)
+}
+
+func recordAvailability(cluster string, err error) {
+	addonAvailable.WithLabelValues(cluster).Set(boolToFloat(err == nil))
+}
+
+// recordComponentHealth reports the result of the health check of an enabled
+// component and drops the series of a disabled one.
+func recordComponentHealth(cluster, component string, enabled bool, err error) {
+	if !enabled {
+		addonComponentHealthy.DeleteLabelValues(cluster, component)
+		return
+	}
+	addonComponentHealthy.WithLabelValues(cluster, component).Set(boolToFloat(err == nil))
+}
+
+// recordInfo reports the prometheus-operator and TLS profile read from the
+// feedback of the addon ManifestWork.
+func recordInfo(cluster string, fields []agent.FieldResult) {
+	operator := prometheusOperatorSelfManaged
+	var version, tlsProfile string
+	for _, field := range fields {
+		for _, value := range field.FeedbackResult.Values {
+			if value.Value.String == nil {
+				continue
+			}
+			switch {
+			case field.ResourceIdentifier.Name == mconfig.AlertmanagerCRDName && value.Name == addoncfg.IsOLMManagedFeedbackName:
+				if strings.ToLower(*value.Value.String) == "true" {
+					operator = prometheusOperatorCOO
+				}
+			case field.ResourceIdentifier.Name == scrapeConfigCRDName && value.Name == addoncfg.PrometheusOperatorVersionFeedbackName:
+				version = *value.Value.String
+			case field.ResourceIdentifier.Name == addoncfg.TLSProfileConfigMapName && value.Name == addoncfg.TLSProfileTypeFeedbackName:
+				tlsProfile = *value.Value.String
+			}
+		}
+	}
+
+	addonInfo.DeletePartialMatch(prometheus.Labels{"managed_cluster": cluster})
+	addonInfo.WithLabelValues(cluster, operator, version, tlsProfile).Set(1)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
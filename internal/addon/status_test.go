@@ -0,0 +1,78 @@
+package addon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stretchr/testify/require"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func stringFieldResult(resource, name string, values map[string]string) agent.FieldResult {
+	result := agent.FieldResult{ResourceIdentifier: workv1.ResourceIdentifier{Resource: resource, Name: name}}
+	for key, value := range values {
+		result.FeedbackResult.Values = append(result.FeedbackResult.Values, workv1.FeedbackValue{
+			Name:  key,
+			Value: workv1.FieldValue{Type: workv1.String, String: &value},
+		})
+	}
+	return result
+}
+
+func TestHealthProber_StatusMetrics(t *testing.T) {
+	// The gauges are shared with the other health prober tests.
+	addonAvailable.Reset()
+	addonComponentHealthy.Reset()
+	addonInfo.Reset()
+
+	managedCluster := addontesting.NewManagedCluster("status-cluster")
+	managedClusterAddOn := addontesting.NewAddon("test", "status-cluster")
+	aodc := newAddonDeploymentConfig()
+	addPlatformMetricsCustomizedVariables(aodc)
+	addLoggingCustomizedVariables(aodc)
+	addAODCConfigReference(managedClusterAddOn, aodc)
+
+	fields := []agent.FieldResult{
+		{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:     cooprometheusv1alpha1.SchemeGroupVersion.Group,
+				Resource:  cooprometheusv1alpha1.PrometheusAgentName,
+				Name:      mconfig.PlatformMetricsCollectorApp,
+				Namespace: addonfactory.AddonDefaultInstallNamespace,
+			},
+			FeedbackResult: stringFieldResult("", "", map[string]string{addoncfg.PaProbeKey: "True"}).FeedbackResult,
+		},
+		scrapeConfigFieldResult(),
+		stringFieldResult(crdResourceName, mconfig.AlertmanagerCRDName, map[string]string{addoncfg.IsOLMManagedFeedbackName: "true"}),
+		stringFieldResult("configmaps", addoncfg.TLSProfileConfigMapName, map[string]string{addoncfg.TLSProfileTypeFeedbackName: "Intermediate"}),
+		stringFieldResult(addoncfg.ClusterLogForwardersResource, "instance", map[string]string{addoncfg.ClfProbeKey: "False"}),
+	}
+
+	healthProber := HealthProber(newTestGetter(aodc), logr.Discard())
+	err := healthProber.WorkProber.HealthChecker(fields, managedCluster, managedClusterAddOn)
+	require.ErrorIs(t, err, errProbeConditionNotSatisfied)
+
+	expected := `
+# HELP acm_observability_addon_available Whether the last health check of the observability addon of the cluster succeeded.
+# TYPE acm_observability_addon_available gauge
+acm_observability_addon_available{managed_cluster="status-cluster"} 0
+# HELP acm_observability_addon_component_healthy Whether a collector or UI component deployed by the observability addon on the cluster is healthy. Only enabled components are reported.
+# TYPE acm_observability_addon_component_healthy gauge
+acm_observability_addon_component_healthy{component="ClusterLogForwarder",managed_cluster="status-cluster"} 0
+acm_observability_addon_component_healthy{component="PrometheusAgent",managed_cluster="status-cluster"} 1
+# HELP acm_observability_addon_info The prometheus-operator running the metrics collectors of the cluster, either deployed by COO or by the addon, and the TLS profile of the cluster.
+# TYPE acm_observability_addon_info gauge
+acm_observability_addon_info{managed_cluster="status-cluster",prometheus_operator="coo",prometheus_operator_version="0.79.0",tls_profile="Intermediate"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(addonAvailable, strings.NewReader(expected), "acm_observability_addon_available"))
+	require.NoError(t, testutil.CollectAndCompare(addonComponentHealthy, strings.NewReader(expected), "acm_observability_addon_component_healthy"))
+	require.NoError(t, testutil.CollectAndCompare(addonInfo, strings.NewReader(expected), "acm_observability_addon_info"))
+}
@@ -10,6 +10,11 @@ import (
 //go:embed manifests/charts/mcoa/charts/logging/templates/_helpers.tpl
 //go:embed manifests/charts/mcoa/charts/metrics/templates/_helpers.tpl
 //go:embed manifests/charts/mcoa/charts/tracing/templates/_helpers.tpl
+//go:embed manifests/charts/mcoa/charts/events/templates/_helpers.tpl
+//go:embed manifests/charts/mcoa/charts/profiling/templates/_helpers.tpl
+//go:embed manifests/charts/mcoa/charts/netflows/templates/_helpers.tpl
+//go:embed manifests/charts/mcoa/charts/otel-gateway/templates/_helpers.tpl
+//go:embed manifests/charts/mcoa/charts/lokistack/templates/_helpers.tpl
 //go:embed manifests/charts/mcoa/charts/coo/templates/_helpers.tpl
 //go:embed manifests/charts/mcoa/charts/metrics/templates/non-ocp/monitoring/kube-state-metrics/_helpers.tpl
 //go:embed manifests/charts/mcoa/charts/metrics/templates/non-ocp/monitoring/node-exporter/_helpers.tpl
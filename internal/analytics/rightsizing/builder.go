@@ -11,10 +11,17 @@ import (
 )
 
 var (
-	errMutuallyExclusiveNamespaceFilter = errors.New("only one of inclusion or exclusion criteria allowed for namespacefiltercriteria")
-	errMutuallyExclusiveLabelFilter     = errors.New("only one of inclusion or exclusion allowed for label_env")
-	errUnmarshalPrometheusRuleConfig    = errors.New("failed to unmarshal prometheusRuleConfig")
-	errUnmarshalPlacementConfig         = errors.New("failed to unmarshal placementConfiguration")
+	errMutuallyExclusiveNamespaceFilter  = errors.New("only one of inclusion or exclusion criteria allowed for namespacefiltercriteria")
+	errMutuallyExclusiveLabelFilter      = errors.New("only one of inclusion or exclusion allowed for label_env")
+	errUnmarshalPrometheusRuleConfig     = errors.New("failed to unmarshal prometheusRuleConfig")
+	errUnmarshalPlacementConfig          = errors.New("failed to unmarshal placementConfiguration")
+	errUnknownProfile                    = errors.New("unknown right-sizing profile")
+	errInvalidAggregationWindow          = errors.New("invalid right-sizing aggregation window")
+	errInvalidRetention                  = errors.New("invalid right-sizing retention")
+	errAggregationWindowExceedsRetention = errors.New("right-sizing aggregation window exceeds the retention")
+	errUnknownPlacementMode              = errors.New("unknown right-sizing placement mode")
+	errUnmarshalExportConfig             = errors.New("failed to unmarshal exportConfiguration")
+	errUnknownExportFormat               = errors.New("unknown right-sizing export format")
 )
 
 // FormatJSON marshals a Go data structure to a JSON string for ConfigMap storage.
@@ -120,6 +127,27 @@ func ParseConfigMapData(data map[string]string) (RSConfigMapData, error) {
 		}
 	}
 
+	configData.PlacementMode = PlacementModeInMemory
+	if mode, ok := data["placementMode"]; ok && strings.TrimSpace(mode) != "" {
+		switch mode = strings.TrimSpace(mode); mode {
+		case PlacementModeInMemory, PlacementModeDecisions:
+			configData.PlacementMode = mode
+		default:
+			return configData, fmt.Errorf("%w: %q", errUnknownPlacementMode, mode)
+		}
+	}
+
+	if exportRaw, ok := data["exportConfiguration"]; ok {
+		if err := sigYaml.Unmarshal([]byte(exportRaw), &configData.ExportConfiguration); err != nil {
+			return configData, fmt.Errorf("%w: %w", errUnmarshalExportConfig, err)
+		}
+		for _, f := range configData.ExportConfiguration.Formats {
+			if f != ExportFormatVPA && f != ExportFormatReport {
+				return configData, fmt.Errorf("%w: %q", errUnknownExportFormat, f)
+			}
+		}
+	}
+
 	return configData, nil
 }
 
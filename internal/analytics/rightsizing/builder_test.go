@@ -3,6 +3,8 @@ package rightsizing
 import (
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -196,4 +198,106 @@ func TestParseConfigMapData(t *testing.T) {
 		_, err := ParseConfigMapData(data)
 		assert.Error(t, err)
 	})
+
+	t.Run("placement mode", func(t *testing.T) {
+		result, err := ParseConfigMapData(map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, PlacementModeInMemory, result.PlacementMode)
+
+		result, err = ParseConfigMapData(map[string]string{"placementMode": " PlacementDecisions\n"})
+		require.NoError(t, err)
+		assert.Equal(t, PlacementModeDecisions, result.PlacementMode)
+
+		_, err = ParseConfigMapData(map[string]string{"placementMode": "Scheduler"})
+		assert.ErrorIs(t, err, errUnknownPlacementMode)
+	})
+
+	t.Run("export configuration", func(t *testing.T) {
+		result, err := ParseConfigMapData(map[string]string{
+			"exportConfiguration": "formats:\n- VerticalPodAutoscaler\n- Report\nprofile: P95\n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{ExportFormatVPA, ExportFormatReport}, result.ExportConfiguration.Formats)
+		assert.Equal(t, ProfileP95, result.ExportConfiguration.Profile)
+
+		_, err = ParseConfigMapData(map[string]string{"exportConfiguration": `{"formats":["CSV"]}`})
+		assert.ErrorIs(t, err, errUnknownExportFormat)
+	})
+}
+
+func TestBuildAggregationWindows(t *testing.T) {
+	t.Run("defaults to Max OverAll over 1d", func(t *testing.T) {
+		windows, err := BuildAggregationWindows(RSPrometheusRuleConfig{}, "")
+		require.NoError(t, err)
+		require.Len(t, windows, 1)
+		assert.Equal(t, "1d", windows[0].Window)
+		require.Len(t, windows[0].Builders, 1)
+		assert.Equal(t, ProfileMaxOverAll, windows[0].Builders[0].Profile)
+		assert.Equal(t, `max_over_time(m:5m[1d])`, windows[0].Builders[0].UsageExpr("m:5m"))
+		assert.Equal(t, `max_over_time(m:5m[1d]) * (110/100)`, windows[0].Builders[0].RecommendationExpr("m:5m", 0))
+	})
+
+	t.Run("one builder per profile and window", func(t *testing.T) {
+		windows, err := BuildAggregationWindows(RSPrometheusRuleConfig{
+			Profiles:           []string{ProfileP95, ProfileMaxOverAll, ProfileP95},
+			AggregationWindows: []string{"1d", "30d", "1d"},
+			Retention:          "90d",
+		}, "")
+		require.NoError(t, err)
+		require.Len(t, windows, 2)
+		assert.Equal(t, "1d", windows[0].Window)
+		assert.Equal(t, "30d", windows[1].Window)
+		require.Len(t, windows[1].Builders, 2)
+
+		p95 := windows[1].Builders[0]
+		assert.Equal(t, ProfileP95, p95.Profile)
+		assert.Equal(t, "30d", p95.Aggregation)
+		assert.Equal(t, `quantile_over_time(0.95, m:5m[30d])`, p95.UsageExpr("m:5m"))
+		assert.Equal(t, `quantile_over_time(0.95, m:5m[30d]) * (120/100)`, p95.RecommendationExpr("m:5m", 120))
+		// The profile only applies to the usage, requests keep their highest value.
+		assert.Equal(t, `max_over_time(m:5m[30d])`, p95.RequestExpr("m:5m"))
+
+		rule := p95.RuleWithLabels("m", p95.UsageExpr("m:5m"))
+		assert.Equal(t, map[string]string{"profile": ProfileP95, "aggregation": "30d"}, rule.Labels)
+	})
+
+	t.Run("windows longer than the retention are rejected", func(t *testing.T) {
+		_, err := BuildAggregationWindows(RSPrometheusRuleConfig{
+			AggregationWindows: []string{"7d", "30d"},
+		}, "")
+		assert.ErrorIs(t, err, errAggregationWindowExceedsRetention)
+
+		windows, err := BuildAggregationWindows(RSPrometheusRuleConfig{
+			AggregationWindows: []string{"7d", "30d"},
+			Retention:          "30d",
+		}, "")
+		require.NoError(t, err)
+		require.Len(t, windows, 2)
+
+		_, err = BuildAggregationWindows(RSPrometheusRuleConfig{Retention: "forever"}, "")
+		assert.ErrorIs(t, err, errInvalidRetention)
+	})
+
+	t.Run("long windows are evaluated less often", func(t *testing.T) {
+		windows, err := BuildAggregationWindows(RSPrometheusRuleConfig{
+			AggregationWindows: []string{"1d", "7d"},
+		}, "")
+		require.NoError(t, err)
+		require.Len(t, windows, 2)
+		assert.Equal(t, AggregationEvalInterval, *windows[0].Interval)
+		assert.Equal(t, LongAggregationEvalInterval, *windows[1].Interval)
+
+		groups := AggregationGroups("rules-%s", windows, func(*RuleBuilder) []monitoringv1.Rule { return nil })
+		assert.Equal(t, LongAggregationEvalInterval, *groups[1].Interval)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := BuildAggregationWindows(RSPrometheusRuleConfig{Profiles: []string{"P42"}}, "")
+		assert.ErrorIs(t, err, errUnknownProfile)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		_, err := BuildAggregationWindows(RSPrometheusRuleConfig{AggregationWindows: []string{"a week"}}, "")
+		assert.ErrorIs(t, err, errInvalidAggregationWindow)
+	})
 }
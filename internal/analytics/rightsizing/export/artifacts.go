@@ -0,0 +1,164 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// RecommendationAnnotation holds the ACM recommendation of every container of the
+	// workload targeted by an exported VerticalPodAutoscaler.
+	RecommendationAnnotation = "observability.open-cluster-management.io/rs-recommendation"
+	// TruncatedAnnotation holds the number of recommendations of a namespace when its
+	// report only holds the first MaxReportRecommendations of them.
+	TruncatedAnnotation = "observability.open-cluster-management.io/rs-report-truncated"
+	// MaxReportRecommendations bounds the recommendations of a namespace report so that
+	// the report, holding them in JSON and CSV, stays below the size limit of an object.
+	MaxReportRecommendations = 2000
+)
+
+var workloadKinds = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+}
+
+// ContainerResources is the recommended requests of a container, as Kubernetes quantities.
+type ContainerResources struct {
+	Name   string `json:"name"`
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+// VPA describes a VerticalPodAutoscaler in "Off" mode targeting a workload.
+type VPA struct {
+	Name            string
+	Namespace       string
+	TargetKind      string
+	TargetName      string
+	Recommendations []ContainerResources
+}
+
+// Spec returns the VerticalPodAutoscaler spec. The updateMode is "Off" so that the
+// VPA never evicts pods; it only surfaces recommendations.
+func (v VPA) Spec() VerticalPodAutoscalerSpec {
+	return VerticalPodAutoscalerSpec{
+		TargetRef: &autoscalingv1.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       v.TargetKind,
+			Name:       v.TargetName,
+		},
+		UpdatePolicy: &PodUpdatePolicy{UpdateMode: ptr.To("Off")},
+	}
+}
+
+// BuildVPAs groups the recommendations per workload and returns one VPA for each.
+// Recommendations of unsupported workload types are skipped.
+func BuildVPAs(recs []Recommendation) []VPA {
+	var ret []VPA
+	index := map[string]int{}
+	for _, r := range recs {
+		kind, ok := workloadKinds[r.WorkloadType]
+		if !ok {
+			continue
+		}
+		key := r.Namespace + "/" + r.WorkloadType + "/" + r.Workload
+		i, ok := index[key]
+		if !ok {
+			i = len(ret)
+			index[key] = i
+			ret = append(ret, VPA{
+				Name:       fmt.Sprintf("acm-rs-%s-%s", r.WorkloadType, r.Workload),
+				Namespace:  r.Namespace,
+				TargetKind: kind,
+				TargetName: r.Workload,
+			})
+		}
+		ret[i].Recommendations = append(ret[i].Recommendations, ContainerResources{
+			Name:   r.Container,
+			CPU:    CPUQuantity(r.CPURecommendation),
+			Memory: MemoryQuantity(r.MemoryRecommendation),
+		})
+	}
+	return ret
+}
+
+// GroupByNamespace splits the recommendations per namespace, preserving their order.
+func GroupByNamespace(recs []Recommendation) (namespaces []string, byNamespace map[string][]Recommendation) {
+	byNamespace = map[string][]Recommendation{}
+	for _, r := range recs {
+		if _, ok := byNamespace[r.Namespace]; !ok {
+			namespaces = append(namespaces, r.Namespace)
+		}
+		byNamespace[r.Namespace] = append(byNamespace[r.Namespace], r)
+	}
+	return namespaces, byNamespace
+}
+
+// CPUQuantity rounds cores up to the next millicore.
+func CPUQuantity(cores float64) string {
+	return resource.NewMilliQuantity(int64(math.Ceil(cores*1000)), resource.DecimalSI).String()
+}
+
+// MemoryQuantity rounds bytes up to the next Mi.
+func MemoryQuantity(bytes float64) string {
+	const mi = 1024 * 1024
+	return resource.NewQuantity(int64(math.Ceil(bytes/mi))*mi, resource.BinarySI).String()
+}
+
+// WriteJSON writes the recommendations as an indented JSON array.
+func WriteJSON(w io.Writer, recs []Recommendation) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if recs == nil {
+		recs = []Recommendation{}
+	}
+	return enc.Encode(recs)
+}
+
+// WriteCSV writes the recommendations as CSV with a header row.
+func WriteCSV(w io.Writer, recs []Recommendation) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"namespace", "workload", "workload_type", "container",
+		"cpu_request", "cpu_recommendation", "memory_request", "memory_recommendation",
+	}); err != nil {
+		return err
+	}
+	for _, r := range recs {
+		if err := cw.Write([]string{
+			r.Namespace, r.Workload, r.WorkloadType, r.Container,
+			formatFloat(r.CPURequest), formatFloat(r.CPURecommendation),
+			formatFloat(r.MemoryRequest), formatFloat(r.MemoryRecommendation),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Report renders the recommendations in both JSON and CSV.
+func Report(recs []Recommendation) (jsonReport, csvReport string, err error) {
+	var jb, cb bytes.Buffer
+	if err := WriteJSON(&jb, recs); err != nil {
+		return "", "", fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	if err := WriteCSV(&cb, recs); err != nil {
+		return "", "", fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return jb.String(), cb.String(), nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// CacheConfigMapName prefixes the hub ConfigMaps, in the namespace of each
+	// managed cluster, caching the recommendations exported to that cluster.
+	CacheConfigMapName = "acm-rs-export-recommendations"
+	// CachePageSize bounds the recommendations of a cache ConfigMap so that it
+	// stays well below the size limit of an object, whatever the cluster size.
+	CachePageSize = 2000
+
+	cacheKey       = "recommendations.json"
+	cachePageLabel = "observability.open-cluster-management.io/rs-export-page"
+)
+
+// CachePageName returns the name of a page of the cache of a cluster.
+func CachePageName(page int) string {
+	return fmt.Sprintf("%s-%d", CacheConfigMapName, page)
+}
+
+// CacheConfigMaps returns the pages caching the recommendations of a cluster.
+// A cluster without recommendations gets a single empty page.
+func CacheConfigMaps(cluster string, recs []Recommendation) ([]*corev1.ConfigMap, error) {
+	var pages []*corev1.ConfigMap
+	for page := 0; page == 0 || page*CachePageSize < len(recs); page++ {
+		pageRecs := recs[min(page*CachePageSize, len(recs)):min((page+1)*CachePageSize, len(recs))]
+		data, err := json.Marshal(pageRecs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the recommendations of %s: %w", cluster, err)
+		}
+		labels := rightsizing.RSLabels()
+		labels[cachePageLabel] = strconv.Itoa(page)
+		pages = append(pages, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CachePageName(page),
+				Namespace: cluster,
+				Labels:    labels,
+			},
+			Data: map[string]string{cacheKey: string(data)},
+		})
+	}
+	return pages, nil
+}
+
+// ReadCache returns the recommendations cached in the pages of a cluster, in
+// page order. The ConfigMaps that are not cache pages are skipped.
+func ReadCache(cms []corev1.ConfigMap) ([]Recommendation, error) {
+	pages := map[int]*corev1.ConfigMap{}
+	for i := range cms {
+		if page, ok := CachePage(&cms[i]); ok {
+			pages[page] = &cms[i]
+		}
+	}
+
+	var recs []Recommendation
+	for _, page := range slices.Sorted(maps.Keys(pages)) {
+		var pageRecs []Recommendation
+		if err := json.Unmarshal([]byte(pages[page].Data[cacheKey]), &pageRecs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the recommendations of %s: %w", pages[page].Namespace, err)
+		}
+		recs = append(recs, pageRecs...)
+	}
+	return recs, nil
+}
+
+// CachePage returns the page number of a cache ConfigMap, false when the
+// object is not a cache page.
+func CachePage(obj client.Object) (int, bool) {
+	if !isCacheConfigMap(obj) {
+		return 0, false
+	}
+	page, err := strconv.Atoi(obj.GetLabels()[cachePageLabel])
+	if err != nil || obj.GetName() != CachePageName(page) {
+		return 0, false
+	}
+	return page, true
+}
+
+// CacheConfigMapPredicate filters ConfigMap events down to the recommendation
+// caches so that the manifests of their cluster are rendered again.
+func CacheConfigMapPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isCacheConfigMap(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isCacheConfigMap(e.ObjectNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isCacheConfigMap(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isCacheConfigMap(obj client.Object) bool {
+	return strings.HasPrefix(obj.GetName(), CacheConfigMapName+"-") && obj.GetLabels()[rightsizing.RSManagedByLabel] == rightsizing.RSManagedByValue
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeQuerier struct {
+	queries []string
+	values  map[string]model.Vector
+}
+
+func (f *fakeQuerier) Query(_ context.Context, query string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	f.queries = append(f.queries, query)
+	for metric, v := range f.values {
+		if strings.Contains(query, metric+"{") {
+			return v, nil, nil
+		}
+	}
+	return model.Vector{}, nil, nil
+}
+
+func sample(cluster, ns, workload, workloadType, container string, v float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			"cluster":       model.LabelValue(cluster),
+			"namespace":     model.LabelValue(ns),
+			"workload":      model.LabelValue(workload),
+			"workload_type": model.LabelValue(workloadType),
+			"container":     model.LabelValue(container),
+		},
+		Value: model.SampleValue(v),
+	}
+}
+
+func TestFetch(t *testing.T) {
+	q := &fakeQuerier{values: map[string]model.Vector{
+		"acm_rs:container:cpu_request": {
+			sample("cluster-1", "shop", "web", "deployment", "nginx", 1),
+			sample("cluster-1", "bank", "db", "statefulset", "postgres", 2),
+			sample("cluster-2", "shop", "web", "deployment", "nginx", 3),
+			sample("", "shop", "web", "deployment", "nginx", 4),
+		},
+		"acm_rs:container:cpu_recommendation": {
+			sample("cluster-1", "shop", "web", "deployment", "nginx", 0.25),
+		},
+		"acm_rs:container:memory_recommendation": {
+			sample("cluster-1", "shop", "web", "deployment", "nginx", 300*1024*1024),
+		},
+	}}
+
+	recs, err := Fetch(context.Background(), q, rightsizing.RSExportConfig{Profile: rightsizing.ProfileP95})
+	require.NoError(t, err)
+	require.Len(t, q.queries, 4)
+	assert.Equal(t, `last_over_time(acm_rs:container:cpu_request{profile="P95", aggregation="1d"}[2d])`, q.queries[0])
+
+	require.Len(t, recs, 2, "series without a cluster are skipped")
+	require.Len(t, recs["cluster-1"], 2)
+	assert.Equal(t, "bank", recs["cluster-1"][0].Namespace)
+	assert.InDelta(t, 2, recs["cluster-1"][0].CPURequest, 0)
+	assert.Equal(t, Recommendation{
+		Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx",
+		CPURequest: 1, CPURecommendation: 0.25, MemoryRecommendation: 300 * 1024 * 1024,
+	}, recs["cluster-1"][1])
+	require.Len(t, recs["cluster-2"], 1)
+	assert.InDelta(t, 3, recs["cluster-2"][0].CPURequest, 0)
+}
+
+func TestCache(t *testing.T) {
+	recs := []Recommendation{
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURecommendation: 0.25},
+	}
+	cms, err := CacheConfigMaps("cluster-1", recs)
+	require.NoError(t, err)
+	require.Len(t, cms, 1)
+	assert.Equal(t, "cluster-1", cms[0].Namespace)
+	assert.Equal(t, CachePageName(0), cms[0].Name)
+	assert.True(t, isCacheConfigMap(cms[0]))
+
+	got, err := ReadCache([]corev1.ConfigMap{*cms[0]})
+	require.NoError(t, err)
+	assert.Equal(t, recs, got)
+
+	cms, err = CacheConfigMaps("cluster-1", nil)
+	require.NoError(t, err)
+	require.Len(t, cms, 1, "a cluster without recommendations keeps an empty page")
+}
+
+func TestCache_Pages(t *testing.T) {
+	recs := make([]Recommendation, 2*CachePageSize+1)
+	for i := range recs {
+		recs[i] = Recommendation{Namespace: "shop", Workload: fmt.Sprintf("web-%d", i), WorkloadType: "deployment", Container: "nginx"}
+	}
+	cms, err := CacheConfigMaps("cluster-1", recs)
+	require.NoError(t, err)
+	require.Len(t, cms, 3)
+	assert.Equal(t, CachePageName(2), cms[2].Name)
+
+	// The pages are read in page order whatever the list order, and other
+	// ConfigMaps of the namespace are skipped.
+	other := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "cluster-1"}}
+	got, err := ReadCache([]corev1.ConfigMap{*cms[2], other, *cms[0], *cms[1]})
+	require.NoError(t, err)
+	assert.Equal(t, recs, got)
+}
+
+func TestBuildVPAs(t *testing.T) {
+	recs := []Recommendation{
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURecommendation: 0.2501, MemoryRecommendation: 100.5 * 1024 * 1024},
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "sidecar", CPURecommendation: 0.01, MemoryRecommendation: 1024},
+		{Namespace: "shop", Workload: "job-1", WorkloadType: "job", Container: "main"},
+	}
+
+	vpas := BuildVPAs(recs)
+	require.Len(t, vpas, 1, "unsupported workload types are skipped")
+	assert.Equal(t, "acm-rs-deployment-web", vpas[0].Name)
+	assert.Equal(t, []ContainerResources{
+		{Name: "nginx", CPU: "251m", Memory: "101Mi"},
+		{Name: "sidecar", CPU: "10m", Memory: "1Mi"},
+	}, vpas[0].Recommendations)
+
+	spec := vpas[0].Spec()
+	assert.Equal(t, "Off", *spec.UpdatePolicy.UpdateMode)
+	assert.Equal(t, "Deployment", spec.TargetRef.Kind)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteCSV(&b, []Recommendation{
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURequest: 1, CPURecommendation: 0.25, MemoryRequest: 1024, MemoryRecommendation: 512},
+	}))
+	assert.Equal(t,
+		"namespace,workload,workload_type,container,cpu_request,cpu_recommendation,memory_request,memory_recommendation\n"+
+			"shop,web,deployment,nginx,1,0.25,1024,512\n",
+		b.String())
+}
+
+func TestReport_Empty(t *testing.T) {
+	jsonReport, csvReport, err := Report(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", jsonReport)
+	assert.Equal(t, 1, strings.Count(csvReport, "\n"), "only the header is written")
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"k8s.io/client-go/transport"
+)
+
+const (
+	// lookback covers at least one evaluation of the daily aggregation rule groups.
+	lookback = "2d"
+
+	// The token of the addon manager service account authenticates the queries
+	// against rbac-query-proxy, whose certificate is signed by the service CA.
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceCAFile           = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+)
+
+var errUnexpectedResultType = errors.New("unexpected query result type")
+
+// Querier is the subset of the Prometheus HTTP API used to read recommendations.
+type Querier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+// NewQuerier returns a Querier for the Prometheus compatible API at endpoint,
+// authenticated as the addon manager service account.
+func NewQuerier(endpoint string) (Querier, error) {
+	rt, err := transport.New(&transport.Config{
+		BearerTokenFile: serviceAccountTokenFile,
+		TLS:             transport.TLSConfig{CAFile: serviceCAFile},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport for %s: %w", endpoint, err)
+	}
+	c, err := api.NewClient(api.Config{Address: endpoint, RoundTripper: rt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client for %s: %w", endpoint, err)
+	}
+	return promv1.NewAPI(c), nil
+}
+
+// Recommendation holds the per-replica request and recommendation of a single container.
+// CPU values are in cores, memory values in bytes.
+type Recommendation struct {
+	Namespace            string  `json:"namespace"`
+	Workload             string  `json:"workload"`
+	WorkloadType         string  `json:"workloadType"`
+	Container            string  `json:"container"`
+	CPURequest           float64 `json:"cpuRequest"`
+	CPURecommendation    float64 `json:"cpuRecommendation"`
+	MemoryRequest        float64 `json:"memoryRequest"`
+	MemoryRecommendation float64 `json:"memoryRecommendation"`
+}
+
+type containerKey struct {
+	cluster, namespace, workload, workloadType, container string
+}
+
+// Fetch reads the latest container recommendations of every cluster for the profile
+// and aggregation window of cfg, keyed by cluster name. The recommendations of a
+// cluster are sorted by namespace, workload and container.
+func Fetch(ctx context.Context, q Querier, cfg rightsizing.RSExportConfig) (map[string][]Recommendation, error) {
+	profile := cfg.Profile
+	if profile == "" {
+		profile = rightsizing.ProfileMaxOverAll
+	}
+	aggregation := cfg.Aggregation
+	if aggregation == "" {
+		aggregation = rightsizing.DefaultAggregationWindow
+	}
+
+	recs := map[containerKey]*Recommendation{}
+	fields := []struct {
+		metric string
+		set    func(r *Recommendation, v float64)
+	}{
+		{"acm_rs:container:cpu_request", func(r *Recommendation, v float64) { r.CPURequest = v }},
+		{"acm_rs:container:cpu_recommendation", func(r *Recommendation, v float64) { r.CPURecommendation = v }},
+		{"acm_rs:container:memory_request", func(r *Recommendation, v float64) { r.MemoryRequest = v }},
+		{"acm_rs:container:memory_recommendation", func(r *Recommendation, v float64) { r.MemoryRecommendation = v }},
+	}
+
+	for _, f := range fields {
+		query := fmt.Sprintf(`last_over_time(%s{profile=%q, aggregation=%q}[%s])`,
+			f.metric, profile, aggregation, lookback)
+		value, _, err := q.Query(ctx, query, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", f.metric, err)
+		}
+		vector, ok := value.(model.Vector)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s for %s", errUnexpectedResultType, value.Type(), f.metric)
+		}
+		for _, sample := range vector {
+			key := containerKey{
+				cluster:      string(sample.Metric["cluster"]),
+				namespace:    string(sample.Metric["namespace"]),
+				workload:     string(sample.Metric["workload"]),
+				workloadType: string(sample.Metric["workload_type"]),
+				container:    string(sample.Metric["container"]),
+			}
+			r, ok := recs[key]
+			if !ok {
+				r = &Recommendation{
+					Namespace:    key.namespace,
+					Workload:     key.workload,
+					WorkloadType: key.workloadType,
+					Container:    key.container,
+				}
+				recs[key] = r
+			}
+			f.set(r, float64(sample.Value))
+		}
+	}
+
+	ret := map[string][]Recommendation{}
+	for key, r := range recs {
+		if key.cluster == "" {
+			continue
+		}
+		ret[key.cluster] = append(ret[key.cluster], *r)
+	}
+	for _, clusterRecs := range ret {
+		slices.SortFunc(clusterRecs, func(a, b Recommendation) int {
+			return strings.Compare(
+				strings.Join([]string{a.Namespace, a.WorkloadType, a.Workload, a.Container}, "/"),
+				strings.Join([]string{b.Namespace, b.WorkloadType, b.Workload, b.Container}, "/"),
+			)
+		})
+	}
+	return ret, nil
+}
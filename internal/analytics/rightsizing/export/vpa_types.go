@@ -0,0 +1,62 @@
+package export
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The VerticalPodAutoscaler types below are the subset of autoscaling.k8s.io/v1 rendered
+// by the export. They are declared here so that the addon scheme can decode the rendered
+// manifests without depending on the autoscaler module.
+
+// VPACRDName is the name of the VerticalPodAutoscaler CRD.
+const VPACRDName = "verticalpodautoscalers.autoscaling.k8s.io"
+
+// SchemeGroupVersion is the group version of the exported VerticalPodAutoscalers.
+var SchemeGroupVersion = schema.GroupVersion{Group: "autoscaling.k8s.io", Version: "v1"}
+
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &VerticalPodAutoscaler{}, &VerticalPodAutoscalerList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// VerticalPodAutoscaler is the configuration for a vertical pod autoscaler.
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+type VerticalPodAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VerticalPodAutoscalerSpec `json:"spec"`
+}
+
+// VerticalPodAutoscalerSpec is the specification of the behavior of the autoscaler.
+// +kubebuilder:object:generate=true
+type VerticalPodAutoscalerSpec struct {
+	TargetRef    *autoscalingv1.CrossVersionObjectReference `json:"targetRef"`
+	UpdatePolicy *PodUpdatePolicy                           `json:"updatePolicy,omitempty"`
+}
+
+// PodUpdatePolicy describes the rules on how changes are applied to the pods.
+// +kubebuilder:object:generate=true
+type PodUpdatePolicy struct {
+	UpdateMode *string `json:"updateMode,omitempty"`
+}
+
+// VerticalPodAutoscalerList is a list of VerticalPodAutoscaler objects.
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+type VerticalPodAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VerticalPodAutoscaler `json:"items"`
+}
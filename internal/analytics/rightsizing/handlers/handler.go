@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -11,17 +12,22 @@ import (
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 	rsnamespace "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/namespace"
 	rsvirtualization "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/virtualization"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workv1 "open-cluster-management.io/api/work/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const crdResourceName = "customresourcedefinitions"
+
 // OptionsBuilder builds right-sizing options for the helm chart
 type OptionsBuilder struct {
 	Client client.Client
@@ -37,9 +43,12 @@ func (o *OptionsBuilder) Build(ctx context.Context, cluster *clusterv1.ManagedCl
 		return ret, nil
 	}
 
-	// Check if this is an OpenShift cluster - right-sizing only works on OpenShift
-	if !common.IsOpenShiftVendor(cluster) {
-		o.Logger.V(2).Info("Skipping right-sizing for non-OpenShift cluster", "cluster", cluster.Name)
+	// On non-OpenShift clusters the rules are evaluated by the addon's own Prometheus server,
+	// which is only deployed along with platform metrics collection.
+	ret.IsOpenShiftVendor = common.IsOpenShiftVendor(cluster)
+	ret.InstallNamespace = opts.InstallNamespace
+	if !ret.IsOpenShiftVendor && !opts.Platform.Metrics.CollectionEnabled {
+		o.Logger.V(2).Info("Skipping right-sizing for non-OpenShift cluster without platform metrics collection", "cluster", cluster.Name)
 		return ret, nil
 	}
 
@@ -71,13 +80,21 @@ func (o *OptionsBuilder) Build(ctx context.Context, cluster *clusterv1.ManagedCl
 			}
 		}
 
-		if clusterMatchesPlacement(cluster, nsConfigData.PlacementConfiguration) {
+		selected, err := o.clusterSelected(ctx, cluster, nsConfigData, rightsizing.NamespacePlacementName)
+		if err != nil {
+			return ret, fmt.Errorf("failed to evaluate namespace right-sizing placement: %w", err)
+		}
+
+		if selected {
 			nsOpts, err := o.buildNamespaceOptionsFromConfig(nsConfigData)
 			if err != nil {
 				return ret, fmt.Errorf("failed to build namespace right-sizing options: %w", err)
 			}
 			ret.NamespaceRightSizing = nsOpts
 			nsMatched = true
+			if ret.Export, err = o.buildExportOptions(ctx, cluster, nsConfigData.ExportConfiguration); err != nil {
+				return ret, fmt.Errorf("failed to build right-sizing export options: %w", err)
+			}
 		} else {
 			o.Logger.V(1).Info("Cluster not selected for namespace right-sizing", "cluster", cluster.Name)
 		}
@@ -101,7 +118,12 @@ func (o *OptionsBuilder) Build(ctx context.Context, cluster *clusterv1.ManagedCl
 			}
 		}
 
-		if clusterMatchesPlacement(cluster, virtConfigData.PlacementConfiguration) {
+		selected, err := o.clusterSelected(ctx, cluster, virtConfigData, rightsizing.VirtualizationPlacementName)
+		if err != nil {
+			return ret, fmt.Errorf("failed to evaluate virtualization right-sizing placement: %w", err)
+		}
+
+		if selected {
 			virtOpts, err := o.buildVirtualizationOptionsFromConfig(virtConfigData)
 			if err != nil {
 				return ret, fmt.Errorf("failed to build virtualization right-sizing options: %w", err)
@@ -140,6 +162,58 @@ func (o *OptionsBuilder) buildVirtualizationOptionsFromConfig(configData rightsi
 	return opts, nil
 }
 
+// buildExportOptions reads the container recommendations of the cluster cached on
+// the hub by the right-sizing export controller. VerticalPodAutoscalers are only
+// exported once their CRD is established on the cluster.
+func (o *OptionsBuilder) buildExportOptions(ctx context.Context, cluster *clusterv1.ManagedCluster, cfg rightsizing.RSExportConfig) (ExportOptions, error) {
+	ret := ExportOptions{}
+	for _, format := range cfg.Formats {
+		if format == rightsizing.ExportFormatVPA {
+			ret.VPACRD = true
+			established, err := o.vpaCRDEstablished(ctx, cluster)
+			if err != nil {
+				return ret, err
+			}
+			if !established {
+				o.Logger.V(1).Info("Skipping the VerticalPodAutoscaler export until its CRD is established", "cluster", cluster.Name)
+				continue
+			}
+		}
+		ret.Formats = append(ret.Formats, format)
+	}
+	if len(ret.Formats) == 0 {
+		return ret, nil
+	}
+
+	cms := &corev1.ConfigMapList{}
+	if err := o.Client.List(ctx, cms, client.InNamespace(cluster.Name), client.MatchingLabels(rightsizing.RSLabels())); err != nil {
+		return ret, fmt.Errorf("failed to list the right-sizing recommendations of %s: %w", cluster.Name, err)
+	}
+	recs, err := export.ReadCache(cms.Items)
+	if err != nil {
+		o.Logger.Error(err, "Failed to read the cached right-sizing recommendations", "cluster", cluster.Name)
+		return ret, nil
+	}
+	ret.Recommendations = recs
+	return ret, nil
+}
+
+// vpaCRDEstablished checks the feedback of the read-only VerticalPodAutoscaler CRD
+// of the ManifestWork of the cluster.
+func (o *OptionsBuilder) vpaCRDEstablished(ctx context.Context, cluster *clusterv1.ManagedCluster) (bool, error) {
+	crd := workv1.ResourceIdentifier{
+		Group:    apiextensionsv1.GroupName,
+		Resource: crdResourceName,
+		Name:     export.VPACRDName,
+	}
+	feedback, err := common.GetFeedbackValuesForResources(ctx, o.Client, cluster.Name, addoncfg.Name, crd)
+	if err != nil {
+		return false, fmt.Errorf("failed to get feedback for the VerticalPodAutoscaler CRD: %w", err)
+	}
+	established := common.FilterFeedbackValuesByName(feedback[crd], addoncfg.IsEstablishedFeedbackName)
+	return len(established) > 0 && established[0].Value.String != nil && strings.EqualFold(*established[0].Value.String, "true"), nil
+}
+
 func (o *OptionsBuilder) getConfigData(ctx context.Context, configMapName string) (rightsizing.RSConfigMapData, error) {
 	cm, err := common.GetConfigMap(ctx, o.Client, addoncfg.InstallNamespace, configMapName)
 	if err != nil {
@@ -209,7 +283,8 @@ func (o *OptionsBuilder) createDefaultConfigMap(ctx context.Context, name string
 
 // clusterMatchesPlacement evaluates placement predicates in-memory against
 // a ManagedCluster, avoiding the need to create Placement resources and rely
-// on the OCM scheduler for PlacementDecisions.
+// on the OCM scheduler for PlacementDecisions. Only label and claim selectors are
+// evaluated; configs needing the full Placement semantics use PlacementModeDecisions.
 // Predicates are ORed (any match selects the cluster). Empty predicates match all.
 func clusterMatchesPlacement(cluster *clusterv1.ManagedCluster, placement clusterv1beta1.Placement) bool {
 	if len(placement.Spec.Predicates) == 0 {
@@ -3,6 +3,7 @@ package handlers
 import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 )
 
 // Options contains the right-sizing configuration for helm values
@@ -10,6 +11,20 @@ type Options struct {
 	NamespaceRightSizing      ComponentOptions
 	VirtualizationRightSizing ComponentOptions
 	ScrapeConfig              *cooprometheusv1alpha1.ScrapeConfig
+	Export                    ExportOptions
+	// IsOpenShiftVendor selects the in-cluster monitoring stack as the rules target.
+	// Otherwise the addon's own Prometheus server in InstallNamespace is used.
+	IsOpenShiftVendor bool
+	InstallNamespace  string
+}
+
+// ExportOptions contains the container recommendations exported to the cluster
+type ExportOptions struct {
+	Formats         []string
+	Recommendations []export.Recommendation
+	// VPACRD adds the read-only VerticalPodAutoscaler CRD to the manifests so
+	// that its feedback tells whether the VerticalPodAutoscalers can be exported.
+	VPACRD bool
 }
 
 // ComponentOptions contains the configuration for a single right-sizing component
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// globalClusterSetName is the ManagedClusterSet holding all the clusters.
+const globalClusterSetName = "global"
+
+// RSPlacementDecisionPredicate returns a predicate that filters PlacementDecision
+// watch events to the decisions of the right-sizing Placements, so that clusters
+// are re-evaluated when the OCM scheduler adds or removes them.
+func RSPlacementDecisionPredicate() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return isRSPlacement(obj.GetNamespace(), obj.GetLabels()[clusterv1beta1.PlacementLabel])
+	})
+}
+
+// RSPlacementPredicate returns a predicate that filters Placement watch events
+// to the right-sizing Placements, so that manual edits are reverted.
+func RSPlacementPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isRSPlacement(e.ObjectNew.GetNamespace(), e.ObjectNew.GetName())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isRSPlacement(e.Object.GetNamespace(), e.Object.GetName())
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isRSPlacement(namespace, name string) bool {
+	if namespace != addoncfg.InstallNamespace {
+		return false
+	}
+	switch name {
+	case rightsizing.NamespacePlacementName, rightsizing.VirtualizationPlacementName:
+		return true
+	}
+	return false
+}
+
+// clusterSelected reports whether the cluster is selected by the placement of the
+// right-sizing config. In PlacementModeDecisions the hub Placement named placementName
+// is the source of truth; otherwise the placement is evaluated in-memory.
+func (o *OptionsBuilder) clusterSelected(ctx context.Context, cluster *clusterv1.ManagedCluster, configData rightsizing.RSConfigMapData, placementName string) (bool, error) {
+	if configData.PlacementMode == rightsizing.PlacementModeDecisions {
+		return o.clusterInPlacementDecisions(ctx, placementName, cluster.Name)
+	}
+
+	if fields := unevaluatedPlacementFields(configData.PlacementConfiguration.Spec); len(fields) > 0 {
+		o.Logger.V(1).Info("Placement fields are ignored by in-memory evaluation, set placementMode to PlacementDecisions to honor them",
+			"placement", placementName, "fields", fields)
+	}
+	return clusterMatchesPlacement(cluster, configData.PlacementConfiguration), nil
+}
+
+// clusterInPlacementDecisions looks for the cluster in the PlacementDecisions of the given
+// Placement. A Placement that has not been scheduled yet selects no cluster.
+func (o *OptionsBuilder) clusterInPlacementDecisions(ctx context.Context, placementName, clusterName string) (bool, error) {
+	decisions := &clusterv1beta1.PlacementDecisionList{}
+	if err := o.Client.List(ctx, decisions,
+		client.InNamespace(addoncfg.InstallNamespace),
+		client.MatchingLabels{clusterv1beta1.PlacementLabel: placementName},
+	); err != nil {
+		return false, fmt.Errorf("failed to list placement decisions of %s: %w", placementName, err)
+	}
+
+	for _, pd := range decisions.Items {
+		for _, d := range pd.Status.Decisions {
+			if d.ClusterName == clusterName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// unevaluatedPlacementFields lists the placement fields that clusterMatchesPlacement
+// cannot evaluate for a single cluster.
+func unevaluatedPlacementFields(spec clusterv1beta1.PlacementSpec) []string {
+	var fields []string
+	if len(spec.ClusterSets) > 0 {
+		fields = append(fields, "clusterSets")
+	}
+	for _, pred := range spec.Predicates {
+		if len(pred.RequiredClusterSelector.CelSelector.CelExpressions) > 0 {
+			fields = append(fields, "celSelector")
+			break
+		}
+	}
+	if spec.NumberOfClusters != nil {
+		fields = append(fields, "numberOfClusters")
+	}
+	if len(spec.PrioritizerPolicy.Configurations) > 0 {
+		fields = append(fields, "prioritizerPolicy")
+	}
+	return fields
+}
+
+// reconcileRSPlacement creates or updates the hub Placement backing a right-sizing config
+// in PlacementModeDecisions, and deletes it in any other mode. It returns the
+// ManagedClusterSets the Placement selects clusters from.
+func (o *OptionsBuilder) reconcileRSPlacement(ctx context.Context, configMapName, placementName string) ([]string, error) {
+	configData, err := o.getConfigData(ctx, configMapName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, o.deleteRSPlacement(ctx, placementName)
+		}
+		return nil, fmt.Errorf("failed to get config %s: %w", configMapName, err)
+	}
+
+	if configData.PlacementMode != rightsizing.PlacementModeDecisions {
+		return nil, o.deleteRSPlacement(ctx, placementName)
+	}
+
+	placement := &clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      placementName,
+			Namespace: addoncfg.InstallNamespace,
+		},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, o.Client, placement, func() error {
+		if placement.Labels == nil {
+			placement.Labels = map[string]string{}
+		}
+		for k, v := range rightsizing.RSLabels() {
+			placement.Labels[k] = v
+		}
+		placement.Spec = configData.PlacementConfiguration.Spec
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile placement %s: %w", placementName, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		o.Logger.V(1).Info("Reconciled right-sizing Placement", "name", placementName, "namespace", addoncfg.InstallNamespace, "operation", op)
+	}
+
+	// A Placement without cluster sets selects from all the sets bound to its
+	// namespace, the global set holds every cluster.
+	if len(placement.Spec.ClusterSets) == 0 {
+		return []string{globalClusterSetName}, nil
+	}
+	return placement.Spec.ClusterSets, nil
+}
+
+// reconcileRSClusterSetBindings binds the ManagedClusterSets of the right-sizing
+// Placements to the install namespace. The OCM scheduler only considers the
+// clusters of the sets bound to the namespace of a Placement, so without them no
+// cluster is ever selected. Bindings created here that are no longer needed are
+// deleted, bindings created by users are left untouched.
+func (o *OptionsBuilder) reconcileRSClusterSetBindings(ctx context.Context, clusterSets []string) error {
+	bindings := &clusterv1beta2.ManagedClusterSetBindingList{}
+	if err := o.Client.List(ctx, bindings, client.InNamespace(addoncfg.InstallNamespace)); err != nil {
+		return fmt.Errorf("failed to list managed cluster set bindings: %w", err)
+	}
+
+	existing := map[string]clusterv1beta2.ManagedClusterSetBinding{}
+	for _, b := range bindings.Items {
+		existing[b.Name] = b
+	}
+
+	for _, set := range clusterSets {
+		if _, ok := existing[set]; ok {
+			continue
+		}
+		binding := &clusterv1beta2.ManagedClusterSetBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      set,
+				Namespace: addoncfg.InstallNamespace,
+				Labels:    rightsizing.RSLabels(),
+			},
+			Spec: clusterv1beta2.ManagedClusterSetBindingSpec{
+				ClusterSet: set,
+			},
+		}
+		if err := o.Client.Create(ctx, binding); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create managed cluster set binding %s: %w", set, err)
+		}
+		o.Logger.V(1).Info("Created right-sizing ManagedClusterSetBinding", "name", set, "namespace", addoncfg.InstallNamespace)
+	}
+
+	for name, b := range existing {
+		if b.Labels[rightsizing.RSManagedByLabel] != rightsizing.RSManagedByValue || slices.Contains(clusterSets, name) {
+			continue
+		}
+		if err := o.Client.Delete(ctx, &b); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete managed cluster set binding %s: %w", name, err)
+		}
+		o.Logger.V(1).Info("Deleted right-sizing ManagedClusterSetBinding", "name", name, "namespace", addoncfg.InstallNamespace)
+	}
+	return nil
+}
+
+// deleteRSPlacement deletes a right-sizing Placement if it exists.
+func (o *OptionsBuilder) deleteRSPlacement(ctx context.Context, placementName string) error {
+	placement := &clusterv1beta1.Placement{}
+	key := types.NamespacedName{Name: placementName, Namespace: addoncfg.InstallNamespace}
+	if err := o.Client.Get(ctx, key, placement); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get placement %s: %w", placementName, err)
+	}
+	if err := o.Client.Delete(ctx, placement); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete placement %s: %w", placementName, err)
+	}
+	o.Logger.V(1).Info("Deleted right-sizing Placement", "name", placementName, "namespace", addoncfg.InstallNamespace)
+	return nil
+}
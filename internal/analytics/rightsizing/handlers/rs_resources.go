@@ -44,6 +44,8 @@ func isRSConfigMap(namespace, name string) bool {
 // ReconcileRSResources cleans up RS ConfigMaps for disabled features, but only
 // when RS is delegated to MCOA (signaled via ADC). In MCO mode, MCO owns
 // ConfigMap lifecycle — deleting them here would cause a create-delete loop.
+// It also keeps the hub Placements of configs in PlacementModeDecisions, and the
+// ManagedClusterSetBindings they need, in sync.
 func (o *OptionsBuilder) ReconcileRSResources(ctx context.Context, opts addon.Options) error {
 	if !opts.Platform.AnalyticsOptions.RightSizing.Delegated {
 		return nil
@@ -54,12 +56,24 @@ func (o *OptionsBuilder) ReconcileRSResources(ctx context.Context, opts addon.Op
 			return fmt.Errorf("failed to cleanup namespace configmap: %w", err)
 		}
 	}
+	nsClusterSets, err := o.reconcileRSPlacement(ctx, rightsizing.NamespaceConfigMapName, rightsizing.NamespacePlacementName)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile namespace placement: %w", err)
+	}
 
 	if !opts.Platform.AnalyticsOptions.RightSizing.VirtualizationEnabled {
 		if err := o.deleteRSConfigMap(ctx, rightsizing.VirtualizationConfigMapName); err != nil {
 			return fmt.Errorf("failed to cleanup virtualization configmap: %w", err)
 		}
 	}
+	virtClusterSets, err := o.reconcileRSPlacement(ctx, rightsizing.VirtualizationConfigMapName, rightsizing.VirtualizationPlacementName)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile virtualization placement: %w", err)
+	}
+
+	if err := o.reconcileRSClusterSetBindings(ctx, append(nsClusterSets, virtClusterSets...)); err != nil {
+		return fmt.Errorf("failed to reconcile managed cluster set bindings: %w", err)
+	}
 
 	return nil
 }
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -15,9 +18,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	addonv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	workv1 "open-cluster-management.io/api/work/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
@@ -28,7 +34,9 @@ func setupTestScheme(t *testing.T) *runtime.Scheme {
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, clusterv1.Install(scheme))
 	require.NoError(t, clusterv1beta1.Install(scheme))
+	require.NoError(t, clusterv1beta2.Install(scheme))
 	require.NoError(t, addonv1beta1.Install(scheme))
+	require.NoError(t, workv1.Install(scheme))
 	return scheme
 }
 
@@ -447,3 +455,294 @@ func TestClusterMatchesPlacement_CombinedLabelAndClaim(t *testing.T) {
 	cluster.Labels["env"] = "staging"
 	assert.False(t, clusterMatchesPlacement(cluster, placement), "label no longer matches")
 }
+
+func createDecisionsConfigMap(name string) *corev1.ConfigMap {
+	placement := clusterv1beta1.Placement{
+		Spec: clusterv1beta1.PlacementSpec{
+			ClusterSets:      []string{"prod"},
+			NumberOfClusters: ptr.To[int32](1),
+		},
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: addoncfg.InstallNamespace,
+		},
+		Data: map[string]string{
+			"placementConfiguration": rightsizing.FormatJSON(placement),
+			"placementMode":          rightsizing.PlacementModeDecisions,
+		},
+	}
+}
+
+func TestReconcileRSResources_PlacementDecisionsMode(t *testing.T) {
+	ob := newTestOptionsBuilder(t, createDecisionsConfigMap(rightsizing.NamespaceConfigMapName))
+	ctx := context.TODO()
+
+	require.NoError(t, ob.ReconcileRSResources(ctx, newPlatformOpts(true, false)))
+
+	placement := &clusterv1beta1.Placement{}
+	err := ob.Client.Get(ctx, types.NamespacedName{
+		Name: rightsizing.NamespacePlacementName, Namespace: addoncfg.InstallNamespace,
+	}, placement)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, placement.Spec.ClusterSets)
+	assert.Equal(t, ptr.To[int32](1), placement.Spec.NumberOfClusters)
+	assert.Equal(t, rightsizing.RSManagedByValue, placement.Labels[rightsizing.RSManagedByLabel])
+
+	err = ob.Client.Get(ctx, types.NamespacedName{
+		Name: rightsizing.VirtualizationPlacementName, Namespace: addoncfg.InstallNamespace,
+	}, &clusterv1beta1.Placement{})
+	assert.True(t, apierrors.IsNotFound(err), "virtualization placement must not be created without config")
+
+	// The cluster set of the Placement is bound to its namespace
+	binding := &clusterv1beta2.ManagedClusterSetBinding{}
+	require.NoError(t, ob.Client.Get(ctx, types.NamespacedName{Name: "prod", Namespace: addoncfg.InstallNamespace}, binding))
+	assert.Equal(t, "prod", binding.Spec.ClusterSet)
+	assert.Equal(t, rightsizing.RSManagedByValue, binding.Labels[rightsizing.RSManagedByLabel])
+
+	// Switching back to in-memory evaluation removes the Placement
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, ob.Client.Get(ctx, types.NamespacedName{
+		Name: rightsizing.NamespaceConfigMapName, Namespace: addoncfg.InstallNamespace,
+	}, cm))
+	cm.Data["placementMode"] = rightsizing.PlacementModeInMemory
+	require.NoError(t, ob.Client.Update(ctx, cm))
+
+	require.NoError(t, ob.ReconcileRSResources(ctx, newPlatformOpts(true, false)))
+	err = ob.Client.Get(ctx, types.NamespacedName{
+		Name: rightsizing.NamespacePlacementName, Namespace: addoncfg.InstallNamespace,
+	}, &clusterv1beta1.Placement{})
+	assert.True(t, apierrors.IsNotFound(err), "placement should be deleted in InMemory mode")
+	err = ob.Client.Get(ctx, types.NamespacedName{Name: "prod", Namespace: addoncfg.InstallNamespace}, &clusterv1beta2.ManagedClusterSetBinding{})
+	assert.True(t, apierrors.IsNotFound(err), "binding should be deleted in InMemory mode")
+}
+
+func TestReconcileRSResources_KeepsUserClusterSetBindings(t *testing.T) {
+	userBinding := &clusterv1beta2.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: addoncfg.InstallNamespace},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "global"},
+	}
+	ob := newTestOptionsBuilder(t, userBinding)
+	ctx := context.TODO()
+
+	require.NoError(t, ob.ReconcileRSResources(ctx, newPlatformOpts(true, true)))
+
+	binding := &clusterv1beta2.ManagedClusterSetBinding{}
+	require.NoError(t, ob.Client.Get(ctx, types.NamespacedName{Name: "global", Namespace: addoncfg.InstallNamespace}, binding))
+	assert.Empty(t, binding.Labels)
+}
+
+func TestBuild_PlacementDecisionsMode(t *testing.T) {
+	decision := &clusterv1beta1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rightsizing.NamespacePlacementName + "-decision-1",
+			Namespace: addoncfg.InstallNamespace,
+			Labels:    map[string]string{clusterv1beta1.PlacementLabel: rightsizing.NamespacePlacementName},
+		},
+		Status: clusterv1beta1.PlacementDecisionStatus{
+			Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "selected"}},
+		},
+	}
+	ob := newTestOptionsBuilder(t, createDecisionsConfigMap(rightsizing.NamespaceConfigMapName), decision)
+	ctx := context.TODO()
+	opts := newPlatformOpts(true, false)
+
+	newCluster := func(name string) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"vendor": "OpenShift"},
+			},
+		}
+	}
+
+	ret, err := ob.Build(ctx, newCluster("selected"), opts)
+	require.NoError(t, err)
+	assert.True(t, ret.NamespaceRightSizing.Enabled)
+
+	ret, err = ob.Build(ctx, newCluster("not-selected"), opts)
+	require.NoError(t, err)
+	assert.False(t, ret.NamespaceRightSizing.Enabled, "clusters missing from the decisions are not selected")
+}
+
+func TestRSPlacementDecisionPredicate(t *testing.T) {
+	pred := RSPlacementDecisionPredicate()
+
+	newDecision := func(namespace, placement string) *clusterv1beta1.PlacementDecision {
+		return &clusterv1beta1.PlacementDecision{ObjectMeta: metav1.ObjectMeta{
+			Name:      placement + "-decision-1",
+			Namespace: namespace,
+			Labels:    map[string]string{clusterv1beta1.PlacementLabel: placement},
+		}}
+	}
+
+	assert.True(t, pred.Create(event.CreateEvent{Object: newDecision(addoncfg.InstallNamespace, rightsizing.NamespacePlacementName)}))
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectNew: newDecision(addoncfg.InstallNamespace, rightsizing.VirtualizationPlacementName)}))
+	assert.False(t, pred.Create(event.CreateEvent{Object: newDecision(addoncfg.InstallNamespace, "global")}))
+	assert.False(t, pred.Create(event.CreateEvent{Object: newDecision("other-namespace", rightsizing.NamespacePlacementName)}))
+}
+
+func TestUnevaluatedPlacementFields(t *testing.T) {
+	assert.Empty(t, unevaluatedPlacementFields(rightsizing.GetDefaultRSPlacement().Spec))
+
+	spec := clusterv1beta1.PlacementSpec{
+		ClusterSets:      []string{"prod"},
+		NumberOfClusters: ptr.To[int32](3),
+		Predicates: []clusterv1beta1.ClusterPredicate{{
+			RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+				CelSelector: clusterv1beta1.ClusterCelSelector{CelExpressions: []string{`managedCluster.metadata.name != ""`}},
+			},
+		}},
+		PrioritizerPolicy: clusterv1beta1.PrioritizerPolicy{
+			Configurations: []clusterv1beta1.PrioritizerConfig{{
+				ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{Type: clusterv1beta1.ScoreCoordinateTypeBuiltIn, BuiltIn: "Steady"},
+			}},
+		},
+	}
+	assert.Equal(t, []string{"clusterSets", "celSelector", "numberOfClusters", "prioritizerPolicy"}, unevaluatedPlacementFields(spec))
+}
+
+func vpaCRDManifestWork(cluster, established string) *workv1.ManifestWork {
+	return &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "addon-" + addoncfg.Name + "-deploy-0",
+			Namespace: cluster,
+			Labels:    map[string]string{addonv1beta1.AddonLabelKey: addoncfg.Name},
+		},
+		Status: workv1.ManifestWorkStatus{
+			ResourceStatus: workv1.ManifestResourceStatus{
+				Manifests: []workv1.ManifestCondition{{
+					ResourceMeta: workv1.ManifestResourceMeta{
+						Group:    "apiextensions.k8s.io",
+						Resource: crdResourceName,
+						Name:     export.VPACRDName,
+					},
+					StatusFeedbacks: workv1.StatusFeedbackResult{
+						Values: []workv1.FeedbackValue{{
+							Name:  addoncfg.IsEstablishedFeedbackName,
+							Value: workv1.FieldValue{Type: workv1.String, String: ptr.To(established)},
+						}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestBuild_ExportRecommendations(t *testing.T) {
+	cm := createTestConfigMap(rightsizing.NamespaceConfigMapName)
+	cm.Data = map[string]string{"exportConfiguration": `{"formats":["VerticalPodAutoscaler","Report"]}`}
+	cache, err := export.CacheConfigMaps("cluster1", []export.Recommendation{
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURecommendation: 0.25},
+	})
+	require.NoError(t, err)
+	ob := newTestOptionsBuilder(t, cm, cache[0], vpaCRDManifestWork("cluster1", "True"))
+
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:   "cluster1",
+		Labels: map[string]string{"vendor": "OpenShift"},
+	}}
+	ret, err := ob.Build(context.TODO(), cluster, newPlatformOpts(true, false))
+	require.NoError(t, err)
+	require.Len(t, ret.Export.Recommendations, 1)
+
+	values, err := BuildValues(ret)
+	require.NoError(t, err)
+	require.NotNil(t, values.Export)
+	assert.True(t, values.Export.VPACRD)
+	require.Len(t, values.Export.VPAs, 1)
+	assert.Equal(t, "shop", values.Export.VPAs[0].Namespace)
+	assert.JSONEq(t, `[{"name":"nginx","cpu":"250m","memory":"0"}]`, values.Export.VPAs[0].Annotations[export.RecommendationAnnotation])
+	require.Len(t, values.Export.Reports, 1)
+	assert.Equal(t, rightsizing.ReportConfigMapName, values.Export.Reports[0].Name)
+	assert.Contains(t, values.Export.Reports[0].CSV, "shop,web,deployment,nginx,0,0.25,0,0")
+	assert.Empty(t, values.Export.Reports[0].Annotations)
+}
+
+func TestBuildExportValues_TruncatesLargeReports(t *testing.T) {
+	recs := make([]export.Recommendation, export.MaxReportRecommendations+1)
+	for i := range recs {
+		recs[i] = export.Recommendation{Namespace: "shop", Workload: fmt.Sprintf("web-%d", i), WorkloadType: "deployment", Container: "nginx"}
+	}
+	values, err := buildExportValues(ExportOptions{Formats: []string{rightsizing.ExportFormatReport}, Recommendations: recs})
+	require.NoError(t, err)
+	require.Len(t, values.Reports, 1)
+	assert.Equal(t, map[string]string{export.TruncatedAnnotation: fmt.Sprint(len(recs))}, values.Reports[0].Annotations)
+	assert.Equal(t, export.MaxReportRecommendations+1, strings.Count(values.Reports[0].CSV, "\n"), "header and the first recommendations")
+}
+
+func TestBuild_ExportWaitsForTheVPACRD(t *testing.T) {
+	cm := createTestConfigMap(rightsizing.NamespaceConfigMapName)
+	cm.Data = map[string]string{"exportConfiguration": `{"formats":["VerticalPodAutoscaler"]}`}
+	cache, err := export.CacheConfigMaps("cluster1", []export.Recommendation{
+		{Namespace: "shop", Workload: "web", WorkloadType: "deployment", Container: "nginx", CPURecommendation: 0.25},
+	})
+	require.NoError(t, err)
+	ob := newTestOptionsBuilder(t, cm, cache[0])
+
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:   "cluster1",
+		Labels: map[string]string{"vendor": "OpenShift"},
+	}}
+	ret, err := ob.Build(context.TODO(), cluster, newPlatformOpts(true, false))
+	require.NoError(t, err)
+	assert.Empty(t, ret.Export.Formats)
+
+	// Only the read-only CRD is rendered, to get its feedback.
+	values, err := BuildValues(ret)
+	require.NoError(t, err)
+	require.NotNil(t, values.Export)
+	assert.True(t, values.Export.VPACRD)
+	assert.Empty(t, values.Export.VPAs)
+}
+
+func TestBuild_ExportWithoutCache(t *testing.T) {
+	cm := createTestConfigMap(rightsizing.NamespaceConfigMapName)
+	cm.Data = map[string]string{"exportConfiguration": `{"formats":["Report"]}`}
+	ob := newTestOptionsBuilder(t, cm)
+
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:   "cluster1",
+		Labels: map[string]string{"vendor": "OpenShift"},
+	}}
+	ret, err := ob.Build(context.TODO(), cluster, newPlatformOpts(true, false))
+	require.NoError(t, err)
+	assert.True(t, ret.NamespaceRightSizing.Enabled)
+	assert.Empty(t, ret.Export.Recommendations)
+}
+
+func TestBuild_NonOpenShiftCluster(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:   "eks-1",
+		Labels: map[string]string{"vendor": "EKS"},
+	}}
+
+	t.Run("skipped without platform metrics collection", func(t *testing.T) {
+		ob := newTestOptionsBuilder(t)
+		ret, err := ob.Build(context.TODO(), cluster, newPlatformOpts(true, true))
+		require.NoError(t, err)
+		assert.False(t, ret.NamespaceRightSizing.Enabled)
+		assert.False(t, ret.VirtualizationRightSizing.Enabled)
+	})
+
+	t.Run("rules target the addon Prometheus server", func(t *testing.T) {
+		ob := newTestOptionsBuilder(t)
+		opts := newPlatformOpts(true, false)
+		opts.InstallNamespace = "open-cluster-management-agent-addon"
+		opts.Platform.Metrics.CollectionEnabled = true
+
+		ret, err := ob.Build(context.TODO(), cluster, opts)
+		require.NoError(t, err)
+		assert.True(t, ret.NamespaceRightSizing.Enabled)
+		assert.False(t, ret.IsOpenShiftVendor)
+
+		values, err := BuildValues(ret)
+		require.NoError(t, err)
+		assert.True(t, values.NonOCP)
+		require.NotNil(t, values.ScrapeConfig)
+		assert.Contains(t, values.ScrapeConfig.Data, `"targets":["acm-prometheus-k8s.open-cluster-management-agent-addon.svc:9091"]`)
+		assert.Contains(t, values.ScrapeConfig.Data, `"scrapeClass":"non-ocp-monitoring"`)
+		assert.Contains(t, values.ScrapeConfig.Data, `"insecureSkipVerify":true`)
+	})
+}
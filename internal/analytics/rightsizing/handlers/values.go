@@ -2,11 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"strconv"
 
-	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
-	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
-	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
-	"k8s.io/utils/ptr"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
+	mmanifests "github.com/stolostron/multicluster-observability-addon/internal/metrics/manifests"
 )
 
 // RightSizingValues contains the helm values for right-sizing
@@ -14,6 +14,33 @@ type RightSizingValues struct {
 	NamespaceRightSizing      *ComponentValues   `json:"namespaceRightSizing,omitempty"`
 	VirtualizationRightSizing *ComponentValues   `json:"virtRightSizing,omitempty"`
 	ScrapeConfig              *ScrapeConfigValue `json:"scrapeConfig,omitempty"`
+	Export                    *ExportValues      `json:"export,omitempty"`
+	// NonOCP deploys the rules to the addon's Prometheus server instead of openshift-monitoring.
+	NonOCP bool `json:"nonOCP"`
+}
+
+// ExportValues contains the helm values for the exported recommendations
+type ExportValues struct {
+	VPACRD  bool          `json:"vpaCRD"`
+	VPAs    []VPAValue    `json:"vpas,omitempty"`
+	Reports []ReportValue `json:"reports,omitempty"`
+}
+
+// VPAValue contains the helm values for a VerticalPodAutoscaler
+type VPAValue struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations"`
+	Data        string            `json:"data"`
+}
+
+// ReportValue contains the helm values for a namespace recommendation report
+type ReportValue struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	JSON        string            `json:"json"`
+	CSV         string            `json:"csv"`
 }
 
 // ScrapeConfigValue contains the helm values for a ScrapeConfig
@@ -42,7 +69,7 @@ func BuildValues(opts Options) (*RightSizingValues, error) {
 		return nil, nil
 	}
 
-	ret := &RightSizingValues{}
+	ret := &RightSizingValues{NonOCP: !opts.IsOpenShiftVendor}
 
 	// Build namespace right-sizing values
 	if opts.NamespaceRightSizing.Enabled {
@@ -80,8 +107,14 @@ func BuildValues(opts Options) (*RightSizingValues, error) {
 		ret.VirtualizationRightSizing = virtValues
 	}
 
+	exportValues, err := buildExportValues(opts.Export)
+	if err != nil {
+		return nil, err
+	}
+	ret.Export = exportValues
+
 	if opts.ScrapeConfig != nil {
-		enrichScrapeConfigForPlatform(opts.ScrapeConfig)
+		mmanifests.SetPlatformTarget(opts.ScrapeConfig, opts.IsOpenShiftVendor, opts.InstallNamespace)
 		scJSON, err := json.Marshal(opts.ScrapeConfig.Spec)
 		if err != nil {
 			return nil, err
@@ -97,17 +130,56 @@ func BuildValues(opts Options) (*RightSizingValues, error) {
 	return ret, nil
 }
 
-// enrichScrapeConfigForPlatform sets the platform target, scheme, and scrape class
-// on the ScrapeConfig so it can be scraped by the platform PrometheusAgent.
-// Right-sizing only runs on OpenShift clusters, so OCP defaults are used.
-func enrichScrapeConfigForPlatform(sc *cooprometheusv1alpha1.ScrapeConfig) {
-	sc.Spec.ScrapeClassName = ptr.To(mconfig.ScrapeClassCfgName)
-	sc.Spec.Scheme = ptr.To(cooprometheusv1.Scheme("HTTPS"))
-	sc.Spec.StaticConfigs = []cooprometheusv1alpha1.StaticConfig{
-		{
-			Targets: []cooprometheusv1alpha1.Target{
-				cooprometheusv1alpha1.Target(mconfig.ScrapeClassPlatformTarget),
-			},
-		},
+// buildExportValues renders the exported recommendations in each requested format.
+func buildExportValues(opts ExportOptions) (*ExportValues, error) {
+	if !opts.VPACRD && (len(opts.Formats) == 0 || len(opts.Recommendations) == 0) {
+		return nil, nil
+	}
+
+	ret := &ExportValues{VPACRD: opts.VPACRD}
+	if len(opts.Recommendations) == 0 {
+		return ret, nil
 	}
+	for _, format := range opts.Formats {
+		switch format {
+		case rightsizing.ExportFormatVPA:
+			for _, vpa := range export.BuildVPAs(opts.Recommendations) {
+				specJSON, err := json.Marshal(vpa.Spec())
+				if err != nil {
+					return nil, err
+				}
+				recJSON, err := json.Marshal(vpa.Recommendations)
+				if err != nil {
+					return nil, err
+				}
+				ret.VPAs = append(ret.VPAs, VPAValue{
+					Name:        vpa.Name,
+					Namespace:   vpa.Namespace,
+					Annotations: map[string]string{export.RecommendationAnnotation: string(recJSON)},
+					Data:        string(specJSON),
+				})
+			}
+		case rightsizing.ExportFormatReport:
+			namespaces, byNamespace := export.GroupByNamespace(opts.Recommendations)
+			for _, ns := range namespaces {
+				recs := byNamespace[ns]
+				report := ReportValue{
+					Name:      rightsizing.ReportConfigMapName,
+					Namespace: ns,
+				}
+				if len(recs) > export.MaxReportRecommendations {
+					report.Annotations = map[string]string{export.TruncatedAnnotation: strconv.Itoa(len(recs))}
+					recs = recs[:export.MaxReportRecommendations]
+				}
+				jsonReport, csvReport, err := export.Report(recs)
+				if err != nil {
+					return nil, err
+				}
+				report.JSON = jsonReport
+				report.CSV = csvReport
+				ret.Reports = append(ret.Reports, report)
+			}
+		}
+	}
+	return ret, nil
 }
@@ -8,6 +8,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// workloadOwnerJoin attaches the owning workload to pod-level series using the
+// kube-prometheus owner relabel rule. Only Deployments, StatefulSets and
+// DaemonSets are kept; bare pods and Jobs have no stable requests to right-size.
+const workloadOwnerJoin = `* on (namespace, pod) group_left(workload, workload_type) ` +
+	`namespace_workload_pod:kube_pod_owner:relabel{workload_type=~"deployment|statefulset|daemonset"}`
+
 // GeneratePrometheusRule builds PrometheusRule based on configdata
 func GeneratePrometheusRule(configData rightsizing.RSConfigMapData) (monitoringv1.PrometheusRule, error) {
 	nsFilter, err := rightsizing.BuildNamespaceFilter(configData.PrometheusRuleConfig)
@@ -23,6 +29,42 @@ func GeneratePrometheusRule(configData rightsizing.RSConfigMapData) (monitoringv
 	// Create rule builder with shared utilities
 	rb := rightsizing.NewRuleBuilder(labelJoin)
 
+	windows, err := rightsizing.BuildAggregationWindows(configData.PrometheusRuleConfig, labelJoin)
+	if err != nil {
+		return monitoringv1.PrometheusRule{}, err
+	}
+	// withConfig adapts the aggregated rule builders to rightsizing.AggregationGroups,
+	// which calls them once per configured profile and window.
+	withConfig := func(fn func(rightsizing.RSConfigMapData, *rightsizing.RuleBuilder) []monitoringv1.Rule) func(*rightsizing.RuleBuilder) []monitoringv1.Rule {
+		return func(wrb *rightsizing.RuleBuilder) []monitoringv1.Rule { return fn(configData, wrb) }
+	}
+
+	var groups []monitoringv1.RuleGroup
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-right-sizing-namespace-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildNamespaceRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-right-sizing-namespace-%s.rules", windows, withConfig(buildNamespaceRulesAggregated))...)
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-right-sizing-cluster-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildClusterRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-right-sizing-cluster-%s.rule", windows, withConfig(buildClusterRulesAggregated))...)
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-right-sizing-workload-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildWorkloadRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-right-sizing-workload-%s.rules", windows, withConfig(buildWorkloadRulesAggregated))...)
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-right-sizing-container-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildContainerRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-right-sizing-container-%s.rules", windows, withConfig(buildContainerRulesAggregated))...)
+
 	return monitoringv1.PrometheusRule{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      rightsizing.NamespacePrometheusRuleName,
@@ -33,28 +75,7 @@ func GeneratePrometheusRule(configData rightsizing.RSConfigMapData) (monitoringv
 			APIVersion: "monitoring.coreos.com/v1",
 		},
 		Spec: monitoringv1.PrometheusRuleSpec{
-			Groups: []monitoringv1.RuleGroup{
-				{
-					Name:     "acm-right-sizing-namespace-5m.rule",
-					Interval: &rightsizing.Duration5m,
-					Rules:    buildNamespaceRules5m(nsFilter, rb),
-				},
-				{
-					Name:     "acm-right-sizing-namespace-1d.rules",
-					Interval: &rightsizing.Duration1d,
-					Rules:    buildNamespaceRules1d(configData, rb),
-				},
-				{
-					Name:     "acm-right-sizing-cluster-5m.rule",
-					Interval: &rightsizing.Duration5m,
-					Rules:    buildClusterRules5m(nsFilter, rb),
-				},
-				{
-					Name:     "acm-right-sizing-cluster-1d.rule",
-					Interval: &rightsizing.Duration1d,
-					Rules:    buildClusterRules1d(configData, rb),
-				},
-			},
+			Groups: groups,
 		},
 	}, nil
 }
@@ -113,22 +134,22 @@ func buildNamespaceRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monit
 	}
 }
 
-// buildNamespaceRules1d builds 1-day aggregation recording rules for namespace-level metrics.
-// Aggregates the 5m rules into daily summaries with profile/aggregation labels for dashboard selection.
-func buildNamespaceRules1d(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+// buildNamespaceRulesAggregated builds window aggregation recording rules for namespace-level metrics.
+// Aggregates the 5m rules over the aggregation window with profile/aggregation labels for dashboard selection.
+func buildNamespaceRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
 	rp := configData.PrometheusRuleConfig.RecommendationPercentage
 	if rp == 0 {
 		rp = rightsizing.DefaultRecommendationPercentage
 	}
 	return []monitoringv1.Rule{
-		rb.RuleWithLabels("acm_rs:namespace:cpu_request_hard", rightsizing.Build1dAggregationExpr("acm_rs:namespace:cpu_request_hard:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:cpu_request", rightsizing.Build1dAggregationExpr("acm_rs:namespace:cpu_request:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:cpu_usage", rightsizing.Build1dAggregationExpr("acm_rs:namespace:cpu_usage:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:cpu_recommendation", rightsizing.BuildRecommendationExpr("acm_rs:namespace:cpu_usage:5m", rp)),
-		rb.RuleWithLabels("acm_rs:namespace:memory_request_hard", rightsizing.Build1dAggregationExpr("acm_rs:namespace:memory_request_hard:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:memory_request", rightsizing.Build1dAggregationExpr("acm_rs:namespace:memory_request:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:memory_usage", rightsizing.Build1dAggregationExpr("acm_rs:namespace:memory_usage:5m")),
-		rb.RuleWithLabels("acm_rs:namespace:memory_recommendation", rightsizing.BuildRecommendationExpr("acm_rs:namespace:memory_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:namespace:cpu_request_hard", rb.RequestExpr("acm_rs:namespace:cpu_request_hard:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:cpu_request", rb.RequestExpr("acm_rs:namespace:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:cpu_usage", rb.UsageExpr("acm_rs:namespace:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:cpu_recommendation", rb.RecommendationExpr("acm_rs:namespace:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:namespace:memory_request_hard", rb.RequestExpr("acm_rs:namespace:memory_request_hard:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:memory_request", rb.RequestExpr("acm_rs:namespace:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:memory_usage", rb.UsageExpr("acm_rs:namespace:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs:namespace:memory_recommendation", rb.RecommendationExpr("acm_rs:namespace:memory_usage:5m", rp)),
 	}
 }
 
@@ -187,21 +208,135 @@ func buildClusterRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monitor
 	}
 }
 
-// buildClusterRules1d builds 1-day aggregation recording rules for cluster-level metrics.
-// Aggregates the 5m cluster rules into daily summaries with profile/aggregation labels for dashboard selection.
-func buildClusterRules1d(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+// buildClusterRulesAggregated builds window aggregation recording rules for cluster-level metrics.
+// Aggregates the 5m cluster rules over the aggregation window with profile/aggregation labels for dashboard selection.
+func buildClusterRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+	rp := configData.PrometheusRuleConfig.RecommendationPercentage
+	if rp == 0 {
+		rp = rightsizing.DefaultRecommendationPercentage
+	}
+	return []monitoringv1.Rule{
+		rb.RuleWithLabels("acm_rs:cluster:cpu_request_hard", rb.RequestExpr("acm_rs:cluster:cpu_request_hard:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:cpu_request", rb.RequestExpr("acm_rs:cluster:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:cpu_usage", rb.UsageExpr("acm_rs:cluster:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:cpu_recommendation", rb.RecommendationExpr("acm_rs:cluster:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:cluster:memory_request_hard", rb.RequestExpr("acm_rs:cluster:memory_request_hard:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:memory_request", rb.RequestExpr("acm_rs:cluster:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:memory_usage", rb.UsageExpr("acm_rs:cluster:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs:cluster:memory_recommendation", rb.RecommendationExpr("acm_rs:cluster:memory_usage:5m", rp)),
+	}
+}
+
+// buildWorkloadRules5m builds 5-minute recording rules for workload-level resource metrics.
+// Container series are summed across all pods owned by the workload, so the values
+// represent the total footprint of the Deployment, StatefulSet or DaemonSet.
+func buildWorkloadRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+	return []monitoringv1.Rule{
+		rb.Rule(
+			"acm_rs:workload:cpu_request:5m",
+			fmt.Sprintf(
+				`max_over_time(sum(kube_pod_container_resource_requests{`+
+					`%s, resource="cpu", container!=""} %s) by (namespace, workload, workload_type)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:workload:cpu_usage:5m",
+			fmt.Sprintf(
+				`max_over_time(sum(node_namespace_pod_container:`+
+					`container_cpu_usage_seconds_total:sum_irate{`+
+					`%s, container!=""} %s) by (namespace, workload, workload_type)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:workload:memory_request:5m",
+			fmt.Sprintf(
+				`max_over_time(sum(kube_pod_container_resource_requests{`+
+					`%s, resource="memory", container!=""} %s) by (namespace, workload, workload_type)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:workload:memory_usage:5m",
+			fmt.Sprintf(
+				`max_over_time(sum(container_memory_working_set_bytes{`+
+					`%s, container!=""} %s) by (namespace, workload, workload_type)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+	}
+}
+
+// buildWorkloadRulesAggregated builds window aggregation recording rules for workload-level metrics.
+func buildWorkloadRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+	rp := configData.PrometheusRuleConfig.RecommendationPercentage
+	if rp == 0 {
+		rp = rightsizing.DefaultRecommendationPercentage
+	}
+	return []monitoringv1.Rule{
+		rb.RuleWithLabels("acm_rs:workload:cpu_request", rb.RequestExpr("acm_rs:workload:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs:workload:cpu_usage", rb.UsageExpr("acm_rs:workload:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs:workload:cpu_recommendation", rb.RecommendationExpr("acm_rs:workload:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:workload:memory_request", rb.RequestExpr("acm_rs:workload:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs:workload:memory_usage", rb.UsageExpr("acm_rs:workload:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs:workload:memory_recommendation", rb.RecommendationExpr("acm_rs:workload:memory_usage:5m", rp)),
+	}
+}
+
+// buildContainerRules5m builds 5-minute recording rules for container-level resource metrics.
+// Uses max across the workload's pods rather than sum, so the values are per replica and
+// map directly to the container's resources.requests in the pod template.
+func buildContainerRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+	return []monitoringv1.Rule{
+		rb.Rule(
+			"acm_rs:container:cpu_request:5m",
+			fmt.Sprintf(
+				`max_over_time(max(kube_pod_container_resource_requests{`+
+					`%s, resource="cpu", container!=""} %s) by (namespace, workload, workload_type, container)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:container:cpu_usage:5m",
+			fmt.Sprintf(
+				`max_over_time(max(node_namespace_pod_container:`+
+					`container_cpu_usage_seconds_total:sum_irate{`+
+					`%s, container!=""} %s) by (namespace, workload, workload_type, container)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:container:memory_request:5m",
+			fmt.Sprintf(
+				`max_over_time(max(kube_pod_container_resource_requests{`+
+					`%s, resource="memory", container!=""} %s) by (namespace, workload, workload_type, container)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+		rb.Rule(
+			"acm_rs:container:memory_usage:5m",
+			fmt.Sprintf(
+				`max_over_time(max(container_memory_working_set_bytes{`+
+					`%s, container!=""} %s) by (namespace, workload, workload_type, container)[5m:])`,
+				nsFilter, workloadOwnerJoin,
+			),
+		),
+	}
+}
+
+// buildContainerRulesAggregated builds window aggregation recording rules for container-level metrics.
+func buildContainerRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
 	rp := configData.PrometheusRuleConfig.RecommendationPercentage
 	if rp == 0 {
 		rp = rightsizing.DefaultRecommendationPercentage
 	}
 	return []monitoringv1.Rule{
-		rb.RuleWithLabels("acm_rs:cluster:cpu_request_hard", rightsizing.Build1dAggregationExpr("acm_rs:cluster:cpu_request_hard:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:cpu_request", rightsizing.Build1dAggregationExpr("acm_rs:cluster:cpu_request:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:cpu_usage", rightsizing.Build1dAggregationExpr("acm_rs:cluster:cpu_usage:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:cpu_recommendation", rightsizing.BuildRecommendationExpr("acm_rs:cluster:cpu_usage:5m", rp)),
-		rb.RuleWithLabels("acm_rs:cluster:memory_request_hard", rightsizing.Build1dAggregationExpr("acm_rs:cluster:memory_request_hard:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:memory_request", rightsizing.Build1dAggregationExpr("acm_rs:cluster:memory_request:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:memory_usage", rightsizing.Build1dAggregationExpr("acm_rs:cluster:memory_usage:5m")),
-		rb.RuleWithLabels("acm_rs:cluster:memory_recommendation", rightsizing.BuildRecommendationExpr("acm_rs:cluster:memory_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:container:cpu_request", rb.RequestExpr("acm_rs:container:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs:container:cpu_usage", rb.UsageExpr("acm_rs:container:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs:container:cpu_recommendation", rb.RecommendationExpr("acm_rs:container:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs:container:memory_request", rb.RequestExpr("acm_rs:container:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs:container:memory_usage", rb.UsageExpr("acm_rs:container:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs:container:memory_recommendation", rb.RecommendationExpr("acm_rs:container:memory_usage:5m", rp)),
 	}
 }
@@ -80,13 +80,17 @@ func TestGeneratePrometheusRule(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, rightsizing.NamespacePrometheusRuleName, rule.Name)
 				assert.Equal(t, rightsizing.MonitoringNamespace, rule.Namespace)
-				require.Len(t, rule.Spec.Groups, 4)
+				require.Len(t, rule.Spec.Groups, 8)
 
 				// Check group names
 				assert.Equal(t, "acm-right-sizing-namespace-5m.rule", rule.Spec.Groups[0].Name)
 				assert.Equal(t, "acm-right-sizing-namespace-1d.rules", rule.Spec.Groups[1].Name)
 				assert.Equal(t, "acm-right-sizing-cluster-5m.rule", rule.Spec.Groups[2].Name)
 				assert.Equal(t, "acm-right-sizing-cluster-1d.rule", rule.Spec.Groups[3].Name)
+				assert.Equal(t, "acm-right-sizing-workload-5m.rule", rule.Spec.Groups[4].Name)
+				assert.Equal(t, "acm-right-sizing-workload-1d.rules", rule.Spec.Groups[5].Name)
+				assert.Equal(t, "acm-right-sizing-container-5m.rule", rule.Spec.Groups[6].Name)
+				assert.Equal(t, "acm-right-sizing-container-1d.rules", rule.Spec.Groups[7].Name)
 			}
 		})
 	}
@@ -117,3 +121,91 @@ func TestDefaultRecommendationPercentage(t *testing.T) {
 	}
 	assert.True(t, found, "cpu_recommendation rule should exist")
 }
+
+// TestWorkloadAndContainerRules verifies that workload and container rules join
+// pods to their owning controller and keep the labels needed to identify
+// which container's requests to change.
+func TestWorkloadAndContainerRules(t *testing.T) {
+	rule, err := GeneratePrometheusRule(rightsizing.RSConfigMapData{
+		PrometheusRuleConfig: rightsizing.GetDefaultRSPrometheusRuleConfig(),
+	})
+	require.NoError(t, err)
+
+	exprs := map[string]string{}
+	labels := map[string]map[string]string{}
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			exprs[r.Record] = r.Expr.String()
+			labels[r.Record] = r.Labels
+		}
+	}
+
+	for _, record := range []string{
+		"acm_rs:workload:cpu_request:5m",
+		"acm_rs:workload:memory_usage:5m",
+	} {
+		require.Contains(t, exprs, record)
+		assert.Contains(t, exprs[record], "namespace_workload_pod:kube_pod_owner:relabel")
+		assert.Contains(t, exprs[record], "by (namespace, workload, workload_type)")
+	}
+
+	for _, record := range []string{
+		"acm_rs:container:cpu_usage:5m",
+		"acm_rs:container:memory_request:5m",
+	} {
+		require.Contains(t, exprs, record)
+		assert.Contains(t, exprs[record], "by (namespace, workload, workload_type, container)")
+	}
+
+	require.Contains(t, exprs, "acm_rs:container:cpu_recommendation")
+	assert.Contains(t, exprs["acm_rs:container:cpu_recommendation"], "acm_rs:container:cpu_usage:5m")
+	assert.Equal(t, "Max OverAll", labels["acm_rs:container:cpu_recommendation"]["profile"])
+	assert.Equal(t, "1d", labels["acm_rs:workload:memory_recommendation"]["aggregation"])
+}
+
+// TestProfilesAndWindows verifies that every configured window gets its own
+// aggregation groups holding one rule set per profile.
+func TestProfilesAndWindows(t *testing.T) {
+	cfg := rightsizing.GetDefaultRSPrometheusRuleConfig()
+	cfg.Profiles = []string{rightsizing.ProfileMaxOverAll, rightsizing.ProfileP95}
+	cfg.AggregationWindows = []string{"1d", "7d", "30d"}
+	cfg.Retention = "30d"
+
+	rule, err := GeneratePrometheusRule(rightsizing.RSConfigMapData{PrometheusRuleConfig: cfg})
+	require.NoError(t, err)
+
+	// 4 levels x (1 x 5m + 3 windows)
+	require.Len(t, rule.Spec.Groups, 16)
+	groupNames := make([]string, 0, len(rule.Spec.Groups))
+	for _, g := range rule.Spec.Groups {
+		groupNames = append(groupNames, g.Name)
+	}
+	assert.Contains(t, groupNames, "acm-right-sizing-namespace-1d.rules")
+	assert.Contains(t, groupNames, "acm-right-sizing-namespace-30d.rules")
+	assert.Contains(t, groupNames, "acm-right-sizing-cluster-7d.rule")
+	assert.Contains(t, groupNames, "acm-right-sizing-container-30d.rules")
+
+	var found bool
+	for _, g := range rule.Spec.Groups {
+		if g.Name != "acm-right-sizing-namespace-30d.rules" {
+			continue
+		}
+		// 8 namespace rules per profile
+		require.Len(t, g.Rules, 16)
+		for _, r := range g.Rules {
+			if r.Record == "acm_rs:namespace:cpu_recommendation" && r.Labels["profile"] == rightsizing.ProfileP95 {
+				assert.Equal(t, "30d", r.Labels["aggregation"])
+				assert.Equal(t, `quantile_over_time(0.95, acm_rs:namespace:cpu_usage:5m[30d]) * (110/100)`, r.Expr.String())
+				found = true
+			}
+			if r.Record == "acm_rs:namespace:cpu_request" && r.Labels["profile"] == rightsizing.ProfileP95 {
+				assert.Equal(t, `max_over_time(acm_rs:namespace:cpu_request:5m[30d])`, r.Expr.String())
+			}
+		}
+	}
+	assert.True(t, found, "P95 30d cpu_recommendation rule should exist")
+
+	cfg.Profiles = []string{"Median"}
+	_, err = GeneratePrometheusRule(rightsizing.RSConfigMapData{PrometheusRuleConfig: cfg})
+	assert.Error(t, err)
+}
@@ -2,8 +2,10 @@ package rightsizing
 
 import (
 	"fmt"
+	"time"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -11,19 +13,31 @@ import (
 var (
 	// Duration5m is the evaluation interval for high-resolution metrics (5-minute aggregations)
 	Duration5m = monitoringv1.Duration("5m")
-	// Duration15m is the evaluation interval for daily aggregated metrics.
-	// Rules evaluate every 15 minutes but aggregate data over 1 day (via max_over_time(...[1d])).
-	// This provides fresh dashboard data without waiting a full day between evaluations.
-	Duration1d = monitoringv1.Duration("15m")
+	// AggregationEvalInterval is the evaluation interval for aggregated metrics over windows up to 1d.
+	// Rules evaluate every 15 minutes but aggregate data over the whole window (e.g. max_over_time(...[1d])).
+	// This provides fresh dashboard data without waiting a full window between evaluations.
+	AggregationEvalInterval = monitoringv1.Duration("15m")
+	// LongAggregationEvalInterval is the evaluation interval for aggregated metrics over windows
+	// longer than 1d. Their aggregations barely move within an hour while each evaluation reads
+	// the whole window, so they are evaluated less often.
+	LongAggregationEvalInterval = monitoringv1.Duration("1h")
 )
 
+// profileQuantiles maps the percentile profiles to their quantile_over_time argument.
+// ProfileMaxOverAll is not listed since it uses max_over_time.
+var profileQuantiles = map[string]string{
+	ProfileP99: "0.99",
+	ProfileP95: "0.95",
+	ProfileP90: "0.90",
+}
+
 // RuleBuilder provides common utilities for building PrometheusRule rules
 type RuleBuilder struct {
 	// LabelJoin is an optional label join expression to append to metrics
 	LabelJoin string
 	// Profile is the profile label value for aggregated rules (default: "Max OverAll")
 	Profile string
-	// Aggregation is the aggregation label value (default: "1d")
+	// Aggregation is the aggregation window and label value (default: "1d")
 	Aggregation string
 }
 
@@ -31,9 +45,103 @@ type RuleBuilder struct {
 func NewRuleBuilder(labelJoin string) *RuleBuilder {
 	return &RuleBuilder{
 		LabelJoin:   labelJoin,
-		Profile:     "Max OverAll",
-		Aggregation: "1d",
+		Profile:     ProfileMaxOverAll,
+		Aggregation: DefaultAggregationWindow,
+	}
+}
+
+// AggregationWindow groups the rule builders that share an aggregation window,
+// one per configured profile.
+type AggregationWindow struct {
+	Window string
+	// Interval is the evaluation interval of the rules of the window.
+	Interval *monitoringv1.Duration
+	Builders []*RuleBuilder
+}
+
+// BuildAggregationWindows returns the aggregation windows and profiles selected
+// by the rule configuration. Empty lists fall back to the "Max OverAll" profile
+// over a 1d window, which matches the rules generated before profiles existed.
+// Windows longer than the retention of the Prometheus evaluating the rules are
+// rejected since older samples are not there to aggregate.
+func BuildAggregationWindows(ruleConfig RSPrometheusRuleConfig, labelJoin string) ([]AggregationWindow, error) {
+	profiles := ruleConfig.Profiles
+	if len(profiles) == 0 {
+		profiles = []string{ProfileMaxOverAll}
+	}
+	for _, p := range profiles {
+		if _, ok := profileQuantiles[p]; !ok && p != ProfileMaxOverAll {
+			return nil, fmt.Errorf("%w: %q", errUnknownProfile, p)
+		}
+	}
+
+	retention := ruleConfig.Retention
+	if retention == "" {
+		retention = DefaultRetention
+	}
+	maxWindow, err := model.ParseDuration(retention)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", errInvalidRetention, retention, err)
 	}
+
+	windows := ruleConfig.AggregationWindows
+	if len(windows) == 0 {
+		windows = []string{DefaultAggregationWindow}
+	}
+
+	var ret []AggregationWindow
+	seen := map[string]struct{}{}
+	for _, w := range windows {
+		d, err := model.ParseDuration(w)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", errInvalidAggregationWindow, w, err)
+		}
+		if d > maxWindow {
+			return nil, fmt.Errorf("%w: %q is longer than %q", errAggregationWindowExceedsRetention, w, retention)
+		}
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+
+		aw := AggregationWindow{Window: w, Interval: &AggregationEvalInterval}
+		if d > model.Duration(24*time.Hour) {
+			aw.Interval = &LongAggregationEvalInterval
+		}
+		profileSeen := map[string]struct{}{}
+		for _, p := range profiles {
+			if _, ok := profileSeen[p]; ok {
+				continue
+			}
+			profileSeen[p] = struct{}{}
+			aw.Builders = append(aw.Builders, &RuleBuilder{
+				LabelJoin:   labelJoin,
+				Profile:     p,
+				Aggregation: w,
+			})
+		}
+		ret = append(ret, aw)
+	}
+	return ret, nil
+}
+
+// AggregationGroups builds one rule group per aggregation window. The group name is
+// built from nameFormat with the window, and its rules are the concatenation of the
+// rules returned by build for every profile of that window.
+func AggregationGroups(nameFormat string, windows []AggregationWindow, build func(rb *RuleBuilder) []monitoringv1.Rule) []monitoringv1.RuleGroup {
+	groups := make([]monitoringv1.RuleGroup, 0, len(windows))
+	for _, w := range windows {
+		var rules []monitoringv1.Rule
+		for _, rb := range w.Builders {
+			rules = append(rules, build(rb)...)
+		}
+		groups = append(groups, monitoringv1.RuleGroup{
+			Name:     fmt.Sprintf(nameFormat, w.Window),
+			Interval: w.Interval,
+			Rules:    rules,
+		})
+	}
+	return groups
 }
 
 // Rule creates a basic PrometheusRule rule with optional label join
@@ -70,15 +178,27 @@ func (rb *RuleBuilder) RuleWithLabels(record, expr string) monitoringv1.Rule {
 	}
 }
 
-// BuildRecommendationExpr builds a recommendation expression with the given percentage
-func BuildRecommendationExpr(usageMetric string, recommendationPercentage int) string {
+// RecommendationExpr builds a recommendation expression with the given percentage
+// on top of the builder's profile aggregation of the usage.
+func (rb *RuleBuilder) RecommendationExpr(usageMetric string, recommendationPercentage int) string {
 	if recommendationPercentage == 0 {
 		recommendationPercentage = DefaultRecommendationPercentage
 	}
-	return fmt.Sprintf(`max_over_time(%s[1d]) * (%d/100)`, usageMetric, recommendationPercentage)
+	return fmt.Sprintf(`%s * (%d/100)`, rb.UsageExpr(usageMetric), recommendationPercentage)
+}
+
+// UsageExpr reduces a 5m usage metric over the builder's aggregation window:
+// max_over_time for the "Max OverAll" profile, quantile_over_time for percentile profiles.
+func (rb *RuleBuilder) UsageExpr(metric5m string) string {
+	if q, ok := profileQuantiles[rb.Profile]; ok {
+		return fmt.Sprintf(`quantile_over_time(%s, %s[%s])`, q, metric5m, rb.Aggregation)
+	}
+	return rb.RequestExpr(metric5m)
 }
 
-// Build1dAggregationExpr builds a 1-day max_over_time aggregation expression
-func Build1dAggregationExpr(metric5m string) string {
-	return fmt.Sprintf(`max_over_time(%s[1d])`, metric5m)
+// RequestExpr reduces a 5m request or quota metric over the builder's aggregation
+// window. Requests are configured rather than sampled, so they always keep their
+// highest value whatever the profile.
+func (rb *RuleBuilder) RequestExpr(metric5m string) string {
+	return fmt.Sprintf(`max_over_time(%s[%s])`, metric5m, rb.Aggregation)
 }
@@ -39,6 +39,18 @@ var NamespaceMetrics = []string{
 	"acm_rs:cluster:memory_request",
 	"acm_rs:cluster:memory_usage",
 	"acm_rs:cluster:memory_recommendation",
+	"acm_rs:workload:cpu_request",
+	"acm_rs:workload:cpu_usage",
+	"acm_rs:workload:cpu_recommendation",
+	"acm_rs:workload:memory_request",
+	"acm_rs:workload:memory_usage",
+	"acm_rs:workload:memory_recommendation",
+	"acm_rs:container:cpu_request",
+	"acm_rs:container:cpu_usage",
+	"acm_rs:container:cpu_recommendation",
+	"acm_rs:container:memory_request",
+	"acm_rs:container:memory_usage",
+	"acm_rs:container:memory_recommendation",
 }
 
 // VirtualizationMetrics are the metrics to federate for virtualization right-sizing
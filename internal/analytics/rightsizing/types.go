@@ -20,6 +20,8 @@ func RSLabels() map[string]string {
 // Common constants
 const (
 	DefaultRecommendationPercentage = 110
+	DefaultAggregationWindow        = "1d"
+	DefaultRetention                = "15d"
 	MonitoringNamespace             = "openshift-monitoring"
 
 	// Namespace right-sizing constants
@@ -29,6 +31,46 @@ const (
 	// Virtualization right-sizing constants
 	VirtualizationPrometheusRuleName = "acm-rs-virt-prometheus-rules"
 	VirtualizationConfigMapName      = "rs-virt-config"
+
+	// Placements created on the hub when placementMode is PlacementModeDecisions
+	NamespacePlacementName      = "rs-namespace-placement"
+	VirtualizationPlacementName = "rs-virt-placement"
+)
+
+// Placement modes select how the placementConfiguration of a right-sizing ConfigMap
+// is turned into a set of clusters.
+const (
+	// PlacementModeInMemory evaluates label and claim selectors against each
+	// ManagedCluster without involving the OCM scheduler.
+	PlacementModeInMemory = "InMemory"
+	// PlacementModeDecisions creates a real Placement on the hub and selects the
+	// clusters listed in its PlacementDecisions, so that clusterSets, CEL selectors,
+	// taints/tolerations, numberOfClusters and prioritizers are all honored.
+	PlacementModeDecisions = "PlacementDecisions"
+)
+
+// Right-sizing profiles select how 5m samples are reduced over an aggregation window.
+// The profile name is used verbatim as the "profile" label of the aggregated rules.
+const (
+	ProfileMaxOverAll = "Max OverAll"
+	ProfileP99        = "P99"
+	ProfileP95        = "P95"
+	ProfileP90        = "P90"
+)
+
+// Export formats for the container recommendations delivered to the managed clusters.
+const (
+	// ExportFormatVPA produces one VerticalPodAutoscaler in "Off" mode per workload,
+	// annotated with the ACM recommendation of each of its containers.
+	ExportFormatVPA = "VerticalPodAutoscaler"
+	// ExportFormatReport produces one ConfigMap per namespace holding the
+	// recommendations as JSON and CSV.
+	ExportFormatReport = "Report"
+
+	// DefaultExportQueryEndpoint is the hub rbac-query-proxy deployed by MCO, the
+	// queries are authenticated as the addon manager service account.
+	DefaultExportQueryEndpoint = "https://rbac-query-proxy.open-cluster-management-observability.svc:8443"
+	ReportConfigMapName        = "acm-rs-report"
 )
 
 // RSLabelFilter represents label filtering criteria for right-sizing
@@ -46,10 +88,33 @@ type RSPrometheusRuleConfig struct {
 	} `json:"namespaceFilterCriteria"`
 	LabelFilterCriteria      []RSLabelFilter `json:"labelFilterCriteria"`
 	RecommendationPercentage int             `json:"recommendationPercentage"`
+	// Profiles lists the profiles to generate rules for (default: ["Max OverAll"]).
+	Profiles []string `json:"profiles,omitempty"`
+	// AggregationWindows lists the Prometheus durations to aggregate over (default: ["1d"]).
+	AggregationWindows []string `json:"aggregationWindows,omitempty"`
+	// Retention of the Prometheus evaluating the rules, longer aggregation windows
+	// are rejected (default: "15d").
+	Retention string `json:"retention,omitempty"`
+}
+
+// RSExportConfig configures the export of container recommendations to the managed clusters
+type RSExportConfig struct {
+	// Formats lists the artifacts to generate; empty disables the export.
+	Formats []string `json:"formats,omitempty"`
+	// Profile of the exported recommendations (default: "Max OverAll").
+	Profile string `json:"profile,omitempty"`
+	// Aggregation window of the exported recommendations (default: "1d").
+	Aggregation string `json:"aggregation,omitempty"`
+	// QueryEndpoint is the hub Prometheus API used to read the recommendations
+	// (default: the rbac-query-proxy of MCO).
+	QueryEndpoint string `json:"queryEndpoint,omitempty"`
 }
 
 // RSConfigMapData represents the configmap data structure for right-sizing
 type RSConfigMapData struct {
 	PrometheusRuleConfig   RSPrometheusRuleConfig   `json:"prometheusRuleConfig"`
 	PlacementConfiguration clusterv1beta1.Placement `json:"placementConfiguration"`
+	// PlacementMode is either PlacementModeInMemory (default) or PlacementModeDecisions.
+	PlacementMode       string         `json:"placementMode,omitempty"`
+	ExportConfiguration RSExportConfig `json:"exportConfiguration,omitempty"`
 }
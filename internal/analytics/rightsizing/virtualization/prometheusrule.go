@@ -23,6 +23,30 @@ func GeneratePrometheusRule(configData rightsizing.RSConfigMapData) (monitoringv
 	// Create rule builder with shared utilities
 	rb := rightsizing.NewRuleBuilder(labelJoin)
 
+	windows, err := rightsizing.BuildAggregationWindows(configData.PrometheusRuleConfig, labelJoin)
+	if err != nil {
+		return monitoringv1.PrometheusRule{}, err
+	}
+	// withConfig adapts the aggregated rule builders to rightsizing.AggregationGroups,
+	// which calls them once per configured profile and window.
+	withConfig := func(fn func(rightsizing.RSConfigMapData, *rightsizing.RuleBuilder) []monitoringv1.Rule) func(*rightsizing.RuleBuilder) []monitoringv1.Rule {
+		return func(wrb *rightsizing.RuleBuilder) []monitoringv1.Rule { return fn(configData, wrb) }
+	}
+
+	var groups []monitoringv1.RuleGroup
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-vm-right-sizing-namespace-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildNamespaceRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-vm-right-sizing-namespace-%s.rules", windows, withConfig(buildNamespaceRulesAggregated))...)
+	groups = append(groups, monitoringv1.RuleGroup{
+		Name:     "acm-vm-right-sizing-cluster-5m.rule",
+		Interval: &rightsizing.Duration5m,
+		Rules:    buildClusterRules5m(nsFilter, rb),
+	})
+	groups = append(groups, rightsizing.AggregationGroups("acm-vm-right-sizing-cluster-%s.rule", windows, withConfig(buildClusterRulesAggregated))...)
+
 	return monitoringv1.PrometheusRule{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      rightsizing.VirtualizationPrometheusRuleName,
@@ -33,28 +57,7 @@ func GeneratePrometheusRule(configData rightsizing.RSConfigMapData) (monitoringv
 			APIVersion: "monitoring.coreos.com/v1",
 		},
 		Spec: monitoringv1.PrometheusRuleSpec{
-			Groups: []monitoringv1.RuleGroup{
-				{
-					Name:     "acm-vm-right-sizing-namespace-5m.rule",
-					Interval: &rightsizing.Duration5m,
-					Rules:    buildNamespaceRules5m(nsFilter, rb),
-				},
-				{
-					Name:     "acm-vm-right-sizing-namespace-1d.rules",
-					Interval: &rightsizing.Duration1d,
-					Rules:    buildNamespaceRules1d(configData, rb),
-				},
-				{
-					Name:     "acm-vm-right-sizing-cluster-5m.rule",
-					Interval: &rightsizing.Duration5m,
-					Rules:    buildClusterRules5m(nsFilter, rb),
-				},
-				{
-					Name:     "acm-vm-right-sizing-cluster-1d.rule",
-					Interval: &rightsizing.Duration1d,
-					Rules:    buildClusterRules1d(configData, rb),
-				},
-			},
+			Groups: groups,
 		},
 	}, nil
 }
@@ -105,20 +108,20 @@ func buildNamespaceRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monit
 	}
 }
 
-// buildNamespaceRules1d builds 1-day aggregation recording rules for VM namespace-level metrics.
-// Aggregates the 5m rules into daily summaries with profile/aggregation labels for dashboard selection.
-func buildNamespaceRules1d(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+// buildNamespaceRulesAggregated builds window aggregation recording rules for VM namespace-level metrics.
+// Aggregates the 5m rules over the aggregation window with profile/aggregation labels for dashboard selection.
+func buildNamespaceRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
 	rp := configData.PrometheusRuleConfig.RecommendationPercentage
 	if rp == 0 {
 		rp = rightsizing.DefaultRecommendationPercentage
 	}
 	return []monitoringv1.Rule{
-		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_request", rightsizing.Build1dAggregationExpr("acm_rs_vm:namespace:cpu_request:5m")),
-		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_usage", rightsizing.Build1dAggregationExpr("acm_rs_vm:namespace:cpu_usage:5m")),
-		rb.RuleWithLabels("acm_rs_vm:namespace:memory_request", rightsizing.Build1dAggregationExpr("acm_rs_vm:namespace:memory_request:5m")),
-		rb.RuleWithLabels("acm_rs_vm:namespace:memory_usage", rightsizing.Build1dAggregationExpr("acm_rs_vm:namespace:memory_usage:5m")),
-		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_recommendation", rightsizing.BuildRecommendationExpr("acm_rs_vm:namespace:cpu_usage:5m", rp)),
-		rb.RuleWithLabels("acm_rs_vm:namespace:memory_recommendation", rightsizing.BuildRecommendationExpr("acm_rs_vm:namespace:memory_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_request", rb.RequestExpr("acm_rs_vm:namespace:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_usage", rb.UsageExpr("acm_rs_vm:namespace:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs_vm:namespace:memory_request", rb.RequestExpr("acm_rs_vm:namespace:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs_vm:namespace:memory_usage", rb.UsageExpr("acm_rs_vm:namespace:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs_vm:namespace:cpu_recommendation", rb.RecommendationExpr("acm_rs_vm:namespace:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs_vm:namespace:memory_recommendation", rb.RecommendationExpr("acm_rs_vm:namespace:memory_usage:5m", rp)),
 	}
 }
 
@@ -171,19 +174,19 @@ func buildClusterRules5m(nsFilter string, rb *rightsizing.RuleBuilder) []monitor
 	}
 }
 
-// buildClusterRules1d builds 1-day aggregation recording rules for VM cluster-level metrics.
-// Aggregates the 5m cluster rules into daily summaries with profile/aggregation labels for dashboard selection.
-func buildClusterRules1d(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
+// buildClusterRulesAggregated builds window aggregation recording rules for VM cluster-level metrics.
+// Aggregates the 5m cluster rules over the aggregation window with profile/aggregation labels for dashboard selection.
+func buildClusterRulesAggregated(configData rightsizing.RSConfigMapData, rb *rightsizing.RuleBuilder) []monitoringv1.Rule {
 	rp := configData.PrometheusRuleConfig.RecommendationPercentage
 	if rp == 0 {
 		rp = rightsizing.DefaultRecommendationPercentage
 	}
 	return []monitoringv1.Rule{
-		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_request", rightsizing.Build1dAggregationExpr("acm_rs_vm:cluster:cpu_request:5m")),
-		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_usage", rightsizing.Build1dAggregationExpr("acm_rs_vm:cluster:cpu_usage:5m")),
-		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_recommendation", rightsizing.BuildRecommendationExpr("acm_rs_vm:cluster:cpu_usage:5m", rp)),
-		rb.RuleWithLabels("acm_rs_vm:cluster:memory_request", rightsizing.Build1dAggregationExpr("acm_rs_vm:cluster:memory_request:5m")),
-		rb.RuleWithLabels("acm_rs_vm:cluster:memory_usage", rightsizing.Build1dAggregationExpr("acm_rs_vm:cluster:memory_usage:5m")),
-		rb.RuleWithLabels("acm_rs_vm:cluster:memory_recommendation", rightsizing.BuildRecommendationExpr("acm_rs_vm:cluster:memory_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_request", rb.RequestExpr("acm_rs_vm:cluster:cpu_request:5m")),
+		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_usage", rb.UsageExpr("acm_rs_vm:cluster:cpu_usage:5m")),
+		rb.RuleWithLabels("acm_rs_vm:cluster:cpu_recommendation", rb.RecommendationExpr("acm_rs_vm:cluster:cpu_usage:5m", rp)),
+		rb.RuleWithLabels("acm_rs_vm:cluster:memory_request", rb.RequestExpr("acm_rs_vm:cluster:memory_request:5m")),
+		rb.RuleWithLabels("acm_rs_vm:cluster:memory_usage", rb.UsageExpr("acm_rs_vm:cluster:memory_usage:5m")),
+		rb.RuleWithLabels("acm_rs_vm:cluster:memory_recommendation", rb.RecommendationExpr("acm_rs_vm:cluster:memory_usage:5m", rp)),
 	}
 }
@@ -19,6 +19,8 @@ import (
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	addonhelm "github.com/stolostron/multicluster-observability-addon/internal/addon/helm"
+	netflowsmanifests "github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -78,6 +80,7 @@ func NewAddonManager(ctx context.Context, kubeConfig *rest.Config, scheme *runti
 		{Version: coomonitoringv1alpha1.SchemeGroupVersion.Version, Group: coomonitoringv1alpha1.SchemeGroupVersion.Group, Resource: coomonitoringv1alpha1.PrometheusAgentName},
 		{Version: coomonitoringv1alpha1.SchemeGroupVersion.Version, Group: coomonitoringv1alpha1.SchemeGroupVersion.Group, Resource: coomonitoringv1alpha1.ScrapeConfigName},
 		{Version: monitoringv1.SchemeGroupVersion.Version, Group: monitoringv1.SchemeGroupVersion.Group, Resource: monitoringv1.PrometheusRuleName},
+		{Version: corev1.SchemeGroupVersion.Version, Group: corev1.SchemeGroupVersion.Group, Resource: addoncfg.ConfigMapsResource},
 		utils.AddOnDeploymentConfigGVR,
 	}
 
@@ -92,6 +95,17 @@ func NewAddonManager(ctx context.Context, kubeConfig *rest.Config, scheme *runti
 		logger.Info("monitoring.rhobs PrometheusRule CRD not found on hub, skipping config GVR registration", "gvr", cooPrometheusRuleGVR)
 	}
 
+	flowCollectorGVR := schema.GroupVersionResource{
+		Version:  netflowsmanifests.FlowCollectorGVK.Version,
+		Group:    netflowsmanifests.FlowCollectorGVK.Group,
+		Resource: addoncfg.FlowCollectorsResource,
+	}
+	if _, mapErr := mapper.RESTMapping(netflowsmanifests.FlowCollectorGVK.GroupKind()); mapErr == nil {
+		configGVRs = append(configGVRs, flowCollectorGVR)
+	} else {
+		logger.Info("FlowCollector CRD not found on hub, skipping config GVR registration", "gvr", flowCollectorGVR)
+	}
+
 	mcoaAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, "manifests/charts/mcoa").
 		WithConfigGVRs(configGVRs...).
 		WithAgentHealthProber(addon.HealthProber(getter, agentLogger)).
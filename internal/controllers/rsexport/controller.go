@@ -0,0 +1,189 @@
+package rsexport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// defaultInterval matches the evaluation interval of the aggregation rules.
+	defaultInterval = 15 * time.Minute
+	// defaultTimeout bounds the queries of a refresh.
+	defaultTimeout = 30 * time.Second
+)
+
+var namespaceConfigPredicate = builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetNamespace() == addoncfg.InstallNamespace && obj.GetName() == rightsizing.NamespaceConfigMapName
+}))
+
+// SetupWithManager sets up the controller with the Manager.
+func SetupWithManager(mgr ctrl.Manager, logger logr.Logger) error {
+	r := &ExportReconciler{
+		Client:     mgr.GetClient(),
+		Log:        logger.WithName("rsexport").WithName("controller"),
+		NewQuerier: export.NewQuerier,
+		Interval:   defaultInterval,
+		Timeout:    defaultTimeout,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("rsexport").
+		For(&corev1.ConfigMap{}, namespaceConfigPredicate).
+		Complete(r)
+}
+
+// ExportReconciler periodically reads the container recommendations exported by
+// the namespace right-sizing config from the hub, and caches those of each
+// managed cluster in its namespace. The manifests of a cluster are rendered from
+// its cache so that rendering never waits on the hub Prometheus API.
+type ExportReconciler struct {
+	client.Client
+	Log logr.Logger
+	// NewQuerier creates the client used to read the recommendations.
+	NewQuerier func(endpoint string) (export.Querier, error)
+	Interval   time.Duration
+	Timeout    time.Duration
+}
+
+// Reconcile refreshes the recommendation caches and requeues itself for the next refresh.
+func (r *ExportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.V(2).Info("reconciliation triggered", "request", req.String())
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.prune(ctx, nil)
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get configmap %s: %w", req.NamespacedName, err)
+	}
+
+	configData, err := rightsizing.ParseConfigMapData(cm.Data)
+	if err != nil {
+		// Invalid configs are reported by the rendering of the manifests, the
+		// refresh waits for the config to be fixed.
+		r.Log.V(1).Info("skipping right-sizing export of an invalid config", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+	cfg := configData.ExportConfiguration
+	if len(cfg.Formats) == 0 {
+		return ctrl.Result{}, r.prune(ctx, nil)
+	}
+
+	endpoint := cfg.QueryEndpoint
+	if endpoint == "" {
+		endpoint = rightsizing.DefaultExportQueryEndpoint
+	}
+	q, err := r.NewQuerier(endpoint)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create right-sizing export querier: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	recs, err := export.Fetch(fetchCtx, q, cfg)
+	if err != nil {
+		// The caches are kept so that the exported recommendations stay in place
+		// until the next successful refresh.
+		r.Log.Error(err, "failed to fetch right-sizing recommendations", "endpoint", endpoint)
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	clusters := &clusterv1.ManagedClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list managed clusters: %w", err)
+	}
+
+	keep := map[string]struct{}{}
+	for _, cluster := range clusters.Items {
+		clusterRecs, ok := recs[cluster.Name]
+		if !ok || cluster.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.updateCache(ctx, cluster.Name, clusterRecs); err != nil {
+			return ctrl.Result{}, err
+		}
+		keep[cluster.Name] = struct{}{}
+	}
+
+	if err := r.prune(ctx, keep); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// updateCache writes the pages of the cache of a cluster and deletes the pages
+// left over from a larger cache.
+func (r *ExportReconciler) updateCache(ctx context.Context, cluster string, recs []export.Recommendation) error {
+	pages, err := export.CacheConfigMaps(cluster, recs)
+	if err != nil {
+		return err
+	}
+
+	for _, desired := range pages {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+			if cm.Labels == nil {
+				cm.Labels = map[string]string{}
+			}
+			for k, v := range desired.Labels {
+				cm.Labels[k] = v
+			}
+			cm.Data = desired.Data
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update the right-sizing recommendations of %s: %w", cluster, err)
+		}
+		if op != controllerutil.OperationResultNone {
+			r.Log.V(1).Info("Updated right-sizing recommendations", "cluster", cluster, "page", desired.Name, "operation", op)
+		}
+	}
+
+	return r.deleteCaches(ctx, func(cm *corev1.ConfigMap, page int) bool {
+		return cm.Namespace == cluster && page >= len(pages)
+	}, client.InNamespace(cluster))
+}
+
+// prune deletes the caches of the clusters missing from keep.
+func (r *ExportReconciler) prune(ctx context.Context, keep map[string]struct{}) error {
+	return r.deleteCaches(ctx, func(cm *corev1.ConfigMap, _ int) bool {
+		_, ok := keep[cm.Namespace]
+		return !ok
+	})
+}
+
+// deleteCaches deletes the cache pages selected by del.
+func (r *ExportReconciler) deleteCaches(ctx context.Context, del func(cm *corev1.ConfigMap, page int) bool, opts ...client.ListOption) error {
+	cms := &corev1.ConfigMapList{}
+	if err := r.List(ctx, cms, append(opts, client.MatchingLabels(rightsizing.RSLabels()))...); err != nil {
+		return fmt.Errorf("failed to list right-sizing recommendation caches: %w", err)
+	}
+
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		page, ok := export.CachePage(cm)
+		if !ok || !del(cm, page) {
+			continue
+		}
+		if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the right-sizing recommendations of %s: %w", cm.Namespace, err)
+		}
+		r.Log.V(1).Info("Deleted right-sizing recommendations", "cluster", cm.Namespace, "page", cm.Name)
+	}
+	return nil
+}
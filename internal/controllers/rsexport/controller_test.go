@@ -0,0 +1,146 @@
+package rsexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeQuerier struct {
+	err     error
+	samples model.Vector
+}
+
+func (f *fakeQuerier) Query(ctx context.Context, _ string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, nil, errors.New("query without a timeout")
+	}
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return f.samples, nil, nil
+}
+
+func newReconciler(t *testing.T, q export.Querier, objs ...client.Object) *ExportReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.Install(scheme))
+
+	return &ExportReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Log:        logr.Discard(),
+		NewQuerier: func(string) (export.Querier, error) { return q, nil },
+		Interval:   time.Minute,
+		Timeout:    time.Second,
+	}
+}
+
+func configMap(formats string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: rightsizing.NamespaceConfigMapName, Namespace: addoncfg.InstallNamespace},
+		Data:       map[string]string{"exportConfiguration": `{"formats":` + formats + `}`},
+	}
+}
+
+func cluster(name string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func cache(t *testing.T, name string) *corev1.ConfigMap {
+	t.Helper()
+	cms, err := export.CacheConfigMaps(name, nil)
+	require.NoError(t, err)
+	return cms[0]
+}
+
+func readCache(t *testing.T, r *ExportReconciler, cluster string) []export.Recommendation {
+	t.Helper()
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, r.List(context.Background(), cms, client.InNamespace(cluster)))
+	recs, err := export.ReadCache(cms.Items)
+	require.NoError(t, err)
+	return recs
+}
+
+var req = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: addoncfg.InstallNamespace, Name: rightsizing.NamespaceConfigMapName}}
+
+func TestReconcile_UpdatesCaches(t *testing.T) {
+	q := &fakeQuerier{samples: model.Vector{
+		{Metric: model.Metric{"cluster": "cluster-1", "namespace": "shop", "workload": "web", "workload_type": "deployment", "container": "nginx"}, Value: 1},
+		{Metric: model.Metric{"cluster": "unknown", "namespace": "shop", "workload": "web", "workload_type": "deployment", "container": "nginx"}, Value: 1},
+	}}
+	r := newReconciler(t, q, configMap(`["Report"]`), cluster("cluster-1"), cluster("cluster-2"), cache(t, "cluster-2"))
+
+	res, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, res.RequeueAfter)
+
+	recs := readCache(t, r, "cluster-1")
+	require.Len(t, recs, 1)
+	assert.Equal(t, "shop", recs[0].Namespace)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(context.Background(), types.NamespacedName{Namespace: "cluster-2", Name: export.CachePageName(0)}, cm)
+	assert.True(t, apierrors.IsNotFound(err), "caches of clusters without recommendations are pruned")
+}
+
+func TestReconcile_KeepsCachesOnQueryFailure(t *testing.T) {
+	r := newReconciler(t, &fakeQuerier{err: errors.New("unavailable")}, configMap(`["Report"]`), cluster("cluster-1"), cache(t, "cluster-1"))
+
+	res, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, res.RequeueAfter)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "cluster-1", Name: export.CachePageName(0)}, cm))
+}
+
+func TestReconcile_PrunesWhenExportDisabled(t *testing.T) {
+	r := newReconciler(t, &fakeQuerier{}, configMap(`[]`), cluster("cluster-1"), cache(t, "cluster-1"))
+
+	res, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Zero(t, res.RequeueAfter)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(context.Background(), types.NamespacedName{Namespace: "cluster-1", Name: export.CachePageName(0)}, cm)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcile_DeletesLeftoverPages(t *testing.T) {
+	q := &fakeQuerier{samples: model.Vector{
+		{Metric: model.Metric{"cluster": "cluster-1", "namespace": "shop", "workload": "web", "workload_type": "deployment", "container": "nginx"}, Value: 1},
+	}}
+	leftover := cache(t, "cluster-1")
+	leftover.Name = export.CachePageName(1)
+	leftover.Labels["observability.open-cluster-management.io/rs-export-page"] = "1"
+	r := newReconciler(t, q, configMap(`["Report"]`), cluster("cluster-1"), leftover)
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(context.Background(), types.NamespacedName{Namespace: "cluster-1", Name: export.CachePageName(1)}, cm)
+	assert.True(t, apierrors.IsNotFound(err), "pages beyond the recommendations are deleted")
+	require.Len(t, readCache(t, r, "cluster-1"), 1)
+}
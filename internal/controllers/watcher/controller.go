@@ -5,15 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	rsexport "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 	rshandlers "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/handlers"
 	coohandlers "github.com/stolostron/multicluster-observability-addon/internal/coo/handlers"
+	lshandlers "github.com/stolostron/multicluster-observability-addon/internal/lokistack/handlers"
 	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	obsapihandlers "github.com/stolostron/multicluster-observability-addon/internal/obsapi/handlers"
+	tracingpolicy "github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -22,6 +28,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"open-cluster-management.io/addon-framework/pkg/addonmanager"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	workv1 "open-cluster-management.io/api/work/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -51,7 +60,16 @@ func (r *WatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	r.Log.V(2).Info("reconciliation triggered", "request", req.String())
 	r.addonManager.Trigger(req.Namespace, req.Name)
 
-	return ctrl.Result{}, nil
+	// The client certificates of the cluster are issued again when the addon
+	// is reconciled, so it must be before they expire.
+	renewTime, err := common.ClientCertRenewTime(ctx, r.Client, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if renewTime.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: max(time.Until(renewTime), time.Minute)}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -71,8 +89,15 @@ func SetupWithManager(mgr ctrl.Manager, addonManager addonmanager.AddonManager,
 		Watches(&workv1.ManifestWork{}, r.enqueueForManifestWork(), builder.WithPredicates(manifestWorkPredicate)).
 		Watches(&corev1.Secret{}, r.enqueueForConfigResource(), builder.OnlyMetadata).
 		Watches(&corev1.ConfigMap{}, r.enqueueForConfigResource(), builder.OnlyMetadata).
-		Watches(&corev1.ConfigMap{}, r.enqueueForAllManagedClusters(), builder.WithPredicates(predicate.Or(imagesConfigMapPredicate, rshandlers.RSConfigMapPredicate())), builder.OnlyMetadata).
-		Watches(&corev1.ConfigMap{}, r.enqueueForLocalCluster(), builder.WithPredicates(coohandlers.CardinalityRulesConfigMapPredicate()), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, r.enqueueForAllManagedClusters(), builder.WithPredicates(predicate.Or(imagesConfigMapPredicate, rshandlers.RSConfigMapPredicate(), tenants.ConfigMapPredicate(), tracingpolicy.ConfigMapPredicate())), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, r.enqueueForLocalCluster(), builder.WithPredicates(predicate.Or(coohandlers.CardinalityRulesConfigMapPredicate(), coohandlers.UserDashboardConfigMapPredicate(), coohandlers.TeamProjectConfigMapPredicate())), builder.OnlyMetadata).
+		Watches(&corev1.Secret{}, r.enqueueForLocalCluster(), builder.WithPredicates(obsapihandlers.SecretPredicate()), builder.OnlyMetadata).
+		Watches(&corev1.Secret{}, r.enqueueForClientCertCluster(), builder.WithPredicates(common.ClientCertPredicate()), builder.OnlyMetadata).
+		Watches(&clusterv1.ManagedCluster{}, r.enqueueForLocalCluster(), builder.WithPredicates(predicate.Or(coohandlers.VirtualizationClusterPredicate(), coohandlers.ClusterSetMembershipPredicate()))).
+		Watches(&addonapiv1beta1.ManagedClusterAddOn{}, r.enqueueForLocalCluster(), builder.WithPredicates(lshandlers.TenantPredicate()), builder.OnlyMetadata).
+		Watches(&corev1.Namespace{}, r.enqueueForTeamProjectNamespace(), builder.WithPredicates(coohandlers.TeamProjectNamespacePredicate()), builder.OnlyMetadata).
+		Watches(&clusterv1beta1.PlacementDecision{}, r.enqueueForAllManagedClusters(), builder.WithPredicates(rshandlers.RSPlacementDecisionPredicate())).
+		Watches(&corev1.ConfigMap{}, r.enqueueForClusterNamespace(), builder.WithPredicates(rsexport.CacheConfigMapPredicate()), builder.OnlyMetadata).
 		Watches(&hyperv1.HostedCluster{}, r.enqueueForLocalCluster(), hostedClusterPredicate).
 		Watches(&prometheusv1.ServiceMonitor{}, r.enqueueForLocalCluster(), hypershiftServiceMonitorsPredicate(r.Log), builder.OnlyMetadata).
 		Watches(common.NewMultiClusterHub(), r.enqueueForAllManagedClusters(), builder.WithPredicates(mchNetworkPoliciesPredicate)).
@@ -174,6 +199,31 @@ func (r *WatcherReconciler) enqueueForLocalCluster() handler.EventHandler {
 	})
 }
 
+// enqueueForTeamProjectNamespace triggers the local cluster when the namespace
+// of a team project is created or deleted, as the project is only deployed
+// into an existing namespace.
+func (r *WatcherReconciler) enqueueForTeamProjectNamespace() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		isTeam, err := coohandlers.IsTeamProjectNamespace(ctx, r.Client, obj.GetName())
+		if err != nil {
+			r.Log.Error(err, "failed to get the team project ConfigMap of the namespace", "namespace", obj.GetName())
+			return nil
+		}
+		if !isTeam {
+			return nil
+		}
+		r.Log.V(2).Info("Enqueue for team project namespace event", "namespace", obj.GetName())
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      addoncfg.Name,
+					Namespace: localClusterNamespace,
+				},
+			},
+		}
+	})
+}
+
 func (r *WatcherReconciler) enqueueForAllManagedClusters() handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 		r.Log.V(2).Info("Enqueue for all managed clusters", "gvk", obj.GetObjectKind().GroupVersionKind().String(), "name", obj.GetName(), "namespace", obj.GetNamespace())
@@ -203,6 +253,37 @@ func (r *WatcherReconciler) enqueueForAllManagedClusters() handler.EventHandler
 	})
 }
 
+// enqueueForClusterNamespace triggers the reconciliation of the cluster owning
+// the namespace of the object.
+func (r *WatcherReconciler) enqueueForClusterNamespace() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		r.Log.V(2).Info("Enqueue for cluster namespace event", "gvk", obj.GetObjectKind().GroupVersionKind().String(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      addoncfg.Name,
+					Namespace: obj.GetNamespace(),
+				},
+			},
+		}
+	})
+}
+
+// enqueueForClientCertCluster triggers the cluster of a client certificate, so
+// that it is requeued before the certificate expires.
+func (r *WatcherReconciler) enqueueForClientCertCluster() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      addoncfg.Name,
+					Namespace: obj.GetLabels()[addoncfg.ClientCertClusterLabelKey],
+				},
+			},
+		}
+	})
+}
+
 func (r *WatcherReconciler) enqueueForConfigResource() handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 		namespaces := r.Cache.GetNamespaces(r.getConfigResourceKey(obj))
@@ -2,14 +2,27 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/go-logr/logr"
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	lshandlers "github.com/stolostron/multicluster-observability-addon/internal/lokistack/handlers"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/perses/userdashboards"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -84,3 +97,241 @@ func CardinalityRulesConfigMapPredicate() predicate.Funcs {
 func isCardinalityRulesConfigMap(namespace, name string) bool {
 	return namespace == addoncfg.InstallNamespace && name == thanosRulerCustomRulesName
 }
+
+const (
+	// virtualizationClusterLabel is set on ManagedClusters that have OpenShift Virtualization installed.
+	virtualizationClusterLabel = "acm/cnv-operator-install"
+	// virtualizationClusterClaim is reported by klusterlets running OpenShift Virtualization.
+	virtualizationClusterClaim = "virtualization.open-cluster-management.io"
+)
+
+// HasVirtualizationClusters reports whether any managed cluster runs OpenShift Virtualization,
+// according to either its ManagedCluster label or its cluster claim. List errors are returned
+// so that a transient failure doesn't prune the virtualization dashboards.
+func HasVirtualizationClusters(ctx context.Context, k8s client.Client, isHub bool) (bool, error) {
+	if !isHub {
+		return false, nil
+	}
+
+	clusters := &clusterv1.ManagedClusterList{}
+	if err := k8s.List(ctx, clusters); err != nil {
+		return false, fmt.Errorf("failed to list managed clusters: %w", err)
+	}
+
+	for i := range clusters.Items {
+		if isVirtualizationCluster(&clusters.Items[i]) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// VirtualizationClusterPredicate filters ManagedCluster events down to the ones that can
+// change the result of HasVirtualizationClusters.
+func VirtualizationClusterPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			mc, ok := e.Object.(*clusterv1.ManagedCluster)
+			return ok && isVirtualizationCluster(mc)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldMC, ok := e.ObjectOld.(*clusterv1.ManagedCluster)
+			if !ok {
+				return false
+			}
+			newMC, ok := e.ObjectNew.(*clusterv1.ManagedCluster)
+			if !ok {
+				return false
+			}
+			return isVirtualizationCluster(oldMC) != isVirtualizationCluster(newMC)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			mc, ok := e.Object.(*clusterv1.ManagedCluster)
+			return ok && isVirtualizationCluster(mc)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isVirtualizationCluster(mc *clusterv1.ManagedCluster) bool {
+	if mc.Labels[virtualizationClusterLabel] == "true" {
+		return true
+	}
+
+	for _, claim := range mc.Status.ClusterClaims {
+		if claim.Name == virtualizationClusterClaim && claim.Value == "true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetUserDashboards collects the dashboards supplied through the labeled ConfigMaps
+// of the hub install namespace. Each ConfigMap key holds one dashboard; invalid
+// dashboards are logged and skipped so they cannot block the other ones.
+func GetUserDashboards(ctx context.Context, k8s client.Client, logger logr.Logger, isHub bool) []manifests.UserDashboardValue {
+	if !isHub {
+		return nil
+	}
+
+	// Like the watcher, only list the metadata of the labeled ConfigMaps and
+	// fetch the content of each of them.
+	cms := &metav1.PartialObjectMetadataList{}
+	cms.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMapList"))
+	if err := k8s.List(ctx, cms, client.InNamespace(addoncfg.InstallNamespace), client.HasLabels{addoncfg.UserDashboardLabelKey}); err != nil {
+		logger.Error(err, "failed to list user dashboard ConfigMaps")
+		return nil
+	}
+
+	sort.Slice(cms.Items, func(i, j int) bool { return cms.Items[i].Name < cms.Items[j].Name })
+
+	var dashboards []manifests.UserDashboardValue
+	for _, item := range cms.Items {
+		cm, err := common.GetConfigMap(ctx, k8s, item.Namespace, item.Name)
+		if err != nil {
+			logger.Error(err, "skipping user dashboard ConfigMap", "configmap", item.Name)
+			continue
+		}
+		keys := make([]string, 0, len(cm.Data))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			db, err := userdashboards.Parse(key, cm.Data[key], addoncfg.InstallNamespace, manifests.ThanosDatasourceName)
+			if err != nil {
+				logger.Error(err, "skipping invalid user dashboard", "configmap", cm.Name, "key", key)
+				continue
+			}
+			data, err := json.Marshal(db.Spec)
+			if err != nil {
+				logger.Error(err, "skipping invalid user dashboard", "configmap", cm.Name, "key", key)
+				continue
+			}
+			dashboards = append(dashboards, manifests.UserDashboardValue{
+				Name:      db.Metadata.Name,
+				ConfigMap: cm.Name,
+				Data:      string(data),
+			})
+		}
+	}
+
+	return dashboards
+}
+
+// UserDashboardConfigMapPredicate filters ConfigMap events down to the user dashboard ConfigMaps.
+// Updates are also let through when the label is removed so the dashboards get pruned.
+func UserDashboardConfigMapPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isUserDashboardConfigMap(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isUserDashboardConfigMap(e.ObjectOld) || isUserDashboardConfigMap(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isUserDashboardConfigMap(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isUserDashboardConfigMap(obj client.Object) bool {
+	if obj.GetNamespace() != addoncfg.InstallNamespace {
+		return false
+	}
+	_, ok := obj.GetLabels()[addoncfg.UserDashboardLabelKey]
+	return ok
+}
+
+// GetLokiStackOutput returns the LokiStack the logs of the managed clusters
+// are stored in, along with the tenants they are written to: the LokiStack of
+// the hub when the addon provisions it, otherwise the first LokiStack output of
+// the ClusterLogForwarder referenced by the addon configs. It returns nil when
+// logs are not forwarded to a LokiStack.
+func GetLokiStackOutput(ctx context.Context, k8s client.Client, logger logr.Logger, mcAddon *addonapiv1beta1.ManagedClusterAddOn, hubLokiStack addon.HubLokiStackOptions, isHub bool) *manifests.LokiStackOutput {
+	if !isHub || mcAddon == nil {
+		return nil
+	}
+
+	if hubLokiStack.Enabled {
+		// The logs of all the clusters, including the hub, are stored in the
+		// LokiStack of the hub, in the tenant of their cluster. The gateway
+		// writes them over OTLP.
+		tenants, err := lshandlers.ListTenants(ctx, k8s)
+		if err != nil {
+			logger.Error(err, "failed to list the tenants of the LokiStack of the hub")
+			return nil
+		}
+		return &manifests.LokiStackOutput{
+			Name:           lsmanifests.LokiStackName,
+			Namespace:      addoncfg.InstallNamespace,
+			Tenants:        tenants,
+			OTel:           true,
+			ClusterTenants: true,
+			CertSecret:     common.ClientCertKey(mcAddon.Namespace, lsmanifests.ReaderCertSecretName).Name,
+		}
+	}
+
+	keys := common.GetObjectKeys(mcAddon.Status.ConfigReferences, loggingv1.GroupVersion.Group, addoncfg.ClusterLogForwardersResource)
+	if len(keys) != 1 {
+		return nil
+	}
+	clf := &loggingv1.ClusterLogForwarder{}
+	if err := k8s.Get(ctx, keys[0], clf); err != nil {
+		logger.Error(err, "failed to get the ClusterLogForwarder", "name", keys[0].Name, "namespace", keys[0].Namespace)
+		return nil
+	}
+
+	return lokiStackOutput(clf)
+}
+
+// lokiStackOutput picks the first LokiStack output of the ClusterLogForwarder.
+func lokiStackOutput(clf *loggingv1.ClusterLogForwarder) *manifests.LokiStackOutput {
+	idx := slices.IndexFunc(clf.Spec.Outputs, func(o loggingv1.OutputSpec) bool {
+		return o.Type == loggingv1.OutputTypeLokiStack && o.LokiStack != nil
+	})
+	if idx < 0 {
+		return nil
+	}
+	output := clf.Spec.Outputs[idx]
+
+	inputTypes := map[string]loggingv1.InputType{
+		string(loggingv1.InputTypeApplication):    loggingv1.InputTypeApplication,
+		string(loggingv1.InputTypeInfrastructure): loggingv1.InputTypeInfrastructure,
+		string(loggingv1.InputTypeAudit):          loggingv1.InputTypeAudit,
+	}
+	for _, input := range clf.Spec.Inputs {
+		inputTypes[input.Name] = input.Type
+	}
+
+	tenants := map[loggingv1.InputType]bool{}
+	for _, pipeline := range clf.Spec.Pipelines {
+		if !slices.Contains(pipeline.OutputRefs, output.Name) {
+			continue
+		}
+		for _, ref := range pipeline.InputRefs {
+			tenants[inputTypes[ref]] = true
+		}
+	}
+
+	// The LokiStack gateway stores each log type in the tenant of the same name.
+	lokiStack := &manifests.LokiStackOutput{
+		Name:      output.LokiStack.Target.Name,
+		Namespace: output.LokiStack.Target.Namespace,
+		OTel:      output.LokiStack.DataModel == loggingv1.LokiStackDataModelOpenTelemetry,
+	}
+	if lokiStack.Namespace == "" {
+		lokiStack.Namespace = addoncfg.SpokeCLFNamespace
+	}
+	for _, inputType := range []loggingv1.InputType{loggingv1.InputTypeApplication, loggingv1.InputTypeInfrastructure, loggingv1.InputTypeAudit} {
+		if tenants[inputType] {
+			lokiStack.Tenants = append(lokiStack.Tenants, string(inputType))
+		}
+	}
+
+	return lokiStack
+}
@@ -5,18 +5,31 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
-var _ = operatorv1alpha1.AddToScheme(scheme.Scheme)
+var (
+	_ = operatorv1alpha1.AddToScheme(scheme.Scheme)
+	_ = loggingv1.AddToScheme(scheme.Scheme)
+	_ = addonapiv1beta1.Install(scheme.Scheme)
+)
 
 func TestInstallCOO(t *testing.T) {
 	tests := []struct {
@@ -179,7 +192,7 @@ func TestInstallCOO(t *testing.T) {
 			}
 
 			result, err := InstallOfCOOOnTheHubIsNeeded(context.Background(), k8sClientBuilder.Build(), logr.Discard(), tc.isHub)
-			cooValues := manifests.BuildValues(tc.options, result, tc.isHub, false)
+			cooValues := manifests.BuildValues(manifests.Options{Addon: tc.options, InstallCOO: result, IsHub: tc.isHub})
 
 			if tc.expectedErrMsg != "" {
 				assert.EqualError(t, err, tc.expectedErrMsg)
@@ -191,3 +204,297 @@ func TestInstallCOO(t *testing.T) {
 		})
 	}
 }
+
+func TestVirtualizationDashboards(t *testing.T) {
+	virtCluster := func(name string, labels map[string]string, claims ...clusterv1.ManagedClusterClaim) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status:     clusterv1.ManagedClusterStatus{ClusterClaims: claims},
+		}
+	}
+	uiOptions := func(override *bool) addon.Options {
+		return addon.Options{
+			Platform: addon.PlatformOptions{
+				Enabled: true,
+				Metrics: addon.MetricsOptions{
+					CollectionEnabled: true,
+					UI: addon.MetricsUIOptions{
+						Enabled:                  true,
+						VirtualizationDashboards: override,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		isHub             bool
+		clusters          []*clusterv1.ManagedCluster
+		options           addon.Options
+		expectedDetected  bool
+		expectedDashboard bool
+	}{
+		{
+			name:     "no virtualization clusters",
+			isHub:    true,
+			clusters: []*clusterv1.ManagedCluster{virtCluster("c1", nil)},
+			options:  uiOptions(nil),
+		},
+		{
+			name:              "cluster labelled with the CNV operator",
+			isHub:             true,
+			clusters:          []*clusterv1.ManagedCluster{virtCluster("c1", nil), virtCluster("c2", map[string]string{virtualizationClusterLabel: "true"})},
+			options:           uiOptions(nil),
+			expectedDetected:  true,
+			expectedDashboard: true,
+		},
+		{
+			name:              "cluster reporting the virtualization claim",
+			isHub:             true,
+			clusters:          []*clusterv1.ManagedCluster{virtCluster("c1", nil, clusterv1.ManagedClusterClaim{Name: virtualizationClusterClaim, Value: "true"})},
+			options:           uiOptions(nil),
+			expectedDetected:  true,
+			expectedDashboard: true,
+		},
+		{
+			name:             "detected but disabled through the ADC",
+			isHub:            true,
+			clusters:         []*clusterv1.ManagedCluster{virtCluster("c1", map[string]string{virtualizationClusterLabel: "true"})},
+			options:          uiOptions(ptr.To(false)),
+			expectedDetected: true,
+		},
+		{
+			name:              "not detected but enabled through the ADC",
+			isHub:             true,
+			options:           uiOptions(ptr.To(true)),
+			expectedDashboard: true,
+		},
+		{
+			name:     "spoke clusters are never inspected",
+			isHub:    false,
+			clusters: []*clusterv1.ManagedCluster{virtCluster("c1", map[string]string{virtualizationClusterLabel: "true"})},
+			options:  uiOptions(nil),
+		},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(s))
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClientBuilder := fake.NewClientBuilder().WithScheme(s)
+			for _, c := range tc.clusters {
+				k8sClientBuilder = k8sClientBuilder.WithObjects(c)
+			}
+
+			detected, err := HasVirtualizationClusters(context.Background(), k8sClientBuilder.Build(), tc.isHub)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDetected, detected)
+
+			cooValues := manifests.BuildValues(manifests.Options{Addon: tc.options, IsHub: tc.isHub, HasVirtualizationClusters: detected})
+			var found bool
+			for _, db := range cooValues.Dashboards {
+				if db.Name == "acm-openshift-virtualization-overview" {
+					found = true
+				}
+			}
+			assert.Equal(t, tc.expectedDashboard, found)
+		})
+	}
+}
+
+func TestHasVirtualizationClusters_ListError(t *testing.T) {
+	// The ManagedCluster type is missing from the scheme so the list fails.
+	k8s := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	_, err := HasVirtualizationClusters(context.Background(), k8s, true)
+	require.Error(t, err)
+}
+
+func TestVirtualizationClusterPredicate(t *testing.T) {
+	plain := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "c1"}}
+	virt := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Labels: map[string]string{virtualizationClusterLabel: "true"}}}
+
+	p := VirtualizationClusterPredicate()
+	assert.False(t, p.Create(event.CreateEvent{Object: plain}))
+	assert.True(t, p.Create(event.CreateEvent{Object: virt}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: plain, ObjectNew: virt}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: virt, ObjectNew: plain}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: virt, ObjectNew: virt}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: virt}))
+	assert.False(t, p.Delete(event.DeleteEvent{Object: plain}))
+}
+
+func TestUserDashboards(t *testing.T) {
+	grafana := func(uid string) string {
+		return `{"uid":"` + uid + `","title":"` + uid + `","panels":[{"type":"stat","title":"Up","gridPos":{"x":0,"y":0,"w":6,"h":4},"targets":[{"expr":"up"}]}]}`
+	}
+	cms := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-a",
+				Namespace: addoncfg.InstallNamespace,
+				Labels:    map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+			},
+			Data: map[string]string{
+				"overview.json": grafana("team-a-overview"),
+				"builtin.json":  grafana("acm-clusters-overview"),
+				"invalid.json":  `not a dashboard`,
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-b",
+				Namespace: addoncfg.InstallNamespace,
+				Labels:    map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+			},
+			Data: map[string]string{"overview.json": grafana("team-a-overview")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-namespace",
+				Namespace: "default",
+				Labels:    map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+			},
+			Data: map[string]string{"overview.json": grafana("team-c-overview")},
+		},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cms...).Build()
+
+	require.Empty(t, GetUserDashboards(context.Background(), k8s, logr.Discard(), false))
+
+	dashboards := GetUserDashboards(context.Background(), k8s, logr.Discard(), true)
+	var names []string
+	for _, db := range dashboards {
+		names = append(names, db.ConfigMap+"/"+db.Name)
+		assert.Contains(t, db.Data, manifests.ThanosDatasourceName)
+	}
+	assert.Equal(t, []string{"team-a/acm-clusters-overview", "team-a/team-a-overview", "team-b/team-a-overview"}, names)
+
+	options := addon.Options{
+		Platform: addon.PlatformOptions{
+			Enabled: true,
+			Metrics: addon.MetricsOptions{
+				CollectionEnabled: true,
+				UI:                addon.MetricsUIOptions{Enabled: true},
+			},
+		},
+	}
+	cooValues := manifests.BuildValues(manifests.Options{Addon: options, IsHub: true, UserDashboards: dashboards})
+	require.Len(t, cooValues.UserDashboards, 1, "built-in and duplicate names are skipped")
+	assert.Equal(t, "team-a", cooValues.UserDashboards[0].ConfigMap)
+	assert.Equal(t, "team-a-overview", cooValues.UserDashboards[0].Name)
+
+	cooValues = manifests.BuildValues(manifests.Options{IsHub: true, UserDashboards: dashboards})
+	assert.Empty(t, cooValues.UserDashboards, "user dashboards need the metrics UI")
+}
+
+func TestUserDashboardConfigMapPredicate(t *testing.T) {
+	labeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "team-a", Namespace: addoncfg.InstallNamespace,
+		Labels: map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+	}}
+	unlabeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: addoncfg.InstallNamespace}}
+	elsewhere := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "team-a", Namespace: "default",
+		Labels: map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+	}}
+
+	p := UserDashboardConfigMapPredicate()
+	assert.True(t, p.Create(event.CreateEvent{Object: labeled}))
+	assert.False(t, p.Create(event.CreateEvent{Object: unlabeled}))
+	assert.False(t, p.Create(event.CreateEvent{Object: elsewhere}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: unlabeled}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: unlabeled}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: labeled}))
+}
+
+func TestGetLokiStackOutput(t *testing.T) {
+	clf := &loggingv1.ClusterLogForwarder{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: addoncfg.InstallNamespace},
+		Spec: loggingv1.ClusterLogForwarderSpec{
+			Inputs: []loggingv1.InputSpec{
+				{Name: "team-a", Type: loggingv1.InputTypeApplication},
+				{Name: "http", Type: loggingv1.InputTypeReceiver},
+			},
+			Outputs: []loggingv1.OutputSpec{
+				{Name: "kafka", Type: loggingv1.OutputTypeKafka},
+				{
+					Name: "hub-lokistack",
+					Type: loggingv1.OutputTypeLokiStack,
+					LokiStack: &loggingv1.LokiStack{
+						Target:    loggingv1.LokiStackTarget{Name: "logging-loki"},
+						DataModel: loggingv1.LokiStackDataModelOpenTelemetry,
+					},
+				},
+			},
+			Pipelines: []loggingv1.PipelineSpec{
+				{Name: "audit", InputRefs: []string{"audit"}, OutputRefs: []string{"kafka"}},
+				{Name: "hub", InputRefs: []string{"http", "infrastructure", "team-a"}, OutputRefs: []string{"hub-lokistack"}},
+			},
+		},
+	}
+	hubAddon := &addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: addoncfg.Name, Namespace: "local-cluster"}}
+	spokeAddon := &addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: addoncfg.Name, Namespace: "spoke-1"}}
+	otherAddon := &addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "spoke-2"}}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(clf, hubAddon, spokeAddon, otherAddon).Build()
+
+	mcAddon := &addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Namespace: "local-cluster"}}
+	require.Nil(t, GetLokiStackOutput(context.Background(), k8s, logr.Discard(), mcAddon, addon.HubLokiStackOptions{}, true), "no ClusterLogForwarder reference")
+
+	mcAddon.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Group:    loggingv1.GroupVersion.Group,
+				Resource: addoncfg.ClusterLogForwardersResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{Name: clf.Name, Namespace: clf.Namespace},
+			},
+		},
+	}
+	require.Nil(t, GetLokiStackOutput(context.Background(), k8s, logr.Discard(), mcAddon, addon.HubLokiStackOptions{}, false))
+
+	lokiStack := GetLokiStackOutput(context.Background(), k8s, logr.Discard(), mcAddon, addon.HubLokiStackOptions{}, true)
+	require.NotNil(t, lokiStack)
+	assert.Equal(t, &manifests.LokiStackOutput{
+		Name:      "logging-loki",
+		Namespace: addoncfg.SpokeCLFNamespace,
+		Tenants:   []string{"application", "infrastructure"},
+		OTel:      true,
+	}, lokiStack)
+
+	// The LokiStack provisioned on the hub takes precedence over the template,
+	// with a tenant per cluster of the addon.
+	hubLokiStack := GetLokiStackOutput(context.Background(), k8s, logr.Discard(), mcAddon, addon.HubLokiStackOptions{Enabled: true}, true)
+	assert.Equal(t, &manifests.LokiStackOutput{
+		Name:           lsmanifests.LokiStackName,
+		Namespace:      addoncfg.InstallNamespace,
+		Tenants:        []string{"local-cluster", "spoke-1"},
+		OTel:           true,
+		ClusterTenants: true,
+		CertSecret:     "mcoa-logs-reader-client-cert-local-cluster",
+	}, hubLokiStack)
+
+	logs := addon.Options{Platform: addon.PlatformOptions{Logs: addon.LogsOptions{CollectionEnabled: true}}}
+	cooValues := manifests.BuildValues(manifests.Options{Addon: logs, InstallCOO: true, IsHub: true, LokiStack: hubLokiStack})
+	require.Len(t, cooValues.LokiDatasources, 2)
+	assert.Equal(t, "loki-spoke-1-datasource", cooValues.LokiDatasources[1].Name)
+	assert.Equal(t, "https://mcoa-logging-loki-gateway-http.open-cluster-management-observability.svc:8080/api/logs/v1/spoke-1", cooValues.LokiDatasources[1].URL)
+	assert.Equal(t, "mcoa-logs-reader-client-cert-local-cluster", cooValues.LokiDatasources[1].CertSecret)
+	assert.Contains(t, cooValues.Dashboards[0].Data, "k8s_cluster_name")
+	assert.Contains(t, cooValues.Dashboards[0].Data, "application|infrastructure|audit")
+
+	cooValues = manifests.BuildValues(manifests.Options{Addon: logs, InstallCOO: true, IsHub: true, LokiStack: lokiStack})
+	require.Len(t, cooValues.LokiDatasources, 2)
+	assert.Equal(t, "loki-application-datasource", cooValues.LokiDatasources[0].Name)
+	assert.Equal(t, "https://logging-loki-gateway-http.openshift-logging.svc:8080/api/logs/v1/infrastructure", cooValues.LokiDatasources[1].URL)
+	assert.Len(t, cooValues.Dashboards, 3)
+	assert.Contains(t, cooValues.Dashboards[0].Data, "openshift_cluster_uid")
+	assert.True(t, cooValues.Perses)
+	assert.True(t, cooValues.InstallCOO)
+
+	cooValues = manifests.BuildValues(manifests.Options{InstallCOO: true, IsHub: true, LokiStack: lokiStack})
+	assert.Empty(t, cooValues.LokiDatasources, "logging dashboards need logs collection")
+	assert.False(t, cooValues.Enabled)
+}
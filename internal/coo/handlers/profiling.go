@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	phandlers "github.com/stolostron/multicluster-observability-addon/internal/profiling/handlers"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetProfilingOutput returns the profile store of the profiling ConfigMap
+// referenced by the hub addon. It returns nil when no valid ConfigMap is
+// referenced.
+func GetProfilingOutput(ctx context.Context, k8s client.Client, logger logr.Logger, mcAddon *addonapiv1beta1.ManagedClusterAddOn, isHub bool) *manifests.ProfilingOutput {
+	if !isHub || mcAddon == nil {
+		return nil
+	}
+
+	config, _, err := phandlers.GetProfilingConfig(ctx, k8s, mcAddon)
+	if err != nil {
+		logger.V(1).Info("no profiling configuration, skipping the Pyroscope datasource", "reason", err.Error())
+		return nil
+	}
+
+	return profilingOutput(config.Endpoint)
+}
+
+// profilingOutput returns the profile store of the endpoint. An HTTPS endpoint
+// of an in-cluster service is expected to be served with a service CA
+// certificate.
+func profilingOutput(endpoint string) *manifests.ProfilingOutput {
+	output := &manifests.ProfilingOutput{URL: endpoint}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return output
+	}
+	output.TLS = u.Scheme == "https" && (strings.HasSuffix(u.Hostname(), ".svc") || strings.Contains(u.Hostname(), ".svc."))
+	return output
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProfilingOutput(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		tls      bool
+	}{
+		{endpoint: "http://pyroscope.profiling.svc:4040"},
+		{endpoint: "https://pyroscope.profiling.svc:4040", tls: true},
+		{endpoint: "https://pyroscope.profiling.svc.cluster.local:4040", tls: true},
+		{endpoint: "https://pyroscope.example.com"},
+	} {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			assert.Equal(t, &manifests.ProfilingOutput{URL: tc.endpoint, TLS: tc.tls}, profilingOutput(tc.endpoint))
+		})
+	}
+}
+
+func TestGetProfilingOutput(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "profiling",
+			Namespace: addoncfg.InstallNamespace,
+			Labels:    map[string]string{addoncfg.ProfilingConfigLabelKey: "true"},
+		},
+		Data: map[string]string{"endpoint": "http://pyroscope.profiling.svc:4040"},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build()
+
+	mcAddon := &addonapiv1beta1.ManagedClusterAddOn{}
+	require.Nil(t, GetProfilingOutput(context.Background(), k8s, logr.Discard(), mcAddon, true), "no profiling ConfigMap reference")
+
+	mcAddon.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Resource: addoncfg.ConfigMapsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{Name: cm.Name, Namespace: cm.Namespace},
+			},
+		},
+	}
+	require.Nil(t, GetProfilingOutput(context.Background(), k8s, logr.Discard(), mcAddon, false))
+
+	profilingOutput := GetProfilingOutput(context.Background(), k8s, logr.Discard(), mcAddon, true)
+	require.NotNil(t, profilingOutput)
+	assert.Equal(t, "http://pyroscope.profiling.svc:4040", profilingOutput.URL)
+
+	profiles := addon.Options{UserWorkloads: addon.UserWorkloadOptions{Profiles: addon.ProfilesOptions{CollectionEnabled: true}}}
+	cooValues := manifests.BuildValues(manifests.Options{Addon: profiles, InstallCOO: true, IsHub: true, Profiling: profilingOutput})
+	require.NotNil(t, cooValues.PyroscopeDatasource)
+	assert.Equal(t, manifests.PyroscopeDatasourceName, cooValues.PyroscopeDatasource.Name)
+	assert.False(t, cooValues.PyroscopeDatasource.TLS)
+	require.Len(t, cooValues.Dashboards, 1)
+	assert.Contains(t, cooValues.Dashboards[0].Data, "PyroscopeProfileQuery")
+	assert.True(t, cooValues.Perses)
+	assert.True(t, cooValues.InstallCOO)
+
+	cooValues = manifests.BuildValues(manifests.Options{InstallCOO: true, IsHub: true, Profiling: profilingOutput})
+	assert.Nil(t, cooValues.PyroscopeDatasource, "profiling dashboards need profiles collection")
+	assert.False(t, cooValues.Enabled)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// The keys of a team project ConfigMap.
+	teamClusterSetsKey = "clusterSets"
+	teamNamespacesKey  = "namespaces"
+	teamDashboardsKey  = "dashboards"
+)
+
+// GetTeamProjects collects the team projects configured through the labeled ConfigMaps
+// of the hub install namespace, along with the clusters of their ManagedClusterSets.
+// The project namespaces are not created by the addon and must already exist.
+// Invalid projects, projects in system namespaces and projects without clusters
+// are logged and skipped.
+func GetTeamProjects(ctx context.Context, k8s client.Client, logger logr.Logger, isHub bool, registries []addonapiv1beta1.ImageMirror) []manifests.TeamProject {
+	if !isHub {
+		return nil
+	}
+
+	cms := &corev1.ConfigMapList{}
+	if err := k8s.List(ctx, cms, client.InNamespace(addoncfg.InstallNamespace), client.HasLabels{addoncfg.TeamProjectLabelKey}); err != nil {
+		logger.Error(err, "failed to list team project ConfigMaps")
+		return nil
+	}
+	if len(cms.Items) == 0 {
+		return nil
+	}
+
+	clusters := &clusterv1.ManagedClusterList{}
+	if err := k8s.List(ctx, clusters, client.HasLabels{clusterv1beta2.ClusterSetLabel}); err != nil {
+		logger.Error(err, "failed to list the ManagedClusters of the cluster sets")
+		return nil
+	}
+
+	images, err := mconfig.GetImageOverrides(ctx, k8s, registries, logger)
+	if err != nil {
+		logger.Error(err, "failed to get the image of the team project proxies")
+		return nil
+	}
+	proxyImage := images.PromLabelProxy
+	if proxyImage == "" {
+		proxyImage = mconfig.PromLabelProxyImage
+	}
+
+	sort.Slice(cms.Items, func(i, j int) bool { return cms.Items[i].Name < cms.Items[j].Name })
+
+	var teams []manifests.TeamProject
+	for _, cm := range cms.Items {
+		if errs := validation.IsDNS1123Label(cm.Name); len(errs) > 0 {
+			logger.Info("skipping team project with an invalid name", "configmap", cm.Name, "errors", strings.Join(errs, ", "))
+			continue
+		}
+		if isSystemNamespace(cm.Name) {
+			logger.Info("skipping team project named after a system namespace", "configmap", cm.Name)
+			continue
+		}
+		if err := k8s.Get(ctx, client.ObjectKey{Name: cm.Name}, &corev1.Namespace{}); err != nil {
+			logger.Info("skipping team project without a namespace, it must be created first", "configmap", cm.Name, "error", err.Error())
+			continue
+		}
+
+		sets := common.SplitList(cm.Data[teamClusterSetsKey])
+		team := manifests.TeamProject{
+			Name:       cm.Name,
+			ConfigMap:  cm.Name,
+			Namespaces: common.SplitList(cm.Data[teamNamespacesKey]),
+			Dashboards: common.SplitList(cm.Data[teamDashboardsKey]),
+			ProxyImage: proxyImage,
+		}
+		for _, mc := range clusters.Items {
+			if slices.Contains(sets, mc.Labels[clusterv1beta2.ClusterSetLabel]) {
+				team.Clusters = append(team.Clusters, mc.Name)
+			}
+		}
+		if len(team.Clusters) == 0 {
+			logger.Info("skipping team project without clusters", "configmap", cm.Name, "clusterSets", sets)
+			continue
+		}
+		sort.Strings(team.Clusters)
+
+		teams = append(teams, team)
+	}
+
+	return teams
+}
+
+// isSystemNamespace reports whether the namespace belongs to the platform or to
+// ACM, which a team project must not be deployed into.
+func isSystemNamespace(name string) bool {
+	switch name {
+	case "default", "openshift", addoncfg.InstallNamespace, addoncfg.AnalyticsNamespace:
+		return true
+	}
+	for _, prefix := range []string{"kube-", "openshift-", "open-cluster-management", "multicluster-engine", "hypershift"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamProjectConfigMapPredicate filters ConfigMap events down to the team project ConfigMaps.
+// Updates are also let through when the label is removed so the projects get pruned.
+func TeamProjectConfigMapPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isTeamProjectConfigMap(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isTeamProjectConfigMap(e.ObjectOld) || isTeamProjectConfigMap(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isTeamProjectConfigMap(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isTeamProjectConfigMap(obj client.Object) bool {
+	if obj.GetNamespace() != addoncfg.InstallNamespace {
+		return false
+	}
+	_, ok := obj.GetLabels()[addoncfg.TeamProjectLabelKey]
+	return ok
+}
+
+// TeamProjectNamespacePredicate filters Namespace events down to the creation
+// and deletion of the namespaces that can hold a team project, which is only
+// deployed once its namespace exists.
+func TeamProjectNamespacePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return !isSystemNamespace(e.Object.GetName())
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool { return false },
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return !isSystemNamespace(e.Object.GetName())
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// IsTeamProjectNamespace reports whether a team project ConfigMap is named
+// after the namespace.
+func IsTeamProjectNamespace(ctx context.Context, k8s client.Reader, namespace string) (bool, error) {
+	cm := &metav1.PartialObjectMetadata{}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	if err := k8s.Get(ctx, client.ObjectKey{Name: namespace, Namespace: addoncfg.InstallNamespace}, cm); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return isTeamProjectConfigMap(cm), nil
+}
+
+// ClusterSetMembershipPredicate filters ManagedCluster events down to the ones that can
+// change the clusters of a team project.
+func ClusterSetMembershipPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			_, ok := e.Object.GetLabels()[clusterv1beta2.ClusterSetLabel]
+			return ok
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetLabels()[clusterv1beta2.ClusterSetLabel] != e.ObjectNew.GetLabels()[clusterv1beta2.ClusterSetLabel]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			_, ok := e.Object.GetLabels()[clusterv1beta2.ClusterSetLabel]
+			return ok
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestGetTeamProjects(t *testing.T) {
+	cluster := func(name, set string) *clusterv1.ManagedCluster {
+		mc := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if set != "" {
+			mc.Labels = map[string]string{clusterv1beta2.ClusterSetLabel: set}
+		}
+		return mc
+	}
+	team := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: addoncfg.InstallNamespace,
+				Labels:    map[string]string{addoncfg.TeamProjectLabelKey: "true"},
+			},
+			Data: data,
+		}
+	}
+
+	namespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	images := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: mconfig.ImagesConfigMapObjKey.Name, Namespace: mconfig.ImagesConfigMapObjKey.Namespace},
+		Data: map[string]string{
+			"prometheus_config_reloader":    "reloader",
+			"kube_rbac_proxy":               "kube-rbac-proxy",
+			"obo_prometheus_rhel9_operator": "operator",
+			"kube_state_metrics":            "kube-state-metrics",
+			"node_exporter":                 "node-exporter",
+			"prometheus":                    "prometheus",
+			"endpoint_monitoring_operator":  "endpoint-operator",
+			"prom_label_proxy":              "quay.io/prometheuscommunity/prom-label-proxy:v0.11.0",
+		},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+	k8s := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		cluster("c2", "payments"),
+		cluster("c1", "payments"),
+		cluster("c3", "shipping"),
+		cluster("c4", ""),
+		team("payments", map[string]string{
+			"clusterSets": "payments,\nshipping",
+			"namespaces":  "checkout, billing",
+			"dashboards":  "k8s-compute-resources-namespace-pods",
+		}),
+		team("empty", map[string]string{"clusterSets": "unknown"}),
+		team("Invalid_Name", map[string]string{"clusterSets": "payments"}),
+		team(addoncfg.AnalyticsNamespace, map[string]string{"clusterSets": "payments"}),
+		team("default", map[string]string{"clusterSets": "payments"}),
+		team("openshift-monitoring", map[string]string{"clusterSets": "payments"}),
+		team("kube-system", map[string]string{"clusterSets": "payments"}),
+		team("missing", map[string]string{"clusterSets": "payments"}),
+		namespace("payments"),
+		namespace("empty"),
+		namespace("default"),
+		namespace("openshift-monitoring"),
+		namespace("kube-system"),
+		images,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: addoncfg.InstallNamespace}},
+	).Build()
+
+	require.Nil(t, GetTeamProjects(context.Background(), k8s, logr.Discard(), false, nil))
+
+	teams := GetTeamProjects(context.Background(), k8s, logr.Discard(), true, nil)
+	assert.Equal(t, []manifests.TeamProject{
+		{
+			Name:       "payments",
+			ConfigMap:  "payments",
+			Clusters:   []string{"c1", "c2", "c3"},
+			Namespaces: []string{"checkout", "billing"},
+			Dashboards: []string{"k8s-compute-resources-namespace-pods"},
+			ProxyImage: "quay.io/prometheuscommunity/prom-label-proxy:v0.11.0",
+		},
+	}, teams)
+
+	options := addon.Options{
+		Platform: addon.PlatformOptions{
+			Enabled: true,
+			Metrics: addon.MetricsOptions{
+				CollectionEnabled: true,
+				UI:                addon.MetricsUIOptions{Enabled: true},
+			},
+		},
+	}
+	cooValues := manifests.BuildValues(manifests.Options{Addon: options, IsHub: true, Teams: teams})
+	require.Len(t, cooValues.TeamProjects, 1)
+	project := cooValues.TeamProjects[0]
+	assert.Equal(t, "payments", project.Name)
+	require.Len(t, project.Dashboards, 1)
+	assert.Equal(t, "k8s-compute-resources-namespace-pods", project.Dashboards[0].Name)
+	assert.Contains(t, project.Dashboards[0].Data, `name=~\"c1|c2|c3\"`)
+	assert.Contains(t, project.Dashboards[0].Data, `namespace=~\"checkout|billing\"`)
+
+	cooValues = manifests.BuildValues(manifests.Options{IsHub: true, Teams: teams})
+	assert.Empty(t, cooValues.TeamProjects, "team projects need the metrics UI")
+}
+
+func TestIsSystemNamespace(t *testing.T) {
+	for _, ns := range []string{"default", "openshift", "openshift-logging", "kube-public", "open-cluster-management-agent", "multicluster-engine", addoncfg.InstallNamespace} {
+		assert.True(t, isSystemNamespace(ns), ns)
+	}
+	for _, ns := range []string{"payments", "team-openshift", "kubeflow"} {
+		assert.False(t, isSystemNamespace(ns), ns)
+	}
+}
+
+func TestTeamProjectPredicates(t *testing.T) {
+	labeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "payments", Namespace: addoncfg.InstallNamespace,
+		Labels: map[string]string{addoncfg.TeamProjectLabelKey: "true"},
+	}}
+	unlabeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: addoncfg.InstallNamespace}}
+
+	p := TeamProjectConfigMapPredicate()
+	assert.True(t, p.Create(event.CreateEvent{Object: labeled}))
+	assert.False(t, p.Create(event.CreateEvent{Object: unlabeled}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: unlabeled}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: labeled}))
+
+	inSet := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Labels: map[string]string{clusterv1beta2.ClusterSetLabel: "payments"}}}
+	moved := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Labels: map[string]string{clusterv1beta2.ClusterSetLabel: "shipping"}}}
+	noSet := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "c1"}}
+
+	m := ClusterSetMembershipPredicate()
+	assert.True(t, m.Create(event.CreateEvent{Object: inSet}))
+	assert.False(t, m.Create(event.CreateEvent{Object: noSet}))
+	assert.True(t, m.Update(event.UpdateEvent{ObjectOld: inSet, ObjectNew: moved}))
+	assert.False(t, m.Update(event.UpdateEvent{ObjectOld: inSet, ObjectNew: inSet}))
+	assert.True(t, m.Delete(event.DeleteEvent{Object: inSet}))
+
+	n := TeamProjectNamespacePredicate()
+	assert.True(t, n.Create(event.CreateEvent{Object: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}}))
+	assert.False(t, n.Create(event.CreateEvent{Object: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-monitoring"}}}))
+	assert.True(t, n.Delete(event.DeleteEvent{Object: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}}))
+
+	k8s := fake.NewClientBuilder().WithObjects(labeled).Build()
+	isTeam, err := IsTeamProjectNamespace(context.Background(), k8s, "payments")
+	require.NoError(t, err)
+	assert.True(t, isTeam)
+	isTeam, err = IsTeamProjectNamespace(context.Background(), k8s, "shipping")
+	require.NoError(t, err)
+	assert.False(t, isTeam)
+}
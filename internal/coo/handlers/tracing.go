@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	tpanels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/tracing"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	spanMetricsConnector = "spanmetrics"
+	tenantHeader         = "X-Scope-OrgID"
+)
+
+var (
+	// tempoGatewayHost matches the gateway of a TempoStack or TempoMonolithic
+	// running with multi-tenancy enabled.
+	tempoGatewayHost = regexp.MustCompile(`^tempo-([a-z0-9-]+)-gateway\.([a-z0-9-]+)(\.svc.*)?$`)
+	// tempoDistributorHost matches the distributor of a TempoStack.
+	tempoDistributorHost = regexp.MustCompile(`^tempo-([a-z0-9-]+)-distributor\.([a-z0-9-]+)(\.svc.*)?$`)
+	// tempoMonolithicHost matches a TempoMonolithic without gateway.
+	tempoMonolithicHost = regexp.MustCompile(`^tempo-([a-z0-9-]+)\.([a-z0-9-]+)(\.svc.*)?$`)
+	// tenantPath matches the OTLP/HTTP path of a tenant on the Tempo gateway.
+	tenantPath = regexp.MustCompile(`^/api/traces/v1/([^/]+)`)
+)
+
+// GetTracingOutput inspects the OpenTelemetryCollector referenced by the hub
+// addon and returns where its trace pipelines export to and whether they feed
+// a spanmetrics connector. It returns nil when no collector is referenced.
+func GetTracingOutput(ctx context.Context, k8s client.Client, logger logr.Logger, mcAddon *addonapiv1beta1.ManagedClusterAddOn, isHub bool) *manifests.TracingOutput {
+	if !isHub || mcAddon == nil {
+		return nil
+	}
+
+	keys := common.GetObjectKeys(mcAddon.Status.ConfigReferences, otelv1beta1.GroupVersion.Group, addoncfg.OpenTelemetryCollectorsResource)
+	if len(keys) != 1 {
+		return nil
+	}
+	otelCol := &otelv1beta1.OpenTelemetryCollector{}
+	if err := k8s.Get(ctx, keys[0], otelCol); err != nil {
+		logger.Error(err, "failed to get the OpenTelemetryCollector", "name", keys[0].Name, "namespace", keys[0].Namespace)
+		return nil
+	}
+
+	output, unmatched := tracingOutput(&otelCol.Spec.Config)
+	if output.TempoURL == "" && len(unmatched) > 0 {
+		logger.Info("the OTLP exporters of the traces pipelines don't point to a Tempo gateway with a tenant, distributor or TempoMonolithic service, skipping the Tempo datasource", "name", otelCol.Name, "namespace", otelCol.Namespace, "endpoints", unmatched)
+	} else if output.TempoURL == "" {
+		logger.V(1).Info("no OTLP exporter in the traces pipelines, skipping the Tempo datasource", "name", otelCol.Name)
+	}
+	return output
+}
+
+// tracingOutput returns the output of the traces pipelines, along with the
+// endpoints of the OTLP exporters from which no Tempo query endpoint could be
+// derived.
+func tracingOutput(cfg *otelv1beta1.Config) (*manifests.TracingOutput, []string) {
+	output := &manifests.TracingOutput{}
+	var unmatched []string
+
+	names := make([]string, 0, len(cfg.Service.Pipelines))
+	for name := range cfg.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pipeline := cfg.Service.Pipelines[name]
+		if pipeline == nil || componentType(name) != "traces" {
+			continue
+		}
+		for _, exporter := range pipeline.Exporters {
+			switch componentType(exporter) {
+			case "otlp", "otlphttp":
+				if output.TempoURL != "" {
+					continue
+				}
+				config, _ := cfg.Exporters.Object[exporter].(map[string]any)
+				output.TempoURL, output.TempoTLS = tempoQueryURL(config)
+				if endpoint, _ := config["endpoint"].(string); output.TempoURL == "" && endpoint != "" {
+					unmatched = append(unmatched, endpoint)
+				}
+			case spanMetricsConnector:
+				if output.SpanMetrics != nil || cfg.Connectors == nil {
+					continue
+				}
+				config, ok := cfg.Connectors.Object[exporter].(map[string]any)
+				if !ok && cfg.Connectors.Object[exporter] != nil {
+					continue
+				}
+				output.SpanMetrics = spanMetrics(config)
+			}
+		}
+	}
+
+	return output, unmatched
+}
+
+// componentType returns the type of a collector component from its ID,
+// e.g. otlphttp for otlphttp/tempo.
+func componentType(id string) string {
+	t, _, _ := strings.Cut(id, "/")
+	return t
+}
+
+// tempoQueryURL derives the query endpoint of Tempo from the configuration of
+// an OTLP exporter sending traces to it.
+func tempoQueryURL(config map[string]any) (string, bool) {
+	endpoint, _ := config["endpoint"].(string)
+	if endpoint == "" {
+		return "", false
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "grpc://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false
+	}
+	host := u.Hostname()
+
+	if m := tempoGatewayHost.FindStringSubmatch(host); m != nil {
+		tenant := ""
+		if p := tenantPath.FindStringSubmatch(u.Path); p != nil {
+			tenant = p[1]
+		} else if headers, ok := config["headers"].(map[string]any); ok {
+			tenant, _ = headers[tenantHeader].(string)
+		}
+		if tenant == "" {
+			return "", false
+		}
+		return fmt.Sprintf("https://tempo-%s-gateway.%s.svc:8080/api/traces/v1/%s/tempo", m[1], m[2], tenant), true
+	}
+	if m := tempoDistributorHost.FindStringSubmatch(host); m != nil {
+		return fmt.Sprintf("http://tempo-%s-query-frontend.%s.svc:3200", m[1], m[2]), false
+	}
+	if m := tempoMonolithicHost.FindStringSubmatch(host); m != nil {
+		return fmt.Sprintf("http://tempo-%s.%s.svc:3200", m[1], m[2]), false
+	}
+	return "", false
+}
+
+// spanMetrics reads the settings of a spanmetrics connector that name its metrics.
+func spanMetrics(config map[string]any) *manifests.SpanMetricsConnector {
+	sm := &manifests.SpanMetricsConnector{
+		Namespace:     tpanels.DefaultSpanMetricsNamespace,
+		HistogramUnit: "ms",
+	}
+	if namespace, ok := config["namespace"].(string); ok {
+		sm.Namespace = namespace
+	}
+	if histogram, ok := config["histogram"].(map[string]any); ok {
+		if unit, ok := histogram["unit"].(string); ok && unit != "" {
+			sm.HistogramUnit = unit
+		}
+	}
+	return sm
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = otelv1beta1.AddToScheme(scheme.Scheme)
+
+func TestTempoQueryURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		expectedURL string
+		expectedTLS bool
+	}{
+		{
+			name:        "tempostack distributor",
+			config:      map[string]any{"endpoint": "tempo-simplest-distributor.tracing.svc.cluster.local:4317"},
+			expectedURL: "http://tempo-simplest-query-frontend.tracing.svc:3200",
+		},
+		{
+			name:        "gateway with tenant path",
+			config:      map[string]any{"endpoint": "https://tempo-simplest-gateway.tracing.svc:8080/api/traces/v1/dev"},
+			expectedURL: "https://tempo-simplest-gateway.tracing.svc:8080/api/traces/v1/dev/tempo",
+			expectedTLS: true,
+		},
+		{
+			name: "gateway with tenant header",
+			config: map[string]any{
+				"endpoint": "tempo-simplest-gateway.tracing.svc:8090",
+				"headers":  map[string]any{"X-Scope-OrgID": "prod"},
+			},
+			expectedURL: "https://tempo-simplest-gateway.tracing.svc:8080/api/traces/v1/prod/tempo",
+			expectedTLS: true,
+		},
+		{
+			name:   "gateway without tenant",
+			config: map[string]any{"endpoint": "tempo-simplest-gateway.tracing.svc:8090"},
+		},
+		{
+			name:        "monolithic",
+			config:      map[string]any{"endpoint": "http://tempo-sample.tracing:4318"},
+			expectedURL: "http://tempo-sample.tracing.svc:3200",
+		},
+		{
+			name:   "not tempo",
+			config: map[string]any{"endpoint": "otel-gateway.example.com:4317"},
+		},
+		{
+			name: "no endpoint",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			url, tls := tempoQueryURL(tc.config)
+			assert.Equal(t, tc.expectedURL, url)
+			assert.Equal(t, tc.expectedTLS, tls)
+		})
+	}
+}
+
+func TestGetTracingOutput(t *testing.T) {
+	otelCol := &otelv1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: addoncfg.InstallNamespace},
+		Spec: otelv1beta1.OpenTelemetryCollectorSpec{
+			Config: otelv1beta1.Config{
+				Receivers: otelv1beta1.AnyConfig{Object: map[string]any{"otlp": map[string]any{}}},
+				Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+					"debug":        map[string]any{},
+					"otlp/tempo":   map[string]any{"endpoint": "tempo-simplest-distributor.tracing.svc:4317"},
+					"prometheus":   map[string]any{"endpoint": "0.0.0.0:8889"},
+					"otlphttp/hub": map[string]any{"endpoint": "https://hub.example.com"},
+				}},
+				Connectors: &otelv1beta1.AnyConfig{Object: map[string]any{
+					"spanmetrics": map[string]any{
+						"namespace": "span.metrics",
+						"histogram": map[string]any{"unit": "s"},
+					},
+				}},
+				Service: otelv1beta1.Service{
+					Pipelines: map[string]*otelv1beta1.Pipeline{
+						"traces": {
+							Receivers: []string{"otlp"},
+							Exporters: []string{"debug", "otlp/tempo", "spanmetrics"},
+						},
+						"metrics/spanmetrics": {
+							Receivers: []string{"spanmetrics"},
+							Exporters: []string{"prometheus", "otlphttp/hub"},
+						},
+					},
+				},
+			},
+		},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(otelCol).Build()
+
+	mcAddon := &addonapiv1beta1.ManagedClusterAddOn{}
+	require.Nil(t, GetTracingOutput(context.Background(), k8s, logr.Discard(), mcAddon, true), "no OpenTelemetryCollector reference")
+
+	mcAddon.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Group:    otelv1beta1.GroupVersion.Group,
+				Resource: addoncfg.OpenTelemetryCollectorsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{Name: otelCol.Name, Namespace: otelCol.Namespace},
+			},
+		},
+	}
+	require.Nil(t, GetTracingOutput(context.Background(), k8s, logr.Discard(), mcAddon, false))
+
+	tracingOutput := GetTracingOutput(context.Background(), k8s, logr.Discard(), mcAddon, true)
+	require.NotNil(t, tracingOutput)
+	assert.Equal(t, &manifests.TracingOutput{
+		TempoURL:    "http://tempo-simplest-query-frontend.tracing.svc:3200",
+		SpanMetrics: &manifests.SpanMetricsConnector{Namespace: "span.metrics", HistogramUnit: "s"},
+	}, tracingOutput)
+
+	traces := addon.Options{UserWorkloads: addon.UserWorkloadOptions{Traces: addon.TracesOptions{CollectionEnabled: true}}}
+	cooValues := manifests.BuildValues(manifests.Options{Addon: traces, InstallCOO: true, IsHub: true, Tracing: tracingOutput})
+	require.NotNil(t, cooValues.TempoDatasource)
+	assert.Equal(t, manifests.TempoDatasourceName, cooValues.TempoDatasource.Name)
+	assert.Equal(t, "http://tempo-simplest-query-frontend.tracing.svc:3200", cooValues.TempoDatasource.URL)
+	assert.False(t, cooValues.TempoDatasource.TLS)
+	require.Len(t, cooValues.Dashboards, 2)
+	assert.Contains(t, cooValues.Dashboards[0].Data, "span_metrics_calls_total")
+	assert.Contains(t, cooValues.Dashboards[0].Data, "span_metrics_duration_seconds_bucket")
+	assert.True(t, cooValues.Perses)
+	assert.True(t, cooValues.InstallCOO)
+
+	cooValues = manifests.BuildValues(manifests.Options{InstallCOO: true, IsHub: true, Tracing: tracingOutput})
+	assert.Nil(t, cooValues.TempoDatasource, "tracing dashboards need traces collection")
+	assert.False(t, cooValues.Enabled)
+}
+
+func TestTracingOutput_UnmatchedEndpoints(t *testing.T) {
+	cfg := &otelv1beta1.Config{
+		Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+			"otlp/gateway": map[string]any{"endpoint": "tempo-simplest-gateway.tracing.svc:8090"},
+			"otlp/jaeger":  map[string]any{"endpoint": "jaeger-collector.tracing.svc:4317"},
+		}},
+		Service: otelv1beta1.Service{
+			Pipelines: map[string]*otelv1beta1.Pipeline{
+				"traces": {Exporters: []string{"otlp/gateway", "otlp/jaeger"}},
+			},
+		},
+	}
+
+	// A gateway without tenant and a non Tempo endpoint are both reported.
+	output, unmatched := tracingOutput(cfg)
+	assert.Empty(t, output.TempoURL)
+	assert.Equal(t, []string{"tempo-simplest-gateway.tracing.svc:8090", "jaeger-collector.tracing.svc:4317"}, unmatched)
+}
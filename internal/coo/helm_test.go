@@ -2,9 +2,12 @@ package coo
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/go-logr/logr"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	persesv1 "github.com/perses/perses-operator/api/v1alpha1"
@@ -14,8 +17,11 @@ import (
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/coo/handlers"
 	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -26,6 +32,7 @@ import (
 	addonutils "open-cluster-management.io/addon-framework/pkg/utils"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -36,6 +43,9 @@ var (
 	_ = addonapiv1beta1.Install(scheme.Scheme)
 	_ = uiplugin.AddToScheme(scheme.Scheme)
 	_ = persesv1.AddToScheme(scheme.Scheme) // Assuming persesv1alpha1 is imported correctly
+	_ = loggingv1.AddToScheme(scheme.Scheme)
+	_ = otelv1beta1.AddToScheme(scheme.Scheme)
+	_ = clusterv1.Install(scheme.Scheme)
 )
 
 func fakeGetValues(ctx context.Context, k8s client.Client) addonfactory.GetValuesFunc {
@@ -66,7 +76,17 @@ func fakeGetValues(ctx context.Context, k8s client.Client) addonfactory.GetValue
 			return nil, err
 		}
 
-		cooValues := manifests.BuildValues(addonOpts, installCOO, isHub, false)
+		userDashboards := handlers.GetUserDashboards(ctx, k8s, logr.Discard(), isHub)
+		cooValues := manifests.BuildValues(manifests.Options{
+			Addon:          addonOpts,
+			InstallCOO:     installCOO,
+			IsHub:          isHub,
+			UserDashboards: userDashboards,
+			LokiStack:      handlers.GetLokiStackOutput(ctx, k8s, logr.Discard(), mcAddon, addonOpts.HubLokiStack, isHub),
+			Tracing:        handlers.GetTracingOutput(ctx, k8s, logr.Discard(), mcAddon, isHub),
+			Profiling:      handlers.GetProfilingOutput(ctx, k8s, logr.Discard(), mcAddon, isHub),
+			Teams:          handlers.GetTeamProjects(ctx, k8s, logr.Discard(), isHub, nil),
+		})
 
 		return addonfactory.JsonStructToValues(cooValues)
 	}
@@ -104,6 +124,8 @@ func Test_IncidentDetection_AllConfigsTogether_AllResources(t *testing.T) {
 		name         string
 		isHub        bool
 		cv           []addonapiv1beta1.CustomizedVariable
+		configs      []addonapiv1beta1.ConfigReference
+		objects      []client.Object
 		expectedFunc func(*testing.T, []runtime.Object)
 	}{
 		{
@@ -247,6 +269,311 @@ func Test_IncidentDetection_AllConfigsTogether_AllResources(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "user dashboards from ConfigMaps",
+			isHub: true,
+			cv: []addonapiv1beta1.CustomizedVariable{
+				{Name: "platformMetricsCollection", Value: "prometheusagents.v1alpha1.monitoring.rhobs"},
+				{Name: addon.KeyMetricsHubHostname, Value: "metrics.hub.com"},
+				{Name: "platformMetricsUI", Value: "uiplugins.v1alpha1.observability.openshift.io"},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "team-a-dashboards",
+						Namespace: addoncfg.InstallNamespace,
+						Labels:    map[string]string{addoncfg.UserDashboardLabelKey: "true"},
+					},
+					Data: map[string]string{
+						"team-a.json": `{"uid":"team-a","title":"Team A","panels":[{"type":"timeseries","title":"Up","gridPos":{"x":0,"y":0,"w":24,"h":8},"targets":[{"expr":"up"}]}]}`,
+						"broken.json": `{"kind":"Dashboard","metadata":{"name":"broken"},"spec":{"panels":{},"layouts":[{"kind":"Grid","spec":{"items":[{"x":0,"y":0,"width":1,"height":1,"content":{"$ref":"#/spec/panels/missing"}}]}}]}}`,
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "unlabeled",
+						Namespace: addoncfg.InstallNamespace,
+					},
+					Data: map[string]string{
+						"team-b.json": `{"uid":"team-b","title":"Team B","panels":[]}`,
+					},
+				},
+			},
+			expectedFunc: func(t *testing.T, objects []runtime.Object) {
+				var userDashboards []*persesv1.PersesDashboard
+				for _, o := range objects {
+					if db, ok := o.(*persesv1.PersesDashboard); ok && db.Labels[addoncfg.UserDashboardSourceLabelKey] != "" {
+						userDashboards = append(userDashboards, db)
+					}
+				}
+
+				require.Len(t, userDashboards, 1)
+				db := userDashboards[0]
+				require.Equal(t, "team-a", db.Name)
+				require.Equal(t, addoncfg.InstallNamespace, db.Namespace)
+				require.Equal(t, "team-a-dashboards", db.Labels[addoncfg.UserDashboardSourceLabelKey])
+				require.Len(t, db.Spec.Panels, 1)
+			},
+		},
+		{
+			name:  "loki datasources and logging dashboards",
+			isHub: true,
+			cv: []addonapiv1beta1.CustomizedVariable{
+				{Name: addon.KeyPlatformLogsCollection, Value: string(addon.ClusterLogForwarderV1)},
+			},
+			configs: []addonapiv1beta1.ConfigReference{
+				{
+					ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+						Group:    loggingv1.GroupVersion.Group,
+						Resource: addoncfg.ClusterLogForwardersResource,
+					},
+					DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+						ConfigReferent: addonapiv1beta1.ConfigReferent{
+							Namespace: addoncfg.InstallNamespace,
+							Name:      "instance",
+						},
+						SpecHash: "fake-spec-hash",
+					},
+				},
+			},
+			objects: []client.Object{
+				&loggingv1.ClusterLogForwarder{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "instance",
+						Namespace: addoncfg.InstallNamespace,
+					},
+					Spec: loggingv1.ClusterLogForwarderSpec{
+						Outputs: []loggingv1.OutputSpec{
+							{
+								Name: "hub-lokistack",
+								Type: loggingv1.OutputTypeLokiStack,
+								LokiStack: &loggingv1.LokiStack{
+									Target: loggingv1.LokiStackTarget{Name: "logging-loki", Namespace: "openshift-logging"},
+								},
+							},
+						},
+						Pipelines: []loggingv1.PipelineSpec{
+							{
+								Name:       "hub",
+								InputRefs:  []string{"application", "infrastructure"},
+								OutputRefs: []string{"hub-lokistack"},
+							},
+						},
+					},
+				},
+			},
+			expectedFunc: func(t *testing.T, objects []runtime.Object) {
+				urls := map[string]string{}
+				var dashboards []string
+				for _, o := range objects {
+					switch obj := o.(type) {
+					case *persesv1.PersesDatasource:
+						if obj.Spec.Config.Plugin.Kind == "LokiDatasource" {
+							urls[obj.Name] = obj.Spec.Config.Plugin.Spec.(map[string]any)["proxy"].(map[string]any)["spec"].(map[string]any)["url"].(string)
+						}
+					case *persesv1.PersesDashboard:
+						dashboards = append(dashboards, obj.Name)
+					}
+				}
+
+				require.Equal(t, map[string]string{
+					"loki-application-datasource":    "https://logging-loki-gateway-http.openshift-logging.svc:8080/api/logs/v1/application",
+					"loki-infrastructure-datasource": "https://logging-loki-gateway-http.openshift-logging.svc:8080/api/logs/v1/infrastructure",
+				}, urls)
+				require.ElementsMatch(t, []string{
+					"acm-logs-volume-by-cluster",
+					"acm-logs-error-rate-by-namespace",
+					"acm-logs-collector-health",
+				}, dashboards)
+			},
+		},
+		{
+			name:  "hub lokistack datasources",
+			isHub: true,
+			cv: []addonapiv1beta1.CustomizedVariable{
+				{Name: addon.KeyPlatformLogsCollection, Value: string(addon.ClusterLogForwarderV1)},
+				{Name: addon.KeyOTelGatewayHostname, Value: "gateway.example.com"},
+				{Name: addon.KeyHubLokiStack, Value: string(addon.LokiStackV1)},
+			},
+			objects: []client.Object{
+				&addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: addoncfg.Name, Namespace: "cluster-1"}},
+				&addonapiv1beta1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: addoncfg.Name, Namespace: "cluster-2"}},
+			},
+			expectedFunc: func(t *testing.T, objects []runtime.Object) {
+				certs := map[string]string{}
+				for _, o := range objects {
+					if obj, ok := o.(*persesv1.PersesDatasource); ok && obj.Spec.Config.Plugin.Kind == "LokiDatasource" {
+						require.NotNil(t, obj.Spec.Client.TLS.UserCert)
+						certs[obj.Name] = obj.Spec.Client.TLS.UserCert.Name
+					}
+				}
+
+				// Each cluster has its own tenant, read with the reader certificate.
+				require.Equal(t, map[string]string{
+					"loki-cluster-1-datasource": "mcoa-logs-reader-client-cert-cluster-1",
+					"loki-cluster-2-datasource": "mcoa-logs-reader-client-cert-cluster-1",
+				}, certs)
+			},
+		},
+		{
+			name:  "tempo datasource and tracing dashboards",
+			isHub: true,
+			cv: []addonapiv1beta1.CustomizedVariable{
+				{Name: addon.KeyUserWorkloadTracesCollection, Value: string(addon.OpenTelemetryCollectorV1beta1)},
+			},
+			configs: []addonapiv1beta1.ConfigReference{
+				{
+					ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+						Group:    otelv1beta1.GroupVersion.Group,
+						Resource: addoncfg.OpenTelemetryCollectorsResource,
+					},
+					DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+						ConfigReferent: addonapiv1beta1.ConfigReferent{
+							Namespace: addoncfg.InstallNamespace,
+							Name:      "instance",
+						},
+						SpecHash: "fake-spec-hash",
+					},
+				},
+			},
+			objects: []client.Object{
+				&otelv1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "instance",
+						Namespace: addoncfg.InstallNamespace,
+					},
+					Spec: otelv1beta1.OpenTelemetryCollectorSpec{
+						Config: otelv1beta1.Config{
+							Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+								"otlphttp": map[string]any{"endpoint": "https://tempo-hub-gateway.tracing.svc:8080/api/traces/v1/acm"},
+							}},
+							Connectors: &otelv1beta1.AnyConfig{Object: map[string]any{
+								"spanmetrics": map[string]any{},
+							}},
+							Service: otelv1beta1.Service{
+								Pipelines: map[string]*otelv1beta1.Pipeline{
+									"traces": {Exporters: []string{"otlphttp", "spanmetrics"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedFunc: func(t *testing.T, objects []runtime.Object) {
+				var tempo *persesv1.PersesDatasource
+				var dashboards []string
+				for _, o := range objects {
+					switch obj := o.(type) {
+					case *persesv1.PersesDatasource:
+						if obj.Spec.Config.Plugin.Kind == "TempoDatasource" {
+							tempo = obj
+						}
+					case *persesv1.PersesDashboard:
+						dashboards = append(dashboards, obj.Name)
+					}
+				}
+
+				require.NotNil(t, tempo)
+				require.Equal(t, manifests.TempoDatasourceName, tempo.Name)
+				require.Equal(t, "https://tempo-hub-gateway.tracing.svc:8080/api/traces/v1/acm/tempo", tempo.Spec.Config.Plugin.Spec.(map[string]any)["proxy"].(map[string]any)["spec"].(map[string]any)["url"])
+				require.NotNil(t, tempo.Spec.Client)
+				require.ElementsMatch(t, []string{
+					"acm-tracing-red-metrics",
+					"acm-tracing-traces",
+				}, dashboards)
+			},
+		},
+		{
+			name:  "team projects scoped to their cluster sets",
+			isHub: true,
+			cv: []addonapiv1beta1.CustomizedVariable{
+				{Name: "platformMetricsCollection", Value: "prometheusagents.v1alpha1.monitoring.rhobs"},
+				{Name: addon.KeyMetricsHubHostname, Value: "metrics.hub.com"},
+				{Name: "platformMetricsUI", Value: "uiplugins.v1alpha1.observability.openshift.io"},
+			},
+			objects: []client.Object{
+				&clusterv1.ManagedCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "payments-1",
+						Labels: map[string]string{clusterv1beta2.ClusterSetLabel: "payments"},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "payments",
+						Namespace: addoncfg.InstallNamespace,
+						Labels:    map[string]string{addoncfg.TeamProjectLabelKey: "true"},
+					},
+					Data: map[string]string{
+						"clusterSets": "payments",
+						"namespaces":  "checkout",
+						"dashboards":  "k8s-compute-resources-cluster,k8s-compute-resources-namespace-pods",
+					},
+				},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: mconfig.ImagesConfigMapObjKey.Name, Namespace: mconfig.ImagesConfigMapObjKey.Namespace},
+					Data: map[string]string{
+						"prometheus_config_reloader":    "reloader",
+						"kube_rbac_proxy":               "kube-rbac-proxy",
+						"obo_prometheus_rhel9_operator": "operator",
+						"kube_state_metrics":            "kube-state-metrics",
+						"node_exporter":                 "node-exporter",
+						"prometheus":                    "prometheus",
+						"endpoint_monitoring_operator":  "endpoint-operator",
+					},
+				},
+			},
+			expectedFunc: func(t *testing.T, objects []runtime.Object) {
+				var proxy *appsv1.Deployment
+				var policy *networkingv1.NetworkPolicy
+				var datasource *persesv1.PersesDatasource
+				var dashboards []string
+				for _, o := range objects {
+					switch obj := o.(type) {
+					case *corev1.Namespace:
+						require.NotEqual(t, "payments", obj.Name, "the project namespace is not managed by the addon")
+					case *appsv1.Deployment:
+						if obj.Namespace == "payments" {
+							proxy = obj
+						}
+					case *networkingv1.NetworkPolicy:
+						if obj.Namespace == "payments" {
+							policy = obj
+						}
+					case *persesv1.PersesDatasource:
+						if obj.Namespace == "payments" {
+							datasource = obj
+						}
+					case *persesv1.PersesDashboard:
+						if obj.Namespace == "payments" {
+							dashboards = append(dashboards, obj.Name)
+						}
+					}
+				}
+
+				require.NotNil(t, proxy)
+				containers := proxy.Spec.Template.Spec.Containers
+				require.Len(t, containers, 2)
+				require.Equal(t, mconfig.PromLabelProxyImage, containers[0].Image)
+				require.Contains(t, containers[0].Args, "-label-value=payments-1")
+				require.Contains(t, containers[0].Args, "-upstream=http://127.0.0.1:8081")
+				require.Contains(t, containers[1].Args, "-label-value=checkout")
+				// only Perses reaches the proxy
+				require.NotNil(t, policy)
+				require.Len(t, policy.Spec.Ingress, 1)
+				require.Equal(t, map[string]string{"app.kubernetes.io/name": "perses"}, policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+				require.NotNil(t, datasource)
+				require.Equal(t, manifests.ThanosDatasourceName, datasource.Name)
+				spec, err := json.Marshal(datasource.Spec.Config.Plugin.Spec)
+				require.NoError(t, err)
+				require.Contains(t, string(spec), "http://team-metrics-proxy.payments.svc.cluster.local:8080")
+				require.ElementsMatch(t, []string{
+					"k8s-compute-resources-cluster",
+					"k8s-compute-resources-namespace-pods",
+				}, dashboards)
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup a managed cluster
@@ -274,6 +601,7 @@ func Test_IncidentDetection_AllConfigsTogether_AllResources(t *testing.T) {
 					},
 				},
 			}
+			mcao.Status.ConfigReferences = append(mcao.Status.ConfigReferences, tc.configs...)
 
 			addc := &addonapiv1beta1.AddOnDeploymentConfig{
 				ObjectMeta: metav1.ObjectMeta{
@@ -286,7 +614,7 @@ func Test_IncidentDetection_AllConfigsTogether_AllResources(t *testing.T) {
 			}
 
 			// Create the COOAgentAddon
-			cooAgentAddon := newCOOAgentAddon([]client.Object{mcao}, addc)
+			cooAgentAddon := newCOOAgentAddon(append([]client.Object{mcao}, tc.objects...), addc)
 
 			// Render manifests and return them as k8s runtime objects
 			objects, err := cooAgentAddon.Manifests(t.Context(), mc, mcao)
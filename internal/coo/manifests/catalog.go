@@ -0,0 +1,94 @@
+package manifests
+
+import (
+	"encoding/json"
+	"fmt"
+
+	persesv1 "github.com/perses/perses-operator/api/v1alpha1"
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/thanos"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AllDashboards renders every dashboard shipped by the addon into the given
+// project, pointing all queries at the given datasource. Unlike BuildValues it
+// ignores feature gates, so it is meant for exporting dashboards outside the addon.
+func AllDashboards(project string, datasource string, clusterLabelName string) ([]persesapiv1.Dashboard, error) {
+	var builders []DashboardBuilder
+	builders = append(builders, acmDashboardBuilders(exportLogLinks())...)
+	builders = append(builders, cardinalityDashboardBuilders()...)
+	builders = append(builders, incidentDetectionDashboardBuilders()...)
+	builders = append(builders, namespaceRSDashboardBuilders()...)
+	builders = append(builders, vmRSDashboardBuilders()...)
+	builders = append(builders, virtualizationDashboardBuilders()...)
+	builders = append(builders, exportLoggingDashboardBuilders()...)
+	builders = append(builders, exportTracingDashboardBuilders()...)
+	builders = append(builders, profilingDashboardBuilders()...)
+
+	var dashboards []persesapiv1.Dashboard
+	for _, builder := range builders {
+		db, err := builder.fn(project, datasource, clusterLabelName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s dashboard: %w", builder.name, err)
+		}
+		dashboards = append(dashboards, db.Dashboard)
+	}
+
+	for _, builder := range k8sDashboardBuilders() {
+		objs, err := builder.fn(project, datasource, clusterLabelName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s dashboards: %w", builder.name, err)
+		}
+		dbs, err := objectDashboards(objs, project, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s dashboards: %w", builder.name, err)
+		}
+		dashboards = append(dashboards, dbs...)
+	}
+
+	objs, err := thanos.BuildThanosDashboards(project, datasource, clusterLabelName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Thanos dashboards: %w", err)
+	}
+	dbs, err := objectDashboards(objs, project, thanosVariableMetricRenames.Replace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Thanos dashboards: %w", err)
+	}
+	dashboards = append(dashboards, dbs...)
+
+	return dashboards, nil
+}
+
+// objectDashboards converts PersesDashboard objects into Perses API dashboards,
+// applying rewrite to the serialized spec when set.
+func objectDashboards(objs []runtime.Object, project string, rewrite func(string) string) ([]persesapiv1.Dashboard, error) {
+	var dashboards []persesapiv1.Dashboard
+	for _, obj := range objs {
+		db, ok := obj.(*persesv1.PersesDashboard)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+
+		spec := db.Spec.DashboardSpec
+		if rewrite != nil {
+			data, err := json.Marshal(spec)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s dashboard: %w", db.Name, err)
+			}
+			spec = persesapiv1.DashboardSpec{}
+			if err := json.Unmarshal([]byte(rewrite(string(data))), &spec); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal %s dashboard: %w", db.Name, err)
+			}
+		}
+
+		dashboards = append(dashboards, persesapiv1.Dashboard{
+			Kind: persesapiv1.KindDashboard,
+			Metadata: persesapiv1.ProjectMetadata{
+				Metadata:               persesapiv1.Metadata{Name: db.Name},
+				ProjectMetadataWrapper: persesapiv1.ProjectMetadataWrapper{Project: project},
+			},
+			Spec: spec,
+		})
+	}
+	return dashboards, nil
+}
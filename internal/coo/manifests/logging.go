@@ -0,0 +1,134 @@
+package manifests
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	logging "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/logging"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+	lpanels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/logging"
+)
+
+const (
+	lokiTenantApplication    = "application"
+	lokiTenantInfrastructure = "infrastructure"
+	lokiTenantAudit          = "audit"
+)
+
+// defaultLokiStackOutput stands for a LokiStack storing every tenant with the
+// ViaQ data model, used when exporting the dashboards outside the addon.
+var defaultLokiStackOutput = &LokiStackOutput{
+	Name:      "logging-loki",
+	Namespace: "openshift-logging",
+	Tenants:   []string{lokiTenantApplication, lokiTenantInfrastructure, lokiTenantAudit},
+}
+
+// enableLoggingUI reports whether the logging datasources and dashboards are
+// deployed, that is on the hub when logs are collected into a LokiStack.
+func enableLoggingUI(opts addon.Options, isHub bool, lokiStack *LokiStackOutput) bool {
+	if !isHub || lokiStack == nil || len(lokiStack.Tenants) == 0 {
+		return false
+	}
+	return opts.Platform.Logs.CollectionEnabled || opts.UserWorkloads.Logs.CollectionEnabled
+}
+
+// lokiDatasourceName returns the name of the Perses datasource querying the tenant.
+func lokiDatasourceName(tenant string) string {
+	return fmt.Sprintf("loki-%s-datasource", tenant)
+}
+
+// buildLokiDatasources returns one datasource per tenant, pointing at the
+// tenant API of the LokiStack gateway.
+func buildLokiDatasources(lokiStack *LokiStackOutput) []LokiDatasourceValue {
+	datasources := make([]LokiDatasourceValue, 0, len(lokiStack.Tenants))
+	for _, tenant := range lokiStack.Tenants {
+		datasources = append(datasources, LokiDatasourceValue{
+			Name:       lokiDatasourceName(tenant),
+			Tenant:     tenant,
+			URL:        fmt.Sprintf("https://%s-gateway-http.%s.svc:8080/api/logs/v1/%s", lokiStack.Name, lokiStack.Namespace, tenant),
+			CertSecret: lokiStack.CertSecret,
+		})
+	}
+	return datasources
+}
+
+// logLinks returns where the compute dashboards link the workload logs to:
+// the application tenant of the LokiStack, filtered with the stream labels of
+// its data model. The links are left out when that tenant is not collected.
+func logLinks(opts addon.Options, isHub bool, lokiStack *LokiStackOutput) dl.LogLinks {
+	if !enableLoggingUI(opts, isHub, lokiStack) {
+		return dl.LogLinks{}
+	}
+	return lokiStackLogLinks(lokiStack)
+}
+
+func lokiStackLogLinks(lokiStack *LokiStackOutput) dl.LogLinks {
+	// The console log view only knows about the tenants of the log types.
+	if lokiStack.ClusterTenants || !slices.Contains(lokiStack.Tenants, lokiTenantApplication) {
+		return dl.LogLinks{}
+	}
+	labels := dl.ViaQLogLabels
+	if lokiStack.OTel {
+		labels = dl.OTelLogLabels
+	}
+	return dl.LogLinks{Tenant: lokiTenantApplication, Labels: labels}
+}
+
+// exportLogLinks is used by AllDashboards, linking to the default LokiStack.
+func exportLogLinks() dl.LogLinks {
+	return lokiStackLogLinks(defaultLokiStackOutput)
+}
+
+func buildLoggingDashboards(lokiStack *LokiStackOutput, datasources []LokiDatasourceValue) []DashboardValue {
+	return buildDashboards(loggingDashboardBuilders(lokiStack, datasources), dsThanos, config.InstallNamespace)
+}
+
+func loggingDashboardBuilders(lokiStack *LokiStackOutput, datasources []LokiDatasourceValue) []DashboardBuilder {
+	labels := lpanels.ViaQStreamLabels
+	switch {
+	case lokiStack.ClusterTenants:
+		labels = lpanels.GatewayStreamLabels
+	case lokiStack.OTel:
+		labels = lpanels.OTelStreamLabels
+	}
+
+	var all, workloads []lpanels.TenantDatasource
+	for _, ds := range datasources {
+		if lokiStack.ClusterTenants {
+			// The tenant of a cluster stores all the types of its logs.
+			all = append(all, lpanels.TenantDatasource{Tenant: ds.Tenant, Datasource: ds.Name, LogTypes: []string{lokiTenantApplication, lokiTenantInfrastructure, lokiTenantAudit}})
+			workloads = append(workloads, lpanels.TenantDatasource{Tenant: ds.Tenant, Datasource: ds.Name, LogTypes: []string{lokiTenantApplication, lokiTenantInfrastructure}})
+			continue
+		}
+		tenant := lpanels.TenantDatasource{Tenant: ds.Tenant, Datasource: ds.Name}
+		all = append(all, tenant)
+		if ds.Tenant == lokiTenantApplication || ds.Tenant == lokiTenantInfrastructure {
+			workloads = append(workloads, tenant)
+		}
+	}
+
+	type tenantsBuilder func(project string, tenants []lpanels.TenantDatasource, labels lpanels.StreamLabels) (dashboard.Builder, error)
+	wrap := func(fn tenantsBuilder, tenants []lpanels.TenantDatasource) DashboardBuilderFunc {
+		return func(project, _, _ string) (dashboard.Builder, error) {
+			return fn(project, tenants, labels)
+		}
+	}
+
+	builders := []DashboardBuilder{
+		{wrap(logging.BuildLogVolumeByCluster, all), "LogVolumeByCluster"},
+	}
+	if len(workloads) > 0 {
+		builders = append(builders, DashboardBuilder{wrap(logging.BuildErrorRateByNamespace, workloads), "ErrorRateByNamespace"})
+	}
+	builders = append(builders, DashboardBuilder{logging.BuildCollectorHealth, "CollectorHealth"})
+	return builders
+}
+
+// exportLoggingDashboardBuilders is used by AllDashboards, which has no
+// ClusterLogForwarder to derive the LokiStack tenants from.
+func exportLoggingDashboardBuilders() []DashboardBuilder {
+	return loggingDashboardBuilders(defaultLokiStackOutput, buildLokiDatasources(defaultLokiStackOutput))
+}
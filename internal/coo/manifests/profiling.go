@@ -0,0 +1,60 @@
+package manifests
+
+import (
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	profiling "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/profiling"
+)
+
+// PyroscopeDatasourceName is the Perses datasource querying the profile store
+// the profiling agents send the profiles to.
+const PyroscopeDatasourceName = "pyroscope-datasource"
+
+// ProfilingOutput describes the profile store configured for the profiling agents.
+type ProfilingOutput struct {
+	// URL is the endpoint of the profile store, serving both the ingestion and
+	// the query APIs.
+	URL string
+	// TLS is set when the endpoint is served with a service CA certificate.
+	TLS bool
+}
+
+// PyroscopeDatasourceValue is the Perses datasource querying Pyroscope.
+type PyroscopeDatasourceValue struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	TLS  bool   `json:"tls"`
+}
+
+// enableProfilingUI reports whether the profiling datasource and dashboards
+// are deployed, that is on the hub when profiles are collected.
+func enableProfilingUI(opts addon.Options, isHub bool, profilingOutput *ProfilingOutput) bool {
+	if !isHub || profilingOutput == nil {
+		return false
+	}
+	return opts.UserWorkloads.Profiles.CollectionEnabled
+}
+
+func buildPyroscopeDatasource(profilingOutput *ProfilingOutput) *PyroscopeDatasourceValue {
+	return &PyroscopeDatasourceValue{
+		Name: PyroscopeDatasourceName,
+		URL:  profilingOutput.URL,
+		TLS:  profilingOutput.TLS,
+	}
+}
+
+func buildProfilingDashboards() []DashboardValue {
+	return buildDashboards(profilingDashboardBuilders(), dsThanos, config.InstallNamespace)
+}
+
+func profilingDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
+		{
+			func(project, datasource, clusterLabelName string) (dashboard.Builder, error) {
+				return profiling.BuildPodProfiles(project, datasource, clusterLabelName, PyroscopeDatasourceName)
+			},
+			"ProfilingPod",
+		},
+	}
+}
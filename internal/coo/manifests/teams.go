@@ -0,0 +1,85 @@
+package manifests
+
+import (
+	"encoding/json"
+	"log"
+	"slices"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/perses/scope"
+)
+
+// TeamProject is a Perses project holding a copy of the ACM dashboards for a
+// team, scoped to the clusters of its ManagedClusterSets. Its datasource goes
+// through a prom-label-proxy enforcing that scope on every query.
+type TeamProject struct {
+	// Name is the name of the project and of its namespace.
+	Name string
+	// ConfigMap is the ConfigMap the project is configured in.
+	ConfigMap string
+	Clusters  []string
+	// Namespaces restricts the namespaces listed by the dashboards, all when empty.
+	Namespaces []string
+	// Dashboards selects the dashboards copied into the project, all when empty.
+	Dashboards []string
+	// ProxyImage is the image of the proxy enforcing the clusters and
+	// namespaces of the team on the queries of the project.
+	ProxyImage string
+}
+
+// TeamProjectValue is a team project with its scoped dashboards.
+type TeamProjectValue struct {
+	Name       string           `json:"name"`
+	ConfigMap  string           `json:"configMap"`
+	Clusters   []string         `json:"clusters"`
+	Namespaces []string         `json:"namespaces,omitempty"`
+	ProxyImage string           `json:"proxyImage"`
+	Dashboards []DashboardValue `json:"dashboards"`
+}
+
+// buildTeamProjects copies the dashboards selected by each team into its
+// project, with their cluster and namespace variables scoped to the team.
+func buildTeamProjects(teams []TeamProject, dashboards []DashboardValue) []TeamProjectValue {
+	var projects []TeamProjectValue
+	for _, team := range teams {
+		project := TeamProjectValue{
+			Name:       team.Name,
+			ConfigMap:  team.ConfigMap,
+			Clusters:   team.Clusters,
+			Namespaces: team.Namespaces,
+			ProxyImage: team.ProxyImage,
+		}
+		for _, db := range dashboards {
+			if len(team.Dashboards) > 0 && !slices.Contains(team.Dashboards, db.Name) {
+				continue
+			}
+			data, err := scopeDashboard(db.Data, team)
+			if err != nil {
+				log.Printf("Failed to scope %s dashboard for project %s: %v", db.Name, team.Name, err)
+				continue
+			}
+			project.Dashboards = append(project.Dashboards, DashboardValue{Name: db.Name, Data: data})
+		}
+		if len(project.Dashboards) == 0 {
+			log.Printf("Skipping project %s from ConfigMap %s: no dashboard selected", team.Name, team.ConfigMap)
+			continue
+		}
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+func scopeDashboard(data string, team TeamProject) (string, error) {
+	var spec persesapiv1.DashboardSpec
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		return "", err
+	}
+	if err := scope.Dashboard(&spec, team.Clusters, team.Namespaces); err != nil {
+		return "", err
+	}
+	scoped, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(scoped), nil
+}
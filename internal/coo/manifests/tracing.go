@@ -0,0 +1,94 @@
+package manifests
+
+import (
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	tracing "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/tracing"
+	tpanels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/tracing"
+)
+
+// TempoDatasourceName is the Perses datasource querying the Tempo instance
+// the hub OpenTelemetryCollector exports traces to.
+const TempoDatasourceName = "tempo-datasource"
+
+// TracingOutput describes where the hub OpenTelemetryCollector sends the
+// traces and whether it derives RED metrics from them.
+type TracingOutput struct {
+	// TempoURL is the query endpoint of Tempo, empty when the OTLP exporter
+	// does not point to a Tempo instance.
+	TempoURL string
+	// TempoTLS is set when the endpoint is served with a service CA certificate.
+	TempoTLS bool
+	// SpanMetrics is set when the collector runs a spanmetrics connector.
+	SpanMetrics *SpanMetricsConnector
+}
+
+// SpanMetricsConnector holds the settings of the spanmetrics connector that
+// determine the names of its metrics.
+type SpanMetricsConnector struct {
+	Namespace     string
+	HistogramUnit string
+}
+
+// TempoDatasourceValue is the Perses datasource querying Tempo.
+type TempoDatasourceValue struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	TLS  bool   `json:"tls"`
+}
+
+// enableTracingUI reports whether the tracing datasource and dashboards are
+// deployed, that is on the hub when traces are collected.
+func enableTracingUI(opts addon.Options, isHub bool, tracingOutput *TracingOutput) bool {
+	if !isHub || tracingOutput == nil {
+		return false
+	}
+	return opts.UserWorkloads.Traces.CollectionEnabled
+}
+
+func buildTempoDatasource(tracingOutput *TracingOutput) *TempoDatasourceValue {
+	if tracingOutput.TempoURL == "" {
+		return nil
+	}
+	return &TempoDatasourceValue{
+		Name: TempoDatasourceName,
+		URL:  tracingOutput.TempoURL,
+		TLS:  tracingOutput.TempoTLS,
+	}
+}
+
+func buildTracingDashboards(tracingOutput *TracingOutput) []DashboardValue {
+	return buildDashboards(tracingDashboardBuilders(tracingOutput), dsThanos, config.InstallNamespace)
+}
+
+func tracingDashboardBuilders(tracingOutput *TracingOutput) []DashboardBuilder {
+	var builders []DashboardBuilder
+	if sm := tracingOutput.SpanMetrics; sm != nil {
+		spanMetrics := tpanels.NewSpanMetrics(sm.Namespace, sm.HistogramUnit)
+		builders = append(builders, DashboardBuilder{
+			func(project, datasource, clusterLabelName string) (dashboard.Builder, error) {
+				return tracing.BuildREDMetrics(project, datasource, clusterLabelName, spanMetrics)
+			},
+			"TracingREDMetrics",
+		})
+	}
+	if tracingOutput.TempoURL != "" {
+		builders = append(builders, DashboardBuilder{
+			func(project, datasource, clusterLabelName string) (dashboard.Builder, error) {
+				return tracing.BuildTraces(project, datasource, clusterLabelName, TempoDatasourceName)
+			},
+			"TracingTraces",
+		})
+	}
+	return builders
+}
+
+// exportTracingDashboardBuilders is used by AllDashboards and assumes both a
+// Tempo instance and a spanmetrics connector with its default settings.
+func exportTracingDashboardBuilders() []DashboardBuilder {
+	return tracingDashboardBuilders(&TracingOutput{
+		TempoURL:    "http://tempo-query-frontend:3200",
+		SpanMetrics: &SpanMetricsConnector{Namespace: tpanels.DefaultSpanMetricsNamespace},
+	})
+}
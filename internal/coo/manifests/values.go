@@ -18,11 +18,17 @@ import (
 	slo "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm/k8s/slo"
 	incident_management "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/incident-management"
 	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/thanos"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/virtualization"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// ThanosDatasourceName is the Perses datasource querying the hub Thanos
+// through the RBAC query proxy.
+const ThanosDatasourceName = "rbac-query-proxy-datasource"
+
 var (
-	dsThanos             = "rbac-query-proxy-datasource"
+	dsThanos             = ThanosDatasourceName
 	dsPlatformPrometheus = "platform-prometheus-datasource"
 	clusterLabelName     = ""
 )
@@ -32,6 +38,36 @@ type DashboardValue struct {
 	Data string `json:"data"`
 }
 
+// UserDashboardValue is a dashboard supplied through a ConfigMap of the hub install namespace.
+type UserDashboardValue struct {
+	Name      string `json:"name"`
+	ConfigMap string `json:"configMap"`
+	Data      string `json:"data"`
+}
+
+// LokiStackOutput is the LokiStack output of the hub ClusterLogForwarder and
+// the tenants its pipelines write to.
+type LokiStackOutput struct {
+	Name      string
+	Namespace string
+	Tenants   []string
+	// OTel is set when the output uses the OpenTelemetry data model.
+	OTel bool
+	// ClusterTenants is set when each tenant stores all the logs of a
+	// managed cluster instead of a type of log, and CertSecret is then the
+	// client certificate the tenants are queried with.
+	ClusterTenants bool
+	CertSecret     string
+}
+
+// LokiDatasourceValue is a Perses datasource querying one tenant of the LokiStack.
+type LokiDatasourceValue struct {
+	Name       string `json:"name"`
+	Tenant     string `json:"tenant"`
+	URL        string `json:"url"`
+	CertSecret string `json:"certSecret,omitempty"`
+}
+
 type DashboardBuilderFunc func(project string, datasource string, clusterLabelName string) (dashboard.Builder, error)
 
 type DashboardBuilder struct {
@@ -44,11 +80,18 @@ type COOValues struct {
 	InstallCOO         bool `json:"installCOO"`
 	MonitoringUIPlugin bool `json:"monitoringUIPlugin"`
 	Perses             bool `json:"perses"`
+	// Namespace is the hub install namespace, the project of the user dashboards.
+	Namespace string `json:"namespace"`
 	// omitempty removed: when no regular dashboards are needed, the key must
 	// still appear in the serialized JSON so Helm uses the empty list instead
 	// of falling back to the default in values.yaml.
 	Dashboards          []DashboardValue                    `json:"dashboards"`
 	AnalyticsDashboards []DashboardValue                    `json:"analyticsDashboards,omitempty"`
+	UserDashboards      []UserDashboardValue                `json:"userDashboards,omitempty"`
+	LokiDatasources     []LokiDatasourceValue               `json:"lokiDatasources,omitempty"`
+	TempoDatasource     *TempoDatasourceValue               `json:"tempoDatasource,omitempty"`
+	PyroscopeDatasource *PyroscopeDatasourceValue           `json:"pyroscopeDatasource,omitempty"`
+	TeamProjects        []TeamProjectValue                  `json:"teamProjects,omitempty"`
 	Metrics             *UIValues                           `json:"metrics,omitempty"`
 	IncidentDetection   *imanifests.IncidentDetectionValues `json:"incidentDetection,omitempty"`
 }
@@ -57,24 +100,74 @@ type UIValues struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Options are the inputs of the COO values: the addon options and what the
+// handlers found on the hub.
+type Options struct {
+	Addon addon.Options
+	// InstallCOO installs the operator on the hub, see InstallOfCOOOnTheHubIsNeeded.
+	// Spokes always install it.
+	InstallCOO                bool
+	IsHub                     bool
+	HasCardinalityRules       bool
+	HasVirtualizationClusters bool
+	UserDashboards            []UserDashboardValue
+	// LokiStack, Tracing and Profiling are the hub outputs the datasources and
+	// dashboards are built for, nil when there is none.
+	LokiStack *LokiStackOutput
+	Tracing   *TracingOutput
+	Profiling *ProfilingOutput
+	Teams     []TeamProject
+}
+
 // BuildValues constructs COO Helm values from addon options, including dashboards and feature gates.
-func BuildValues(opts addon.Options, installOfCOOOnTheHubIsNeeded bool, isHubCluster bool, hasCardinalityRules bool) *COOValues {
+func BuildValues(opts Options) *COOValues {
 	var dashboards []DashboardValue
+	var validUserDashboards []UserDashboardValue
 	var incidentDetectionEnabled bool
 	var rightSizingEnabled bool
-	metricsUI := enableUI(opts.Platform.Metrics, isHubCluster)
+	var teamProjects []TeamProjectValue
+	metricsUI := enableUI(opts.Addon.Platform.Metrics, opts.IsHub)
 	if metricsUI != nil {
 		if metricsUI.Enabled {
-			dashboards = append(dashboards, buildACMDashboards()...)
-			dashboards = append(dashboards, buildK8sDashboards()...)
+			acmDashboards := append(buildACMDashboards(logLinks(opts.Addon, opts.IsHub, opts.LokiStack)), buildK8sDashboards()...)
+			dashboards = append(dashboards, acmDashboards...)
+			teamProjects = buildTeamProjects(opts.Teams, acmDashboards)
 			dashboards = append(dashboards, buildThanosDashboards()...)
-			if hasCardinalityRules {
+			if opts.HasCardinalityRules {
 				dashboards = append(dashboards, buildCardinalityDashboards()...)
 			}
+			if enableVirtualizationDashboards(opts.Addon.Platform.Metrics.UI, opts.HasVirtualizationClusters) {
+				dashboards = append(dashboards, buildVirtualizationDashboards()...)
+			}
 		}
 	}
 
-	incidentDetection := imanifests.EnableUI(opts.Platform.AnalyticsOptions.IncidentDetection)
+	var lokiDatasources []LokiDatasourceValue
+	if enableLoggingUI(opts.Addon, opts.IsHub, opts.LokiStack) {
+		lokiDatasources = buildLokiDatasources(opts.LokiStack)
+		dashboards = append(dashboards, buildLoggingDashboards(opts.LokiStack, lokiDatasources)...)
+	}
+
+	var tempoDatasource *TempoDatasourceValue
+	var tracingDashboards bool
+	if enableTracingUI(opts.Addon, opts.IsHub, opts.Tracing) {
+		tempoDatasource = buildTempoDatasource(opts.Tracing)
+		tracingDashboardValues := buildTracingDashboards(opts.Tracing)
+		tracingDashboards = len(tracingDashboardValues) > 0
+		dashboards = append(dashboards, tracingDashboardValues...)
+	}
+
+	var pyroscopeDatasource *PyroscopeDatasourceValue
+	if enableProfilingUI(opts.Addon, opts.IsHub, opts.Profiling) {
+		pyroscopeDatasource = buildPyroscopeDatasource(opts.Profiling)
+		dashboards = append(dashboards, buildProfilingDashboards()...)
+	}
+
+	if metricsUI != nil && metricsUI.Enabled {
+		validUserDashboards = filterUserDashboards(opts.UserDashboards, dashboards)
+	}
+
+	incidentDetection := imanifests.EnableUI(opts.Addon.Platform.AnalyticsOptions.IncidentDetection)
 	if incidentDetection != nil {
 		if incidentDetection.Enabled {
 			incidentDetectionEnabled = true
@@ -82,16 +175,16 @@ func BuildValues(opts addon.Options, installOfCOOOnTheHubIsNeeded bool, isHubClu
 	}
 
 	var analyticsDashboards []DashboardValue
-	if isHubCluster {
+	if opts.IsHub {
 		if incidentDetectionEnabled {
 			analyticsDashboards = append(analyticsDashboards, buildIncidentDetetctionDashboards()...)
 		}
-		if opts.Platform.AnalyticsOptions.RightSizing.Delegated {
-			if opts.Platform.AnalyticsOptions.RightSizing.NamespaceEnabled {
+		if opts.Addon.Platform.AnalyticsOptions.RightSizing.Delegated {
+			if opts.Addon.Platform.AnalyticsOptions.RightSizing.NamespaceEnabled {
 				rightSizingEnabled = true
 				analyticsDashboards = append(analyticsDashboards, buildNamespaceRSDashboards()...)
 			}
-			if opts.Platform.AnalyticsOptions.RightSizing.VirtualizationEnabled {
+			if opts.Addon.Platform.AnalyticsOptions.RightSizing.VirtualizationEnabled {
 				rightSizingEnabled = true
 				analyticsDashboards = append(analyticsDashboards, buildVMRSDashboards()...)
 			}
@@ -99,9 +192,9 @@ func BuildValues(opts addon.Options, installOfCOOOnTheHubIsNeeded bool, isHubClu
 	}
 
 	var installCOO bool
-	if (metricsUI != nil && metricsUI.Enabled) || incidentDetectionEnabled || rightSizingEnabled {
-		if isHubCluster {
-			installCOO = installOfCOOOnTheHubIsNeeded
+	if (metricsUI != nil && metricsUI.Enabled) || len(lokiDatasources) > 0 || tracingDashboards || pyroscopeDatasource != nil || incidentDetectionEnabled || rightSizingEnabled {
+		if opts.IsHub {
+			installCOO = opts.InstallCOO
 		} else {
 			installCOO = true
 		}
@@ -112,13 +205,40 @@ func BuildValues(opts addon.Options, installOfCOOOnTheHubIsNeeded bool, isHubClu
 		InstallCOO:          installCOO,
 		MonitoringUIPlugin:  len(dashboards) > 0 || len(analyticsDashboards) > 0 || incidentDetectionEnabled,
 		Perses:              len(dashboards) > 0 || len(analyticsDashboards) > 0,
+		Namespace:           config.InstallNamespace,
 		Dashboards:          dashboards,
 		AnalyticsDashboards: analyticsDashboards,
+		UserDashboards:      validUserDashboards,
+		LokiDatasources:     lokiDatasources,
+		TempoDatasource:     tempoDatasource,
+		PyroscopeDatasource: pyroscopeDatasource,
+		TeamProjects:        teamProjects,
 		Metrics:             metricsUI,
 		IncidentDetection:   incidentDetection,
 	}
 }
 
+// filterUserDashboards drops the user dashboards that would overwrite a
+// built-in dashboard or another user dashboard of the same name.
+func filterUserDashboards(userDashboards []UserDashboardValue, builtin []DashboardValue) []UserDashboardValue {
+	names := make(map[string]bool, len(builtin)+len(userDashboards))
+	for _, db := range builtin {
+		names[db.Name] = true
+	}
+
+	var filtered []UserDashboardValue
+	for _, db := range userDashboards {
+		if names[db.Name] {
+			log.Printf("Skipping dashboard %s from ConfigMap %s: name already in use", db.Name, db.ConfigMap)
+			continue
+		}
+		names[db.Name] = true
+		filtered = append(filtered, db)
+	}
+
+	return filtered
+}
+
 func enableUI(opts addon.MetricsOptions, isHub bool) *UIValues {
 	if !isHub {
 		return nil
@@ -133,6 +253,15 @@ func enableUI(opts addon.MetricsOptions, isHub bool) *UIValues {
 	}
 }
 
+// enableVirtualizationDashboards honours the explicit ADC override and otherwise
+// falls back to whether the fleet has OpenShift Virtualization clusters.
+func enableVirtualizationDashboards(opts addon.MetricsUIOptions, hasVirtualizationClusters bool) bool {
+	if opts.VirtualizationDashboards != nil {
+		return *opts.VirtualizationDashboards
+	}
+	return hasVirtualizationClusters
+}
+
 func buildDashboards(builders []DashboardBuilder, datasource string, project string) []DashboardValue {
 	var dashboards []DashboardValue
 
@@ -156,8 +285,19 @@ func buildDashboards(builders []DashboardBuilder, datasource string, project str
 	return dashboards
 }
 
-func buildACMDashboards() []DashboardValue {
-	builders := []DashboardBuilder{
+func buildACMDashboards(logs dl.LogLinks) []DashboardValue {
+	return buildDashboards(acmDashboardBuilders(logs), dsThanos, config.InstallNamespace)
+}
+
+func acmDashboardBuilders(logs dl.LogLinks) []DashboardBuilder {
+	type logsBuilder func(project string, datasource string, clusterLabelName string, logs dl.LogLinks) (dashboard.Builder, error)
+	withLogs := func(fn logsBuilder) DashboardBuilderFunc {
+		return func(project, datasource, clusterLabelName string) (dashboard.Builder, error) {
+			return fn(project, datasource, clusterLabelName, logs)
+		}
+	}
+
+	return []DashboardBuilder{
 		{acm.BuildClusterResourceUse, "ClusterResourceUse"},
 		{acm.BuildNodeResourceUse, "NodeResourceUse"},
 		{acm.BuildACMOptimizationOverview, "ACMOptimizationOverview"},
@@ -165,6 +305,7 @@ func buildACMDashboards() []DashboardValue {
 		{acm.BuildACMAlertAnalysis, "ACMAlertAnalysis"},
 		{acm.BuildACMAlertsByCluster, "ACMAlertsByCluster"},
 		{acm.BuildACMClustersByAlert, "ACMClustersByAlert"},
+		{acm.BuildACMObservabilityFleetHealth, "ACMObservabilityFleetHealth"},
 		{hcp.BuildACMHCPOverview, "ACMHCPOverview"},
 		{hcp.BuildACMHCPResources, "ACMHCPResources"},
 		{slo.BuildSLOAPIServer, "SLOAPIServer"},
@@ -173,15 +314,13 @@ func buildACMDashboards() []DashboardValue {
 		{networking.BuildNetworkingNamespacePods, "NetworkingNamespacePods"},
 		{networking.BuildNetworkingNode, "NetworkingNode"},
 		{networking.BuildNetworkingPod, "NetworkingPod"},
-		{compute.BuildComputeCluster, "ComputeCluster"},
-		{compute.BuildComputeNamespacePods, "ComputeNamespacePods"},
-		{compute.BuildComputeNamespaceWorkloads, "ComputeNamespaceWorkloads"},
+		{withLogs(compute.BuildComputeCluster), "ComputeCluster"},
+		{withLogs(compute.BuildComputeNamespacePods), "ComputeNamespacePods"},
+		{withLogs(compute.BuildComputeNamespaceWorkloads), "ComputeNamespaceWorkloads"},
 		{compute.BuildComputeNodePods, "ComputeNodePods"},
 		{compute.BuildComputePod, "ComputePod"},
-		{compute.BuildComputeWorkload, "ComputeWorkload"},
+		{withLogs(compute.BuildComputeWorkload), "ComputeWorkload"},
 	}
-
-	return buildDashboards(builders, dsThanos, config.InstallNamespace)
 }
 
 // thanosVariableMetricRenames covers the small set of metric names referenced
@@ -223,35 +362,44 @@ func buildThanosDashboards() []DashboardValue {
 }
 
 func buildCardinalityDashboards() []DashboardValue {
-	builders := []DashboardBuilder{
+	return buildDashboards(cardinalityDashboardBuilders(), dsThanos, config.InstallNamespace)
+}
+
+func cardinalityDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
 		{acm.BuildACMMetricsCardinalityOverview, "ACMMetricsCardinalityOverview"},
 		{acm.BuildACMMetricsCardinalityCluster, "ACMMetricsCardinalityCluster"},
 		{acm.BuildACMMetricsCardinalityName, "ACMMetricsCardinalityName"},
 	}
-
-	return buildDashboards(builders, dsThanos, config.InstallNamespace)
 }
 
 func buildIncidentDetetctionDashboards() []DashboardValue {
-	builders := []DashboardBuilder{
+	return buildDashboards(incidentDetectionDashboardBuilders(), dsThanos, config.AnalyticsNamespace)
+}
+
+func incidentDetectionDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
 		{incident_management.BuildACMIncidentsOverview, "IncidentDetectionOverview"},
 	}
+}
 
-	return buildDashboards(builders, dsThanos, config.AnalyticsNamespace)
+// objectDashboardBuilder is a DashboardBuilder for the builders that render
+// several dashboards at once as PersesDashboard objects.
+type objectDashboardBuilder struct {
+	fn   func(project string, datasource string, clusterLabelName string) ([]runtime.Object, error)
+	name string
 }
 
-func buildK8sDashboards() []DashboardValue {
-	type dashboardBuilder struct {
-		fn   func(string, string, string) ([]runtime.Object, error)
-		name string
-	}
-	builders := []dashboardBuilder{
+func k8sDashboardBuilders() []objectDashboardBuilder {
+	return []objectDashboardBuilder{
 		{acm.BuildK8sDashboards, "Kubernetes"},
 		{acm.BuildETCDDashboards, "ETCD"},
 	}
+}
 
+func buildK8sDashboards() []DashboardValue {
 	var dashboards []DashboardValue
-	for _, builder := range builders {
+	for _, builder := range k8sDashboardBuilders() {
 		objs, err := builder.fn(config.InstallNamespace, dsThanos, clusterLabelName)
 		if err != nil {
 			log.Printf("Failed to build %s dashboards: %v", builder.name, err)
@@ -278,19 +426,42 @@ func buildK8sDashboards() []DashboardValue {
 }
 
 func buildNamespaceRSDashboards() []DashboardValue {
-	builders := []DashboardBuilder{
+	return buildDashboards(namespaceRSDashboardBuilders(), dsThanos, config.AnalyticsNamespace)
+}
+
+func namespaceRSDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
 		{rsperses.BuildNamespaceRightSizing, "NamespaceRightSizing"},
+		{rsperses.BuildWorkloadRightSizing, "WorkloadRightSizing"},
 	}
-
-	return buildDashboards(builders, dsThanos, config.AnalyticsNamespace)
 }
 
 func buildVMRSDashboards() []DashboardValue {
-	builders := []DashboardBuilder{
+	return buildDashboards(vmRSDashboardBuilders(), dsThanos, config.AnalyticsNamespace)
+}
+
+func vmRSDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
 		{rsperses.BuildVMOverview, "VMRightSizingOverview"},
 		{rsperses.BuildVMOverestimation, "VMOverestimation"},
 		{rsperses.BuildVMUnderestimation, "VMUnderestimation"},
 	}
+}
 
-	return buildDashboards(builders, dsThanos, config.AnalyticsNamespace)
+func buildVirtualizationDashboards() []DashboardValue {
+	return buildDashboards(virtualizationDashboardBuilders(), dsThanos, config.InstallNamespace)
+}
+
+func virtualizationDashboardBuilders() []DashboardBuilder {
+	return []DashboardBuilder{
+		{virtualization.BuildVirtOverview, "VirtOverview"},
+		{virtualization.BuildSingleClusterView, "VirtSingleClusterView"},
+		{virtualization.BuildSingleVMView, "VirtSingleVMView"},
+		{virtualization.BuildVMInventory, "VirtVMInventory"},
+		{virtualization.BuildTopConsumers, "VirtTopConsumers"},
+		{virtualization.BuildNodeMemoryOverview, "VirtNodeMemoryOverview"},
+		{virtualization.BuildVMByTimeInStatus, "VirtVMByTimeInStatus"},
+		{virtualization.BuildVMServiceLevel, "VirtVMServiceLevel"},
+		{virtualization.BuildVMUtilization, "VirtVMUtilization"},
+	}
 }
@@ -20,12 +20,13 @@ import (
 // expectedRSDashboards are the dashboard IDs produced by the right-sizing builders.
 var (
 	namespaceDashboardID = "acm-rs-namespace-overview"
+	workloadDashboardID  = "acm-rs-workload-overview"
 	vmDashboardIDs       = []string{
 		"acm-rightsizing-openshift-virtualization",
 		"acm-rightsizing-vm-overestimation",
 		"acm-rightsizing-vm-underestimation",
 	}
-	allRSDashboardIDs = append([]string{namespaceDashboardID}, vmDashboardIDs...)
+	allRSDashboardIDs = append([]string{namespaceDashboardID, workloadDashboardID}, vmDashboardIDs...)
 )
 
 func renderRSManifests(t *testing.T, isHub bool, cv []addonapiv1beta1.CustomizedVariable) []runtime.Object {
@@ -126,7 +127,7 @@ func TestRightSizing_HubCluster_BothEnabled(t *testing.T) {
 		require.True(t, found, "rbac-query-proxy-datasource must exist in analytics namespace")
 	})
 
-	t.Run("creates all 4 right-sizing dashboards", func(t *testing.T) {
+	t.Run("creates all 5 right-sizing dashboards", func(t *testing.T) {
 		dashNames := dashboardNames(r.dashboards)
 		for _, expected := range allRSDashboardIDs {
 			assert.Contains(t, dashNames, expected, "dashboard %q should be rendered", expected)
@@ -161,10 +162,14 @@ func TestRightSizing_HubCluster_BothEnabled(t *testing.T) {
 			assert.Greater(t, len(raw), 100, "dashboard %q spec should be non-trivial", db.Name)
 
 			specStr := string(raw)
-			if db.Name == namespaceDashboardID {
+			switch db.Name {
+			case namespaceDashboardID:
 				assert.Contains(t, specStr, "acm_rs:cluster:cpu_recommendation")
 				assert.Contains(t, specStr, "acm_rs:namespace:cpu_usage")
-			} else {
+			case workloadDashboardID:
+				assert.Contains(t, specStr, "acm_rs:workload:cpu_recommendation")
+				assert.Contains(t, specStr, "acm_rs:container:memory_recommendation")
+			default:
 				assert.Contains(t, specStr, "acm_rs_vm:namespace:cpu_request")
 				assert.Contains(t, specStr, "acm_rs_vm:namespace:memory_request")
 			}
@@ -182,9 +187,10 @@ func TestRightSizing_HubCluster_NamespaceOnly(t *testing.T) {
 	objects := renderRSManifests(t, true, cv)
 	r := classify(objects)
 
-	t.Run("creates namespace RS dashboard only", func(t *testing.T) {
+	t.Run("creates namespace RS dashboards only", func(t *testing.T) {
 		dashNames := dashboardNames(r.dashboards)
 		assert.Contains(t, dashNames, namespaceDashboardID)
+		assert.Contains(t, dashNames, workloadDashboardID)
 		for _, vmID := range vmDashboardIDs {
 			assert.NotContains(t, dashNames, vmID, "VM dashboard %q should not be rendered", vmID)
 		}
@@ -209,6 +215,7 @@ func TestRightSizing_HubCluster_VirtualizationOnly(t *testing.T) {
 	t.Run("creates 3 VM RS dashboards, no namespace dashboard", func(t *testing.T) {
 		dashNames := dashboardNames(r.dashboards)
 		assert.NotContains(t, dashNames, namespaceDashboardID)
+		assert.NotContains(t, dashNames, workloadDashboardID)
 		for _, vmID := range vmDashboardIDs {
 			assert.Contains(t, dashNames, vmID, "VM dashboard %q should be rendered", vmID)
 		}
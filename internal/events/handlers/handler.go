@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/events/manifests"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BuildOptions returns the options of the events collector of the cluster.
+// The TLS secret of the endpoint is looked up in the namespace of the cluster
+// first, then in the install namespace of the addon.
+func BuildOptions(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, events addon.EventsOptions) (manifests.Options, error) {
+	opts := manifests.Options{
+		ClusterName: cluster.Name,
+		ClusterID:   common.GetManagedClusterID(cluster),
+		Events:      events,
+	}
+
+	if events.Secret == "" {
+		return opts, nil
+	}
+
+	secrets, err := common.GetSecrets(ctx, k8s, addoncfg.InstallNamespace, mcAddon.Namespace, []string{events.Secret})
+	if err != nil {
+		return opts, err
+	}
+	opts.Secret = &secrets[0]
+
+	return opts, nil
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/events/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/events/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	_ = otelv1beta1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1alpha1.AddToScheme(scheme.Scheme)
+)
+
+func fakeGetValues(k8s client.Client, events addon.EventsOptions, installOperator bool, channel string) addonfactory.GetValuesFunc {
+	return func(
+		cluster *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, cluster, mcAddon, events)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := manifests.BuildValues(opts)
+		if err != nil {
+			return nil, err
+		}
+		values.InstallOperator = installOperator
+		values.SubscriptionChannel = channel
+
+		return addonfactory.JsonStructToValues(values)
+	}
+}
+
+func Test_Events_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedCluster.Labels = map[string]string{addoncfg.ManagedClusterLabelClusterID: "1234"}
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	// The secret is looked up in the install namespace when it is not found
+	// in the namespace of the cluster.
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "events-tls",
+			Namespace: addoncfg.InstallNamespace,
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("data"),
+			"ca.crt":  []byte("data"),
+			"tls.key": []byte("data"),
+		},
+	}
+	fakeKubeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(tlsSecret).
+		Build()
+
+	for _, tc := range []struct {
+		name            string
+		events          addon.EventsOptions
+		installOperator bool
+		channel         string
+		objects         int
+		exporter        string
+		wantChannel     string
+	}{
+		{
+			name: "otlp with tls",
+			events: addon.EventsOptions{
+				CollectionEnabled: true,
+				Endpoint:          "https://otlp.example.com:4317",
+				Exporter:          addon.EventsExporterOTLP,
+				Secret:            "events-tls",
+			},
+			installOperator: true,
+			objects:         9,
+			exporter:        "otlp",
+			wantChannel:     "stable",
+		},
+		{
+			name: "otlp with the operator channel set",
+			events: addon.EventsOptions{
+				CollectionEnabled: true,
+				Endpoint:          "https://otlp.example.com:4317",
+				Exporter:          addon.EventsExporterOTLP,
+			},
+			installOperator: true,
+			channel:         "stable-0.140",
+			objects:         8,
+			exporter:        "otlp",
+			wantChannel:     "stable-0.140",
+		},
+		{
+			name: "loki with the operator installed by the tracing chart",
+			events: addon.EventsOptions{
+				CollectionEnabled: true,
+				Endpoint:          "https://loki.example.com/otlp",
+				Exporter:          addon.EventsExporterLoki,
+			},
+			objects:  4,
+			exporter: "otlphttp",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			eventsAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.EventsChartDir).
+				WithGetValuesFuncs(fakeGetValues(fakeKubeClient, tc.events, tc.installOperator, tc.channel)).
+				WithAgentRegistrationOption(&agent.RegistrationOption{}).
+				WithScheme(scheme.Scheme).
+				BuildHelmAgentAddon()
+			require.NoError(t, err)
+
+			objects, err := eventsAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+			require.NoError(t, err)
+			require.Len(t, objects, tc.objects)
+
+			foundCollector := false
+			for _, obj := range objects {
+				switch obj := obj.(type) {
+				case *otelv1beta1.OpenTelemetryCollector:
+					// Check name and namespace to make sure that if we change the helm
+					// manifests that we don't break the addon probes
+					require.Equal(t, addoncfg.SpokeEventsCollectorName, obj.Name)
+					require.Equal(t, addoncfg.SpokeOTELColNamespace, obj.Namespace)
+					require.Equal(t, otelv1beta1.ModeDeployment, obj.Spec.Mode)
+					require.Contains(t, obj.Spec.Config.Receivers.Object, "k8s_events")
+					require.Contains(t, obj.Spec.Config.Exporters.Object, tc.exporter)
+					require.Equal(t, []string{tc.exporter}, obj.Spec.Config.Service.Pipelines["logs"].Exporters)
+
+					attributes := obj.Spec.Config.Processors.Object["resource"].(map[string]any)["attributes"].([]any)
+					require.Contains(t, attributes, map[string]any{"key": "k8s.cluster.name", "value": "cluster-1", "action": "upsert"})
+					require.Contains(t, attributes, map[string]any{"key": "k8s.cluster.uid", "value": "1234", "action": "upsert"})
+					require.Equal(t, "mcoa-events", obj.Spec.ServiceAccount)
+					foundCollector = true
+				case *rbacv1.ClusterRoleBinding:
+					require.Equal(t, "mcoa-events", obj.Subjects[0].Name)
+					require.Equal(t, addoncfg.SpokeOTELColNamespace, obj.Subjects[0].Namespace)
+				case *corev1.Secret:
+					require.Equal(t, tlsSecret.Data, obj.Data)
+				case *operatorsv1alpha1.Subscription:
+					require.Equal(t, tc.wantChannel, obj.Spec.Channel)
+				}
+			}
+			require.True(t, foundCollector)
+		})
+	}
+}
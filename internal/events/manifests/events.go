@@ -0,0 +1,115 @@
+package manifests
+
+import (
+	"path"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// serviceAccountName is bound by the chart to the ClusterRole allowing the
+	// k8s_events receiver to watch the events of every namespace.
+	serviceAccountName = "mcoa-events"
+	tlsSecretName      = "mcoa-events-tls"
+	tlsMountPath       = "/etc/mcoa-events/tls"
+
+	receiverName = "k8s_events"
+	// The resource attributes holding the name and ID of the cluster, the
+	// counterparts of the cluster and clusterID labels set on the metrics.
+	clusterNameAttribute = "k8s.cluster.name"
+	clusterIDAttribute   = "k8s.cluster.uid"
+)
+
+// buildOTELColSpec returns a single replica collector watching the events of
+// the cluster and exporting them as logs, tagged with the name and ID of the
+// cluster.
+func buildOTELColSpec(opts Options) *otelv1beta1.OpenTelemetryCollectorSpec {
+	exporterName, exporter := buildExporter(opts)
+
+	spec := &otelv1beta1.OpenTelemetryCollectorSpec{
+		Mode: otelv1beta1.ModeDeployment,
+		OpenTelemetryCommonFields: otelv1beta1.OpenTelemetryCommonFields{
+			ManagementState: otelv1beta1.ManagementStateManaged,
+			// The receiver does not shard the events, more replicas would
+			// export them several times.
+			Replicas:       ptr.To[int32](1),
+			ServiceAccount: serviceAccountName,
+		},
+		Config: otelv1beta1.Config{
+			Receivers: otelv1beta1.AnyConfig{
+				Object: map[string]any{
+					receiverName: map[string]any{},
+				},
+			},
+			Processors: &otelv1beta1.AnyConfig{
+				Object: map[string]any{
+					"resource": map[string]any{
+						"attributes": []any{
+							map[string]any{"key": clusterNameAttribute, "value": opts.ClusterName, "action": "upsert"},
+							map[string]any{"key": clusterIDAttribute, "value": opts.ClusterID, "action": "upsert"},
+						},
+					},
+					"batch": map[string]any{},
+				},
+			},
+			Exporters: otelv1beta1.AnyConfig{
+				Object: map[string]any{
+					exporterName: exporter,
+				},
+			},
+			Service: otelv1beta1.Service{
+				Pipelines: map[string]*otelv1beta1.Pipeline{
+					"logs": {
+						Receivers:  []string{receiverName},
+						Processors: []string{"resource", "batch"},
+						Exporters:  []string{exporterName},
+					},
+				},
+			},
+		},
+	}
+
+	if opts.Secret != nil {
+		spec.Volumes = []corev1.Volume{
+			{
+				Name: tlsSecretName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName},
+				},
+			},
+		}
+		spec.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      tlsSecretName,
+				MountPath: tlsMountPath,
+				ReadOnly:  true,
+			},
+		}
+	}
+
+	return spec
+}
+
+// buildExporter returns the name and configuration of the exporter sending
+// the events to the configured endpoint. Loki is reached on its OTLP HTTP
+// endpoint.
+func buildExporter(opts Options) (string, map[string]any) {
+	exporter := map[string]any{
+		"endpoint": opts.Events.Endpoint,
+	}
+	if opts.Secret != nil {
+		exporter["tls"] = map[string]any{
+			"cert_file": path.Join(tlsMountPath, corev1.TLSCertKey),
+			"key_file":  path.Join(tlsMountPath, corev1.TLSPrivateKeyKey),
+			"ca_file":   path.Join(tlsMountPath, "ca.crt"),
+		}
+	}
+
+	if opts.Events.Exporter == addon.EventsExporterLoki {
+		return "otlphttp", exporter
+	}
+	return "otlp", exporter
+}
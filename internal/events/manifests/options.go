@@ -0,0 +1,14 @@
+package manifests
+
+import (
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type Options struct {
+	ClusterName string
+	ClusterID   string
+	// Secret holds the TLS client certificate and CA of the events endpoint.
+	Secret *corev1.Secret
+	Events addon.EventsOptions
+}
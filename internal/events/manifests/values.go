@@ -0,0 +1,48 @@
+package manifests
+
+import (
+	"encoding/json"
+)
+
+type EventsValues struct {
+	Enabled bool `json:"enabled"`
+	// InstallOperator is false when the OpenTelemetry operator is already
+	// installed by the tracing chart.
+	InstallOperator bool `json:"installOperator"`
+	// SubscriptionChannel overrides the channel of the operator Subscription
+	// set in the values of the chart.
+	SubscriptionChannel string       `json:"subscriptionChannel,omitempty"`
+	OTELColSpec         string       `json:"otelColSpec"`
+	Secret              *SecretValue `json:"secret,omitempty"`
+}
+
+type SecretValue struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+func BuildValues(opts Options) (EventsValues, error) {
+	values := EventsValues{
+		Enabled:         true,
+		InstallOperator: true,
+	}
+
+	if opts.Secret != nil {
+		dataJSON, err := json.Marshal(opts.Secret.Data)
+		if err != nil {
+			return values, err
+		}
+		values.Secret = &SecretValue{
+			Name: tlsSecretName,
+			Data: string(dataJSON),
+		}
+	}
+
+	b, err := json.Marshal(buildOTELColSpec(opts))
+	if err != nil {
+		return values, err
+	}
+	values.OTELColSpec = string(b)
+
+	return values, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/logging/manifests"
+	gwhandlers "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/handlers"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,7 +30,7 @@ var (
 	errMissingField          = errors.New("missing field needed by output type")
 )
 
-func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, platform, userWorkloads addon.LogsOptions, isHub bool) (manifests.Options, error) {
+func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, platform, userWorkloads addon.LogsOptions, gateway addon.OTelGatewayOptions, isHub, federate bool) (manifests.Options, error) {
 	opts := manifests.Options{
 		Platform:      platform,
 		UserWorkloads: userWorkloads,
@@ -54,28 +55,45 @@ func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1bet
 	}
 	opts.ClusterLogForwarder = clf
 
-	secretNames := []string{}
-	configmapNames := []string{}
-	for _, output := range clf.Spec.Outputs {
-		extractedSecretsNames, extracedConfigmapNames, err := getOutputResourcesNames(output)
+	// The outputs of the template are replaced by the gateway, which also
+	// forwards the logs to the LokiStack of the hub when it is enabled, so
+	// only the secrets of the gateway are needed.
+	switch {
+	case gateway.Enabled:
+		spoke, secrets, err := gwhandlers.BuildSpoke(ctx, k8s, mcAddon, gateway)
 		if err != nil {
 			return opts, err
 		}
-		secretNames = append(secretNames, extractedSecretsNames...)
-		configmapNames = append(configmapNames, extracedConfigmapNames...)
-	}
+		opts.Gateway = spoke
+		opts.Secrets = secrets
+	default:
+		secretNames := []string{}
+		configmapNames := []string{}
+		for _, output := range clf.Spec.Outputs {
+			extractedSecretsNames, extracedConfigmapNames, err := getOutputResourcesNames(output)
+			if err != nil {
+				return opts, err
+			}
+			secretNames = append(secretNames, extractedSecretsNames...)
+			configmapNames = append(configmapNames, extracedConfigmapNames...)
+		}
 
-	secrets, err := common.GetSecrets(ctx, k8s, clf.Namespace, mcAddon.Namespace, secretNames)
-	if err != nil {
-		return opts, err
+		secrets, err := common.GetSecrets(ctx, k8s, clf.Namespace, mcAddon.Namespace, secretNames)
+		if err != nil {
+			return opts, err
+		}
+		opts.Secrets = secrets
+
+		configMaps, err := common.GetConfigMaps(ctx, k8s, clf.Namespace, mcAddon.Namespace, configmapNames)
+		if err != nil {
+			return opts, err
+		}
+		opts.ConfigMaps = configMaps
 	}
-	opts.Secrets = secrets
 
-	configMaps, err := common.GetConfigMaps(ctx, k8s, clf.Namespace, mcAddon.Namespace, configmapNames)
-	if err != nil {
-		return opts, err
+	if federate {
+		opts.ScrapeConfig = manifests.GenerateScrapeConfig()
 	}
-	opts.ConfigMaps = configMaps
 
 	// Currently we are only able to access the cluster-logging subscription in the hub
 	// since we don't have k8s clients for the spokes
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
 )
 
 var (
@@ -112,5 +113,9 @@ func buildClusterLogForwarderSpec(opts Options) (*loggingv1.ClusterLogForwarderS
 		return nil, errUserWorkloadLogsNotDefined
 	}
 
+	if opts.Gateway != nil {
+		gwmanifests.ForwardToGateway(&clf.Spec, *opts.Gateway)
+	}
+
 	return &clf.Spec, nil
 }
@@ -3,7 +3,9 @@ package manifests
 import (
 	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -15,4 +17,9 @@ type Options struct {
 	UserWorkloads              addon.LogsOptions
 	SubscriptionChannel        string
 	ClusterLoggingSubscription *operatorv1alpha1.Subscription
+	// Gateway is set when the logs are forwarded to the gateway on the hub.
+	Gateway *gwmanifests.Spoke
+	// ScrapeConfig federates the collector metrics through the platform
+	// PrometheusAgent when the platform metrics are collected.
+	ScrapeConfig *cooprometheusv1alpha1.ScrapeConfig
 }
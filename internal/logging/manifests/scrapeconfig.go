@@ -0,0 +1,70 @@
+package manifests
+
+import (
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ScrapeConfigName is the name of the logging collector ScrapeConfig
+	ScrapeConfigName = "platform-metrics-logging-collector"
+
+	// ScrapeConfigJobName is the job name of the logging collector scrape job
+	ScrapeConfigJobName = "logging-collector"
+)
+
+// CollectorMetrics are the metrics of the log collectors shown by the logging
+// collector dashboard.
+var CollectorMetrics = []string{
+	"vector_buffer_events",
+	"vector_component_discarded_events_total",
+	"vector_component_errors_total",
+	"vector_component_received_events_total",
+	"vector_component_sent_events_total",
+}
+
+// GenerateScrapeConfig generates the ScrapeConfig federating the metrics of
+// the log collectors from the in-cluster monitoring stack, which scrapes them
+// through the ServiceMonitor of the ClusterLogForwarder, through the platform
+// PrometheusAgent.
+func GenerateScrapeConfig() *cooprometheusv1alpha1.ScrapeConfig {
+	matchParams := make([]string, 0, len(CollectorMetrics))
+	for _, metric := range CollectorMetrics {
+		matchParams = append(matchParams, "{__name__=\""+metric+"\"}")
+	}
+
+	return &cooprometheusv1alpha1.ScrapeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ScrapeConfig",
+			APIVersion: "monitoring.rhobs/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ScrapeConfigName,
+			Labels: map[string]string{
+				addoncfg.ComponentK8sLabelKey: mconfig.PlatformPrometheusMatchLabels[addoncfg.ComponentK8sLabelKey],
+				addoncfg.PartOfK8sLabelKey:    addoncfg.Name,
+				addoncfg.ManagedByK8sLabelKey: addoncfg.Name,
+			},
+		},
+		Spec: cooprometheusv1alpha1.ScrapeConfigSpec{
+			JobName:         ptr.To(ScrapeConfigJobName),
+			MetricsPath:     ptr.To("/federate"),
+			ScrapeClassName: ptr.To(mconfig.ScrapeClassCfgName),
+			Scheme:          ptr.To(cooprometheusv1.Scheme("HTTPS")),
+			StaticConfigs: []cooprometheusv1alpha1.StaticConfig{
+				{
+					Targets: []cooprometheusv1alpha1.Target{
+						cooprometheusv1alpha1.Target(mconfig.ScrapeClassPlatformTarget),
+					},
+				},
+			},
+			Params: map[string][]string{
+				"match[]": matchParams,
+			},
+		},
+	}
+}
@@ -2,23 +2,33 @@ package manifests
 
 import (
 	"encoding/json"
+	"maps"
+
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
 )
 
 type LoggingValues struct {
-	Enabled                 bool            `json:"enabled"`
-	InstallCLO              bool            `json:"installCLO"`
-	CLFAnnotations          string          `json:"clfAnnotations"`
-	CLFSpec                 string          `json:"clfSpec"`
-	ServiceAccountName      string          `json:"serviceAccountName"`
-	OpenshiftLoggingChannel string          `json:"openshiftLoggingChannel"`
-	Secrets                 []ResourceValue `json:"secrets"`
-	ConfigMaps              []ResourceValue `json:"configmaps"`
+	Enabled                 bool               `json:"enabled"`
+	InstallCLO              bool               `json:"installCLO"`
+	CLFAnnotations          string             `json:"clfAnnotations"`
+	CLFSpec                 string             `json:"clfSpec"`
+	ServiceAccountName      string             `json:"serviceAccountName"`
+	OpenshiftLoggingChannel string             `json:"openshiftLoggingChannel"`
+	Secrets                 []ResourceValue    `json:"secrets"`
+	ConfigMaps              []ResourceValue    `json:"configmaps"`
+	ScrapeConfig            *ScrapeConfigValue `json:"scrapeConfig,omitempty"`
 }
 type ResourceValue struct {
 	Name string `json:"name"`
 	Data string `json:"data"`
 }
 
+type ScrapeConfigValue struct {
+	Name   string            `json:"name"`
+	Data   string            `json:"data"`
+	Labels map[string]string `json:"labels"`
+}
+
 func BuildValues(opts Options) (*LoggingValues, error) {
 	values := &LoggingValues{
 		Enabled: true,
@@ -47,6 +57,13 @@ func BuildValues(opts Options) (*LoggingValues, error) {
 	// CLO uses annotations to signal feature flags so users must be able to set
 	// them
 	clfAnnotations := opts.ClusterLogForwarder.GetAnnotations()
+	if opts.Gateway != nil {
+		clfAnnotations = maps.Clone(clfAnnotations)
+		if clfAnnotations == nil {
+			clfAnnotations = map[string]string{}
+		}
+		clfAnnotations[gwmanifests.OTLPOutputAnnotation] = "enabled"
+	}
 	clfAnnotationsJson, err := json.Marshal(clfAnnotations)
 	if err != nil {
 		return nil, err
@@ -65,6 +82,18 @@ func BuildValues(opts Options) (*LoggingValues, error) {
 	values.CLFSpec = string(b)
 	values.ServiceAccountName = opts.ClusterLogForwarder.Spec.ServiceAccount.Name
 
+	if opts.ScrapeConfig != nil {
+		scJSON, err := json.Marshal(opts.ScrapeConfig.Spec)
+		if err != nil {
+			return nil, err
+		}
+		values.ScrapeConfig = &ScrapeConfigValue{
+			Name:   opts.ScrapeConfig.Name,
+			Data:   string(scJSON),
+			Labels: opts.ScrapeConfig.Labels,
+		}
+	}
+
 	return values, nil
 }
 
@@ -3,6 +3,7 @@ package manifests
 import (
 	"testing"
 
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
 	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,3 +140,23 @@ func TestShouldInstallCLO(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildValues_ScrapeConfig(t *testing.T) {
+	opts := Options{
+		ClusterLogForwarder: &loggingv1.ClusterLogForwarder{},
+		ScrapeConfig:        GenerateScrapeConfig(),
+	}
+
+	values, err := BuildValues(opts)
+	require.NoError(t, err)
+	require.NotNil(t, values.ScrapeConfig)
+	assert.Equal(t, ScrapeConfigName, values.ScrapeConfig.Name)
+	for _, metric := range CollectorMetrics {
+		assert.Contains(t, values.ScrapeConfig.Data, metric)
+	}
+
+	opts.ScrapeConfig = nil
+	values, err = BuildValues(opts)
+	require.NoError(t, err)
+	assert.Nil(t, values.ScrapeConfig)
+}
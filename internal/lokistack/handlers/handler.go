@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var (
+	errMissingLokiStackConfigRef  = errors.New("missing LokiStack ConfigMap reference on addon installation")
+	errMultipleLokiStackConfigRef = errors.New("multiple LokiStack ConfigMap references on addon installation")
+	errMissingLokiStackField      = errors.New("missing field in LokiStack ConfigMap")
+)
+
+// GetLokiStackConfig returns the configuration of the hub LokiStack from the
+// ConfigMap labeled with the LokiStack config label among the addon configs.
+func GetLokiStackConfig(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn) (manifests.Config, error) {
+	var cms []*corev1.ConfigMap
+	for _, key := range common.GetObjectKeys(mcAddon.Status.ConfigReferences, "", addoncfg.ConfigMapsResource) {
+		cm := &corev1.ConfigMap{}
+		if err := k8s.Get(ctx, key, cm, &client.GetOptions{}); err != nil {
+			return manifests.Config{}, err
+		}
+		if _, ok := cm.Labels[addoncfg.LokiStackConfigLabelKey]; !ok {
+			continue
+		}
+		cms = append(cms, cm)
+	}
+	switch {
+	case len(cms) == 0:
+		return manifests.Config{}, errMissingLokiStackConfigRef
+	case len(cms) > 1:
+		return manifests.Config{}, errMultipleLokiStackConfigRef
+	}
+
+	cm := cms[0]
+	config := manifests.Config{
+		Size:                cm.Data["size"],
+		StorageSecret:       cm.Data["storageSecret"],
+		StorageType:         cm.Data["storageType"],
+		StorageClassName:    cm.Data["storageClassName"],
+		SchemaEffectiveDate: cm.Data["schemaEffectiveDate"],
+	}
+	for _, field := range []string{"storageSecret", "storageClassName"} {
+		if cm.Data[field] == "" {
+			return config, fmt.Errorf("%w %s/%s: %s", errMissingLokiStackField, cm.Namespace, cm.Name, field)
+		}
+	}
+	if config.Size == "" {
+		config.Size = manifests.DefaultSize
+	}
+	if config.StorageType == "" {
+		config.StorageType = manifests.DefaultStorageType
+	}
+	if config.SchemaEffectiveDate == "" {
+		config.SchemaEffectiveDate = manifests.DefaultSchemaEffectiveDate
+	}
+
+	return config, nil
+}
+
+// BuildOptions returns the options of the LokiStack of the hub, with a tenant
+// per managed cluster. The client certificate the Perses datasources of the
+// hub read the logs with is issued along.
+func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, subscriptionChannel string) (manifests.Options, error) {
+	config, err := GetLokiStackConfig(ctx, k8s, mcAddon)
+	if err != nil {
+		return manifests.Options{}, err
+	}
+
+	tenants, err := ListTenants(ctx, k8s)
+	if err != nil {
+		return manifests.Options{}, err
+	}
+
+	ca := &corev1.Secret{}
+	if err := k8s.Get(ctx, types.NamespacedName{Name: addoncfg.ClientCASecretName, Namespace: addoncfg.InstallNamespace}, ca); err != nil {
+		return manifests.Options{}, fmt.Errorf("failed to get secret %s in namespace %s: %w", addoncfg.ClientCASecretName, addoncfg.InstallNamespace, err)
+	}
+
+	if _, err := common.EnsureClientCert(ctx, k8s, mcAddon, manifests.ReaderCertSecretName, []string{manifests.ReaderName}); err != nil {
+		return manifests.Options{}, err
+	}
+
+	return manifests.Options{
+		Config:              config,
+		SubscriptionChannel: subscriptionChannel,
+		Tenants:             tenants,
+		ClientCA:            string(ca.Data[corev1.TLSCertKey]),
+	}, nil
+}
+
+// ListTenants returns the tenants of the LokiStack of the hub: the sorted
+// names of the managed clusters the addon is installed on.
+func ListTenants(ctx context.Context, k8s client.Client) ([]string, error) {
+	addons := &addonapiv1beta1.ManagedClusterAddOnList{}
+	if err := k8s.List(ctx, addons); err != nil {
+		return nil, fmt.Errorf("failed to list the managed cluster addons: %w", err)
+	}
+
+	var tenants []string
+	for _, mcAddon := range addons.Items {
+		if mcAddon.Name == addoncfg.Name {
+			tenants = append(tenants, mcAddon.Namespace)
+		}
+	}
+	slices.Sort(tenants)
+
+	return tenants, nil
+}
+
+// TenantPredicate filters the addons added to or removed from a managed
+// cluster, which adds or removes a tenant of the LokiStack of the hub.
+func TenantPredicate() predicate.Funcs {
+	isAddon := func(obj client.Object) bool {
+		return obj.GetName() == addoncfg.Name
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isAddon(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isAddon(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
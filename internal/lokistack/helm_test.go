@@ -0,0 +1,182 @@
+package lokistack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift/library-go/pkg/crypto"
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/lokistack/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	_ = operatorsv1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1alpha1.AddToScheme(scheme.Scheme)
+	_ = manifests.AddToScheme(scheme.Scheme)
+	_ = addonapiv1beta1.Install(scheme.Scheme)
+	_ = clusterv1.Install(scheme.Scheme)
+)
+
+func fakeGetValues(k8s client.Client) addonfactory.GetValuesFunc {
+	return func(
+		_ *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, mcAddon, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return addonfactory.JsonStructToValues(manifests.BuildValues(opts))
+	}
+}
+
+func Test_LokiStack_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("local-cluster")
+	managedClusterAddOn := addontesting.NewAddon("test", "local-cluster")
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lokistack",
+			Namespace: "open-cluster-management-observability",
+			Labels: map[string]string{
+				addoncfg.LokiStackConfigLabelKey: "",
+			},
+		},
+		Data: map[string]string{
+			"storageSecret":    "logging-loki-s3",
+			"storageClassName": "gp3-csi",
+		},
+	}
+
+	ca, err := crypto.MakeSelfSignedCAConfig("observability-client-ca-certificate", time.Hour*24*365)
+	require.NoError(t, err)
+	caPEM, caKeyPEM, err := ca.GetPEMBytes()
+	require.NoError(t, err)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      addoncfg.ClientCASecretName,
+			Namespace: addoncfg.InstallNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       caPEM,
+			corev1.TLSPrivateKeyKey: caKeyPEM,
+		},
+	}
+
+	fakeKubeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			config,
+			caSecret,
+			managedCluster,
+			addontesting.NewAddon(addoncfg.Name, "local-cluster"),
+			addontesting.NewAddon(addoncfg.Name, "spoke-1"),
+		).
+		Build()
+
+	managedClusterAddOn.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Resource: addoncfg.ConfigMapsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{
+					Namespace: config.Namespace,
+					Name:      config.Name,
+				},
+			},
+		},
+	}
+
+	lokiStackAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.LokiStackChartDir).
+		WithGetValuesFuncs(fakeGetValues(fakeKubeClient)).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := lokiStackAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+	// The operator namespace, operator group and subscription, the LokiStack
+	// and the ConfigMap of the client CA.
+	require.Len(t, objects, 5)
+
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *unstructured.Unstructured:
+			require.Equal(t, manifests.LokiStackGVK, obj.GroupVersionKind())
+			size, _, _ := unstructured.NestedString(obj.Object, "spec", "size")
+			require.Equal(t, manifests.DefaultSize, size)
+			storageType, _, _ := unstructured.NestedString(obj.Object, "spec", "storage", "secret", "type")
+			require.Equal(t, manifests.DefaultStorageType, storageType)
+			storageSecret, _, _ := unstructured.NestedString(obj.Object, "spec", "storage", "secret", "name")
+			require.Equal(t, "logging-loki-s3", storageSecret)
+			mode, _, _ := unstructured.NestedString(obj.Object, "spec", "tenants", "mode")
+			require.Equal(t, "static", mode)
+
+			// Each cluster of the addon has a tenant authenticated with the
+			// client CA.
+			authentication, _, _ := unstructured.NestedSlice(obj.Object, "spec", "tenants", "authentication")
+			require.Len(t, authentication, 2)
+			require.Equal(t, map[string]any{
+				"tenantName": "spoke-1",
+				"tenantId":   "spoke-1",
+				"mTLS": map[string]any{
+					"ca": map[string]any{
+						"caName": manifests.ClientCAConfigMapName,
+						"caKey":  "ca.crt",
+					},
+				},
+			}, authentication[1])
+
+			roles, _, _ := unstructured.NestedSlice(obj.Object, "spec", "tenants", "authorization", "roles")
+			require.Len(t, roles, 2)
+			for _, role := range roles {
+				require.Equal(t, []any{"local-cluster", "spoke-1"}, role.(map[string]any)["tenants"])
+			}
+			bindings, _, _ := unstructured.NestedSlice(obj.Object, "spec", "tenants", "authorization", "roleBindings")
+			require.Equal(t, []any{
+				map[string]any{
+					"name":     manifests.WriterName,
+					"subjects": []any{map[string]any{"kind": "group", "name": manifests.WriterName}},
+					"roles":    []any{manifests.WriterName},
+				},
+				map[string]any{
+					"name":     manifests.ReaderName,
+					"subjects": []any{map[string]any{"kind": "group", "name": manifests.ReaderName}},
+					"roles":    []any{manifests.ReaderName},
+				},
+			}, bindings)
+		case *operatorsv1alpha1.Subscription:
+			require.Equal(t, manifests.DefaultSubscriptionChannel, obj.Spec.Channel)
+		case *corev1.ConfigMap:
+			require.Equal(t, manifests.ClientCAConfigMapName, obj.Name)
+			require.Equal(t, string(caPEM), obj.Data["ca.crt"])
+		}
+	}
+
+	// The Perses datasources of the hub read with a certificate of the
+	// reader group.
+	reader := &corev1.Secret{}
+	require.NoError(t, fakeKubeClient.Get(t.Context(), common.ClientCertKey("local-cluster", manifests.ReaderCertSecretName), reader))
+	require.NotEmpty(t, reader.Data[corev1.TLSCertKey])
+}
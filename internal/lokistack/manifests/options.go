@@ -0,0 +1,23 @@
+package manifests
+
+// Config is the configuration of the LokiStack provisioned on the hub, read
+// from the ConfigMap referenced by the addon configs.
+type Config struct {
+	Size string
+	// StorageSecret is the name of the secret, in the install namespace of the
+	// hub, holding the object storage credentials of the LokiStack.
+	StorageSecret       string
+	StorageType         string
+	StorageClassName    string
+	SchemaEffectiveDate string
+}
+
+type Options struct {
+	Config              Config
+	SubscriptionChannel string
+	// Tenants are the managed clusters of the addon, each storing its logs
+	// in the tenant of the same name.
+	Tenants []string
+	// ClientCA is the certificate of the client CA of the hub.
+	ClientCA string
+}
@@ -0,0 +1,26 @@
+package manifests
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// LokiStackGVK is the kind of the LokiStack of the Loki operator.
+var LokiStackGVK = schema.GroupVersionKind{
+	Group:   "loki.grafana.com",
+	Version: "v1",
+	Kind:    "LokiStack",
+}
+
+// AddToScheme registers the LokiStack as an unstructured object so that the
+// addon scheme can decode the rendered manifests without depending on the
+// Loki operator module.
+func AddToScheme(scheme *runtime.Scheme) error {
+	gv := LokiStackGVK.GroupVersion()
+	scheme.AddKnownTypeWithName(LokiStackGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gv.WithKind(LokiStackGVK.Kind+"List"), &unstructured.UnstructuredList{})
+	metav1.AddToGroupVersion(scheme, gv)
+	return nil
+}
@@ -0,0 +1,32 @@
+package manifests
+
+type LokiStackValues struct {
+	Enabled             bool     `json:"enabled"`
+	Size                string   `json:"size"`
+	StorageSecret       string   `json:"storageSecret"`
+	StorageType         string   `json:"storageType"`
+	StorageClassName    string   `json:"storageClassName"`
+	SchemaEffectiveDate string   `json:"schemaEffectiveDate"`
+	SubscriptionChannel string   `json:"subscriptionChannel"`
+	Tenants             []string `json:"tenants"`
+	ClientCA            string   `json:"clientCA"`
+}
+
+func BuildValues(opts Options) *LokiStackValues {
+	channel := opts.SubscriptionChannel
+	if channel == "" {
+		channel = DefaultSubscriptionChannel
+	}
+
+	return &LokiStackValues{
+		Enabled:             true,
+		Size:                opts.Config.Size,
+		StorageSecret:       opts.Config.StorageSecret,
+		StorageType:         opts.Config.StorageType,
+		StorageClassName:    opts.Config.StorageClassName,
+		SchemaEffectiveDate: opts.Config.SchemaEffectiveDate,
+		SubscriptionChannel: channel,
+		Tenants:             opts.Tenants,
+		ClientCA:            opts.ClientCA,
+	}
+}
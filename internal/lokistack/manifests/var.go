@@ -0,0 +1,33 @@
+package manifests
+
+const (
+	// LokiStackName is the name of the LokiStack provisioned on the hub.
+	LokiStackName = "mcoa-logging-loki"
+
+	// GatewayEndpoint is the service of the LokiStack gateway, to which the
+	// OpenTelemetry gateway of the hub forwards the logs of each tenant.
+	GatewayEndpoint = "https://" + LokiStackName + "-gateway-http.open-cluster-management-observability.svc:8080"
+
+	// ClientCAConfigMapName holds the client CA of the hub, which issues the
+	// certificates the tenants of the LokiStack are authenticated with.
+	ClientCAConfigMapName = "mcoa-logs-client-ca"
+
+	// WriterName is the group, role and binding of the LokiStack allowing to
+	// push logs to all the tenants. Only the client certificate of the
+	// OpenTelemetry gateway of the hub, WriterCertSecretName, has the group:
+	// the managed clusters don't write to the LokiStack directly.
+	WriterName           = "mcoa-logs-writer"
+	WriterCertSecretName = "mcoa-logs-writer-client-cert"
+	// ReaderName is the group, role and binding of the LokiStack allowing to
+	// query the logs of all the tenants. The Perses datasources of the hub
+	// console read with the client certificate ReaderCertSecretName.
+	ReaderName           = "mcoa-logs-reader"
+	ReaderCertSecretName = "mcoa-logs-reader-client-cert"
+
+	// DefaultSubscriptionChannel follows the version of the logging operator
+	// installed on the managed clusters.
+	DefaultSubscriptionChannel = "stable-6.3"
+	DefaultSize                = "1x.extra-small"
+	DefaultStorageType         = "s3"
+	DefaultSchemaEffectiveDate = "2024-10-01"
+)
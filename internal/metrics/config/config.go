@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -69,6 +70,8 @@ const (
 	ThanosOperatorAppName = "thanos-operator"
 	// TODO: replace with image from ACM image overrides ConfigMap once available.
 	ThanosOperatorImage = "quay.io/thanos/thanos-operator:main-2026-04-09-a4dc024"
+	// TODO: replace with image from ACM image overrides ConfigMap once available.
+	PromLabelProxyImage = "registry.redhat.io/openshift4/ose-prom-label-proxy-rhel9:v4.18"
 
 	AlertmanagerAccessorSecretName = "observability-alertmanager-accessor"
 	AlertmanagerRouterCASecretName = "hub-alertmanager-router-ca"
@@ -80,6 +83,12 @@ const (
 	HubMtlsCAShortName   = "hub-mtls-ca"
 	HubMtlsCertShortName = "hub-mtls-cert"
 
+	// ExemplarStorageFeature and NativeHistogramsFeature are the Prometheus
+	// feature flags enabled when exemplars and native histograms are sent to
+	// the hub.
+	ExemplarStorageFeature  cooprometheusv1.EnableFeature = "exemplar-storage"
+	NativeHistogramsFeature cooprometheusv1.EnableFeature = "native-histograms"
+
 	MTLSCASecretKey      = "ca.crt"
 	MTLSCertSecretKey    = "tls.crt"
 	MTLSCertKeySecretKey = "tls.key"
@@ -105,6 +114,15 @@ var (
 	}
 
 	ErrMissingImageOverride = errors.New("missing image override")
+
+	// NativeHistogramsScrapeProtocols prefer the protobuf format, the only one
+	// exposing native histograms.
+	NativeHistogramsScrapeProtocols = []cooprometheusv1.ScrapeProtocol{
+		cooprometheusv1.PrometheusProto,
+		cooprometheusv1.OpenMetricsText1_0_0,
+		cooprometheusv1.OpenMetricsText0_0_1,
+		cooprometheusv1.PrometheusText0_0_4,
+	}
 )
 
 type ImageOverrides struct {
@@ -116,6 +134,9 @@ type ImageOverrides struct {
 	Prometheus                 string `json:"prometheus"`
 	EndpointMonitoringOperator string `json:"endpoint_monitoring_operator"`
 	ThanosOperator             string `json:"thanos_operator"`
+	PromLabelProxy             string `json:"prom_label_proxy"`
+	GrafanaAlloy               string `json:"grafana_alloy"`
+	Observatorium              string `json:"observatorium"`
 }
 
 func GetImageOverrides(ctx context.Context, c client.Client, registries []addonapiv1beta1.ImageMirror, logger logr.Logger) (ImageOverrides, error) {
@@ -157,6 +178,15 @@ func GetImageOverrides(ctx context.Context, c client.Client, registries []addona
 		if ret.ThanosOperator != "" {
 			ret.ThanosOperator = overrideImage(ret.ThanosOperator, registries, logger)
 		}
+		if ret.PromLabelProxy != "" {
+			ret.PromLabelProxy = overrideImage(ret.PromLabelProxy, registries, logger)
+		}
+		if ret.GrafanaAlloy != "" {
+			ret.GrafanaAlloy = overrideImage(ret.GrafanaAlloy, registries, logger)
+		}
+		if ret.Observatorium != "" {
+			ret.Observatorium = overrideImage(ret.Observatorium, registries, logger)
+		}
 	}
 
 	return ret, nil
@@ -18,6 +18,7 @@ import (
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/metrics/remotewrite"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -57,6 +58,8 @@ func (o *OptionsBuilder) Build(ctx context.Context, mcAddon *addonapiv1beta1.Man
 		NodeExporter:              opts.Platform.Metrics.NodeExporter,
 		PlatformAlertsEnabled:     opts.Platform.Metrics.AlertsEnabled,
 		UserWorkloadAlertsEnabled: opts.UserWorkloads.Metrics.AlertsEnabled,
+		ExemplarsEnabled:          opts.Platform.Metrics.ExemplarsEnabled,
+		NativeHistogramsEnabled:   opts.Platform.Metrics.NativeHistogramsEnabled,
 	}
 
 	if !opts.Platform.Metrics.CollectionEnabled && !opts.UserWorkloads.Metrics.CollectionEnabled {
@@ -107,6 +110,7 @@ func (o *OptionsBuilder) Build(ctx context.Context, mcAddon *addonapiv1beta1.Man
 		if len(ret.Platform.ScrapeConfigs) == 0 {
 			o.Logger.V(2).Info("No scrape configs found for platform metrics")
 		}
+		ret.Platform.ScrapeConfigs = append(ret.Platform.ScrapeConfigs, GenerateHealthScrapeConfig(ret.InstallNamespace, ret.IsHub))
 		ret.Platform.Rules = common.FilterResourcesByLabelSelector[*prometheusv1.PrometheusRule](configResources, config.PlatformPrometheusMatchLabels)
 		if len(ret.Platform.Rules) == 0 {
 			o.Logger.V(2).Info("No rules found for platform metrics")
@@ -118,6 +122,22 @@ func (o *OptionsBuilder) Build(ctx context.Context, mcAddon *addonapiv1beta1.Man
 	isHypershiftCluster := IsHypershiftEnabled(managedCluster) && HasHostedCLusters(ctx, o.Client, o.Logger)
 
 	if isOpenShiftVendor && opts.UserWorkloads.Metrics.CollectionEnabled {
+		// The tenants are written through the observability API of the hub,
+		// which authorizes the cluster from its own client certificate.
+		if opts.HubObsAPI.Enabled {
+			var allTenants []tenants.Tenant
+			if allTenants, err = tenants.GetTenants(ctx, o.Client, o.Logger); err != nil {
+				return ret, err
+			}
+			ret.Tenants = tenants.ForCluster(allTenants, managedCluster)
+			ret.TenantsEndpoint = opts.HubObsAPI.Endpoint
+		}
+		if len(ret.Tenants) > 0 {
+			if _, err = common.EnsureClientCert(ctx, o.Client, mcAddon, tenants.ClientCertSecretName, tenants.ClusterGroups(managedCluster)); err != nil {
+				return ret, fmt.Errorf("failed to ensure the metrics tenants client certificate: %w", err)
+			}
+		}
+
 		if err = o.buildPrometheusAgent(ctx, &ret, configResources, config.UserWorkloadMetricsCollectorApp, isHypershiftCluster); err != nil {
 			return ret, fmt.Errorf("failed to build user workloads metrics collector: %w", err)
 		}
@@ -376,6 +396,13 @@ func (o *OptionsBuilder) buildPrometheusAgent(ctx context.Context, opts *Options
 		mainRw.TLSConfig.KeyFile = fmt.Sprintf("/etc/prometheus/secrets/%s/%s", certTargetName, config.MTLSCertKeySecretKey)
 	}
 
+	// Route the user workload metrics of the tenants to their own remote writes
+	if appName == config.UserWorkloadMetricsCollectorApp {
+		if err := tenants.RouteRemoteWrites(&agent.Spec.CommonPrometheusFields, opts.Tenants, opts.TenantsEndpoint); err != nil {
+			return fmt.Errorf("failed to route the metrics of the tenants in agent %s/%s: %w", agent.Namespace, agent.Name, err)
+		}
+	}
+
 	// Fetch related secrets
 	for _, secretName := range agent.Spec.Secrets {
 		var sourceName string
@@ -386,6 +413,10 @@ func (o *OptionsBuilder) buildPrometheusAgent(ctx context.Context, opts *Options
 		} else if secretName == certTargetName {
 			sourceName = config.ClientCertSecretName
 			sourceNamespace = config.HubInstallNamespace
+		} else if secretName == tenants.ClientCertSecretName {
+			key := common.ClientCertKey(opts.ClusterName, secretName)
+			sourceName = key.Name
+			sourceNamespace = key.Namespace
 		} else if secretName == config.GetAlertmanagerAccessorSecretName(trimmedClusterID) {
 			sourceName = config.AlertmanagerAccessorSecretName
 			sourceNamespace = config.HubInstallNamespace
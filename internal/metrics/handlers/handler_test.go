@@ -419,8 +419,9 @@ func TestBuildOptions(t *testing.T) {
 				assert.Len(t, opts.Secrets, 5)
 				// Check that user workloads are not enabled
 				assert.Nil(t, opts.UserWorkloads.PrometheusAgent)
-				// Check that scrape configs are set
-				assert.Len(t, opts.Platform.ScrapeConfigs, 1)
+				// Check that scrape configs are set, along with the addon health one
+				require.Len(t, opts.Platform.ScrapeConfigs, 2)
+				assert.Equal(t, HealthScrapeConfigName, opts.Platform.ScrapeConfigs[1].Name)
 				// Check that CoreOS PrometheusRules are included
 				assert.Len(t, opts.Platform.Rules, 1, "expected one monitoring.coreos.com PrometheusRule")
 				assert.Equal(t, platformRule.Name, opts.Platform.Rules[0].Name, "expected CoreOS PrometheusRule")
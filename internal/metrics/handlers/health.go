@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// HealthScrapeConfigName is the name of the addon health ScrapeConfig
+	HealthScrapeConfigName = "platform-metrics-addon-health"
+
+	// HealthScrapeConfigJobName is the job name of the addon health scrape job
+	HealthScrapeConfigJobName = "addon-health"
+)
+
+// RemoteWriteHealthMetrics are the remote write metrics of the metrics
+// collectors shown by the fleet health dashboard.
+var RemoteWriteHealthMetrics = []string{
+	"prometheus_remote_storage_highest_timestamp_in_seconds",
+	"prometheus_remote_storage_queue_highest_sent_timestamp_seconds",
+	"prometheus_remote_storage_samples_failed_total",
+}
+
+// AddonStatusMetrics are the status metrics of the addon manager shown by the
+// fleet health dashboard. The manager only runs on the hub.
+var AddonStatusMetrics = []string{
+	"acm_observability_addon_available",
+	"acm_observability_addon_component_healthy",
+	"acm_observability_addon_info",
+}
+
+// GenerateHealthScrapeConfig generates the ScrapeConfig federating the fleet
+// health metrics from the in-cluster monitoring stack through the platform
+// PrometheusAgent: the remote write metrics of the collectors deployed in the
+// namespace and, on the hub, the status metrics of the addon manager.
+func GenerateHealthScrapeConfig(namespace string, isHub bool) *cooprometheusv1alpha1.ScrapeConfig {
+	matchParams := make([]string, 0, len(RemoteWriteHealthMetrics)+len(AddonStatusMetrics))
+	for _, metric := range RemoteWriteHealthMetrics {
+		matchParams = append(matchParams, "{__name__=\""+metric+"\",namespace=\""+namespace+"\"}")
+	}
+	if isHub {
+		for _, metric := range AddonStatusMetrics {
+			matchParams = append(matchParams, "{__name__=\""+metric+"\",namespace=\""+addoncfg.InstallNamespace+"\"}")
+		}
+	}
+
+	return &cooprometheusv1alpha1.ScrapeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ScrapeConfig",
+			APIVersion: "monitoring.rhobs/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: HealthScrapeConfigName,
+			Labels: map[string]string{
+				addoncfg.ComponentK8sLabelKey: config.PlatformPrometheusMatchLabels[addoncfg.ComponentK8sLabelKey],
+				addoncfg.PartOfK8sLabelKey:    addoncfg.Name,
+				addoncfg.ManagedByK8sLabelKey: addoncfg.Name,
+			},
+		},
+		Spec: cooprometheusv1alpha1.ScrapeConfigSpec{
+			JobName:         ptr.To(HealthScrapeConfigJobName),
+			MetricsPath:     ptr.To("/federate"),
+			ScrapeClassName: ptr.To(config.ScrapeClassCfgName),
+			Scheme:          ptr.To(cooprometheusv1.Scheme("HTTPS")),
+			StaticConfigs: []cooprometheusv1alpha1.StaticConfig{
+				{
+					Targets: []cooprometheusv1alpha1.Target{
+						cooprometheusv1alpha1.Target(config.ScrapeClassPlatformTarget),
+					},
+				},
+			},
+			Params: map[string][]string{
+				"match[]": matchParams,
+			},
+		},
+	}
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"net/url"
+
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
 	thanosv1alpha1 "github.com/thanos-community/thanos-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	addonv1beta1 "open-cluster-management.io/api/addon/v1beta1"
@@ -14,6 +17,8 @@ import (
 type Options struct {
 	PlatformAlertsEnabled     bool
 	UserWorkloadAlertsEnabled bool
+	ExemplarsEnabled          bool
+	NativeHistogramsEnabled   bool
 	Platform                  Collector
 	UserWorkloads             Collector
 	Secrets                   []*corev1.Secret
@@ -46,6 +51,13 @@ type Options struct {
 	MonitoringStackPatches []MonitoringStackPatch
 
 	PrometheusServerRemoteWrite []cooprometheusv1.RemoteWriteSpec
+
+	// Tenants are the tenants of the hub the user workload metrics of the
+	// cluster are routed to, besides the default one.
+	Tenants []tenants.Tenant
+	// TenantsEndpoint is the observability API of the hub the metrics of the
+	// tenants are written to.
+	TenantsEndpoint url.URL
 }
 
 type MonitoringStackPatch struct {
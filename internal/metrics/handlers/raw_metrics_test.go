@@ -221,8 +221,9 @@ func TestBuildOptionsRawMetrics(t *testing.T) {
 	retOpts, err := builder.Build(context.Background(), mcAddon, managedCluster, opts)
 	require.NoError(t, err)
 
-	// ScrapeConfig targeted to a COO stack should NOT be exported to managed cluster
-	assert.Empty(t, retOpts.Platform.ScrapeConfigs)
+	// ScrapeConfig targeted to a COO stack should NOT be exported to managed cluster, only the addon health one is
+	require.Len(t, retOpts.Platform.ScrapeConfigs, 1)
+	assert.Equal(t, HealthScrapeConfigName, retOpts.Platform.ScrapeConfigs[0].Name)
 
 	// Secrets should be distributed to the COO stack namespace
 	assert.NotEmpty(t, retOpts.Secrets)
@@ -444,8 +445,9 @@ func TestBuildOptionsRawMetricsNonOCP(t *testing.T) {
 	retOpts, err := builder.Build(context.Background(), mcAddon, managedCluster, opts)
 	require.NoError(t, err)
 
-	// ScrapeConfig targeted for Raw metrics should NOT be exported directly
-	assert.Empty(t, retOpts.Platform.ScrapeConfigs)
+	// ScrapeConfig targeted for Raw metrics should NOT be exported directly, only the addon health one is
+	require.Len(t, retOpts.Platform.ScrapeConfigs, 1)
+	assert.Equal(t, HealthScrapeConfigName, retOpts.Platform.ScrapeConfigs[0].Name)
 
 	// No MonitoringStack patches on non-OCP since there's no COO there
 	assert.Empty(t, retOpts.MonitoringStackPatches)
@@ -147,7 +147,7 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				assert.Contains(t, agent[0].Spec.ConfigMaps, "my-configmap")
 				// ensure that scrape config is created and matches the agent
 				scrapeCfgs := common.FilterResourcesByLabelSelector[*cooprometheusv1alpha1.ScrapeConfig](objects, config.PlatformPrometheusMatchLabels)
-				assert.Len(t, scrapeCfgs, 2)
+				assert.Len(t, scrapeCfgs, 3) // with the addon health one
 				assert.Equal(t, config.PrometheusControllerID, scrapeCfgs[0].Annotations["operator.prometheus.io/controller-id"])
 				assert.NotEmpty(t, agent[0].Spec.ScrapeConfigSelector.MatchLabels)
 				scrapeConfigsSelector := labels.SelectorFromSet(labels.Set(agent[0].Spec.ScrapeConfigSelector.MatchLabels))
@@ -237,7 +237,7 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				assert.Equal(t, "--cluster-name=cluster-1", jobClusterNameArg)
 				assert.Equal(t, "--hub-alertmanager-ca-secret=hub-mtls-ca-97e513873da14ae489e", jobHubCASecretArg)
 				// ensure that the number of objects is correct
-				expectedCount := 47
+				expectedCount := 48
 				if len(objects) != expectedCount {
 					t.Fatalf("expected %d objects, but got %d:\n%s", expectedCount, len(objects), formatObjects(objects))
 				}
@@ -283,10 +283,11 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				agent := common.FilterResourcesByLabelSelector[*cooprometheusv1alpha1.PrometheusAgent](objects, config.PlatformPrometheusMatchLabels)
 				assert.Len(t, agent, 1)
 
-				// Ensure standard scrape config is created and has standard component label
+				// Ensure standard scrape config is created, next to the addon health one, and has standard component label
 				scrapeCfgs := common.FilterResourcesByLabelSelector[*cooprometheusv1alpha1.ScrapeConfig](objects, config.PlatformPrometheusMatchLabels)
-				assert.Len(t, scrapeCfgs, 1)
+				require.Len(t, scrapeCfgs, 2)
 				assert.Equal(t, "platform", scrapeCfgs[0].Name)
+				assert.Equal(t, handlers.HealthScrapeConfigName, scrapeCfgs[1].Name)
 				assert.Equal(t, "platform-metrics-collector", scrapeCfgs[0].Labels[addoncfg.ComponentK8sLabelKey])
 
 				// Verify that the ScrapeConfig with raw resolution strategy preserves its component label as platform-metrics-collector-raw
@@ -314,10 +315,17 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 					t.Fatalf("expected %d objects, but got %d", expectedCount, len(crds))
 				}
 				// ensure that the number of objects is correct
-				expectedCount = 47
+				expectedCount = 52
 				if len(objects) != expectedCount {
 					t.Fatalf("expected %d objects, but got %d:\n%s", expectedCount, len(objects), formatObjects(objects))
 				}
+				// ensure the addon manager status metrics are scraped on the hub
+				serviceMonitors := common.FilterResourcesByLabelSelector[*prometheusv1.ServiceMonitor](objects, map[string]string{"app.kubernetes.io/component": "manager"})
+				require.Len(t, serviceMonitors, 1)
+				assert.Equal(t, addoncfg.InstallNamespace, serviceMonitors[0].Namespace)
+				healthCfgs := common.FilterResourcesByLabelSelector[*cooprometheusv1alpha1.ScrapeConfig](objects, config.PlatformPrometheusMatchLabels)
+				require.Len(t, healthCfgs, 3)
+				assert.Contains(t, healthCfgs[2].Spec.Params["match[]"], `{__name__="acm_observability_addon_available",namespace="open-cluster-management-observability"}`)
 				verifyClusterScopedResourcesPrefix(t, objects)
 			},
 		},
@@ -333,7 +341,7 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				assert.Equal(t, "observability-operator", agent[0].Labels["app.kubernetes.io/managed-by"])
 				assert.Empty(t, agent[0].Annotations["operator.prometheus.io/controller-id"])
 				// ensure that the number of objects is correct
-				expectedCount := 39
+				expectedCount := 40
 				if len(objects) != expectedCount {
 					t.Fatalf("expected %d objects, but got %d:\n%s", expectedCount, len(objects), formatObjects(objects))
 				}
@@ -514,7 +522,7 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				verifyClusterScopedResourcesPrefix(t, objects)
 
 				// ensure that the number of objects is correct
-				expectedCount := 76
+				expectedCount := 77
 				if len(objects) != expectedCount {
 					t.Fatalf("expected %d objects, but got %d:\n%s", expectedCount, len(objects), formatObjects(objects))
 				}
@@ -635,7 +643,7 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 				assert.Equal(t, "metrics", ns[0].Labels["app"])
 
 				// ensure that the number of objects is correct
-				expectedCount := 76
+				expectedCount := 77
 				if len(objects) != expectedCount {
 					t.Fatalf("expected %d objects, but got %d:\n%s", expectedCount, len(objects), formatObjects(objects))
 				}
@@ -1055,6 +1063,9 @@ func TestHelmBuild_Metrics_All(t *testing.T) {
 						assert.Equal(t, "open-cluster-management-agent", accessor.GetNamespace(), "Object: %s/%s", obj.GetObjectKind().GroupVersionKind(), accessor.GetName())
 					} else if obj.GetObjectKind().GroupVersionKind().Kind == "PrometheusRule" && accessor.GetName() == "uwl-rules-additional" {
 						assert.Equal(t, "target-namespace", accessor.GetNamespace(), "Object: %s/%s", obj.GetObjectKind().GroupVersionKind(), accessor.GetName())
+					} else if tc.IsHub && accessor.GetNamespace() == addoncfg.InstallNamespace {
+						// the addon manager metrics are scraped in the namespace of the manager
+						assert.Contains(t, []string{"Service", "ServiceMonitor", "Role", "RoleBinding"}, obj.GetObjectKind().GroupVersionKind().Kind, "Object: %s/%s", obj.GetObjectKind().GroupVersionKind(), accessor.GetName())
 					} else {
 						installNamespace := addonfactory.AddonDefaultInstallNamespace
 						if tc.InstallNamespace != "" {
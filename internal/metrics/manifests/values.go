@@ -8,6 +8,7 @@ import (
 
 	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
 	"github.com/stolostron/multicluster-observability-addon/internal/metrics/handlers"
 	corev1 "k8s.io/api/core/v1"
@@ -31,11 +32,14 @@ type MetricsValues struct {
 	ClusterName                    string                            `json:"clusterName"`
 	PlatformAlertsEnabled          bool                              `json:"platformAlertsEnabled"`
 	UserWorkloadAlertsEnabled      bool                              `json:"userWorkloadAlertsEnabled"`
+	ExemplarsEnabled               bool                              `json:"exemplarsEnabled"`
+	NativeHistogramsEnabled        bool                              `json:"nativeHistogramsEnabled"`
 	Platform                       Collector                         `json:"platform"`
 	UserWorkload                   Collector                         `json:"userWorkload"`
 	DeployNonOCPStack              bool                              `json:"deployNonOCPStack"`
 	DeployCOOResources             bool                              `json:"deployCOOResources"`
 	IsHub                          bool                              `json:"isHub"`
+	ManagerNamespace               string                            `json:"managerNamespace,omitempty"`
 	PrometheusOperatorAnnotations  string                            `json:"prometheusOperatorAnnotations,omitempty"`
 	HubEndpoint                    string                            `json:"hubEndpoint,omitempty"`
 	Tolerations                    []corev1.Toleration               `json:"tolerations"`
@@ -111,6 +115,8 @@ func BuildValues(opts handlers.Options) (*MetricsValues, error) {
 		ClusterName:                    opts.ClusterName,
 		PlatformAlertsEnabled:          opts.PlatformAlertsEnabled,
 		UserWorkloadAlertsEnabled:      opts.UserWorkloadAlertsEnabled,
+		ExemplarsEnabled:               opts.ExemplarsEnabled,
+		NativeHistogramsEnabled:        opts.NativeHistogramsEnabled,
 		Platform: Collector{
 			AppName:            config.PlatformMetricsCollectorApp,
 			RBACProxyTLSSecret: config.PlatformRBACProxyTLSSecret,
@@ -133,6 +139,12 @@ func BuildValues(opts handlers.Options) (*MetricsValues, error) {
 		TLSCipherSuites: opts.TLSCipherSuites,
 	}
 
+	// The addon manager runs on the hub, where its status metrics are scraped
+	// by the in-cluster monitoring stack.
+	if opts.IsHub {
+		ret.ManagerNamespace = addoncfg.InstallNamespace
+	}
+
 	if opts.IsOpenShiftVendor {
 		configureAgentForOCP(opts.Platform.PrometheusAgent)
 		configureAgentForOCP(opts.UserWorkloads.PrometheusAgent)
@@ -172,26 +184,7 @@ func BuildValues(opts handlers.Options) (*MetricsValues, error) {
 
 	// Build scrape configs
 	for _, scrapeConfig := range opts.Platform.ScrapeConfigs {
-		target := config.ScrapeClassPlatformTarget
-		scheme := "HTTPS"
-		scrapeClassName := config.ScrapeClassCfgName
-		if !opts.IsOpenShiftVendor {
-			target = fmt.Sprintf("%s.%s.svc:9091", config.PrometheusServerName, opts.InstallNamespace)
-			scrapeClassName = config.NonOCPScrapeClassName
-			scrapeConfig.Spec.TLSConfig = &cooprometheusv1.SafeTLSConfig{
-				InsecureSkipVerify: ptr.To(true),
-			}
-		}
-
-		scrapeConfig.Spec.ScrapeClassName = ptr.To(scrapeClassName)
-		scrapeConfig.Spec.Scheme = ptr.To(cooprometheusv1.Scheme(scheme))
-		scrapeConfig.Spec.StaticConfigs = []cooprometheusv1alpha1.StaticConfig{
-			{
-				Targets: []cooprometheusv1alpha1.Target{
-					cooprometheusv1alpha1.Target(target),
-				},
-			},
-		}
+		SetPlatformTarget(scrapeConfig, opts.IsOpenShiftVendor, opts.InstallNamespace)
 
 		scrapeConfigJson, err := json.Marshal(scrapeConfig.Spec)
 		if err != nil {
@@ -519,3 +512,28 @@ func configureAgentForNonOCP(agent *cooprometheusv1alpha1.PrometheusAgent) {
 		agent.Spec.Volumes = slices.Delete(agent.Spec.Volumes, idxToRemove, idxToRemove+1)
 	}
 }
+
+// SetPlatformTarget points a platform ScrapeConfig to the Prometheus it
+// federates: the in-cluster monitoring stack on OpenShift, the addon's own
+// Prometheus server on other clusters.
+func SetPlatformTarget(scrapeConfig *cooprometheusv1alpha1.ScrapeConfig, isOpenShiftVendor bool, installNamespace string) {
+	target := config.ScrapeClassPlatformTarget
+	scrapeClassName := config.ScrapeClassCfgName
+	if !isOpenShiftVendor {
+		target = fmt.Sprintf("%s.%s.svc:9091", config.PrometheusServerName, installNamespace)
+		scrapeClassName = config.NonOCPScrapeClassName
+		scrapeConfig.Spec.TLSConfig = &cooprometheusv1.SafeTLSConfig{
+			InsecureSkipVerify: ptr.To(true),
+		}
+	}
+
+	scrapeConfig.Spec.ScrapeClassName = ptr.To(scrapeClassName)
+	scrapeConfig.Spec.Scheme = ptr.To(cooprometheusv1.Scheme("HTTPS"))
+	scrapeConfig.Spec.StaticConfigs = []cooprometheusv1alpha1.StaticConfig{
+		{
+			Targets: []cooprometheusv1alpha1.Target{
+				cooprometheusv1alpha1.Target(target),
+			},
+		},
+	}
+}
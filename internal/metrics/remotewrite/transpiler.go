@@ -209,6 +209,12 @@ func Transpile(scrapeConfig *cooprometheusv1alpha1.ScrapeConfig, agent *cooprome
 		if agentRw.Name != nil {
 			spec.Name = ptr.To(*agentRw.Name)
 		}
+		if agentRw.SendExemplars != nil {
+			spec.SendExemplars = ptr.To(*agentRw.SendExemplars)
+		}
+		if agentRw.SendNativeHistograms != nil {
+			spec.SendNativeHistograms = ptr.To(*agentRw.SendNativeHistograms)
+		}
 
 		specs = append(specs, spec)
 	}
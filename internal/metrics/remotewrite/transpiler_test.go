@@ -392,8 +392,10 @@ func TestTranspile_MultipleRemoteWrites(t *testing.T) {
 			CommonPrometheusFields: cooprometheusv1.CommonPrometheusFields{
 				RemoteWrite: []cooprometheusv1.RemoteWriteSpec{
 					{
-						Name: ptr.To("primary-hub"),
-						URL:  "https://hub-primary.example.com",
+						Name:                 ptr.To("primary-hub"),
+						URL:                  "https://hub-primary.example.com",
+						SendExemplars:        ptr.To(true),
+						SendNativeHistograms: ptr.To(true),
 					},
 					{
 						Name: ptr.To("secondary-hub"),
@@ -421,6 +423,14 @@ func TestTranspile_MultipleRemoteWrites(t *testing.T) {
 		t.Errorf("Second spec incorrect: name=%v, url=%s", gotList[1].Name, gotList[1].URL)
 	}
 
+	// Verify the sending of exemplars and native histograms is carried over
+	if gotList[0].SendExemplars == nil || !*gotList[0].SendExemplars || gotList[0].SendNativeHistograms == nil || !*gotList[0].SendNativeHistograms {
+		t.Errorf("First spec doesn't send exemplars and native histograms")
+	}
+	if gotList[1].SendExemplars != nil || gotList[1].SendNativeHistograms != nil {
+		t.Errorf("Second spec unexpectedly sends exemplars or native histograms")
+	}
+
 	// Verify both specs successfully receive the transpiled relabel configurations
 	if len(gotList[0].WriteRelabelConfigs) == 0 {
 		t.Errorf("First spec missing relabel configs")
@@ -99,6 +99,10 @@ type PrometheusAgentSSA struct {
 	Labels              map[string]string
 	RemoteWriteEndpoint string
 	Annotations         map[string]string
+	// SendExemplars and SendNativeHistograms enable the collection of
+	// exemplars and native histograms, and their sending to the hub.
+	SendExemplars        bool
+	SendNativeHistograms bool
 
 	desiredAgent *cooprometheusv1alpha1.PrometheusAgent
 }
@@ -161,6 +165,7 @@ func (p *PrometheusAgentSSA) Build() *cooprometheusv1alpha1.PrometheusAgent {
 	}
 
 	p.setPrometheusRemoteWriteConfig()
+	p.setExemplarsAndNativeHistograms()
 	p.setWatchedResources()
 	p.setScrapeClasses()
 	p.setKubeRBACProxySidecar()
@@ -192,6 +197,12 @@ func (p *PrometheusAgentSSA) setPrometheusRemoteWriteConfig() {
 		},
 		// WriteRelabelConfigs is set individually for each managed cluster in order to enforce cluster identification labels
 	}
+	if p.SendExemplars {
+		desiredRemoteWriteSpec.SendExemplars = ptr.To(true)
+	}
+	if p.SendNativeHistograms {
+		desiredRemoteWriteSpec.SendNativeHistograms = ptr.To(true)
+	}
 
 	// Ensure there is a single instance of our config
 	var found *cooprometheusv1.RemoteWriteSpec
@@ -228,6 +239,27 @@ func (p *PrometheusAgentSSA) setPrometheusRemoteWriteConfig() {
 	}
 }
 
+// setExemplarsAndNativeHistograms enables the storage of exemplars and the
+// scraping of native histograms. The features are a set, so the ones enabled
+// by the user are kept. Classic histograms are still scraped as the dashboards
+// rely on their buckets. The scrape protocols are only defaulted when the user
+// hasn't set them, or when they are the ones set by the addon so that they
+// stay owned by it.
+func (p *PrometheusAgentSSA) setExemplarsAndNativeHistograms() {
+	if p.SendExemplars {
+		p.desiredAgent.Spec.EnableFeatures = append(p.desiredAgent.Spec.EnableFeatures, config.ExemplarStorageFeature)
+	}
+	if p.SendNativeHistograms {
+		p.desiredAgent.Spec.EnableFeatures = append(p.desiredAgent.Spec.EnableFeatures, config.NativeHistogramsFeature)
+		p.desiredAgent.Spec.ScrapeClassicHistograms = ptr.To(true)
+		// Native histograms are only exposed in the protobuf format.
+		protocols := p.ExistingAgent.Spec.ScrapeProtocols
+		if len(protocols) == 0 || slices.Equal(protocols, config.NativeHistogramsScrapeProtocols) {
+			p.desiredAgent.Spec.ScrapeProtocols = config.NativeHistogramsScrapeProtocols
+		}
+	}
+}
+
 func (p *PrometheusAgentSSA) setWatchedResources() {
 	if p.IsUwl {
 		p.desiredAgent.Spec.ScrapeConfigSelector = &metav1.LabelSelector{
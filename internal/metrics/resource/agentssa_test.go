@@ -19,7 +19,9 @@ func TestPrometheusAgentSSA(t *testing.T) {
 		Name          string
 		ExistingAgent *cooprometheusv1alpha1.PrometheusAgent
 		Labels        map[string]string
-		Expect        func(*testing.T, *cooprometheusv1alpha1.PrometheusAgent)
+		// Histograms enables the sending of exemplars and native histograms.
+		Histograms bool
+		Expect     func(*testing.T, *cooprometheusv1alpha1.PrometheusAgent)
 	}{
 		{
 			Name: "mandatory fields are set",
@@ -36,6 +38,45 @@ func TestPrometheusAgentSSA(t *testing.T) {
 				assert.Equal(t, "kube-rbac-proxy:latest", agent.Spec.Containers[0].Image) // kube-rbac-proxy image
 				assert.NotEmpty(t, agent.Spec.RemoteWrite)
 				assert.NotEmpty(t, agent.Spec.RemoteWrite[0].URL)
+				assert.Nil(t, agent.Spec.RemoteWrite[0].SendExemplars)
+				assert.Empty(t, agent.Spec.EnableFeatures)
+			},
+		},
+		{
+			Name:       "exemplars and native histograms are sent",
+			Histograms: true,
+			ExistingAgent: &cooprometheusv1alpha1.PrometheusAgent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: cooprometheusv1alpha1.PrometheusAgentSpec{},
+			},
+			Expect: func(t *testing.T, agent *cooprometheusv1alpha1.PrometheusAgent) {
+				assert.Equal(t, ptr.To(true), agent.Spec.RemoteWrite[0].SendExemplars)
+				assert.Equal(t, ptr.To(true), agent.Spec.RemoteWrite[0].SendNativeHistograms)
+				assert.Equal(t, []cooprometheusv1.EnableFeature{config.ExemplarStorageFeature, config.NativeHistogramsFeature}, agent.Spec.EnableFeatures)
+				assert.Equal(t, ptr.To(true), agent.Spec.ScrapeClassicHistograms)
+				assert.Equal(t, cooprometheusv1.PrometheusProto, agent.Spec.ScrapeProtocols[0])
+			},
+		},
+		{
+			Name:       "user scrape protocols are kept with native histograms",
+			Histograms: true,
+			ExistingAgent: &cooprometheusv1alpha1.PrometheusAgent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: cooprometheusv1alpha1.PrometheusAgentSpec{
+					CommonPrometheusFields: cooprometheusv1.CommonPrometheusFields{
+						ScrapeProtocols: []cooprometheusv1.ScrapeProtocol{cooprometheusv1.OpenMetricsText1_0_0},
+					},
+				},
+			},
+			Expect: func(t *testing.T, agent *cooprometheusv1alpha1.PrometheusAgent) {
+				assert.Contains(t, agent.Spec.EnableFeatures, config.NativeHistogramsFeature)
+				assert.Nil(t, agent.Spec.ScrapeProtocols)
 			},
 		},
 		{
@@ -159,10 +200,12 @@ func TestPrometheusAgentSSA(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
 			builder := resource.PrometheusAgentSSA{
-				ExistingAgent:       tc.ExistingAgent,
-				RemoteWriteEndpoint: "https://example.com/write",
-				KubeRBACProxyImage:  "kube-rbac-proxy:latest",
-				Labels:              tc.Labels,
+				ExistingAgent:        tc.ExistingAgent,
+				RemoteWriteEndpoint:  "https://example.com/write",
+				KubeRBACProxyImage:   "kube-rbac-proxy:latest",
+				Labels:               tc.Labels,
+				SendExemplars:        tc.Histograms,
+				SendNativeHistograms: tc.Histograms,
 			}
 
 			result := builder.Build()
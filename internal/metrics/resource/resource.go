@@ -356,11 +356,13 @@ func (d DefaultStackResources) reconcileAgents(ctx context.Context, isUWL bool)
 		}
 
 		promBuilder := PrometheusAgentSSA{
-			ExistingAgent:       agent,
-			IsUwl:               isUWL,
-			PrometheusImage:     d.PrometheusImage,
-			KubeRBACProxyImage:  d.KubeRBACProxyImage,
-			RemoteWriteEndpoint: d.AddonOptions.Platform.Metrics.HubEndpoint.String(),
+			ExistingAgent:        agent,
+			IsUwl:                isUWL,
+			PrometheusImage:      d.PrometheusImage,
+			KubeRBACProxyImage:   d.KubeRBACProxyImage,
+			RemoteWriteEndpoint:  d.AddonOptions.Platform.Metrics.HubEndpoint.String(),
+			SendExemplars:        d.AddonOptions.Platform.Metrics.ExemplarsEnabled,
+			SendNativeHistograms: d.AddonOptions.Platform.Metrics.NativeHistogramsEnabled,
 		}
 		promSSA := promBuilder.Build()
 
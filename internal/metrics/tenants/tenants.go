@@ -0,0 +1,243 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// DefaultTenant receives the metrics that are not mapped to a tenant.
+	DefaultTenant = "default"
+
+	// The keys of a tenant ConfigMap.
+	clusterSetsKey = "clusterSets"
+	namespacesKey  = "namespaces"
+	readersKey     = "readers"
+
+	// namespaceLabel is the label of the user workload series selecting their
+	// tenant.
+	namespaceLabel cooprometheusv1.LabelName = "namespace"
+
+	// ClientCertSecretName is the secret of the client certificate the
+	// managed cluster writes the metrics of its tenants with.
+	ClientCertSecretName = "mcoa-metrics-tenant-client-cert"
+	// WriterGroup is the group of all the managed clusters writing the metrics
+	// of tenants, and clusterSetGroupPrefix the prefix of the group of the
+	// clusters of a ManagedClusterSet.
+	WriterGroup           = "mcoa-metrics-writer"
+	clusterSetGroupPrefix = "mcoa-metrics-clusterset-"
+)
+
+var errMissingRemoteWrite = errors.New("missing remote write spec")
+
+// Tenant maps the user workload metrics of some clusters and namespaces to a
+// tenant of the hub.
+type Tenant struct {
+	// Name is the name of the tenant and of its ConfigMap.
+	Name string
+	// ClusterSets restricts the tenant to the clusters of these
+	// ManagedClusterSets, all the clusters when empty.
+	ClusterSets []string
+	// Namespaces restricts the tenant to the series of these namespaces, all
+	// the series when empty. Regular expressions are allowed.
+	Namespaces []string
+	// Readers are the groups allowed to query the metrics of the tenant.
+	Readers []string
+}
+
+// Matches returns true when the tenant applies to the cluster.
+func (t Tenant) Matches(cluster *clusterv1.ManagedCluster) bool {
+	return len(t.ClusterSets) == 0 || slices.Contains(t.ClusterSets, cluster.Labels[clusterv1beta2.ClusterSetLabel])
+}
+
+// WriterGroups returns the groups of the clusters allowed to write the
+// metrics of the tenant.
+func (t Tenant) WriterGroups() []string {
+	if len(t.ClusterSets) == 0 {
+		return []string{WriterGroup}
+	}
+	groups := make([]string, 0, len(t.ClusterSets))
+	for _, set := range t.ClusterSets {
+		groups = append(groups, ClusterSetGroup(set))
+	}
+	return groups
+}
+
+// ClusterSetGroup returns the group of the clusters of the ManagedClusterSet.
+func ClusterSetGroup(set string) string {
+	return clusterSetGroupPrefix + set
+}
+
+// ClusterGroups returns the groups of the client certificate of the cluster,
+// matching the WriterGroups of the tenants applying to it.
+func ClusterGroups(cluster *clusterv1.ManagedCluster) []string {
+	groups := []string{WriterGroup}
+	if set := cluster.Labels[clusterv1beta2.ClusterSetLabel]; set != "" {
+		groups = append(groups, ClusterSetGroup(set))
+	}
+	return groups
+}
+
+// GetTenants collects the tenants configured through the labeled ConfigMaps of
+// the hub install namespace. Invalid tenants are logged and skipped.
+func GetTenants(ctx context.Context, k8s client.Client, logger logr.Logger) ([]Tenant, error) {
+	cms := &corev1.ConfigMapList{}
+	if err := k8s.List(ctx, cms, client.InNamespace(addoncfg.InstallNamespace), client.HasLabels{addoncfg.MetricsTenantLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list metrics tenant ConfigMaps: %w", err)
+	}
+
+	sort.Slice(cms.Items, func(i, j int) bool { return cms.Items[i].Name < cms.Items[j].Name })
+
+	var tenants []Tenant
+	for _, cm := range cms.Items {
+		if errs := validation.IsDNS1123Label(cm.Name); len(errs) > 0 {
+			logger.Info("skipping metrics tenant with an invalid name", "configmap", cm.Name, "errors", strings.Join(errs, ", "))
+			continue
+		}
+		if cm.Name == DefaultTenant {
+			logger.Info("skipping metrics tenant named after the default tenant", "configmap", cm.Name)
+			continue
+		}
+
+		tenant := Tenant{
+			Name:        cm.Name,
+			ClusterSets: common.SplitList(cm.Data[clusterSetsKey]),
+			Namespaces:  common.SplitList(cm.Data[namespacesKey]),
+			Readers:     common.SplitList(cm.Data[readersKey]),
+		}
+		if len(tenant.ClusterSets) == 0 && len(tenant.Namespaces) == 0 {
+			logger.Info("skipping metrics tenant without cluster sets nor namespaces", "configmap", cm.Name)
+			continue
+		}
+		if invalid := slices.IndexFunc(tenant.Namespaces, func(ns string) bool {
+			_, err := regexp.Compile(ns)
+			return err != nil
+		}); invalid >= 0 {
+			logger.Info("skipping metrics tenant with an invalid namespace", "configmap", cm.Name, "namespace", tenant.Namespaces[invalid])
+			continue
+		}
+
+		tenants = append(tenants, tenant)
+	}
+
+	return tenants, nil
+}
+
+// ForCluster returns the tenants applying to the cluster.
+func ForCluster(tenants []Tenant, cluster *clusterv1.ManagedCluster) []Tenant {
+	var ret []Tenant
+	for _, tenant := range tenants {
+		if tenant.Matches(cluster) {
+			ret = append(ret, tenant)
+		}
+	}
+	return ret
+}
+
+// RouteRemoteWrites adds to the agent one copy of the acm-observability remote
+// write per tenant, writing to the tenant on the observability API of the hub
+// and keeping only its series. The copies authenticate with the client
+// certificate of the cluster, whose groups only let it write to the tenants
+// applying to it. The acm-observability remote write drops the series of the
+// tenants, so that the readers of the default tenant can't query them.
+func RouteRemoteWrites(spec *cooprometheusv1.CommonPrometheusFields, tenants []Tenant, endpoint url.URL) error {
+	if len(tenants) == 0 {
+		return nil
+	}
+
+	idx := slices.IndexFunc(spec.RemoteWrite, func(e cooprometheusv1.RemoteWriteSpec) bool {
+		return e.Name != nil && *e.Name == config.RemoteWriteCfgName
+	})
+	if idx == -1 {
+		return fmt.Errorf("%w: %s", errMissingRemoteWrite, config.RemoteWriteCfgName)
+	}
+	base := spec.RemoteWrite[idx]
+
+	if !slices.Contains(spec.Secrets, ClientCertSecretName) {
+		spec.Secrets = append(spec.Secrets, ClientCertSecretName)
+	}
+
+	var tenantNamespaces []string
+	for _, tenant := range tenants {
+		namespaces := tenant.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{".*"}
+		}
+		tenantNamespaces = append(tenantNamespaces, namespaces...)
+
+		rw := *base.DeepCopy()
+		rw.Name = ptr.To(fmt.Sprintf("%s-%s", config.RemoteWriteCfgName, tenant.Name))
+		tu := endpoint
+		tu.Path = fmt.Sprintf("/api/metrics/v1/%s/api/v1/receive", tenant.Name)
+		rw.URL = cooprometheusv1.URL(tu.String())
+		if rw.TLSConfig == nil {
+			rw.TLSConfig = &cooprometheusv1.TLSConfig{}
+		}
+		rw.TLSConfig.CertFile = fmt.Sprintf("/etc/prometheus/secrets/%s/%s", ClientCertSecretName, corev1.TLSCertKey)
+		rw.TLSConfig.KeyFile = fmt.Sprintf("/etc/prometheus/secrets/%s/%s", ClientCertSecretName, corev1.TLSPrivateKeyKey)
+		rw.WriteRelabelConfigs = append([]cooprometheusv1.RelabelConfig{
+			{
+				SourceLabels: []cooprometheusv1.LabelName{namespaceLabel},
+				Regex:        strings.Join(namespaces, "|"),
+				Action:       "keep",
+			},
+		}, rw.WriteRelabelConfigs...)
+		spec.RemoteWrite = append(spec.RemoteWrite, rw)
+	}
+
+	spec.RemoteWrite[idx].WriteRelabelConfigs = append([]cooprometheusv1.RelabelConfig{
+		{
+			SourceLabels: []cooprometheusv1.LabelName{namespaceLabel},
+			Regex:        strings.Join(tenantNamespaces, "|"),
+			Action:       "drop",
+		},
+	}, spec.RemoteWrite[idx].WriteRelabelConfigs...)
+
+	return nil
+}
+
+// ConfigMapPredicate filters ConfigMap events down to the tenant ConfigMaps.
+// Updates are also let through when the label is removed so the tenants get
+// pruned.
+func ConfigMapPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isTenantConfigMap(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isTenantConfigMap(e.ObjectOld) || isTenantConfigMap(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isTenantConfigMap(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isTenantConfigMap(obj client.Object) bool {
+	if obj.GetNamespace() != addoncfg.InstallNamespace {
+		return false
+	}
+	_, ok := obj.GetLabels()[addoncfg.MetricsTenantLabelKey]
+	return ok
+}
@@ -0,0 +1,170 @@
+package tenants
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func tenantConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: addoncfg.InstallNamespace,
+			Labels: map[string]string{
+				addoncfg.MetricsTenantLabelKey: "true",
+			},
+		},
+		Data: data,
+	}
+}
+
+func TestGetTenants(t *testing.T) {
+	objs := []client.Object{
+		tenantConfigMap("team-b", map[string]string{"namespaces": "checkout, billing-.*", "readers": "team-b-admins"}),
+		tenantConfigMap("team-a", map[string]string{"clusterSets": "payments"}),
+		// Invalid tenants
+		tenantConfigMap("default", map[string]string{"clusterSets": "payments"}),
+		tenantConfigMap("Team-C", map[string]string{"clusterSets": "payments"}),
+		tenantConfigMap("team-d", map[string]string{"readers": "team-d-admins"}),
+		tenantConfigMap("team-e", map[string]string{"namespaces": "billing-("}),
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+
+	tenants, err := GetTenants(t.Context(), k8s, logr.Discard())
+	require.NoError(t, err)
+	require.Equal(t, []Tenant{
+		{Name: "team-a", ClusterSets: []string{"payments"}},
+		{Name: "team-b", Namespaces: []string{"checkout", "billing-.*"}, Readers: []string{"team-b-admins"}},
+	}, tenants)
+
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-1"}}
+	require.Equal(t, []Tenant{tenants[1]}, ForCluster(tenants, cluster))
+	cluster.Labels = map[string]string{clusterv1beta2.ClusterSetLabel: "payments"}
+	require.Equal(t, tenants, ForCluster(tenants, cluster))
+}
+
+func TestClusterGroups(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-1"}}
+	require.Equal(t, []string{WriterGroup}, ClusterGroups(cluster))
+	cluster.Labels = map[string]string{clusterv1beta2.ClusterSetLabel: "payments"}
+	require.Equal(t, []string{WriterGroup, "mcoa-metrics-clusterset-payments"}, ClusterGroups(cluster))
+
+	// A tenant of some cluster sets is only writable by their clusters.
+	require.Equal(t, []string{WriterGroup}, Tenant{Name: "team-b", Namespaces: []string{"checkout"}}.WriterGroups())
+	require.Equal(t, []string{"mcoa-metrics-clusterset-payments"}, Tenant{Name: "team-a", ClusterSets: []string{"payments"}}.WriterGroups())
+}
+
+func TestRouteRemoteWrites(t *testing.T) {
+	identity := cooprometheusv1.RelabelConfig{TargetLabel: "cluster", Replacement: ptr.To("cluster-1"), Action: "replace"}
+	base := cooprometheusv1.RemoteWriteSpec{
+		Name:                ptr.To(config.RemoteWriteCfgName),
+		URL:                 "https://observatorium-api.example.com/api/metrics/v1/default/api/v1/receive",
+		WriteRelabelConfigs: []cooprometheusv1.RelabelConfig{identity},
+		TLSConfig: &cooprometheusv1.TLSConfig{
+			TLSFilesConfig: cooprometheusv1.TLSFilesConfig{
+				CAFile:   "/etc/prometheus/secrets/hub-ca/ca.crt",
+				CertFile: "/etc/prometheus/secrets/hub-cert/tls.crt",
+				KeyFile:  "/etc/prometheus/secrets/hub-cert/tls.key",
+			},
+		},
+	}
+	spec := &cooprometheusv1.CommonPrometheusFields{
+		Secrets:     []string{"hub-ca", "hub-cert"},
+		RemoteWrite: []cooprometheusv1.RemoteWriteSpec{*base.DeepCopy()},
+	}
+	endpoint := url.URL{Scheme: "https", Host: "mcoa-observatorium-api.example.com"}
+
+	err := RouteRemoteWrites(spec, []Tenant{
+		{Name: "team-a"},
+		{Name: "team-b", Namespaces: []string{"checkout", "billing-.*"}},
+	}, endpoint)
+	require.NoError(t, err)
+	require.Len(t, spec.RemoteWrite, 3)
+	require.Equal(t, []string{"hub-ca", "hub-cert", ClientCertSecretName}, spec.Secrets)
+
+	// The default tenant drops the series of the tenants.
+	require.Equal(t, []cooprometheusv1.RelabelConfig{
+		{SourceLabels: []cooprometheusv1.LabelName{namespaceLabel}, Regex: ".*|checkout|billing-.*", Action: "drop"},
+		identity,
+	}, spec.RemoteWrite[0].WriteRelabelConfigs)
+	require.Equal(t, base.URL, spec.RemoteWrite[0].URL)
+
+	teamA := spec.RemoteWrite[1]
+	require.Equal(t, config.RemoteWriteCfgName+"-team-a", *teamA.Name)
+	require.Equal(t, cooprometheusv1.URL("https://mcoa-observatorium-api.example.com/api/metrics/v1/team-a/api/v1/receive"), teamA.URL)
+	require.Equal(t, ".*", teamA.WriteRelabelConfigs[0].Regex)
+	require.Equal(t, cooprometheusv1.TLSFilesConfig{
+		CAFile:   "/etc/prometheus/secrets/hub-ca/ca.crt",
+		CertFile: "/etc/prometheus/secrets/mcoa-metrics-tenant-client-cert/tls.crt",
+		KeyFile:  "/etc/prometheus/secrets/mcoa-metrics-tenant-client-cert/tls.key",
+	}, teamA.TLSConfig.TLSFilesConfig)
+
+	teamB := spec.RemoteWrite[2]
+	require.Equal(t, cooprometheusv1.URL("https://mcoa-observatorium-api.example.com/api/metrics/v1/team-b/api/v1/receive"), teamB.URL)
+	require.Equal(t, []cooprometheusv1.RelabelConfig{
+		{SourceLabels: []cooprometheusv1.LabelName{namespaceLabel}, Regex: "checkout|billing-.*", Action: "keep"},
+		identity,
+	}, teamB.WriteRelabelConfigs)
+
+	// A series of a tenant is only written to the tenant.
+	spec = &cooprometheusv1.CommonPrometheusFields{RemoteWrite: []cooprometheusv1.RemoteWriteSpec{*base.DeepCopy()}}
+	require.NoError(t, RouteRemoteWrites(spec, []Tenant{{Name: "team-b", Namespaces: []string{"checkout", "billing-.*"}}}, endpoint))
+	for _, tc := range []struct {
+		namespace   string
+		wantDefault bool
+		wantTenant  bool
+	}{
+		{namespace: "billing-eu", wantDefault: false, wantTenant: true},
+		{namespace: "checkout", wantDefault: false, wantTenant: true},
+		{namespace: "frontend", wantDefault: true, wantTenant: false},
+		{namespace: "", wantDefault: true, wantTenant: false},
+	} {
+		series := labels.FromStrings("__name__", "http_requests_total", "namespace", tc.namespace)
+		require.Equal(t, tc.wantDefault, keeps(t, spec.RemoteWrite[0].WriteRelabelConfigs, series), tc.namespace)
+		require.Equal(t, tc.wantTenant, keeps(t, spec.RemoteWrite[1].WriteRelabelConfigs, series), tc.namespace)
+	}
+
+	// Without acm-observability remote write, there is nothing to copy.
+	spec.RemoteWrite = nil
+	require.ErrorIs(t, RouteRemoteWrites(spec, []Tenant{{Name: "team-a"}}, endpoint), errMissingRemoteWrite)
+}
+
+// keeps evaluates the keep and drop write relabel configs on the series.
+func keeps(t *testing.T, cfgs []cooprometheusv1.RelabelConfig, series labels.Labels) bool {
+	t.Helper()
+	var promCfgs []*relabel.Config
+	for _, c := range cfgs {
+		if c.Action != "keep" && c.Action != "drop" {
+			continue
+		}
+		var sourceLabels model.LabelNames
+		for _, l := range c.SourceLabels {
+			sourceLabels = append(sourceLabels, model.LabelName(l))
+		}
+		promCfgs = append(promCfgs, &relabel.Config{
+			SourceLabels:         sourceLabels,
+			Separator:            ";",
+			Regex:                relabel.MustNewRegexp(c.Regex),
+			Action:               relabel.Action(c.Action),
+			NameValidationScheme: model.LegacyValidation,
+		})
+	}
+	return relabel.ProcessBuilder(labels.NewBuilder(series), promCfgs...)
+}
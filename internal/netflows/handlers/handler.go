@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	errMissingFlowCollectorRef  = errors.New("missing FlowCollector reference on addon installation")
+	errMultipleFlowCollectorRef = errors.New("multiple FlowCollector references on addon installation")
+)
+
+// BuildOptions returns the options of the network flows collection of the
+// cluster from the FlowCollector referenced by the addon configs. Since the
+// FlowCollector is cluster-scoped, the secrets and configmaps it references are
+// looked up in the namespace of the cluster first, then in the install
+// namespace of the addon.
+func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, platform addon.NetworkFlowsOptions, federate bool) (manifests.Options, error) {
+	opts := manifests.Options{
+		Platform: platform,
+	}
+
+	keys := common.GetObjectKeys(mcAddon.Status.ConfigReferences, addoncfg.FlowCollectorsGroup, addoncfg.FlowCollectorsResource)
+	switch {
+	case len(keys) == 0:
+		return opts, errMissingFlowCollectorRef
+	case len(keys) > 1:
+		return opts, errMultipleFlowCollectorRef
+	}
+	fc := &unstructured.Unstructured{}
+	fc.SetGroupVersionKind(manifests.FlowCollectorGVK)
+	if err := k8s.Get(ctx, keys[0], fc, &client.GetOptions{}); err != nil {
+		return opts, err
+	}
+	opts.FlowCollector = fc
+
+	secretNames, configMapNames := manifests.GetResourceReferences(fc)
+
+	secrets, err := common.GetSecrets(ctx, k8s, addoncfg.InstallNamespace, mcAddon.Namespace, secretNames)
+	if err != nil {
+		return opts, err
+	}
+	opts.Secrets = secrets
+
+	configMaps, err := common.GetConfigMaps(ctx, k8s, addoncfg.InstallNamespace, mcAddon.Namespace, configMapNames)
+	if err != nil {
+		return opts, err
+	}
+	opts.ConfigMaps = configMaps
+
+	if federate {
+		opts.ScrapeConfig = manifests.GenerateScrapeConfig()
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,186 @@
+package netflows
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/netflows/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	_ = operatorsv1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1alpha1.AddToScheme(scheme.Scheme)
+	_ = manifests.AddToScheme(scheme.Scheme)
+)
+
+func fakeGetValues(k8s client.Client) addonfactory.GetValuesFunc {
+	return func(
+		_ *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, mcAddon, addon.NetworkFlowsOptions{CollectionEnabled: true}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := manifests.BuildValues(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return addonfactory.JsonStructToValues(values)
+	}
+}
+
+func Test_NetFlows_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+
+	flowCollector := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name": "mcoa-network-flows",
+		},
+		"spec": map[string]any{
+			"namespace":       "netobserv",
+			"deploymentModel": "Kafka",
+			"loki": map[string]any{
+				"enable": false,
+			},
+			"processor": map[string]any{
+				"metrics": map[string]any{
+					"includeList": []any{"namespace_flows_total"},
+				},
+			},
+			"exporters": []any{
+				map[string]any{
+					"type": "Kafka",
+					"kafka": map[string]any{
+						"address": "kafka.example.com:9093",
+						"topic":   "network-flows",
+						"tls": map[string]any{
+							"enable": true,
+							"caCert": map[string]any{
+								"type":      "configmap",
+								"name":      "kafka-ca",
+								"namespace": "open-cluster-management-observability",
+								"certFile":  "ca.crt",
+							},
+							"userCert": map[string]any{
+								"type":     "secret",
+								"name":     "kafka-user",
+								"certFile": "tls.crt",
+								"certKey":  "tls.key",
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	flowCollector.SetGroupVersionKind(manifests.FlowCollectorGVK)
+
+	// The secret is looked up in the install namespace when it is not found
+	// in the namespace of the cluster.
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-user",
+			Namespace: addoncfg.InstallNamespace,
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("data"),
+			"tls.key": []byte("data"),
+		},
+	}
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-ca",
+			Namespace: "cluster-1",
+		},
+		Data: map[string]string{
+			"ca.crt": "data",
+		},
+	}
+
+	fakeKubeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(flowCollector, userSecret, caConfigMap).
+		Build()
+
+	managedClusterAddOn.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Group:    addoncfg.FlowCollectorsGroup,
+				Resource: addoncfg.FlowCollectorsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{
+					Name: flowCollector.GetName(),
+				},
+			},
+		},
+	}
+
+	netflowsAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.NetFlowsChartDir).
+		WithGetValuesFuncs(fakeGetValues(fakeKubeClient)).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := netflowsAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+	// 2 namespaces, the operator group, the subscription, the secret, the
+	// configmap and the FlowCollector.
+	require.Len(t, objects, 7)
+
+	foundFlowCollector := false
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *unstructured.Unstructured:
+			if obj.GroupVersionKind() != manifests.FlowCollectorGVK {
+				continue
+			}
+			// Check the name to make sure that if we change the helm manifests
+			// that we don't break the addon probes
+			require.Equal(t, addoncfg.SpokeFlowCollectorName, obj.GetName())
+
+			includeList, _, err := unstructured.NestedStringSlice(obj.Object, "spec", "processor", "metrics", "includeList")
+			require.NoError(t, err)
+			require.Subset(t, includeList, append([]string{"namespace_flows_total"}, manifests.FlowMetrics...))
+
+			exporters, _, err := unstructured.NestedSlice(obj.Object, "spec", "exporters")
+			require.NoError(t, err)
+			caCert, _, err := unstructured.NestedMap(exporters[0].(map[string]any), "kafka", "tls", "caCert")
+			require.NoError(t, err)
+			require.NotContains(t, caCert, "namespace")
+			require.Equal(t, "kafka-ca", caCert["name"])
+			foundFlowCollector = true
+		case *corev1.Secret:
+			require.Equal(t, "netobserv", obj.Namespace)
+			require.Equal(t, userSecret.Data, obj.Data)
+		case *corev1.ConfigMap:
+			require.Equal(t, "netobserv", obj.Namespace)
+			require.Equal(t, caConfigMap.Data, obj.Data)
+		case *corev1.Namespace:
+			require.Equal(t, "true", obj.Labels["openshift.io/cluster-monitoring"])
+		}
+	}
+	require.True(t, foundFlowCollector)
+}
@@ -0,0 +1,92 @@
+package manifests
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Namespace returns the namespace where the FlowCollector deploys the flow
+// collection, which is also where its secrets and configmaps must live.
+func Namespace(fc *unstructured.Unstructured) string {
+	ns, _, _ := unstructured.NestedString(fc.Object, "spec", "namespace")
+	if ns == "" {
+		return DefaultNamespace
+	}
+	return ns
+}
+
+// GetResourceReferences returns the names of the secrets and configmaps
+// referenced by the FlowCollector, e.g. by the TLS configuration of its Loki,
+// Kafka and exporters. References are objects with a type of "secret" or
+// "configmap" and a name.
+func GetResourceReferences(fc *unstructured.Unstructured) ([]string, []string) {
+	secretNames := []string{}
+	configMapNames := []string{}
+	walkReferences(fc.Object["spec"], func(ref map[string]any) {
+		name, _ := ref["name"].(string)
+		switch ref["type"] {
+		case refTypeSecret:
+			if !slices.Contains(secretNames, name) {
+				secretNames = append(secretNames, name)
+			}
+		case refTypeConfigMap:
+			if !slices.Contains(configMapNames, name) {
+				configMapNames = append(configMapNames, name)
+			}
+		}
+	})
+	slices.Sort(secretNames)
+	slices.Sort(configMapNames)
+	return secretNames, configMapNames
+}
+
+// walkReferences calls fn on every secret or configmap reference found in
+// obj.
+func walkReferences(obj any, fn func(ref map[string]any)) {
+	switch obj := obj.(type) {
+	case map[string]any:
+		if name, ok := obj["name"].(string); ok && name != "" && (obj["type"] == refTypeSecret || obj["type"] == refTypeConfigMap) {
+			fn(obj)
+			return
+		}
+		for _, v := range obj {
+			walkReferences(v, fn)
+		}
+	case []any:
+		for _, v := range obj {
+			walkReferences(v, fn)
+		}
+	}
+}
+
+func buildFlowCollectorSpec(opts Options) (map[string]any, error) {
+	spec, _, err := unstructured.NestedMap(opts.FlowCollector.Object, "spec")
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		spec = map[string]any{}
+	}
+
+	// The secrets and configmaps are deployed next to the flow collection,
+	// where the operator looks for them when no namespace is set.
+	walkReferences(spec, func(ref map[string]any) {
+		delete(ref, "namespace")
+	})
+
+	includeList, _, err := unstructured.NestedStringSlice(spec, "processor", "metrics", "includeList")
+	if err != nil {
+		return nil, err
+	}
+	for _, metric := range FlowMetrics {
+		if !slices.Contains(includeList, metric) {
+			includeList = append(includeList, metric)
+		}
+	}
+	if err := unstructured.SetNestedStringSlice(spec, includeList, "processor", "metrics", "includeList"); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,20 @@
+package manifests
+
+import (
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type Options struct {
+	ConfigMaps []corev1.ConfigMap
+	Secrets    []corev1.Secret
+	// FlowCollector is the hub-side template of the FlowCollector of the
+	// cluster, referenced by the addon configs.
+	FlowCollector *unstructured.Unstructured
+	Platform      addon.NetworkFlowsOptions
+	// ScrapeConfig federates the flow metrics through the platform
+	// PrometheusAgent. It is nil when platform metrics are not collected.
+	ScrapeConfig *cooprometheusv1alpha1.ScrapeConfig
+}
@@ -0,0 +1,18 @@
+package manifests
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AddToScheme registers the FlowCollector as an unstructured object so that
+// the addon scheme can decode the rendered manifests without depending on the
+// network observability operator module.
+func AddToScheme(scheme *runtime.Scheme) error {
+	gv := FlowCollectorGVK.GroupVersion()
+	scheme.AddKnownTypeWithName(FlowCollectorGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gv.WithKind(FlowCollectorGVK.Kind+"List"), &unstructured.UnstructuredList{})
+	metav1.AddToGroupVersion(scheme, gv)
+	return nil
+}
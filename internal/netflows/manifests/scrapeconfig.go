@@ -0,0 +1,66 @@
+package manifests
+
+import (
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ScrapeConfigName is the name of the network flows ScrapeConfig
+	ScrapeConfigName = "platform-metrics-network-flows"
+
+	// ScrapeConfigJobName is the job name of the network flows scrape job
+	ScrapeConfigJobName = "network-flows"
+)
+
+// GenerateScrapeConfig generates the ScrapeConfig federating the FlowCollector
+// metrics from the in-cluster monitoring stack, which scrapes them, through
+// the platform PrometheusAgent. The FlowCollector is only deployed on
+// OpenShift clusters, so is the ScrapeConfig.
+func GenerateScrapeConfig() *cooprometheusv1alpha1.ScrapeConfig {
+	matchParams := make([]string, 0, len(FlowMetrics))
+	for _, metric := range FlowMetrics {
+		matchParams = append(matchParams, "{__name__=\""+metricsPrefix+metric+"\"}")
+	}
+
+	return &cooprometheusv1alpha1.ScrapeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ScrapeConfig",
+			APIVersion: "monitoring.rhobs/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ScrapeConfigName,
+			Labels: map[string]string{
+				addoncfg.ComponentK8sLabelKey: mconfig.PlatformPrometheusMatchLabels[addoncfg.ComponentK8sLabelKey],
+				addoncfg.PartOfK8sLabelKey:    addoncfg.Name,
+				addoncfg.ManagedByK8sLabelKey: addoncfg.Name,
+			},
+		},
+		Spec: cooprometheusv1alpha1.ScrapeConfigSpec{
+			JobName:         ptr.To(ScrapeConfigJobName),
+			MetricsPath:     ptr.To("/federate"),
+			ScrapeClassName: ptr.To(mconfig.ScrapeClassCfgName),
+			Scheme:          ptr.To(cooprometheusv1.Scheme("HTTPS")),
+			StaticConfigs: []cooprometheusv1alpha1.StaticConfig{
+				{
+					Targets: []cooprometheusv1alpha1.Target{
+						cooprometheusv1alpha1.Target(mconfig.ScrapeClassPlatformTarget),
+					},
+				},
+			},
+			Params: map[string][]string{
+				"match[]": matchParams,
+			},
+			MetricRelabelConfigs: []cooprometheusv1.RelabelConfig{
+				{
+					Action: "labeldrop",
+					Regex:  "managed_cluster|managed_cluster_name|id",
+				},
+			},
+		},
+	}
+}
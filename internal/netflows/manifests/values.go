@@ -0,0 +1,83 @@
+package manifests
+
+import (
+	"encoding/json"
+)
+
+type NetFlowsValues struct {
+	Enabled bool `json:"enabled"`
+	// Namespace is where the flow collection and its secrets and
+	// configmaps are deployed.
+	Namespace                string             `json:"namespace"`
+	FlowCollectorAnnotations string             `json:"flowCollectorAnnotations"`
+	FlowCollectorSpec        string             `json:"flowCollectorSpec"`
+	Secrets                  []ResourceValue    `json:"secrets"`
+	ConfigMaps               []ResourceValue    `json:"configmaps"`
+	ScrapeConfig             *ScrapeConfigValue `json:"scrapeConfig,omitempty"`
+}
+
+type ResourceValue struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+type ScrapeConfigValue struct {
+	Name   string            `json:"name"`
+	Data   string            `json:"data"`
+	Labels map[string]string `json:"labels"`
+}
+
+func BuildValues(opts Options) (*NetFlowsValues, error) {
+	values := &NetFlowsValues{
+		Enabled:    true,
+		Namespace:  Namespace(opts.FlowCollector),
+		Secrets:    []ResourceValue{},
+		ConfigMaps: []ResourceValue{},
+	}
+
+	for _, secret := range opts.Secrets {
+		dataJSON, err := json.Marshal(secret.Data)
+		if err != nil {
+			return nil, err
+		}
+		values.Secrets = append(values.Secrets, ResourceValue{Name: secret.Name, Data: string(dataJSON)})
+	}
+
+	for _, configmap := range opts.ConfigMaps {
+		dataJSON, err := json.Marshal(configmap.Data)
+		if err != nil {
+			return nil, err
+		}
+		values.ConfigMaps = append(values.ConfigMaps, ResourceValue{Name: configmap.Name, Data: string(dataJSON)})
+	}
+
+	annotationsJSON, err := json.Marshal(opts.FlowCollector.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+	values.FlowCollectorAnnotations = string(annotationsJSON)
+
+	spec, err := buildFlowCollectorSpec(opts)
+	if err != nil {
+		return nil, err
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	values.FlowCollectorSpec = string(specJSON)
+
+	if opts.ScrapeConfig != nil {
+		scJSON, err := json.Marshal(opts.ScrapeConfig.Spec)
+		if err != nil {
+			return nil, err
+		}
+		values.ScrapeConfig = &ScrapeConfigValue{
+			Name:   opts.ScrapeConfig.Name,
+			Data:   string(scJSON),
+			Labels: opts.ScrapeConfig.Labels,
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,36 @@
+package manifests
+
+import (
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// DefaultNamespace is where the network observability operator deploys
+	// the flow collection when the FlowCollector doesn't set one.
+	DefaultNamespace = "netobserv"
+
+	refTypeSecret    = "secret"
+	refTypeConfigMap = "configmap"
+)
+
+var FlowCollectorGVK = schema.GroupVersionKind{
+	Group:   addoncfg.FlowCollectorsGroup,
+	Version: "v1beta2",
+	Kind:    "FlowCollector",
+}
+
+// FlowMetrics are the metrics of the FlowCollector queried by the networking
+// dashboards. They are always added to the metrics of the FlowCollector and
+// federated to the hub.
+var FlowMetrics = []string{
+	"namespace_ingress_bytes_total",
+	"namespace_drop_packets_total",
+	"workload_ingress_bytes_total",
+	"workload_egress_bytes_total",
+	"node_ingress_bytes_total",
+	"node_egress_bytes_total",
+}
+
+// metricsPrefix is prepended to the names of the FlowCollector metrics.
+const metricsPrefix = "netobserv_"
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	"github.com/stolostron/multicluster-observability-addon/internal/obsapi/manifests"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/yaml"
+)
+
+// clientSecretKey is the key of the OIDC client secret in the Secret of a
+// tenant.
+const clientSecretKey = "clientSecret"
+
+var (
+	errMultipleObsAPIConfigRef = errors.New("multiple observability API ConfigMap references on addon installation")
+	errInvalidObsAPIField      = errors.New("invalid field in observability API ConfigMap")
+	errInvalidObsAPITenant     = errors.New("invalid tenant in observability API ConfigMap")
+	errMissingClientSecret     = errors.New("missing OIDC client secret")
+	errMissingOIDCFields       = errors.New("OIDC requires issuerURL and clientID")
+	errInvalidTenantAuth       = errors.New("a tenant requires exactly one of oidc and mTLS")
+	errInvalidEndpointURL      = errors.New("expected a URL with a host")
+	errInvalidEndpointHostPort = errors.New("expected a host and port")
+	errMissingImage            = errors.New("missing observatorium image in the images ConfigMap")
+)
+
+// GetObsAPIConfig returns the configuration of the observability API of the
+// hub from the ConfigMap labeled with the observability API config label among
+// the addon configs, along with the ConfigMap itself. The defaults are
+// returned when no ConfigMap is referenced.
+func GetObsAPIConfig(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn) (manifests.Config, *corev1.ConfigMap, error) {
+	config := manifests.Config{
+		Replicas: manifests.DefaultReplicas,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(manifests.DefaultCPURequest),
+				corev1.ResourceMemory: resource.MustParse(manifests.DefaultMemoryRequest),
+			},
+		},
+		ImagePullSecret: manifests.DefaultImagePullSecret,
+		Endpoints: manifests.Endpoints{
+			MetricsRead:  manifests.DefaultMetricsReadEndpoint,
+			MetricsWrite: manifests.DefaultMetricsWriteEndpoint,
+		},
+	}
+
+	var cms []*corev1.ConfigMap
+	for _, key := range common.GetObjectKeys(mcAddon.Status.ConfigReferences, "", addoncfg.ConfigMapsResource) {
+		cm := &corev1.ConfigMap{}
+		if err := k8s.Get(ctx, key, cm, &client.GetOptions{}); err != nil {
+			return config, nil, err
+		}
+		if _, ok := cm.Labels[addoncfg.ObsAPIConfigLabelKey]; !ok {
+			continue
+		}
+		cms = append(cms, cm)
+	}
+	switch {
+	case len(cms) == 0:
+		return config, nil, nil
+	case len(cms) > 1:
+		return config, nil, errMultipleObsAPIConfigRef
+	}
+
+	cm := cms[0]
+	invalid := func(field string, err error) error {
+		return fmt.Errorf("%w %s/%s: %s: %w", errInvalidObsAPIField, cm.Namespace, cm.Name, field, err)
+	}
+
+	if v, ok := cm.Data["replicas"]; ok {
+		replicas, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return config, cm, invalid("replicas", err)
+		}
+		if replicas < 1 {
+			return config, cm, invalid("replicas", fmt.Errorf("must be at least 1, got %d", replicas))
+		}
+		config.Replicas = int32(replicas)
+	}
+	if v, ok := cm.Data["resources"]; ok {
+		resources := corev1.ResourceRequirements{}
+		if err := yaml.UnmarshalStrict([]byte(v), &resources); err != nil {
+			return config, cm, invalid("resources", err)
+		}
+		config.Resources = resources
+	}
+	if v := cm.Data["image"]; v != "" {
+		config.Image = v
+	}
+	if v := cm.Data["imagePullSecret"]; v != "" {
+		config.ImagePullSecret = v
+	}
+
+	endpoints := map[string]*string{
+		"metricsReadEndpoint":  &config.Endpoints.MetricsRead,
+		"metricsWriteEndpoint": &config.Endpoints.MetricsWrite,
+		"logsReadEndpoint":     &config.Endpoints.LogsRead,
+		"logsTailEndpoint":     &config.Endpoints.LogsTail,
+		"logsWriteEndpoint":    &config.Endpoints.LogsWrite,
+		"tracesReadEndpoint":   &config.Endpoints.TracesRead,
+	}
+	// Sorted so that the first invalid endpoint is always the one reported.
+	for _, field := range slices.Sorted(maps.Keys(endpoints)) {
+		endpoint := endpoints[field]
+		v := cm.Data[field]
+		if v == "" {
+			continue
+		}
+		if !addon.IsURL(v) {
+			return config, cm, invalid(field, errInvalidEndpointURL)
+		}
+		*endpoint = v
+	}
+	// The traces are written over OTLP gRPC, to a host and port.
+	if v := cm.Data["tracesWriteEndpoint"]; v != "" {
+		if !addon.IsHostPort(v) {
+			return config, cm, invalid("tracesWriteEndpoint", errInvalidEndpointHostPort)
+		}
+		config.Endpoints.TracesWrite = v
+	}
+
+	if v, ok := cm.Data["tenants"]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &config.Tenants); err != nil {
+			return config, cm, invalid("tenants", err)
+		}
+	}
+	if v, ok := cm.Data["metricsTenantsOIDC"]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &config.MetricsTenantsOIDC); err != nil {
+			return config, cm, invalid("metricsTenantsOIDC", err)
+		}
+	}
+	if v, ok := cm.Data["rbac"]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &config.RBAC); err != nil {
+			return config, cm, invalid("rbac", err)
+		}
+	}
+	if v, ok := cm.Data["rateLimits"]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &config.RateLimits); err != nil {
+			return config, cm, invalid("rateLimits", err)
+		}
+	}
+
+	return config, cm, nil
+}
+
+// BuildOptions returns the options of the observability API of the hub,
+// served on the host of its route. The image defaults to the observatorium
+// image of the images ConfigMap. The configured tenants can't reuse the name
+// of the default or of a metrics tenant and must set their authentication, and
+// the OIDC client secrets are read from their Secrets, next to the ConfigMap.
+func BuildOptions(ctx context.Context, k8s client.Client, logger logr.Logger, mcAddon *addonapiv1beta1.ManagedClusterAddOn, host string, registries []addonapiv1beta1.ImageMirror) (manifests.Options, error) {
+	config, cm, err := GetObsAPIConfig(ctx, k8s, mcAddon)
+	if err != nil {
+		return manifests.Options{}, err
+	}
+
+	if config.Image == "" {
+		images, err := mconfig.GetImageOverrides(ctx, k8s, registries, logger)
+		if err != nil {
+			return manifests.Options{}, err
+		}
+		if images.Observatorium == "" {
+			return manifests.Options{}, errMissingImage
+		}
+		config.Image = images.Observatorium
+	}
+
+	metricsTenants, err := tenants.GetTenants(ctx, k8s, logger)
+	if err != nil {
+		return manifests.Options{}, err
+	}
+
+	opts := manifests.Options{
+		Config:         config,
+		Host:           host,
+		MetricsTenants: metricsTenants,
+		ClientSecrets:  map[string]string{},
+	}
+	if cm == nil {
+		return opts, nil
+	}
+
+	if config.MetricsTenantsOIDC != nil {
+		clientSecret, err := getClientSecret(ctx, k8s, cm, config.MetricsTenantsOIDC)
+		if err != nil {
+			return opts, fmt.Errorf("%w %s/%s: metrics tenants: %w", errInvalidObsAPITenant, cm.Namespace, cm.Name, err)
+		}
+		opts.MetricsTenantsClientSecret = clientSecret
+	}
+
+	names := map[string]struct{}{tenants.DefaultTenant: {}}
+	for _, tenant := range metricsTenants {
+		names[tenant.Name] = struct{}{}
+	}
+	for _, tenant := range config.Tenants {
+		if errs := validation.IsDNS1123Label(tenant.Name); len(errs) > 0 {
+			return opts, fmt.Errorf("%w %s/%s: %q: %v", errInvalidObsAPITenant, cm.Namespace, cm.Name, tenant.Name, errs)
+		}
+		if _, ok := names[tenant.Name]; ok {
+			return opts, fmt.Errorf("%w %s/%s: %q: name already used", errInvalidObsAPITenant, cm.Namespace, cm.Name, tenant.Name)
+		}
+		names[tenant.Name] = struct{}{}
+
+		if (tenant.OIDC == nil) == (tenant.MTLS == nil) {
+			return opts, fmt.Errorf("%w %s/%s: %q: %w", errInvalidObsAPITenant, cm.Namespace, cm.Name, tenant.Name, errInvalidTenantAuth)
+		}
+		if tenant.OIDC == nil {
+			continue
+		}
+		clientSecret, err := getClientSecret(ctx, k8s, cm, tenant.OIDC)
+		if err != nil {
+			return opts, fmt.Errorf("%w %s/%s: %q: %w", errInvalidObsAPITenant, cm.Namespace, cm.Name, tenant.Name, err)
+		}
+		if clientSecret != "" {
+			opts.ClientSecrets[tenant.Name] = clientSecret
+		}
+	}
+
+	return opts, nil
+}
+
+// getClientSecret validates the OIDC configuration and returns its client
+// secret, empty when the client has none.
+func getClientSecret(ctx context.Context, k8s client.Client, cm *corev1.ConfigMap, oidc *manifests.OIDC) (string, error) {
+	if oidc.IssuerURL == "" || oidc.ClientID == "" {
+		return "", errMissingOIDCFields
+	}
+	if oidc.ClientSecretName == "" {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := k8s.Get(ctx, types.NamespacedName{Name: oidc.ClientSecretName, Namespace: cm.Namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s in namespace %s: %w", oidc.ClientSecretName, cm.Namespace, err)
+	}
+	clientSecret := string(secret.Data[clientSecretKey])
+	if clientSecret == "" {
+		return "", fmt.Errorf("%w: %s/%s", errMissingClientSecret, secret.Namespace, secret.Name)
+	}
+	return clientSecret, nil
+}
+
+// SecretPredicate filters Secret events down to the labeled OIDC client
+// secrets of the tenants.
+func SecretPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isObsAPISecret(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isObsAPISecret(e.ObjectOld) || isObsAPISecret(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isObsAPISecret(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isObsAPISecret(obj client.Object) bool {
+	_, ok := obj.GetLabels()[addoncfg.ObsAPIConfigLabelKey]
+	return ok
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/obsapi/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const tenantsYAML = `
+- name: sre
+  oidc:
+    issuerURL: https://sso.example.com/realms/ocm
+    clientID: observatorium
+    clientSecretName: sre-oidc
+    groupClaim: groups
+- name: batch
+  mTLS: {}
+`
+
+func newAddon(cm *corev1.ConfigMap) *addonapiv1beta1.ManagedClusterAddOn {
+	mcAddon := addontesting.NewAddon(addoncfg.Name, "local-cluster")
+	if cm == nil {
+		return mcAddon
+	}
+	mcAddon.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Resource: addoncfg.ConfigMapsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{
+					Namespace: cm.Namespace,
+					Name:      cm.Name,
+				},
+			},
+		},
+	}
+	return mcAddon
+}
+
+func newConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "observatorium-api",
+			Namespace: addoncfg.InstallNamespace,
+			Labels: map[string]string{
+				addoncfg.ObsAPIConfigLabelKey: "",
+			},
+		},
+		Data: data,
+	}
+}
+
+func newImagesList(observatorium string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mconfig.ImagesConfigMapObjKey.Name,
+			Namespace: mconfig.ImagesConfigMapObjKey.Namespace,
+		},
+		Data: map[string]string{
+			"prometheus_config_reloader":    "reloader",
+			"kube_rbac_proxy":               "kube-rbac-proxy",
+			"obo_prometheus_rhel9_operator": "operator",
+			"kube_state_metrics":            "kube-state-metrics",
+			"node_exporter":                 "node-exporter",
+			"prometheus":                    "prometheus",
+			"endpoint_monitoring_operator":  "endpoint-operator",
+			"observatorium":                 observatorium,
+		},
+	}
+}
+
+func TestBuildOptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sre-oidc",
+			Namespace: addoncfg.InstallNamespace,
+			Labels: map[string]string{
+				addoncfg.ObsAPIConfigLabelKey: "",
+			},
+		},
+		Data: map[string][]byte{
+			clientSecretKey: []byte("s3cr3t"),
+		},
+	}
+	metricsTenant := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "payments",
+			Namespace: addoncfg.InstallNamespace,
+			Labels: map[string]string{
+				addoncfg.MetricsTenantLabelKey: "",
+			},
+		},
+		Data: map[string]string{
+			"clusterSets": "payments",
+		},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		config      *corev1.ConfigMap
+		images      *corev1.ConfigMap
+		expectedErr error
+		expectedMsg string
+		check       func(t *testing.T, opts manifests.Options)
+	}{
+		{
+			name: "defaults without config",
+			check: func(t *testing.T, opts manifests.Options) {
+				require.Equal(t, manifests.DefaultReplicas, opts.Config.Replicas)
+				require.Equal(t, "registry.example.com/observatorium:v1", opts.Config.Image)
+				require.Equal(t, manifests.DefaultMetricsWriteEndpoint, opts.Config.Endpoints.MetricsWrite)
+				require.Empty(t, opts.Config.Endpoints.LogsRead)
+				require.Len(t, opts.MetricsTenants, 1)
+			},
+		},
+		{
+			name: "configured tenants",
+			config: newConfigMap(map[string]string{
+				"replicas":          "1",
+				"image":             "quay.io/observatorium/api:main",
+				"logsWriteEndpoint": "https://loki.example.com",
+				"tenants":           tenantsYAML,
+				"rateLimits":        "- endpoint: /api/metrics/v1/.+/api/v1/receive\n  limit: 100\n  window: 1s\n",
+			}),
+			check: func(t *testing.T, opts manifests.Options) {
+				require.Equal(t, int32(1), opts.Config.Replicas)
+				require.Equal(t, "quay.io/observatorium/api:main", opts.Config.Image)
+				require.Equal(t, "https://loki.example.com", opts.Config.Endpoints.LogsWrite)
+				require.Len(t, opts.Config.Tenants, 2)
+				require.Equal(t, "groups", opts.Config.Tenants[0].OIDC.GroupClaim)
+				require.Equal(t, map[string]string{"sre": "s3cr3t"}, opts.ClientSecrets)
+				require.Equal(t, 100, opts.Config.RateLimits[0].Limit)
+			},
+		},
+		{
+			name: "metrics tenants readers",
+			config: newConfigMap(map[string]string{
+				"metricsTenantsOIDC": "issuerURL: https://sso.example.com/realms/ocm\nclientID: observatorium\nclientSecretName: sre-oidc\n",
+			}),
+			check: func(t *testing.T, opts manifests.Options) {
+				require.Equal(t, "observatorium", opts.Config.MetricsTenantsOIDC.ClientID)
+				require.Equal(t, "s3cr3t", opts.MetricsTenantsClientSecret)
+				require.Equal(t, "observatorium-api.example.com", opts.Host)
+			},
+		},
+		{
+			name:        "metrics tenants oidc without issuer",
+			config:      newConfigMap(map[string]string{"metricsTenantsOIDC": "clientID: observatorium\n"}),
+			expectedErr: errMissingOIDCFields,
+		},
+		{
+			name:        "image missing from the images ConfigMap",
+			images:      newImagesList(""),
+			expectedErr: errMissingImage,
+		},
+		{
+			name:        "invalid replicas",
+			config:      newConfigMap(map[string]string{"replicas": "0"}),
+			expectedErr: errInvalidObsAPIField,
+		},
+		{
+			name:        "invalid endpoint",
+			config:      newConfigMap(map[string]string{"tracesReadEndpoint": "tempo"}),
+			expectedErr: errInvalidEndpointURL,
+		},
+		{
+			name: "first invalid endpoint by key",
+			config: newConfigMap(map[string]string{
+				"tracesReadEndpoint":   "tempo",
+				"metricsWriteEndpoint": "thanos",
+				"logsTailEndpoint":     "loki",
+			}),
+			expectedErr: errInvalidEndpointURL,
+			expectedMsg: "logsTailEndpoint",
+		},
+		{
+			name:        "traces write endpoint without port",
+			config:      newConfigMap(map[string]string{"tracesWriteEndpoint": "https://tempo.example.com"}),
+			expectedErr: errInvalidEndpointHostPort,
+		},
+		{
+			name:        "unknown tenant field",
+			config:      newConfigMap(map[string]string{"tenants": "- name: sre\n  ldap: {}\n"}),
+			expectedErr: errInvalidObsAPIField,
+		},
+		{
+			name:        "tenant reusing a metrics tenant name",
+			config:      newConfigMap(map[string]string{"tenants": "- name: payments\n  mTLS: {}\n"}),
+			expectedErr: errInvalidObsAPITenant,
+		},
+		{
+			name:        "oidc tenant without client id",
+			config:      newConfigMap(map[string]string{"tenants": "- name: sre\n  oidc:\n    issuerURL: https://sso.example.com\n"}),
+			expectedErr: errInvalidObsAPITenant,
+		},
+		{
+			name:        "tenant without authentication",
+			config:      newConfigMap(map[string]string{"tenants": "- name: batch\n"}),
+			expectedErr: errInvalidTenantAuth,
+		},
+		{
+			name:        "tenant with both authentications",
+			config:      newConfigMap(map[string]string{"tenants": "- name: sre\n  mTLS: {}\n  oidc:\n    issuerURL: https://sso.example.com\n    clientID: observatorium\n"}),
+			expectedErr: errInvalidTenantAuth,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			images := tc.images
+			if images == nil {
+				images = newImagesList("registry.example.com/observatorium:v1")
+			}
+			objs := []client.Object{clientSecret, metricsTenant, images}
+			if tc.config != nil {
+				objs = append(objs, tc.config)
+			}
+			k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+			opts, err := BuildOptions(t.Context(), k8s, logr.Discard(), newAddon(tc.config), "observatorium-api.example.com", nil)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				if tc.expectedMsg != "" {
+					require.ErrorContains(t, err, tc.expectedMsg)
+				}
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, opts)
+		})
+	}
+}
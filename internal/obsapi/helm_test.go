@@ -0,0 +1,142 @@
+package obsapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/obsapi/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/obsapi/manifests"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = routev1.AddToScheme(scheme.Scheme)
+
+func fakeGetValues(k8s client.Client) addonfactory.GetValuesFunc {
+	return func(
+		_ *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, logr.Discard(), mcAddon, "observatorium-api.example.com", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := manifests.BuildValues(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return addonfactory.JsonStructToValues(values)
+	}
+}
+
+func Test_ObsAPI_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("local-cluster")
+	managedClusterAddOn := addontesting.NewAddon("test", "local-cluster")
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "observatorium-api",
+			Namespace: "open-cluster-management-observability",
+			Labels: map[string]string{
+				addoncfg.ObsAPIConfigLabelKey: "",
+			},
+		},
+		Data: map[string]string{
+			"replicas":            "3",
+			"resources":           "limits:\n  memory: 1Gi\n",
+			"logsReadEndpoint":    "https://loki.example.com",
+			"tracesWriteEndpoint": "tempo.example.com:4317",
+		},
+	}
+
+	imagesList := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mconfig.ImagesConfigMapObjKey.Name,
+			Namespace: mconfig.ImagesConfigMapObjKey.Namespace,
+		},
+		Data: map[string]string{
+			"prometheus_config_reloader":    "reloader",
+			"kube_rbac_proxy":               "kube-rbac-proxy",
+			"obo_prometheus_rhel9_operator": "operator",
+			"kube_state_metrics":            "kube-state-metrics",
+			"node_exporter":                 "node-exporter",
+			"prometheus":                    "prometheus",
+			"endpoint_monitoring_operator":  "endpoint-operator",
+			"observatorium":                 "registry.example.com/observatorium:v1",
+		},
+	}
+
+	fakeKubeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(config, imagesList).
+		Build()
+
+	managedClusterAddOn.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Resource: addoncfg.ConfigMapsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{
+					Namespace: config.Namespace,
+					Name:      config.Name,
+				},
+			},
+		},
+	}
+
+	obsAPIAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.ObsAPIChartDir).
+		WithGetValuesFuncs(fakeGetValues(fakeKubeClient)).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := obsAPIAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+	// The deployment, its service and route, and the RBAC and tenants
+	// configurations.
+	require.Len(t, objects, 5)
+
+	for _, obj := range objects {
+		require.Equal(t, addoncfg.InstallNamespace, obj.(client.Object).GetNamespace())
+		switch obj := obj.(type) {
+		case *appsv1.Deployment:
+			require.Equal(t, addoncfg.HubObsAPIName, obj.Name)
+			require.Equal(t, int32(3), *obj.Spec.Replicas)
+			require.Equal(t, manifests.DefaultImagePullSecret, obj.Spec.Template.Spec.ImagePullSecrets[0].Name)
+
+			container := obj.Spec.Template.Spec.Containers[0]
+			require.Equal(t, "registry.example.com/observatorium:v1", container.Image)
+			require.Equal(t, resource.MustParse("1Gi"), container.Resources.Limits[corev1.ResourceMemory])
+			require.Empty(t, container.Resources.Requests)
+			require.NotNil(t, container.ReadinessProbe)
+			require.Contains(t, container.Args, "--metrics.read.endpoint="+manifests.DefaultMetricsReadEndpoint)
+			require.Contains(t, container.Args, "--logs.read.endpoint=https://loki.example.com")
+			require.Contains(t, container.Args, "--traces.write.otlpgrpc.endpoint=tempo.example.com:4317")
+			require.NotContains(t, container.Args, "--logs.write.endpoint=")
+		case *corev1.Secret:
+			require.Contains(t, obj.StringData["tenants.yaml"], "name: default")
+		case *routev1.Route:
+			require.Equal(t, "observatorium-api.example.com", obj.Spec.Host)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package manifests
+
+import (
+	"fmt"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultTenantID is the id of the default tenant, under which the
+	// metrics are stored by Thanos.
+	defaultTenantID = "0fc2b00e-201b-4c17-b9f2-19d91adc4fd2"
+	// clientCAPath is where the CA of the client certificates is mounted.
+	clientCAPath = "/var/run/mtls/default/tls.crt"
+
+	// managedClusterGroup is the group of the client certificate the managed
+	// clusters write their metrics with, and grafanaUser the user of the
+	// client certificate reading the metrics of the default tenant on the hub.
+	managedClusterGroup = "acm"
+	grafanaUser         = "grafana"
+
+	// receivePattern matches the write path of the metrics, authenticated
+	// with the client certificates of the clusters. The other paths of the
+	// metrics tenants are authenticated with OIDC.
+	receivePattern = "/api/metrics/v1/[^/]+/api/v1/receive"
+
+	metricsResource = "metrics"
+	readPermission  = "read"
+	writePermission = "write"
+)
+
+type tenantsConfig struct {
+	Tenants []tenantConfig `json:"tenants"`
+}
+
+type tenantConfig struct {
+	Name       string      `json:"name"`
+	ID         string      `json:"id"`
+	OIDC       *oidcConfig `json:"oidc,omitempty"`
+	MTLS       *mtlsConfig `json:"mTLS,omitempty"`
+	RateLimits []RateLimit `json:"rateLimits,omitempty"`
+}
+
+type oidcConfig struct {
+	ClientID      string `json:"clientID"`
+	ClientSecret  string `json:"clientSecret,omitempty"`
+	IssuerURL     string `json:"issuerURL"`
+	RedirectURL   string `json:"redirectURL,omitempty"`
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	GroupClaim    string `json:"groupClaim,omitempty"`
+	// Paths restricts the authenticator to the matching paths, all the paths
+	// when empty.
+	Paths []pathConfig `json:"paths,omitempty"`
+}
+
+type mtlsConfig struct {
+	CAPath string       `json:"caPath"`
+	Paths  []pathConfig `json:"paths,omitempty"`
+}
+
+type pathConfig struct {
+	Operator string `json:"operator"`
+	Pattern  string `json:"pattern"`
+}
+
+// buildTenantsConfig returns the default tenant, the metrics tenants and the
+// configured tenants. The id of a metrics tenant is its name. The metrics
+// tenants are written with the client certificates of the clusters and read
+// with OIDC. The configured tenants use the authentication they set.
+func buildTenantsConfig(opts Options) (string, error) {
+	hubMTLS := &mtlsConfig{CAPath: clientCAPath}
+	cfg := tenantsConfig{
+		Tenants: []tenantConfig{
+			{Name: tenants.DefaultTenant, ID: defaultTenantID, MTLS: hubMTLS, RateLimits: opts.Config.RateLimits},
+		},
+	}
+	for _, tenant := range opts.MetricsTenants {
+		tc := tenantConfig{
+			Name:       tenant.Name,
+			ID:         tenant.Name,
+			MTLS:       &mtlsConfig{CAPath: clientCAPath, Paths: []pathConfig{{Operator: "=~", Pattern: receivePattern}}},
+			RateLimits: opts.Config.RateLimits,
+		}
+		if oidc := opts.Config.MetricsTenantsOIDC; oidc != nil {
+			tc.OIDC = newOIDCConfig(oidc, opts.MetricsTenantsClientSecret)
+			tc.OIDC.Paths = []pathConfig{{Operator: "!~", Pattern: receivePattern}}
+		}
+		cfg.Tenants = append(cfg.Tenants, tc)
+	}
+	for _, tenant := range opts.Config.Tenants {
+		tc := tenantConfig{Name: tenant.Name, ID: tenant.ID, RateLimits: tenant.RateLimits}
+		if tc.ID == "" {
+			tc.ID = tenant.Name
+		}
+		if len(tc.RateLimits) == 0 {
+			tc.RateLimits = opts.Config.RateLimits
+		}
+		switch {
+		case tenant.OIDC != nil:
+			tc.OIDC = newOIDCConfig(tenant.OIDC, opts.ClientSecrets[tenant.Name])
+		case tenant.MTLS != nil:
+			tc.MTLS = hubMTLS
+		}
+		cfg.Tenants = append(cfg.Tenants, tc)
+	}
+
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func newOIDCConfig(oidc *OIDC, clientSecret string) *oidcConfig {
+	return &oidcConfig{
+		ClientID:      oidc.ClientID,
+		ClientSecret:  clientSecret,
+		IssuerURL:     oidc.IssuerURL,
+		RedirectURL:   oidc.RedirectURL,
+		UsernameClaim: oidc.UsernameClaim,
+		GroupClaim:    oidc.GroupClaim,
+	}
+}
+
+// buildRBACConfig lets the managed clusters write the metrics of the default
+// tenant, and the clusters of its cluster sets, or all the clusters when it
+// has none, write the metrics of a metrics tenant. The reads of a metrics
+// tenant are restricted to its readers, authenticated with OIDC. The
+// configured roles and bindings come last.
+func buildRBACConfig(opts Options) (string, error) {
+	cfg := RBAC{
+		Roles: []Role{
+			{Name: "read-only-metrics", Resources: []string{metricsResource}, Tenants: []string{tenants.DefaultTenant}, Permissions: []string{readPermission}},
+			{Name: "write-only-metrics", Resources: []string{metricsResource}, Tenants: []string{tenants.DefaultTenant}, Permissions: []string{writePermission}},
+		},
+		RoleBindings: []RoleBinding{
+			{Name: "read-only-metrics", Roles: []string{"read-only-metrics"}, Subjects: []Subject{{Name: grafanaUser, Kind: "user"}}},
+			{Name: "write-only-metrics", Roles: []string{"write-only-metrics"}, Subjects: []Subject{{Name: managedClusterGroup, Kind: "group"}}},
+		},
+	}
+	for _, tenant := range opts.MetricsTenants {
+		name := fmt.Sprintf("%s-write-metrics", tenant.Name)
+		cfg.Roles = append(cfg.Roles, Role{Name: name, Resources: []string{metricsResource}, Tenants: []string{tenant.Name}, Permissions: []string{writePermission}})
+		cfg.RoleBindings = append(cfg.RoleBindings, RoleBinding{Name: name, Roles: []string{name}, Subjects: groupSubjects(tenant.WriterGroups())})
+
+		if opts.Config.MetricsTenantsOIDC == nil || len(tenant.Readers) == 0 {
+			continue
+		}
+		name = fmt.Sprintf("%s-read-metrics", tenant.Name)
+		cfg.Roles = append(cfg.Roles, Role{Name: name, Resources: []string{metricsResource}, Tenants: []string{tenant.Name}, Permissions: []string{readPermission}})
+		cfg.RoleBindings = append(cfg.RoleBindings, RoleBinding{Name: name, Roles: []string{name}, Subjects: groupSubjects(tenant.Readers)})
+	}
+	cfg.Roles = append(cfg.Roles, opts.Config.RBAC.Roles...)
+	cfg.RoleBindings = append(cfg.RoleBindings, opts.Config.RBAC.RoleBindings...)
+
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func groupSubjects(groups []string) []Subject {
+	subjects := make([]Subject, 0, len(groups))
+	for _, group := range groups {
+		subjects = append(subjects, Subject{Name: group, Kind: "group"})
+	}
+	return subjects
+}
@@ -0,0 +1,113 @@
+package manifests
+
+import (
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Config is the configuration of the observability API of the hub, read from
+// the ConfigMap referenced by the addon configs.
+type Config struct {
+	Replicas        int32
+	Resources       corev1.ResourceRequirements
+	Image           string
+	ImagePullSecret string
+	Endpoints       Endpoints
+	// Tenants are authenticated either with OIDC or with the client
+	// certificates of the hub.
+	Tenants []Tenant
+	// MetricsTenantsOIDC authenticates the readers of the metrics tenants,
+	// whose groups are the readers of the tenants. The metrics tenants can't
+	// be read without it.
+	MetricsTenantsOIDC *OIDC
+	// RBAC holds roles and bindings added to the generated ones.
+	RBAC RBAC
+	// RateLimits apply to the tenants that don't set their own.
+	RateLimits []RateLimit
+}
+
+// Endpoints are the upstreams the API proxies the signals to. The logs and
+// traces APIs are only served when their endpoints are set.
+type Endpoints struct {
+	MetricsRead  string
+	MetricsWrite string
+	LogsRead     string
+	LogsTail     string
+	LogsWrite    string
+	TracesRead   string
+	// TracesWrite is the host and port of an OTLP gRPC receiver.
+	TracesWrite string
+}
+
+// Tenant is a tenant of the API in addition to the default and metrics
+// tenants.
+type Tenant struct {
+	Name string `json:"name"`
+	// ID is the id the signals of the tenant are stored under, the name of the
+	// tenant when unset.
+	ID string `json:"id,omitempty"`
+	// Exactly one of OIDC and MTLS authenticates the tenant.
+	OIDC       *OIDC       `json:"oidc,omitempty"`
+	MTLS       *MTLS       `json:"mTLS,omitempty"`
+	RateLimits []RateLimit `json:"rateLimits,omitempty"`
+}
+
+// MTLS authenticates the tenant with the client certificates issued by the
+// client CA of the hub.
+type MTLS struct{}
+
+type OIDC struct {
+	IssuerURL string `json:"issuerURL"`
+	ClientID  string `json:"clientID"`
+	// ClientSecretName is the name of the Secret, in the namespace of the
+	// ConfigMap, holding the client secret under the clientSecret key.
+	ClientSecretName string `json:"clientSecretName,omitempty"`
+	RedirectURL      string `json:"redirectURL,omitempty"`
+	UsernameClaim    string `json:"usernameClaim,omitempty"`
+	GroupClaim       string `json:"groupClaim,omitempty"`
+}
+
+// RateLimit limits the requests of a tenant to the endpoints matching a
+// regular expression, e.g. /api/metrics/v1/.+/api/v1/receive.
+type RateLimit struct {
+	Endpoint string `json:"endpoint"`
+	Limit    int    `json:"limit"`
+	// Window is a duration, e.g. 1s.
+	Window   string `json:"window"`
+	FailOpen bool   `json:"failOpen,omitempty"`
+}
+
+type RBAC struct {
+	Roles        []Role        `json:"roles"`
+	RoleBindings []RoleBinding `json:"roleBindings"`
+}
+
+type Role struct {
+	Name        string   `json:"name"`
+	Resources   []string `json:"resources"`
+	Tenants     []string `json:"tenants"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleBinding struct {
+	Name     string    `json:"name"`
+	Roles    []string  `json:"roles"`
+	Subjects []Subject `json:"subjects"`
+}
+
+type Subject struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type Options struct {
+	Config Config
+	// Host is the host of the route of the API.
+	Host           string
+	MetricsTenants []tenants.Tenant
+	// MetricsTenantsClientSecret is the client secret of MetricsTenantsOIDC.
+	MetricsTenantsClientSecret string
+	// ClientSecrets are the OIDC client secrets of the tenants, by tenant
+	// name.
+	ClientSecrets map[string]string
+}
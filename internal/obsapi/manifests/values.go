@@ -1,15 +1,64 @@
 package manifests
 
+import (
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
 type ObsAPIValues struct {
-	Enabled bool `json:"enabled"`
+	Enabled         bool                        `json:"enabled"`
+	Namespace       string                      `json:"namespace"`
+	Host            string                      `json:"host,omitempty"`
+	Replicas        int32                       `json:"replicas"`
+	Resources       corev1.ResourceRequirements `json:"resources"`
+	Image           string                      `json:"image"`
+	ImagePullSecret string                      `json:"imagePullSecret"`
+	Endpoints       EndpointsValues             `json:"endpoints"`
+	// Tenants and RBAC are the tenants.yaml and rbac.yaml configurations of
+	// the API.
+	Tenants string `json:"tenants"`
+	RBAC    string `json:"rbac"`
+}
+
+type EndpointsValues struct {
+	MetricsRead  string `json:"metricsRead"`
+	MetricsWrite string `json:"metricsWrite"`
+	LogsRead     string `json:"logsRead,omitempty"`
+	LogsTail     string `json:"logsTail,omitempty"`
+	LogsWrite    string `json:"logsWrite,omitempty"`
+	TracesRead   string `json:"tracesRead,omitempty"`
+	TracesWrite  string `json:"tracesWrite,omitempty"`
 }
 
-func BuildValues(isHubCluster, obsAPIEnabled bool) *ObsAPIValues {
-	if !isHubCluster || !obsAPIEnabled {
-		return nil
+func BuildValues(opts Options) (*ObsAPIValues, error) {
+	tenantsConfig, err := buildTenantsConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	rbacConfig, err := buildRBACConfig(opts)
+	if err != nil {
+		return nil, err
 	}
 
+	endpoints := opts.Config.Endpoints
 	return &ObsAPIValues{
-		Enabled: true,
-	}
+		Enabled:         true,
+		Namespace:       addoncfg.InstallNamespace,
+		Host:            opts.Host,
+		Replicas:        opts.Config.Replicas,
+		Resources:       opts.Config.Resources,
+		Image:           opts.Config.Image,
+		ImagePullSecret: opts.Config.ImagePullSecret,
+		Endpoints: EndpointsValues{
+			MetricsRead:  endpoints.MetricsRead,
+			MetricsWrite: endpoints.MetricsWrite,
+			LogsRead:     endpoints.LogsRead,
+			LogsTail:     endpoints.LogsTail,
+			LogsWrite:    endpoints.LogsWrite,
+			TracesRead:   endpoints.TracesRead,
+			TracesWrite:  endpoints.TracesWrite,
+		},
+		Tenants: tenantsConfig,
+		RBAC:    rbacConfig,
+	}, nil
 }
@@ -0,0 +1,102 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildValues(t *testing.T) {
+	receiveLimit := RateLimit{Endpoint: "/api/metrics/v1/.+/api/v1/receive", Limit: 1000, Window: "1s"}
+	values, err := BuildValues(Options{
+		Config: Config{
+			Replicas: 3,
+			Endpoints: Endpoints{
+				MetricsRead:  DefaultMetricsReadEndpoint,
+				MetricsWrite: DefaultMetricsWriteEndpoint,
+				LogsRead:     "https://loki.example.com",
+			},
+			Tenants: []Tenant{
+				{Name: "sre", OIDC: &OIDC{IssuerURL: "https://sso.example.com", ClientID: "observatorium", ClientSecretName: "sre-oidc"}},
+				{Name: "batch", ID: "6b0e1b5f", MTLS: &MTLS{}, RateLimits: []RateLimit{{Endpoint: "/api/logs/v1/.+", Limit: 10, Window: "1m"}}},
+			},
+			RBAC: RBAC{
+				Roles:        []Role{{Name: "sre-read-logs", Resources: []string{"logs"}, Tenants: []string{"sre"}, Permissions: []string{"read"}}},
+				RoleBindings: []RoleBinding{{Name: "sre-read-logs", Roles: []string{"sre-read-logs"}, Subjects: []Subject{{Name: "sre", Kind: "group"}}}},
+			},
+			RateLimits:         []RateLimit{receiveLimit},
+			MetricsTenantsOIDC: &OIDC{IssuerURL: "https://sso.example.com", ClientID: "metrics", GroupClaim: "groups"},
+		},
+		Host: "observatorium-api.example.com",
+		MetricsTenants: []tenants.Tenant{
+			{Name: "team-a", ClusterSets: []string{"payments"}, Readers: []string{"team-a-admins", "sre"}},
+			{Name: "team-b", Namespaces: []string{"checkout"}},
+		},
+		ClientSecrets:              map[string]string{"sre": "s3cr3t"},
+		MetricsTenantsClientSecret: "m3tr1cs",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), values.Replicas)
+	require.Equal(t, "open-cluster-management-observability", values.Namespace)
+	require.Equal(t, "observatorium-api.example.com", values.Host)
+	require.Equal(t, "https://loki.example.com", values.Endpoints.LogsRead)
+	require.Empty(t, values.Endpoints.TracesWrite)
+
+	tenantsCfg := tenantsConfig{}
+	require.NoError(t, yaml.Unmarshal([]byte(values.Tenants), &tenantsCfg))
+	require.Len(t, tenantsCfg.Tenants, 5)
+	require.Equal(t, tenantConfig{Name: "default", ID: defaultTenantID, MTLS: &mtlsConfig{CAPath: clientCAPath}, RateLimits: []RateLimit{receiveLimit}}, tenantsCfg.Tenants[0])
+
+	// The metrics tenants are written with the client certificates of the
+	// clusters and read with OIDC.
+	teamA := tenantsCfg.Tenants[1]
+	require.Equal(t, "team-a", teamA.ID)
+	require.Equal(t, &mtlsConfig{CAPath: clientCAPath, Paths: []pathConfig{{Operator: "=~", Pattern: receivePattern}}}, teamA.MTLS)
+	require.Equal(t, &oidcConfig{
+		ClientID:     "metrics",
+		ClientSecret: "m3tr1cs",
+		IssuerURL:    "https://sso.example.com",
+		GroupClaim:   "groups",
+		Paths:        []pathConfig{{Operator: "!~", Pattern: receivePattern}},
+	}, teamA.OIDC)
+
+	sre := tenantsCfg.Tenants[3]
+	require.Equal(t, "sre", sre.ID)
+	require.Nil(t, sre.MTLS)
+	require.Equal(t, &oidcConfig{ClientID: "observatorium", ClientSecret: "s3cr3t", IssuerURL: "https://sso.example.com"}, sre.OIDC)
+	require.Equal(t, []RateLimit{receiveLimit}, sre.RateLimits)
+
+	// The mTLS tenants use the client certificates of the hub, and their own
+	// rate limits take precedence.
+	batch := tenantsCfg.Tenants[4]
+	require.Equal(t, "6b0e1b5f", batch.ID)
+	require.Equal(t, &mtlsConfig{CAPath: clientCAPath}, batch.MTLS)
+	require.Equal(t, "1m", batch.RateLimits[0].Window)
+
+	rbacCfg := RBAC{}
+	require.NoError(t, yaml.Unmarshal([]byte(values.RBAC), &rbacCfg))
+	// The managed clusters only write the default tenant with the shared
+	// certificate.
+	require.Equal(t, []string{"default"}, rbacCfg.Roles[1].Tenants)
+	require.Equal(t, []Subject{{Name: "acm", Kind: "group"}}, rbacCfg.RoleBindings[1].Subjects)
+
+	// A metrics tenant is written by the clusters of its cluster sets, or by
+	// all the writers without cluster sets, and read by its readers. The
+	// configured roles come last.
+	require.Len(t, rbacCfg.Roles, 6)
+	require.Equal(t, Role{Name: "team-a-write-metrics", Resources: []string{"metrics"}, Tenants: []string{"team-a"}, Permissions: []string{"write"}}, rbacCfg.Roles[2])
+	require.Equal(t, []Subject{{Name: "mcoa-metrics-clusterset-payments", Kind: "group"}}, rbacCfg.RoleBindings[2].Subjects)
+	require.Equal(t, Role{Name: "team-a-read-metrics", Resources: []string{"metrics"}, Tenants: []string{"team-a"}, Permissions: []string{"read"}}, rbacCfg.Roles[3])
+	require.Equal(t, []Subject{{Name: "team-a-admins", Kind: "group"}, {Name: "sre", Kind: "group"}}, rbacCfg.RoleBindings[3].Subjects)
+	require.Equal(t, "team-b-write-metrics", rbacCfg.Roles[4].Name)
+	require.Equal(t, []Subject{{Name: tenants.WriterGroup, Kind: "group"}}, rbacCfg.RoleBindings[4].Subjects)
+	require.Equal(t, "sre-read-logs", rbacCfg.Roles[5].Name)
+	require.Equal(t, "sre-read-logs", rbacCfg.RoleBindings[5].Name)
+
+	// Without OIDC, the readers can't authenticate and get no role.
+	noOIDC, err := buildRBACConfig(Options{MetricsTenants: []tenants.Tenant{{Name: "team-a", ClusterSets: []string{"payments"}, Readers: []string{"team-a-admins"}}}})
+	require.NoError(t, err)
+	require.NotContains(t, noOIDC, "team-a-read-metrics")
+}
@@ -0,0 +1,14 @@
+package manifests
+
+const (
+	DefaultReplicas        int32 = 2
+	DefaultImagePullSecret       = "multiclusterhub-operator-pull-secret"
+	DefaultCPURequest            = "20m"
+	DefaultMemoryRequest         = "128Mi"
+
+	// DefaultMetricsReadEndpoint and DefaultMetricsWriteEndpoint are the Thanos
+	// query frontend and receive of the hub, deployed by the
+	// multicluster-observability-operator.
+	DefaultMetricsReadEndpoint  = "http://observability-thanos-query-frontend.open-cluster-management-observability.svc.cluster.local:9090"
+	DefaultMetricsWriteEndpoint = "http://observability-thanos-receive.open-cluster-management-observability.svc.cluster.local:19291"
+)
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	ocinfrav1 "github.com/openshift/api/config/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	lshandlers "github.com/stolostron/multicluster-observability-addon/internal/lokistack/handlers"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	errMissingOTELColRef  = errors.New("missing OpenTelemetryCollector reference on addon installation of the hub")
+	errMultipleOTELColRef = errors.New("multiple OpenTelemetryCollector references on addon installation of the hub")
+)
+
+// BuildOptions returns the options of the gateway from the
+// OpenTelemetryCollector referenced by the addon configs of the hub.
+func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, gateway addon.OTelGatewayOptions, hubLokiStack addon.HubLokiStackOptions, registries []addonapiv1beta1.ImageMirror) (manifests.Options, error) {
+	opts := manifests.Options{
+		Gateway: gateway,
+	}
+
+	keys := common.GetObjectKeys(mcAddon.Status.ConfigReferences, otelv1beta1.GroupVersion.Group, addoncfg.OpenTelemetryCollectorsResource)
+	switch {
+	case len(keys) == 0:
+		return opts, errMissingOTELColRef
+	case len(keys) > 1:
+		return opts, errMultipleOTELColRef
+	}
+	otelCol := &otelv1beta1.OpenTelemetryCollector{}
+	if err := k8s.Get(ctx, keys[0], otelCol, &client.GetOptions{}); err != nil {
+		return opts, err
+	}
+	opts.OpenTelemetryCollector = otelCol
+
+	images, err := mconfig.GetImageOverrides(ctx, k8s, registries, klog.Background())
+	if err != nil {
+		return opts, err
+	}
+	opts.ProxyImage = images.KubeRBACProxy
+
+	if hubLokiStack.Enabled {
+		opts.LokiStack, err = buildLokiStack(ctx, k8s, mcAddon)
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
+
+// buildLokiStack returns the tenants of the LokiStack of the hub and issues
+// the client certificate the gateway writes to them with.
+func buildLokiStack(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn) (*manifests.LokiStack, error) {
+	tenants, err := lshandlers.ListTenants(ctx, k8s)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := common.EnsureClientCert(ctx, k8s, mcAddon, lsmanifests.WriterCertSecretName, []string{lsmanifests.WriterName})
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifests.LokiStack{
+		Tenants:    tenants,
+		CertSecret: cert.Name,
+	}, nil
+}
+
+// BuildSpoke returns the connection of a managed cluster to the gateway, the
+// copy of the hub CA and the copy of the client certificate of the cluster.
+func BuildSpoke(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, gateway addon.OTelGatewayOptions) (*manifests.Spoke, []corev1.Secret, error) {
+	hubSecrets, err := CopyHubMTLSSecrets(ctx, k8s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := common.EnsureClientCert(ctx, k8s, mcAddon, manifests.ClientCertSecretName, []string{manifests.ClientCertGroup})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secrets := []corev1.Secret{
+		hubSecrets[0],
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: manifests.ClientCertSecretName,
+				Annotations: map[string]string{
+					addoncfg.AnnotationOriginalResource: fmt.Sprintf("%s/%s", cert.Namespace, cert.Name),
+				},
+			},
+			Data: cert.Data,
+		},
+	}
+
+	spoke := &manifests.Spoke{
+		Endpoint:   gateway.Endpoint,
+		CASecret:   secrets[0].Name,
+		CertSecret: secrets[1].Name,
+	}
+
+	return spoke, secrets, nil
+}
+
+// CopyHubMTLSSecrets returns copies of the hub CA and of the client
+// certificate, in this order. The copies are named like the ones used to send
+// metrics to the hub.
+func CopyHubMTLSSecrets(ctx context.Context, k8s client.Client) ([]corev1.Secret, error) {
+	clusterVersion := &ocinfrav1.ClusterVersion{}
+	if err := k8s.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion); err != nil {
+		return nil, fmt.Errorf("failed to get clusterVersion: %w", err)
+	}
+	trimmedHubID := mconfig.GetTrimmedClusterID(string(clusterVersion.Spec.ClusterID))
+
+	secrets := make([]corev1.Secret, 0, 2)
+	for _, ref := range []struct{ source, target string }{
+		{mconfig.HubCASecretName, mconfig.GetHubMtlsCASecretName(trimmedHubID)},
+		{mconfig.ClientCertSecretName, mconfig.GetHubMtlsCertSecretName(trimmedHubID)},
+	} {
+		secret := &corev1.Secret{}
+		if err := k8s.Get(ctx, types.NamespacedName{Name: ref.source, Namespace: mconfig.HubInstallNamespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get secret %s in namespace %s: %w", ref.source, mconfig.HubInstallNamespace, err)
+		}
+		secrets = append(secrets, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ref.target,
+				Annotations: map[string]string{
+					addoncfg.AnnotationOriginalResource: fmt.Sprintf("%s/%s", secret.Namespace, secret.Name),
+				},
+			},
+			Data: secret.Data,
+		})
+	}
+
+	return secrets, nil
+}
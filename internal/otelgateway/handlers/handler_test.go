@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	ocinfrav1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/crypto"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	_ = ocinfrav1.AddToScheme(scheme.Scheme)
+	_ = addonapiv1beta1.Install(scheme.Scheme)
+	_ = clusterv1.Install(scheme.Scheme)
+)
+
+func newCASecret(t *testing.T) *corev1.Secret {
+	ca, err := crypto.MakeSelfSignedCAConfig("observability-client-ca-certificate", time.Hour*24*365)
+	require.NoError(t, err)
+	certPEM, keyPEM, err := ca.GetPEMBytes()
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      manifests.ClientCASecretName,
+			Namespace: mconfig.HubInstallNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+}
+
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestBuildSpoke(t *testing.T) {
+	mcAddon := addontesting.NewAddon("multicluster-observability-addon", "cluster-1")
+	cluster := addontesting.NewManagedCluster("cluster-1")
+	cluster.UID = types.UID("cluster-uid")
+	objs := []client.Object{
+		mcAddon,
+		cluster,
+		newCASecret(t),
+		&ocinfrav1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "version"},
+			Spec:       ocinfrav1.ClusterVersionSpec{ClusterID: "9e8a2f4c-hub"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: mconfig.HubCASecretName, Namespace: mconfig.HubInstallNamespace},
+			Data:       map[string][]byte{mconfig.MTLSCASecretKey: []byte("ca")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: mconfig.ClientCertSecretName, Namespace: mconfig.HubInstallNamespace},
+			Data:       map[string][]byte{mconfig.MTLSCertSecretKey: []byte("shared")},
+		},
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+	gateway := addon.OTelGatewayOptions{
+		Enabled:  true,
+		Endpoint: url.URL{Scheme: "https", Host: "otel-gateway.example.com"},
+	}
+
+	spoke, secrets, err := BuildSpoke(t.Context(), k8s, mcAddon, gateway)
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	require.Equal(t, secrets[0].Name, spoke.CASecret)
+	require.Equal(t, manifests.ClientCertSecretName, spoke.CertSecret)
+
+	// The certificate identifies the cluster and is kept on the hub, owned by
+	// the cluster.
+	cert := parseCert(t, secrets[1].Data[corev1.TLSCertKey])
+	require.Equal(t, "cluster-1", cert.Subject.CommonName)
+	require.Equal(t, []string{manifests.ClientCertGroup}, cert.Subject.Organization)
+
+	stored := &corev1.Secret{}
+	require.NoError(t, k8s.Get(t.Context(), common.ClientCertKey("cluster-1", manifests.ClientCertSecretName), stored))
+	require.Equal(t, secrets[1].Data, stored.Data)
+	require.Len(t, stored.OwnerReferences, 1)
+	require.Equal(t, cluster.UID, stored.OwnerReferences[0].UID)
+
+	// The certificate is reused while it is valid.
+	_, again, err := BuildSpoke(t.Context(), k8s, mcAddon, gateway)
+	require.NoError(t, err)
+	require.Equal(t, secrets[1].Data, again[1].Data)
+
+	// It is issued again when the CA changes.
+	require.NoError(t, k8s.Update(t.Context(), newCASecret(t)))
+	_, renewed, err := BuildSpoke(t.Context(), k8s, mcAddon, gateway)
+	require.NoError(t, err)
+	require.NotEqual(t, secrets[1].Data, renewed[1].Data)
+	require.Equal(t, "cluster-1", parseCert(t, renewed[1].Data[corev1.TLSCertKey]).Subject.CommonName)
+}
+
+func TestBuildLokiStack(t *testing.T) {
+	mcAddon := addontesting.NewAddon("multicluster-observability-addon", "local-cluster")
+	objs := []client.Object{
+		mcAddon,
+		addontesting.NewAddon("multicluster-observability-addon", "spoke-1"),
+		addontesting.NewAddon("other-addon", "spoke-2"),
+		addontesting.NewManagedCluster("local-cluster"),
+		newCASecret(t),
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+
+	lokiStack, err := buildLokiStack(t.Context(), k8s, mcAddon)
+	require.NoError(t, err)
+	require.Equal(t, []string{"local-cluster", "spoke-1"}, lokiStack.Tenants)
+
+	// The gateway writes to the tenants with a certificate of the writer group.
+	stored := &corev1.Secret{}
+	require.NoError(t, k8s.Get(t.Context(), common.ClientCertKey("local-cluster", lsmanifests.WriterCertSecretName), stored))
+	require.Equal(t, stored.Name, lokiStack.CertSecret)
+	cert := parseCert(t, stored.Data[corev1.TLSCertKey])
+	require.Equal(t, []string{lsmanifests.WriterName}, cert.Subject.OrganizationalUnit)
+}
@@ -0,0 +1,215 @@
+package otelgateway
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	routev1 "github.com/openshift/api/route/v1"
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/otelgateway/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	_ = otelv1beta1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1.AddToScheme(scheme.Scheme)
+	_ = operatorsv1alpha1.AddToScheme(scheme.Scheme)
+	_ = routev1.AddToScheme(scheme.Scheme)
+)
+
+var gateway = addon.OTelGatewayOptions{
+	Enabled: true,
+	Endpoint: url.URL{
+		Scheme: "https",
+		Host:   "otel-gateway.example.com",
+	},
+}
+
+func fakeGetValues(k8s client.Client) addonfactory.GetValuesFunc {
+	return func(
+		_ *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, mcAddon, gateway, addon.HubLokiStackOptions{}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := manifests.BuildValues(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return addonfactory.JsonStructToValues(values)
+	}
+}
+
+func Test_OTelGateway_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("local-cluster")
+	managedClusterAddOn := addontesting.NewAddon("test", "local-cluster")
+
+	otelCol := &otelv1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mcoa-instance",
+			Namespace: "open-cluster-management-observability",
+		},
+		Spec: otelv1beta1.OpenTelemetryCollectorSpec{
+			Config: otelv1beta1.Config{
+				Receivers: otelv1beta1.AnyConfig{Object: map[string]any{
+					"otlp": map[string]any{},
+				}},
+				Processors: &otelv1beta1.AnyConfig{Object: map[string]any{
+					"batch": map[string]any{},
+				}},
+				Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+					"otlp/tempo": map[string]any{"endpoint": "tempo.example.com:4317"},
+					"otlp/loki":  map[string]any{"endpoint": "loki.example.com:4317"},
+					"debug":      map[string]any{},
+				}},
+				Service: otelv1beta1.Service{
+					Pipelines: map[string]*otelv1beta1.Pipeline{
+						"traces": {
+							Receivers:  []string{"otlp"},
+							Processors: []string{"batch"},
+							Exporters:  []string{"otlp/tempo"},
+						},
+						"logs/backend": {
+							Receivers:  []string{"otlp"},
+							Processors: []string{"batch"},
+							Exporters:  []string{"otlp/loki"},
+						},
+						"metrics": {
+							Receivers: []string{"otlp"},
+							Exporters: []string{"debug"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	imagesList := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mconfig.ImagesConfigMapObjKey.Name,
+			Namespace: mconfig.ImagesConfigMapObjKey.Namespace,
+		},
+		Data: map[string]string{
+			"prometheus_config_reloader":    "reloader",
+			"kube_rbac_proxy":               "kube-rbac-proxy",
+			"obo_prometheus_rhel9_operator": "operator",
+			"kube_state_metrics":            "kube-state-metrics",
+			"node_exporter":                 "node-exporter",
+			"prometheus":                    "prometheus",
+			"endpoint_monitoring_operator":  "endpoint-operator",
+		},
+	}
+
+	fakeKubeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(otelCol, imagesList).
+		Build()
+
+	managedClusterAddOn.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{
+		{
+			ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+				Group:    otelv1beta1.GroupVersion.Group,
+				Resource: addoncfg.OpenTelemetryCollectorsResource,
+			},
+			DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+				ConfigReferent: addonapiv1beta1.ConfigReferent{
+					Namespace: otelCol.Namespace,
+					Name:      otelCol.Name,
+				},
+			},
+		},
+	}
+
+	gatewayAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.OTelGatewayChartDir).
+		WithGetValuesFuncs(fakeGetValues(fakeKubeClient)).
+		WithAgentRegistrationOption(&agent.RegistrationOption{}).
+		WithScheme(scheme.Scheme).
+		BuildHelmAgentAddon()
+	require.NoError(t, err)
+
+	objects, err := gatewayAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+	require.NoError(t, err)
+	// The operator namespace, operator group and subscription, the
+	// collector, the service, the route and the RBAC of the proxy.
+	require.Len(t, objects, 9)
+
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *otelv1beta1.OpenTelemetryCollector:
+			require.Equal(t, manifests.GatewayName, obj.Name)
+			require.Equal(t, otelv1beta1.ModeDeployment, obj.Spec.Mode)
+			receiver := obj.Spec.Config.Receivers.Object[manifests.ReceiverName].(map[string]any)
+			http := receiver["protocols"].(map[string]any)["http"].(map[string]any)
+			require.Equal(t, "127.0.0.1:8444", http["endpoint"])
+			require.NotContains(t, http, "tls")
+
+			// The cluster is only read from the header set by the proxy.
+			processor := obj.Spec.Config.Processors.Object[manifests.ProcessorName].(map[string]any)
+			require.Equal(t, []any{
+				map[string]any{
+					"key":          manifests.ClusterNameAttribute,
+					"from_context": "metadata." + manifests.RemoteUserHeader,
+					"action":       "upsert",
+				},
+			}, processor["attributes"])
+
+			require.Len(t, obj.Spec.AdditionalContainers, 1)
+			proxy := obj.Spec.AdditionalContainers[0]
+			require.Equal(t, "kube-rbac-proxy", proxy.Image)
+			require.Contains(t, proxy.Args, "--upstream=http://127.0.0.1:8444/")
+			require.Contains(t, proxy.Args, "--client-ca-file=/etc/mcoa-gateway/client-ca/tls.crt")
+			require.Contains(t, proxy.Args, "--auth-header-user-field-name="+manifests.RemoteUserHeader)
+			require.Empty(t, obj.Spec.VolumeMounts)
+
+			pipelines := obj.Spec.Config.Service.Pipelines
+			for _, name := range []string{"traces", "logs/backend"} {
+				require.Equal(t, []string{"otlp", manifests.ReceiverName}, pipelines[name].Receivers)
+				require.Equal(t, []string{manifests.ProcessorName, "batch"}, pipelines[name].Processors)
+			}
+			require.Equal(t, []string{"otlp/tempo"}, pipelines["traces"].Exporters)
+			require.Equal(t, []string{"otlp"}, pipelines["metrics"].Receivers)
+			require.Empty(t, pipelines["metrics"].Processors)
+
+			volumes := []string{}
+			for _, v := range obj.Spec.Volumes {
+				volumes = append(volumes, v.Secret.SecretName)
+				if v.Secret.SecretName == manifests.ClientCASecretName {
+					// The private key of the client CA must not be mounted.
+					require.Equal(t, []corev1.KeyToPath{{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey}}, v.Secret.Items)
+				}
+			}
+			require.ElementsMatch(t, []string{manifests.ServerCertSecretName, manifests.ClientCASecretName}, volumes)
+		case *routev1.Route:
+			require.Equal(t, "otel-gateway.example.com", obj.Spec.Host)
+			require.Equal(t, routev1.TLSTerminationPassthrough, obj.Spec.TLS.Termination)
+		case *corev1.Service:
+			require.Equal(t, int32(manifests.GatewayPort), obj.Spec.Ports[0].Port)
+		case *rbacv1.ClusterRoleBinding:
+			if obj.Name == "mcoa-otel-gateway-client" {
+				require.Equal(t, manifests.ClientCertGroup, obj.Subjects[0].Name)
+			}
+		}
+	}
+}
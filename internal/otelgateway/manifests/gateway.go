@@ -0,0 +1,151 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var errNoGatewayPipelines = errors.New("no traces or logs pipeline found in the OpenTelemetryCollector")
+
+// isGatewayPipeline returns true for the pipelines of the signals sent through
+// the gateway, e.g. traces or logs/backend.
+func isGatewayPipeline(name string) bool {
+	signal, _, _ := strings.Cut(name, "/")
+	return signal == "traces" || signal == "logs"
+}
+
+// buildGatewaySpec returns the spec of the gateway from the template. The
+// receiver and the cluster identity processor are added to its traces and
+// logs pipelines so that the data of the managed clusters is sent to the
+// exporters of the template. The receiver only listens on the loopback
+// interface, behind a kube-rbac-proxy authenticating the client certificates
+// of the managed clusters. The logs are also sent to the LokiStack of the hub
+// when it is enabled.
+func buildGatewaySpec(opts Options) (*otelv1beta1.OpenTelemetryCollectorSpec, error) {
+	spec := opts.OpenTelemetryCollector.Spec.DeepCopy()
+	spec.ManagementState = otelv1beta1.ManagementStateManaged
+	spec.Mode = otelv1beta1.ModeDeployment
+
+	found := false
+	for name, pipeline := range spec.Config.Service.Pipelines {
+		if pipeline == nil || !isGatewayPipeline(name) {
+			continue
+		}
+		if !slices.Contains(pipeline.Receivers, ReceiverName) {
+			pipeline.Receivers = append(pipeline.Receivers, ReceiverName)
+		}
+		// The identity is read from the request metadata which is lost by
+		// processors like batch, so the processor must come first.
+		pipeline.Processors = append([]string{ProcessorName}, slices.DeleteFunc(pipeline.Processors, func(p string) bool {
+			return p == ProcessorName
+		})...)
+		found = true
+	}
+	if !found && opts.LokiStack == nil {
+		return nil, errNoGatewayPipelines
+	}
+
+	if spec.Config.Receivers.Object == nil {
+		spec.Config.Receivers.Object = map[string]any{}
+	}
+	spec.Config.Receivers.Object[ReceiverName] = map[string]any{
+		"protocols": map[string]any{
+			"http": map[string]any{
+				"endpoint":         fmt.Sprintf("127.0.0.1:%d", ReceiverPort),
+				"include_metadata": true,
+			},
+		},
+	}
+
+	if spec.Config.Processors == nil {
+		spec.Config.Processors = &otelv1beta1.AnyConfig{}
+	}
+	if spec.Config.Processors.Object == nil {
+		spec.Config.Processors.Object = map[string]any{}
+	}
+	// The cluster is the common name of the client certificate, passed on by
+	// the proxy.
+	spec.Config.Processors.Object[ProcessorName] = map[string]any{
+		"attributes": []any{
+			map[string]any{
+				"key":          ClusterNameAttribute,
+				"from_context": "metadata." + RemoteUserHeader,
+				"action":       "upsert",
+			},
+		},
+	}
+
+	if opts.LokiStack != nil {
+		addLokiStackPipelines(spec, *opts.LokiStack)
+	}
+
+	// Only the certificate of the client CA is mounted, not its private key.
+	spec.Volumes = append(spec.Volumes, secretVolume(ServerCertSecretName), secretVolume(ClientCASecretName, corev1.TLSCertKey))
+	spec.AdditionalContainers = append(spec.AdditionalContainers, buildProxyContainer(opts.ProxyImage))
+
+	return spec, nil
+}
+
+// buildProxyContainer returns the kube-rbac-proxy terminating the mTLS
+// connections of the managed clusters. The client certificates are
+// authenticated with the client CA and authorized with a SubjectAccessReview,
+// so only the ones issued for the gateway are let through.
+func buildProxyContainer(image string) corev1.Container {
+	return corev1.Container{
+		Name:  proxyContainerName,
+		Image: image,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1m"),
+				corev1.ResourceMemory: resource.MustParse("15Mi"),
+			},
+		},
+		Args: []string{
+			fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", GatewayPort),
+			fmt.Sprintf("--upstream=http://127.0.0.1:%d/", ReceiverPort),
+			"--tls-cert-file=" + path.Join(serverCertMountPath, "tls.crt"),
+			"--tls-private-key-file=" + path.Join(serverCertMountPath, "tls.key"),
+			"--client-ca-file=" + path.Join(clientCAMountPath, "tls.crt"),
+			"--auth-header-fields-enabled=true",
+			"--auth-header-user-field-name=" + RemoteUserHeader,
+			"--allow-paths=/v1/traces,/v1/logs",
+			"--logtostderr=true",
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "otlp-mtls",
+				ContainerPort: GatewayPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: ServerCertSecretName, MountPath: serverCertMountPath, ReadOnly: true},
+			{Name: ClientCASecretName, MountPath: clientCAMountPath, ReadOnly: true},
+		},
+	}
+}
+
+// secretVolume returns the volume of the named secret, projecting only the
+// given keys when there are any.
+func secretVolume(name string, keys ...string) corev1.Volume {
+	var items []corev1.KeyToPath
+	for _, key := range keys {
+		items = append(items, corev1.KeyToPath{Key: key, Path: key})
+	}
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: name,
+				Items:      items,
+			},
+		},
+	}
+}
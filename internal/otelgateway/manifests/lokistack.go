@@ -0,0 +1,64 @@
+package manifests
+
+import (
+	"fmt"
+	"maps"
+	"path"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	lsmanifests "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// addLokiStackPipelines adds a logs pipeline per tenant of the LokiStack of
+// the hub to the gateway. Each pipeline keeps the logs of its managed cluster
+// and sends them to the OTLP endpoint of the tenant of the cluster,
+// authenticated with the client certificate of the gateway. The identity of
+// the cluster is set by the gateway from its client certificate, so a managed
+// cluster can't write logs to the tenant of another one.
+func addLokiStackPipelines(spec *otelv1beta1.OpenTelemetryCollectorSpec, lokiStack LokiStack) {
+	processors := maps.Clone(spec.Config.Processors.Object)
+	exporters := maps.Clone(spec.Config.Exporters.Object)
+	if exporters == nil {
+		exporters = map[string]any{}
+	}
+	pipelines := maps.Clone(spec.Config.Service.Pipelines)
+	if pipelines == nil {
+		pipelines = map[string]*otelv1beta1.Pipeline{}
+	}
+
+	for _, tenant := range lokiStack.Tenants {
+		name := LokiStackComponentPrefix + tenant
+		processors["filter/"+name] = map[string]any{
+			"error_mode": "ignore",
+			"logs": map[string]any{
+				"log_record": []string{
+					fmt.Sprintf("resource.attributes[%q] != %q", ClusterNameAttribute, tenant),
+				},
+			},
+		}
+		exporters["otlphttp/"+name] = map[string]any{
+			"endpoint": fmt.Sprintf("%s/api/logs/v1/%s/otlp", lsmanifests.GatewayEndpoint, tenant),
+			"tls": map[string]any{
+				"ca_file":   path.Join(serviceAccountMountPath, "service-ca.crt"),
+				"cert_file": path.Join(lokiStackCertMountPath, corev1.TLSCertKey),
+				"key_file":  path.Join(lokiStackCertMountPath, corev1.TLSPrivateKeyKey),
+			},
+		}
+		pipelines["logs/"+name] = &otelv1beta1.Pipeline{
+			Receivers:  []string{ReceiverName},
+			Processors: []string{ProcessorName, "filter/" + name},
+			Exporters:  []string{"otlphttp/" + name},
+		}
+	}
+	spec.Config.Processors.Object = processors
+	spec.Config.Exporters.Object = exporters
+	spec.Config.Service.Pipelines = pipelines
+
+	spec.Volumes = append(spec.Volumes, secretVolume(lokiStack.CertSecret))
+	spec.VolumeMounts = append(spec.VolumeMounts, corev1.VolumeMount{
+		Name:      lokiStack.CertSecret,
+		MountPath: lokiStackCertMountPath,
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,69 @@
+package manifests
+
+import (
+	"testing"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuildGatewaySpec_LokiStack(t *testing.T) {
+	opts := Options{
+		OpenTelemetryCollector: &otelv1beta1.OpenTelemetryCollector{
+			Spec: otelv1beta1.OpenTelemetryCollectorSpec{
+				Config: otelv1beta1.Config{
+					Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+						"debug": map[string]any{},
+					}},
+					Service: otelv1beta1.Service{
+						Pipelines: map[string]*otelv1beta1.Pipeline{
+							"metrics": {
+								Receivers: []string{"otlp"},
+								Exporters: []string{"debug"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Without traces or logs pipelines, the gateway has nothing to send.
+	_, err := buildGatewaySpec(opts)
+	require.ErrorIs(t, err, errNoGatewayPipelines)
+
+	opts.LokiStack = &LokiStack{
+		Tenants:    []string{"local-cluster", "spoke-1"},
+		CertSecret: "mcoa-logs-writer-client-cert-local-cluster",
+	}
+	spec, err := buildGatewaySpec(opts)
+	require.NoError(t, err)
+
+	pipeline := spec.Config.Service.Pipelines["logs/mcoa-lokistack-spoke-1"]
+	require.NotNil(t, pipeline)
+	require.Equal(t, []string{ReceiverName}, pipeline.Receivers)
+	// The identity of the cluster is set before the logs are filtered.
+	require.Equal(t, []string{ProcessorName, "filter/mcoa-lokistack-spoke-1"}, pipeline.Processors)
+	require.Equal(t, []string{"otlphttp/mcoa-lokistack-spoke-1"}, pipeline.Exporters)
+	require.Contains(t, spec.Config.Service.Pipelines, "logs/mcoa-lokistack-local-cluster")
+
+	filter := spec.Config.Processors.Object["filter/mcoa-lokistack-spoke-1"].(map[string]any)
+	require.Equal(t, []string{`resource.attributes["k8s.cluster.name"] != "spoke-1"`}, filter["logs"].(map[string]any)["log_record"])
+
+	exporter := spec.Config.Exporters.Object["otlphttp/mcoa-lokistack-spoke-1"].(map[string]any)
+	require.Equal(t, "https://mcoa-logging-loki-gateway-http.open-cluster-management-observability.svc:8080/api/logs/v1/spoke-1/otlp", exporter["endpoint"])
+	require.Equal(t, map[string]any{
+		"ca_file":   "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt",
+		"cert_file": "/etc/mcoa-gateway/lokistack/tls.crt",
+		"key_file":  "/etc/mcoa-gateway/lokistack/tls.key",
+	}, exporter["tls"])
+	require.Contains(t, spec.Config.Exporters.Object, "debug")
+
+	require.Contains(t, spec.VolumeMounts, corev1.VolumeMount{
+		Name:      "mcoa-logs-writer-client-cert-local-cluster",
+		MountPath: "/etc/mcoa-gateway/lokistack",
+		ReadOnly:  true,
+	})
+	require.Contains(t, spec.Volumes, secretVolume("mcoa-logs-writer-client-cert-local-cluster"))
+}
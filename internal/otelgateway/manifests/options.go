@@ -0,0 +1,40 @@
+package manifests
+
+import (
+	"net/url"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+)
+
+type Options struct {
+	// OpenTelemetryCollector is the template referenced by the addon configs
+	// of the hub. Its exporters define where the gateway sends the traces and
+	// logs of the managed clusters.
+	OpenTelemetryCollector *otelv1beta1.OpenTelemetryCollector
+	Gateway                addon.OTelGatewayOptions
+	// ProxyImage is the kube-rbac-proxy authenticating the managed clusters.
+	ProxyImage string
+	// LokiStack is set when the gateway forwards the logs to the LokiStack of
+	// the hub, in addition to the exporters of the template.
+	LokiStack *LokiStack
+}
+
+// LokiStack is the connection of the gateway to the LokiStack of the hub.
+type LokiStack struct {
+	// Tenants are the managed clusters, each writing to the tenant of the
+	// same name.
+	Tenants []string
+	// CertSecret is the client certificate the gateway writes with, in the
+	// install namespace.
+	CertSecret string
+}
+
+// Spoke is the connection of a managed cluster to the gateway.
+type Spoke struct {
+	Endpoint url.URL
+	// CASecret is the copy of the hub CA also used to send metrics to the hub
+	// and CertSecret the copy of the client certificate of the cluster.
+	CASecret   string
+	CertSecret string
+}
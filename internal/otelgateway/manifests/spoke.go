@@ -0,0 +1,88 @@
+package manifests
+
+import (
+	"maps"
+	"path"
+	"slices"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ExportToGateway sends the traces and logs pipelines of the collector of a
+// managed cluster to the gateway. The exporters of the template are kept for
+// its other pipelines.
+func ExportToGateway(spec *otelv1beta1.OpenTelemetryCollectorSpec, spoke Spoke) {
+	pipelines := make(map[string]*otelv1beta1.Pipeline, len(spec.Config.Service.Pipelines))
+	for name, pipeline := range spec.Config.Service.Pipelines {
+		if pipeline == nil || !isGatewayPipeline(name) {
+			pipelines[name] = pipeline
+			continue
+		}
+		pipelines[name] = &otelv1beta1.Pipeline{
+			Receivers:  pipeline.Receivers,
+			Processors: pipeline.Processors,
+			Exporters:  []string{ExporterName},
+		}
+	}
+	spec.Config.Service.Pipelines = pipelines
+
+	exporters := maps.Clone(spec.Config.Exporters.Object)
+	if exporters == nil {
+		exporters = map[string]any{}
+	}
+	exporters[ExporterName] = map[string]any{
+		"endpoint": spoke.Endpoint.String(),
+		"tls": map[string]any{
+			"ca_file":   path.Join(caMountPath, mconfig.MTLSCASecretKey),
+			"cert_file": path.Join(certMountPath, mconfig.MTLSCertSecretKey),
+			"key_file":  path.Join(certMountPath, mconfig.MTLSCertKeySecretKey),
+		},
+	}
+	spec.Config.Exporters.Object = exporters
+
+	spec.Volumes = append(slices.Clone(spec.Volumes), secretVolume(spoke.CASecret), secretVolume(spoke.CertSecret))
+	spec.VolumeMounts = append(slices.Clone(spec.VolumeMounts),
+		corev1.VolumeMount{Name: spoke.CASecret, MountPath: caMountPath, ReadOnly: true},
+		corev1.VolumeMount{Name: spoke.CertSecret, MountPath: certMountPath, ReadOnly: true},
+	)
+}
+
+// ForwardToGateway replaces the outputs of the ClusterLogForwarder of a
+// managed cluster with an OTLP output to the gateway.
+func ForwardToGateway(spec *loggingv1.ClusterLogForwarderSpec, spoke Spoke) {
+	spec.Outputs = []loggingv1.OutputSpec{
+		{
+			Name: OutputName,
+			Type: loggingv1.OutputTypeOTLP,
+			OTLP: &loggingv1.OTLP{
+				URL: spoke.Endpoint.JoinPath("/v1/logs").String(),
+			},
+			TLS: &loggingv1.OutputTLSSpec{
+				TLSSpec: loggingv1.TLSSpec{
+					CA: &loggingv1.ValueReference{
+						Key:        mconfig.MTLSCASecretKey,
+						SecretName: spoke.CASecret,
+					},
+					Certificate: &loggingv1.ValueReference{
+						Key:        mconfig.MTLSCertSecretKey,
+						SecretName: spoke.CertSecret,
+					},
+					Key: &loggingv1.SecretReference{
+						Key:        mconfig.MTLSCertKeySecretKey,
+						SecretName: spoke.CertSecret,
+					},
+				},
+			},
+		},
+	}
+
+	pipelines := make([]loggingv1.PipelineSpec, 0, len(spec.Pipelines))
+	for _, pipeline := range spec.Pipelines {
+		pipeline.OutputRefs = []string{OutputName}
+		pipelines = append(pipelines, pipeline)
+	}
+	spec.Pipelines = pipelines
+}
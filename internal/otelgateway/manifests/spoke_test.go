@@ -0,0 +1,103 @@
+package manifests
+
+import (
+	"net/url"
+	"testing"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	loggingv1 "github.com/openshift/cluster-logging-operator/api/observability/v1"
+	"github.com/stretchr/testify/require"
+)
+
+var spoke = Spoke{
+	Endpoint: url.URL{
+		Scheme: "https",
+		Host:   "otel-gateway.example.com",
+	},
+	CASecret:   "hub-mtls-ca-123",
+	CertSecret: ClientCertSecretName,
+}
+
+func TestExportToGateway(t *testing.T) {
+	spec := &otelv1beta1.OpenTelemetryCollectorSpec{
+		Config: otelv1beta1.Config{
+			Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+				"otlp/tempo": map[string]any{},
+				"debug":      map[string]any{},
+			}},
+			Service: otelv1beta1.Service{
+				Pipelines: map[string]*otelv1beta1.Pipeline{
+					"traces": {
+						Receivers:  []string{"otlp"},
+						Processors: []string{"batch"},
+						Exporters:  []string{"otlp/tempo", "debug"},
+					},
+					"metrics": {
+						Receivers: []string{"otlp"},
+						Exporters: []string{"debug"},
+					},
+				},
+			},
+		},
+	}
+	template := spec.Config.Service.Pipelines["traces"]
+
+	ExportToGateway(spec, spoke)
+
+	pipelines := spec.Config.Service.Pipelines
+	require.Equal(t, []string{ExporterName}, pipelines["traces"].Exporters)
+	require.Equal(t, []string{"batch"}, pipelines["traces"].Processors)
+	require.Equal(t, []string{"debug"}, pipelines["metrics"].Exporters)
+	// The pipelines of the template are left untouched.
+	require.Equal(t, []string{"otlp/tempo", "debug"}, template.Exporters)
+
+	exporter := spec.Config.Exporters.Object[ExporterName].(map[string]any)
+	require.Equal(t, "https://otel-gateway.example.com", exporter["endpoint"])
+	// The cluster is identified by its client certificate, not by a header.
+	require.NotContains(t, exporter, "headers")
+
+	volumes := []string{}
+	for _, v := range spec.Volumes {
+		volumes = append(volumes, v.Secret.SecretName)
+	}
+	require.Equal(t, []string{"hub-mtls-ca-123", ClientCertSecretName}, volumes)
+}
+
+func TestForwardToGateway(t *testing.T) {
+	spec := &loggingv1.ClusterLogForwarderSpec{
+		Outputs: []loggingv1.OutputSpec{
+			{Name: "loki", Type: loggingv1.OutputTypeLoki},
+		},
+		Filters: []loggingv1.FilterSpec{
+			{Name: "drop-debug", Type: loggingv1.FilterTypeDrop},
+		},
+		Pipelines: []loggingv1.PipelineSpec{
+			{
+				Name:       "app-logs",
+				InputRefs:  []string{string(loggingv1.InputTypeApplication)},
+				OutputRefs: []string{"loki"},
+				FilterRefs: []string{"drop-debug"},
+			},
+			{
+				Name:       "infra-logs",
+				InputRefs:  []string{string(loggingv1.InputTypeInfrastructure)},
+				OutputRefs: []string{"loki"},
+			},
+		},
+	}
+
+	ForwardToGateway(spec, spoke)
+
+	require.Len(t, spec.Outputs, 1)
+	output := spec.Outputs[0]
+	require.Equal(t, OutputName, output.Name)
+	require.Equal(t, "https://otel-gateway.example.com/v1/logs", output.OTLP.URL)
+	require.Equal(t, "hub-mtls-ca-123", output.TLS.CA.SecretName)
+	require.Equal(t, ClientCertSecretName, output.TLS.Certificate.SecretName)
+	require.Equal(t, ClientCertSecretName, output.TLS.Key.SecretName)
+
+	require.Equal(t, []string{OutputName}, spec.Pipelines[0].OutputRefs)
+	require.Equal(t, []string{"drop-debug"}, spec.Pipelines[0].FilterRefs)
+	require.Equal(t, []string{OutputName}, spec.Pipelines[1].OutputRefs)
+	require.Empty(t, spec.Pipelines[1].FilterRefs)
+}
@@ -0,0 +1,37 @@
+package manifests
+
+import (
+	"encoding/json"
+)
+
+type OTelGatewayValues struct {
+	Enabled bool `json:"enabled"`
+	// Host is the host of the Route of the gateway, which the managed
+	// clusters send their traces and logs to.
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// SubscriptionChannel overrides the channel of the operator Subscription
+	// set in the values of the chart.
+	SubscriptionChannel string `json:"subscriptionChannel,omitempty"`
+	OTELColSpec         string `json:"otelColSpec"`
+}
+
+func BuildValues(opts Options) (*OTelGatewayValues, error) {
+	values := &OTelGatewayValues{
+		Enabled: true,
+		Host:    opts.Gateway.Endpoint.Hostname(),
+		Port:    GatewayPort,
+	}
+
+	spec, err := buildGatewaySpec(opts)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	values.OTELColSpec = string(b)
+
+	return values, nil
+}
@@ -0,0 +1,70 @@
+package manifests
+
+import addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+
+const (
+	// GatewayName is the name of the OpenTelemetryCollector, Service and Route
+	// of the gateway on the hub.
+	GatewayName = "mcoa-otel-gateway"
+	// GatewayPort is where the kube-rbac-proxy of the gateway receives OTLP
+	// over HTTP with mTLS. It differs from the default OTLP ports so that it
+	// doesn't conflict with the receivers of the template. The proxy forwards
+	// the authenticated requests to the receiver of the gateway on
+	// ReceiverPort.
+	GatewayPort  = 8443
+	ReceiverPort = 8444
+
+	// ServerCertSecretName and ClientCASecretName are the certificates of the
+	// observability API on the hub. The gateway reuses its server certificate
+	// and trusts the client certificates issued by its client CA.
+	ServerCertSecretName = "observability-server-certs"
+	ClientCASecretName   = addoncfg.ClientCASecretName
+
+	// ClientCertSecretName is the client certificate of a managed cluster,
+	// issued by the client CA for the gateway. It is kept in the namespace of
+	// the cluster on the hub and copied next to its collectors. The name of
+	// the cluster is the common name of the certificate and ClientCertGroup
+	// its organization.
+	ClientCertSecretName = "mcoa-otel-gateway-client-cert"
+	ClientCertGroup      = "mcoa-otel-gateway"
+
+	// ReceiverName is the OTLP receiver added to the traces and logs pipelines
+	// of the gateway.
+	ReceiverName = "otlp/mcoa-gateway"
+	// ProcessorName is the resource processor injecting the identity of the
+	// managed cluster in the data received by the gateway.
+	ProcessorName = "resource/mcoa-cluster-identity"
+	// ExporterName is the exporter of the spoke collectors sending traces and
+	// logs to the gateway.
+	ExporterName = "otlphttp/mcoa-gateway"
+	// OutputName is the ClusterLogForwarder output sending logs to the
+	// gateway.
+	OutputName = "mcoa-otel-gateway"
+
+	// RemoteUserHeader is set by the kube-rbac-proxy of the gateway to the
+	// common name of the client certificate, replacing any value sent by the
+	// managed cluster.
+	RemoteUserHeader = "x-remote-user"
+	// ClusterNameAttribute is the resource attribute holding the name of the
+	// managed cluster in the data forwarded by the gateway.
+	ClusterNameAttribute = "k8s.cluster.name"
+
+	// LokiStackComponentPrefix prefixes the pipelines, filters and exporters
+	// forwarding the logs to the tenants of the LokiStack of the hub.
+	LokiStackComponentPrefix = "mcoa-lokistack-"
+
+	proxyContainerName  = "kube-rbac-proxy"
+	serverCertMountPath = "/etc/mcoa-gateway/server"
+	clientCAMountPath   = "/etc/mcoa-gateway/client-ca"
+	caMountPath         = "/etc/mcoa-gateway/ca"
+	certMountPath       = "/etc/mcoa-gateway/cert"
+	// lokiStackCertMountPath holds the client certificate of the gateway for
+	// the LokiStack of the hub.
+	lokiStackCertMountPath = "/etc/mcoa-gateway/lokistack"
+	// serviceAccountMountPath holds the CA of the service serving
+	// certificates.
+	serviceAccountMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// OTLPOutputAnnotation enables the OTLP output of the ClusterLogForwarder.
+	OTLPOutputAnnotation = "observability.openshift.io/tech-preview-otlp-output"
+)
@@ -1,35 +1,13 @@
 package apiserver
 
 import (
-	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/acm/k8s/apiserver"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 )
 
-func getInstanceVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("instance",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("instance",
-				labelValuesVar.Matchers(
-					promql.SetLabelMatchers(
-						"process_resident_memory_bytes",
-						[]promql.LabelMatcher{{Name: "cluster", Type: "=", Value: "$cluster"}},
-					),
-				),
-				dashboards.AddVariableDatasource(datasource),
-			),
-			listVar.DisplayName("instance"),
-			listVar.AllowAllValue(true),
-			listVar.AllowMultiple(false),
-		),
-	)
-}
-
 func withOverviewGroup(datasource string) dashboard.Option {
 	return acm.AddCustomPanelGroup(
 		"Overview",
@@ -71,13 +49,14 @@ func withResourcesGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildAPIServerOverview(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildAPIServerOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-apiserver",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / API server"),
 
-		acm.GetClusterVariable(datasource),
-		getInstanceVariable(datasource),
+		vars.Clusters(),
+		vars.Instances("process_resident_memory_bytes", variables.Multi()),
 
 		withOverviewGroup(datasource),
 		withWorkQueueGroup(datasource),
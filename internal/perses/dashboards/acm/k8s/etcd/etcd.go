@@ -5,6 +5,7 @@ import (
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/acm/k8s/etcd"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 )
 
 func withOverviewGroup(datasource string) dashboard.Option {
@@ -51,12 +52,13 @@ func withRaftGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildETCDOverview(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildETCDOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-etcd",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / etcd Cluster"),
 
-		acm.GetClusterVariable(datasource),
+		vars.Clusters(),
 
 		withOverviewGroup(datasource),
 		withStorageGroup(datasource),
@@ -15,9 +15,10 @@ import (
 	staticListVar "github.com/perses/plugins/staticlistvariable/sdk/go"
 	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/rightsizing"
 	acmHelpers "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 )
 
-func withCPUSection(datasource string) dashboard.Option {
+func withCPUSection(datasource string, project string) dashboard.Option {
 	return acmHelpers.AddCustomPanelGroup("CPU",
 		[]acmHelpers.GridItem{
 			{X: 0, Y: 0, W: 6, H: 4},
@@ -32,11 +33,11 @@ func withCPUSection(datasource string) dashboard.Option {
 		panels.CPURequestPanel(datasource),
 		panels.CPUUtilizationPanel(datasource),
 		panels.CPUTopNamespacesPanel(datasource),
-		panels.CPUQuotaTablePanel(datasource),
+		panels.CPUQuotaTablePanel(datasource, project),
 	)
 }
 
-func withMemSection(datasource string) dashboard.Option {
+func withMemSection(datasource string, project string) dashboard.Option {
 	return acmHelpers.AddCustomPanelGroup("Memory",
 		[]acmHelpers.GridItem{
 			{X: 0, Y: 0, W: 6, H: 4},
@@ -51,46 +52,49 @@ func withMemSection(datasource string) dashboard.Option {
 		panels.MemRequestPanel(datasource),
 		panels.MemUtilizationPanel(datasource),
 		panels.MemTopNamespacesPanel(datasource),
-		panels.MemQuotaTablePanel(datasource),
+		panels.MemQuotaTablePanel(datasource, project),
 	)
 }
 
 // BuildNamespaceRightSizing creates the namespace right-sizing dashboard
 func BuildNamespaceRightSizing(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-rs-namespace-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Right-Sizing Namespace"),
 		dashboard.Duration(time.Hour*24*7),
 
-		dashboard.AddVariable("cluster",
+		vars.Clusters(variables.ClustersWith("acm_rs:cluster:cpu_request")),
+
+		dashboard.AddVariable("profile",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("cluster",
+				labelValuesVar.PrometheusLabelValues("profile",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							"acm_rs:cluster:cpu_request",
+							`acm_rs:namespace:cpu_usage{cluster="$cluster"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Cluster"),
-				listVar.DefaultValue("local-cluster"),
+				listVar.DisplayName("Profile"),
+				listVar.DefaultValue("Max OverAll"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
 		),
 
-		dashboard.AddVariable("profile",
+		dashboard.AddVariable("aggregation",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("profile",
+				labelValuesVar.PrometheusLabelValues("aggregation",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`acm_rs:namespace:cpu_usage{cluster="$cluster"}`,
+							`acm_rs:namespace:cpu_usage{cluster="$cluster",profile="$profile"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Profile"),
-				listVar.DefaultValue("Max OverAll"),
+				listVar.DisplayName("Window"),
+				listVar.DefaultValue("1d"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
@@ -108,7 +112,7 @@ func BuildNamespaceRightSizing(project string, datasource string, clusterLabelNa
 			),
 		),
 
-		withCPUSection(datasource),
-		withMemSection(datasource),
+		withCPUSection(datasource, project),
+		withMemSection(datasource, project),
 	)
 }
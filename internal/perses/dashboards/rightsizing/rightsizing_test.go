@@ -6,6 +6,7 @@ package rightsizing
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/perses/perses/go-sdk/dashboard"
@@ -30,10 +31,11 @@ func TestBuildNamespaceRightSizing(t *testing.T) {
 	assert.Equal(t, "ACM Right-Sizing Namespace", spec.Display.Name)
 
 	t.Run("has expected variables", func(t *testing.T) {
-		require.Len(t, spec.Variables, 3, "expected cluster, profile, days")
+		require.Len(t, spec.Variables, 7, "expected clusterset, cluster_label, cluster_label_value, cluster, profile, aggregation, days")
 		varNames := extractVarNames(spec.Variables)
 		assert.Contains(t, varNames, "cluster")
 		assert.Contains(t, varNames, "profile")
+		assert.Contains(t, varNames, "aggregation")
 		assert.Contains(t, varNames, "days")
 	})
 
@@ -79,6 +81,64 @@ func TestBuildNamespaceRightSizing_Idempotent(t *testing.T) {
 	assert.Equal(t, string(data1), string(data2), "repeated builds should produce identical specs")
 }
 
+// --- Workload Right-Sizing Dashboard ---
+
+func TestBuildWorkloadRightSizing(t *testing.T) {
+	db, err := BuildWorkloadRightSizing(testProject, testDatasource, testClusterLbl)
+	require.NoError(t, err)
+
+	spec := db.Dashboard.Spec
+	assert.Equal(t, "acm-rs-workload-overview", db.Dashboard.Metadata.Name)
+	assert.Equal(t, "ACM Right-Sizing Workload", spec.Display.Name)
+
+	t.Run("has expected variables", func(t *testing.T) {
+		require.Len(t, spec.Variables, 8, "expected clusterset, cluster_label, cluster_label_value, cluster, profile, aggregation, days, namespace")
+		varNames := extractVarNames(spec.Variables)
+		assert.Contains(t, varNames, "cluster")
+		assert.Contains(t, varNames, "profile")
+		assert.Contains(t, varNames, "aggregation")
+		assert.Contains(t, varNames, "days")
+		assert.Contains(t, varNames, "namespace")
+	})
+
+	t.Run("has expected panel groups", func(t *testing.T) {
+		require.Len(t, spec.Layouts, 2, "Workloads section + Containers section")
+	})
+
+	t.Run("panels query acm_rs workload and container metrics", func(t *testing.T) {
+		raw, err := json.Marshal(spec)
+		require.NoError(t, err)
+		specStr := string(raw)
+		assert.Contains(t, specStr, "acm_rs:workload:cpu_recommendation")
+		assert.Contains(t, specStr, "acm_rs:workload:memory_usage")
+		assert.Contains(t, specStr, "acm_rs:container:cpu_request")
+		assert.Contains(t, specStr, "acm_rs:container:memory_recommendation")
+	})
+}
+
+func TestBuildWorkloadRightSizing_ClusterLabel(t *testing.T) {
+	db, err := BuildWorkloadRightSizing(testProject, testDatasource, "managed_cluster")
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(db.Dashboard.Spec)
+	require.NoError(t, err)
+	specStr := string(raw)
+	assert.Contains(t, specStr, `acm_rs:workload:cpu_usage{managed_cluster=\"$cluster\",profile=\"$profile\"}`)
+	assert.Contains(t, specStr, `acm_rs:container:cpu_request{managed_cluster=\"$cluster\"`)
+	assert.NotContains(t, specStr, `{cluster=`)
+}
+
+func TestBuildNamespaceRightSizing_WorkloadDrillDown(t *testing.T) {
+	db, err := BuildNamespaceRightSizing("my-analytics-ns", testDatasource, testClusterLbl)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(db.Dashboard.Spec)
+	require.NoError(t, err)
+	specStr := string(raw)
+	assert.Contains(t, specStr, "dashboard=acm-rs-workload-overview")
+	assert.Contains(t, specStr, "project=my-analytics-ns")
+}
+
 // --- VM Overview Dashboard ---
 
 func TestBuildVMOverview(t *testing.T) {
@@ -90,10 +150,11 @@ func TestBuildVMOverview(t *testing.T) {
 	assert.Equal(t, "ACM Right-Sizing OpenShift Virtualization", spec.Display.Name)
 
 	t.Run("has expected variables", func(t *testing.T) {
-		require.Len(t, spec.Variables, 4, "expected cluster, profile, days, namespace")
+		require.Len(t, spec.Variables, 8, "expected clusterset, cluster_label, cluster_label_value, cluster, profile, aggregation, days, namespace")
 		varNames := extractVarNames(spec.Variables)
 		assert.Contains(t, varNames, "cluster")
 		assert.Contains(t, varNames, "profile")
+		assert.Contains(t, varNames, "aggregation")
 		assert.Contains(t, varNames, "days")
 		assert.Contains(t, varNames, "namespace")
 	})
@@ -144,10 +205,11 @@ func TestBuildVMOverestimation(t *testing.T) {
 	assert.Equal(t, "ACM Right-Sizing OpenShift Virtualization VM Overestimation", spec.Display.Name)
 
 	t.Run("has expected variables", func(t *testing.T) {
-		require.Len(t, spec.Variables, 5, "expected cluster, profile, days, namespace, vm")
+		require.Len(t, spec.Variables, 9, "expected clusterset, cluster_label, cluster_label_value, cluster, profile, aggregation, days, namespace, vm")
 		varNames := extractVarNames(spec.Variables)
 		assert.Contains(t, varNames, "cluster")
 		assert.Contains(t, varNames, "profile")
+		assert.Contains(t, varNames, "aggregation")
 		assert.Contains(t, varNames, "days")
 		assert.Contains(t, varNames, "namespace")
 		assert.Contains(t, varNames, "vm")
@@ -197,10 +259,11 @@ func TestBuildVMUnderestimation(t *testing.T) {
 	assert.Equal(t, "ACM Right-Sizing OpenShift Virtualization VM Underestimation", spec.Display.Name)
 
 	t.Run("has expected variables", func(t *testing.T) {
-		require.Len(t, spec.Variables, 5, "expected cluster, profile, days, namespace, vm")
+		require.Len(t, spec.Variables, 9, "expected clusterset, cluster_label, cluster_label_value, cluster, profile, aggregation, days, namespace, vm")
 		varNames := extractVarNames(spec.Variables)
 		assert.Contains(t, varNames, "cluster")
 		assert.Contains(t, varNames, "profile")
+		assert.Contains(t, varNames, "aggregation")
 		assert.Contains(t, varNames, "days")
 		assert.Contains(t, varNames, "namespace")
 		assert.Contains(t, varNames, "vm")
@@ -241,6 +304,36 @@ func TestBuildVMUnderestimation_Idempotent(t *testing.T) {
 
 // --- Cross-Dashboard Consistency ---
 
+func TestAllDashboards_FilterOnAggregationWindow(t *testing.T) {
+	for _, b := range []struct {
+		name string
+		fn   func(string, string, string) (dashboard.Builder, error)
+	}{
+		{"NamespaceRightSizing", BuildNamespaceRightSizing},
+		{"WorkloadRightSizing", BuildWorkloadRightSizing},
+		{"VMOverview", BuildVMOverview},
+		{"VMOverestimation", BuildVMOverestimation},
+		{"VMUnderestimation", BuildVMUnderestimation},
+	} {
+		t.Run(b.name, func(t *testing.T) {
+			db, err := b.fn(testProject, testDatasource, testClusterLbl)
+			require.NoError(t, err)
+
+			raw, err := json.Marshal(db.Dashboard.Spec)
+			require.NoError(t, err)
+			specStr := string(raw)
+			// Every profile filter must be paired with an aggregation filter, otherwise
+			// series from different windows would be summed together.
+			assert.Equal(t,
+				strings.Count(specStr, `profile=\"$profile\"`),
+				strings.Count(specStr, `profile=\"$profile\", aggregation=\"$aggregation\"`)+
+					strings.Count(specStr, `profile=\"$profile\",aggregation=\"$aggregation\"`)+
+					1, // the aggregation variable matcher itself
+			)
+		})
+	}
+}
+
 func TestAllDashboards_ProjectAndDatasourceThreading(t *testing.T) {
 	customProject := "custom-project"
 	customDS := "custom-datasource"
@@ -250,6 +343,7 @@ func TestAllDashboards_ProjectAndDatasourceThreading(t *testing.T) {
 		fn   func(string, string, string) (dashboard.Builder, error)
 	}{
 		{"NamespaceRightSizing", BuildNamespaceRightSizing},
+		{"WorkloadRightSizing", BuildWorkloadRightSizing},
 		{"VMOverview", BuildVMOverview},
 		{"VMOverestimation", BuildVMOverestimation},
 		{"VMUnderestimation", BuildVMUnderestimation},
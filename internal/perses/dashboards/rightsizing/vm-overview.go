@@ -15,6 +15,7 @@ import (
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	staticListVar "github.com/perses/plugins/staticlistvariable/sdk/go"
 	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 )
 
 func withVMOverviewStatsGroup(datasource string) dashboard.Option {
@@ -62,39 +63,43 @@ func withVMMemUnderestimationTableGroup(datasource string, project string) dashb
 
 // BuildVMOverview creates the main VM right-sizing overview dashboard
 func BuildVMOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-rightsizing-openshift-virtualization",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Right-Sizing OpenShift Virtualization"),
 		dashboard.Duration(time.Hour*24*7),
 
-		dashboard.AddVariable("cluster",
+		vars.Clusters(variables.ClustersWith("acm_rs_vm:namespace:cpu_request")),
+
+		dashboard.AddVariable("profile",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("cluster",
+				labelValuesVar.PrometheusLabelValues("profile",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							"acm_rs_vm:namespace:cpu_request",
+							`acm_rs_vm:namespace:cpu_usage{cluster="$cluster"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Cluster"),
+				listVar.DisplayName("Profile"),
+				listVar.DefaultValue("Max OverAll"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
 		),
 
-		dashboard.AddVariable("profile",
+		dashboard.AddVariable("aggregation",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("profile",
+				labelValuesVar.PrometheusLabelValues("aggregation",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`acm_rs_vm:namespace:cpu_usage{cluster="$cluster"}`,
+							`acm_rs_vm:namespace:cpu_usage{cluster="$cluster",profile="$profile"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Profile"),
-				listVar.DefaultValue("Max OverAll"),
+				listVar.DisplayName("Window"),
+				listVar.DefaultValue("1d"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
@@ -118,7 +123,7 @@ func BuildVMOverview(project string, datasource string, clusterLabelName string)
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`acm_rs_vm:namespace:cpu_usage{cluster="$cluster",profile="$profile"}`,
+							`acm_rs_vm:namespace:cpu_usage{cluster="$cluster",profile="$profile",aggregation="$aggregation"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
@@ -15,43 +15,48 @@ import (
 	staticListVar "github.com/perses/plugins/staticlistvariable/sdk/go"
 	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/rightsizing"
 	acmHelpers "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 )
 
 // BuildVMUnderestimation creates the VM underestimation detail dashboard
 func BuildVMUnderestimation(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-rightsizing-vm-underestimation",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Right-Sizing OpenShift Virtualization VM Underestimation"),
 		dashboard.Duration(time.Hour*24*7),
 
-		dashboard.AddVariable("cluster",
+		vars.Clusters(variables.ClustersWith(`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)"}`)),
+
+		dashboard.AddVariable("profile",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("cluster",
+				labelValuesVar.PrometheusLabelValues("profile",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)"}`,
+							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Cluster"),
+				listVar.DisplayName("Profile"),
+				listVar.DefaultValue("Max OverAll"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
 		),
 
-		dashboard.AddVariable("profile",
+		dashboard.AddVariable("aggregation",
 			listVar.List(
-				labelValuesVar.PrometheusLabelValues("profile",
+				labelValuesVar.PrometheusLabelValues("aggregation",
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster"}`,
+							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster",profile="$profile"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
-				listVar.DisplayName("Profile"),
-				listVar.DefaultValue("Max OverAll"),
+				listVar.DisplayName("Window"),
+				listVar.DefaultValue("1d"),
 				listVar.AllowAllValue(false),
 				listVar.AllowMultiple(false),
 			),
@@ -75,7 +80,7 @@ func BuildVMUnderestimation(project string, datasource string, clusterLabelName
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster",profile="$profile"}`,
+							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster",profile="$profile",aggregation="$aggregation"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
@@ -91,7 +96,7 @@ func BuildVMUnderestimation(project string, datasource string, clusterLabelName
 					dashboards.AddVariableDatasource(datasource),
 					labelValuesVar.Matchers(
 						promql.SetLabelMatchers(
-							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster",profile="$profile",namespace="$namespace"}`,
+							`{__name__=~"acm_rs_vm:namespace:(cpu_request|cpu_usage|memory_request|memory_usage)",cluster="$cluster",profile="$profile",aggregation="$aggregation",namespace="$namespace"}`,
 							[]promql.LabelMatcher{},
 						)),
 				),
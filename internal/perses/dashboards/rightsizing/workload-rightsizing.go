@@ -0,0 +1,115 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package rightsizing
+
+import (
+	"time"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	"github.com/perses/community-mixins/pkg/promql"
+	"github.com/perses/perses/go-sdk/dashboard"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	staticListVar "github.com/perses/plugins/staticlistvariable/sdk/go"
+	panels "github.com/stolostron/multicluster-observability-addon/internal/perses/panels/rightsizing"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+)
+
+func withWorkloadSection(datasource, clusterLabelName string) dashboard.Option {
+	return dashboard.AddPanelGroup("Workloads",
+		panelgroup.PanelsPerLine(1),
+		panelgroup.PanelHeight(10),
+		panels.WorkloadCPUTablePanel(datasource, clusterLabelName),
+		panels.WorkloadMemTablePanel(datasource, clusterLabelName),
+	)
+}
+
+func withContainerSection(datasource, clusterLabelName string) dashboard.Option {
+	return dashboard.AddPanelGroup("Containers",
+		panelgroup.PanelsPerLine(1),
+		panelgroup.PanelHeight(10),
+		panels.ContainerCPUTablePanel(datasource, clusterLabelName),
+		panels.ContainerMemTablePanel(datasource, clusterLabelName),
+	)
+}
+
+// BuildWorkloadRightSizing creates the workload and container right-sizing dashboard
+func BuildWorkloadRightSizing(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New(panels.WorkloadDashboardName,
+		dashboard.ProjectName(project),
+		dashboard.Name("ACM Right-Sizing Workload"),
+		dashboard.Duration(time.Hour*24*7),
+
+		vars.Clusters(variables.ClustersWith("acm_rs:workload:cpu_request")),
+
+		dashboard.AddVariable("profile",
+			listVar.List(
+				labelValuesVar.PrometheusLabelValues("profile",
+					dashboards.AddVariableDatasource(datasource),
+					labelValuesVar.Matchers(
+						promql.SetLabelMatchers(
+							`acm_rs:workload:cpu_usage{`+vars.ClusterLabel()+`="$cluster"}`,
+							[]promql.LabelMatcher{},
+						)),
+				),
+				listVar.DisplayName("Profile"),
+				listVar.DefaultValue("Max OverAll"),
+				listVar.AllowAllValue(false),
+				listVar.AllowMultiple(false),
+			),
+		),
+
+		dashboard.AddVariable("aggregation",
+			listVar.List(
+				labelValuesVar.PrometheusLabelValues("aggregation",
+					dashboards.AddVariableDatasource(datasource),
+					labelValuesVar.Matchers(
+						promql.SetLabelMatchers(
+							`acm_rs:workload:cpu_usage{`+vars.ClusterLabel()+`="$cluster",profile="$profile"}`,
+							[]promql.LabelMatcher{},
+						)),
+				),
+				listVar.DisplayName("Window"),
+				listVar.DefaultValue("1d"),
+				listVar.AllowAllValue(false),
+				listVar.AllowMultiple(false),
+			),
+		),
+
+		dashboard.AddVariable("days",
+			listVar.List(
+				staticListVar.StaticList(
+					staticListVar.Values("1d", "2d", "5d", "10d", "30d", "60d", "90d"),
+				),
+				listVar.DisplayName("Days"),
+				listVar.DefaultValue("10d"),
+				listVar.AllowAllValue(false),
+				listVar.AllowMultiple(false),
+			),
+		),
+
+		dashboard.AddVariable("namespace",
+			listVar.List(
+				labelValuesVar.PrometheusLabelValues("namespace",
+					dashboards.AddVariableDatasource(datasource),
+					labelValuesVar.Matchers(
+						promql.SetLabelMatchers(
+							`acm_rs:workload:cpu_usage{`+vars.ClusterLabel()+`="$cluster",profile="$profile",aggregation="$aggregation"}`,
+							[]promql.LabelMatcher{},
+						)),
+				),
+				listVar.DisplayName("Namespace"),
+				listVar.DefaultValue("$__all"),
+				listVar.AllowAllValue(true),
+				listVar.AllowMultiple(true),
+			),
+		),
+
+		withWorkloadSection(datasource, vars.ClusterLabel()),
+		withContainerSection(datasource, vars.ClusterLabel()),
+	)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	persesv1 "github.com/perses/perses-operator/api/v1alpha1"
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/perses/grafana"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Format is an output format of the dashboards export command.
+type Format string
+
+const (
+	// FormatPersesDashboard renders PersesDashboard custom resources as YAML.
+	FormatPersesDashboard Format = "persesdashboard"
+	// FormatPerses renders a JSON bundle for the Perses API.
+	FormatPerses Format = "perses"
+	// FormatGrafana renders Grafana dashboard JSON models.
+	FormatGrafana Format = "grafana"
+)
+
+// Formats lists the supported export formats.
+var Formats = []Format{FormatPersesDashboard, FormatPerses, FormatGrafana}
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Write renders all dashboards in the given format as a single document: a
+// multi-document YAML stream for PersesDashboards and a JSON array otherwise.
+func Write(w io.Writer, format Format, dashboards []persesapiv1.Dashboard) error {
+	switch format {
+	case FormatPersesDashboard:
+		for i := range dashboards {
+			data, err := marshal(format, &dashboards[i])
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatPerses, FormatGrafana:
+		docs := make([]json.RawMessage, 0, len(dashboards))
+		for i := range dashboards {
+			data, err := marshal(format, &dashboards[i])
+			if err != nil {
+				return err
+			}
+			docs = append(docs, data)
+		}
+		data, err := json.MarshalIndent(docs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal dashboards: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// WriteDir renders each dashboard in the given format into its own file in dir.
+func WriteDir(dir string, format Format, dashboards []persesapiv1.Dashboard) error {
+	ext := ".json"
+	switch format {
+	case FormatPersesDashboard:
+		ext = ".yaml"
+	case FormatPerses, FormatGrafana:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for i := range dashboards {
+		data, err := marshal(format, &dashboards[i])
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, dashboards[i].Metadata.Name+ext)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func marshal(format Format, db *persesapiv1.Dashboard) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case FormatPersesDashboard:
+		data, err = yaml.Marshal(PersesDashboard(db))
+	case FormatPerses:
+		data, err = json.MarshalIndent(db, "", "  ")
+	case FormatGrafana:
+		var gd *grafana.Dashboard
+		gd, err = grafana.FromPerses(db)
+		if err == nil {
+			data, err = json.MarshalIndent(gd, "", "  ")
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s dashboard as %s: %w", db.Metadata.Name, format, err)
+	}
+	return data, nil
+}
+
+// PersesDashboard wraps a Perses API dashboard into a PersesDashboard custom
+// resource living in the namespace named after its project.
+func PersesDashboard(db *persesapiv1.Dashboard) *persesv1.PersesDashboard {
+	return &persesv1.PersesDashboard{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: persesv1.GroupVersion.String(),
+			Kind:       "PersesDashboard",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      db.Metadata.Name,
+			Namespace: db.Metadata.Project,
+		},
+		Spec: persesv1.Dashboard{DashboardSpec: db.Spec},
+	}
+}
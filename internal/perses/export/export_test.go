@@ -0,0 +1,190 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	persesv1 "github.com/perses/perses-operator/api/v1alpha1"
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/perses/grafana"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func allDashboards(t *testing.T) []persesapiv1.Dashboard {
+	t.Helper()
+	dashboards, err := manifests.AllDashboards("my-project", "my-datasource", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, dashboards)
+	return dashboards
+}
+
+func findDashboard(t *testing.T, dashboards []persesapiv1.Dashboard, name string) *persesapiv1.Dashboard {
+	t.Helper()
+	for i := range dashboards {
+		if dashboards[i].Metadata.Name == name {
+			return &dashboards[i]
+		}
+	}
+	require.Failf(t, "dashboard not found", "%s", name)
+	return nil
+}
+
+func TestWrite_PersesDashboard(t *testing.T) {
+	dashboards := allDashboards(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatPersesDashboard, dashboards))
+
+	docs := strings.Split(strings.TrimPrefix(buf.String(), "---\n"), "---\n")
+	require.Len(t, docs, len(dashboards))
+	for _, doc := range docs {
+		var db persesv1.PersesDashboard
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &db))
+		assert.Equal(t, "PersesDashboard", db.Kind)
+		assert.Equal(t, "my-project", db.Namespace)
+		assert.NotEmpty(t, db.Spec.Panels)
+	}
+}
+
+func TestWrite_Perses(t *testing.T) {
+	dashboards := allDashboards(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatPerses, dashboards))
+
+	var bundle []persesapiv1.Dashboard
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &bundle))
+	require.Len(t, bundle, len(dashboards))
+	for _, db := range bundle {
+		assert.Equal(t, persesapiv1.KindDashboard, db.Kind)
+		assert.Equal(t, "my-project", db.Metadata.Project)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.ErrorIs(t, Write(&buf, "jsonnet", nil), ErrUnknownFormat)
+	assert.ErrorIs(t, WriteDir(t.TempDir(), "jsonnet", nil), ErrUnknownFormat)
+}
+
+func TestWriteDir(t *testing.T) {
+	dashboards := allDashboards(t)
+	dir := filepath.Join(t.TempDir(), "grafana")
+
+	require.NoError(t, WriteDir(dir, FormatGrafana, dashboards))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(dashboards))
+
+	uids := map[string]bool{}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		var gd grafana.Dashboard
+		require.NoError(t, json.Unmarshal(data, &gd))
+		assert.LessOrEqual(t, len(gd.UID), 40, entry.Name())
+		assert.False(t, uids[gd.UID], "duplicate uid %s", gd.UID)
+		uids[gd.UID] = true
+	}
+}
+
+func TestFromPerses_Panels(t *testing.T) {
+	dashboards := allDashboards(t)
+
+	gd, err := grafana.FromPerses(findDashboard(t, dashboards, "acm-cluster-rsrc-use"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "USE Method / Cluster", gd.Title)
+	assert.Equal(t, "now-3h", gd.Time.From)
+	require.NotEmpty(t, gd.Templating.List)
+	assert.Equal(t, "datasource", gd.Templating.List[0].Type)
+
+	var rows, timeseries int
+	for _, p := range gd.Panels {
+		switch p.Type {
+		case "row":
+			rows++
+		case "timeseries":
+			timeseries++
+			require.NotEmpty(t, p.Targets)
+			assert.Equal(t, "${datasource}", p.Targets[0].Datasource.UID)
+			assert.Equal(t, "A", p.Targets[0].RefID)
+			assert.NotEmpty(t, p.Targets[0].Expr)
+		}
+	}
+	assert.Positive(t, rows)
+	assert.Positive(t, timeseries)
+}
+
+func TestFromPerses_LokiQueries(t *testing.T) {
+	dashboards := allDashboards(t)
+
+	gd, err := grafana.FromPerses(findDashboard(t, dashboards, "acm-logs-volume-by-cluster"))
+	require.NoError(t, err)
+
+	var lokiVariables []string
+	for _, v := range gd.Templating.List {
+		if v.Type == "datasource" && v.Query == "loki" {
+			lokiVariables = append(lokiVariables, v.Name)
+		}
+	}
+	assert.Equal(t, []string{"loki_application_datasource", "loki_audit_datasource", "loki_infrastructure_datasource"}, lokiVariables)
+
+	var panels int
+	for _, p := range gd.Panels {
+		if p.Type != "timeseries" {
+			continue
+		}
+		panels++
+		assert.Equal(t, "-- Mixed --", p.Datasource.UID)
+		require.Len(t, p.Targets, 3)
+		assert.Equal(t, &grafana.DatasourceRef{Type: "loki", UID: "${loki_application_datasource}"}, p.Targets[0].Datasource)
+		assert.Contains(t, p.Targets[0].Expr, `{log_type="application"}`)
+	}
+	assert.Equal(t, 2, panels)
+}
+
+func TestFromPerses_PanelKinds(t *testing.T) {
+	dashboards := allDashboards(t)
+
+	types := map[string]int{}
+	for i := range dashboards {
+		gd, err := grafana.FromPerses(&dashboards[i])
+		require.NoError(t, err, dashboards[i].Metadata.Name)
+		var walk func([]grafana.Panel)
+		walk = func(panels []grafana.Panel) {
+			for _, p := range panels {
+				types[p.Type]++
+				walk(p.Panels)
+
+				switch p.Type {
+				case "table":
+					for _, target := range p.Targets {
+						assert.Equal(t, "table", target.Format)
+						assert.True(t, target.Instant)
+					}
+				case "stat", "gauge":
+					require.NotNil(t, p.FieldConfig)
+					assert.Contains(t, p.Options, "reduceOptions")
+				}
+			}
+		}
+		walk(gd.Panels)
+	}
+
+	for _, kind := range []string{"timeseries", "stat", "gauge", "table", "text"} {
+		assert.Positive(t, types[kind], kind)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+// Package grafana converts dashboards between Perses and Grafana. Both
+// directions share the Grafana dashboard model and the unit and calculation
+// mappings below so that a dashboard exported to Grafana and imported back
+// keeps its formats.
+package grafana
+
+import "encoding/json"
+
+// Dashboard is the subset of the Grafana dashboard JSON model produced by
+// FromPerses and read by ToPerses.
+type Dashboard struct {
+	UID           string    `json:"uid"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description,omitempty"`
+	Editable      bool      `json:"editable"`
+	SchemaVersion int       `json:"schemaVersion"`
+	Time          TimeRange `json:"time"`
+	// Refresh is a duration string, or false when auto-refresh is disabled.
+	Refresh    any        `json:"refresh,omitempty"`
+	Templating Templating `json:"templating"`
+	Panels     []Panel    `json:"panels"`
+}
+
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+type Variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	Type  string `json:"type"`
+	// Query is a string, or an object carrying a query field in newer Grafana versions.
+	Query      any            `json:"query"`
+	Regex      string         `json:"regex,omitempty"`
+	Datasource *DatasourceRef `json:"datasource,omitempty"`
+	Current    *VariableValue `json:"current,omitempty"`
+	Multi      bool           `json:"multi"`
+	IncludeAll bool           `json:"includeAll"`
+	AllValue   string         `json:"allValue,omitempty"`
+	Hide       int            `json:"hide"`
+	Refresh    int            `json:"refresh,omitempty"`
+}
+
+// VariableValue holds a single value or a list of values.
+type VariableValue struct {
+	Text  any `json:"text"`
+	Value any `json:"value"`
+}
+
+type DatasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// UnmarshalJSON also accepts the datasource names of older Grafana versions.
+func (r *DatasourceRef) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*r = DatasourceRef{UID: name}
+		return nil
+	}
+	type ref DatasourceRef
+	return json.Unmarshal(data, (*ref)(r))
+}
+
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type Panel struct {
+	ID              int              `json:"id"`
+	Type            string           `json:"type"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description,omitempty"`
+	GridPos         GridPos          `json:"gridPos"`
+	Datasource      *DatasourceRef   `json:"datasource,omitempty"`
+	Targets         []Target         `json:"targets,omitempty"`
+	FieldConfig     *FieldConfig     `json:"fieldConfig,omitempty"`
+	Options         map[string]any   `json:"options,omitempty"`
+	Transformations []Transformation `json:"transformations,omitempty"`
+	Links           []Link           `json:"links,omitempty"`
+	Collapsed       bool             `json:"collapsed,omitempty"`
+	Panels          []Panel          `json:"panels,omitempty"`
+}
+
+type Target struct {
+	RefID        string         `json:"refId"`
+	Datasource   *DatasourceRef `json:"datasource"`
+	Expr         string         `json:"expr"`
+	LegendFormat string         `json:"legendFormat,omitempty"`
+	Format       string         `json:"format,omitempty"`
+	Instant      bool           `json:"instant,omitempty"`
+	Hide         bool           `json:"hide,omitempty"`
+}
+
+type FieldConfig struct {
+	Defaults  FieldDefaults `json:"defaults"`
+	Overrides []Override    `json:"overrides"`
+}
+
+type FieldDefaults struct {
+	Unit       string      `json:"unit,omitempty"`
+	Decimals   *int        `json:"decimals,omitempty"`
+	Min        *float64    `json:"min,omitempty"`
+	Max        *float64    `json:"max,omitempty"`
+	Thresholds *Thresholds `json:"thresholds,omitempty"`
+}
+
+type Thresholds struct {
+	Mode  string          `json:"mode"`
+	Steps []ThresholdStep `json:"steps"`
+}
+
+type ThresholdStep struct {
+	Color string   `json:"color"`
+	Value *float64 `json:"value"`
+}
+
+type Override struct {
+	Matcher    Matcher    `json:"matcher"`
+	Properties []Property `json:"properties"`
+}
+
+type Matcher struct {
+	ID      string `json:"id"`
+	Options string `json:"options"`
+}
+
+type Property struct {
+	ID    string `json:"id"`
+	Value any    `json:"value"`
+}
+
+type Transformation struct {
+	ID      string         `json:"id"`
+	Options map[string]any `json:"options"`
+}
+
+type Link struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	TargetBlank bool   `json:"targetBlank,omitempty"`
+}
+
+// units maps Perses format units to Grafana units.
+var units = map[string]string{
+	"decimal":         "short",
+	"percent":         "percent",
+	"percent-decimal": "percentunit",
+	"bytes":           "bytes",
+	"decbytes":        "decbytes",
+	"bytes/sec":       "Bps",
+	"bits/sec":        "bps",
+	"counts/sec":      "cps",
+	"packets/sec":     "pps",
+	"requests/sec":    "reqps",
+	"ops/sec":         "ops",
+	"reads/sec":       "rps",
+	"writes/sec":      "wps",
+	"seconds":         "s",
+	"milliseconds":    "ms",
+	"microseconds":    "µs",
+	"nanoseconds":     "ns",
+	"minutes":         "m",
+	"hours":           "h",
+	"days":            "d",
+}
+
+// calculations maps Perses calculations to Grafana reducers.
+var calculations = map[string]string{
+	"last-number":  "lastNotNull",
+	"last":         "last",
+	"first-number": "firstNotNull",
+	"first":        "first",
+	"mean":         "mean",
+	"sum":          "sum",
+	"min":          "min",
+	"max":          "max",
+}
+
+const (
+	defaultPersesUnit        = "decimal"
+	defaultPersesCalculation = "last-number"
+)
+
+// grafanaUnit returns the Grafana unit of a Perses unit; unknown units are kept as-is.
+func grafanaUnit(unit string) string {
+	if u, ok := units[unit]; ok {
+		return u
+	}
+	return unit
+}
+
+// persesUnit returns the Perses unit of a Grafana unit; units without a
+// Perses equivalent are shown as plain numbers.
+func persesUnit(unit string) string {
+	for perses, grafana := range units {
+		if grafana == unit {
+			return perses
+		}
+	}
+	return defaultPersesUnit
+}
+
+// grafanaCalculation returns the Grafana reducer of a Perses calculation.
+func grafanaCalculation(calculation string) string {
+	if c, ok := calculations[calculation]; ok {
+		return c
+	}
+	return calculations[defaultPersesCalculation]
+}
+
+// persesCalculation returns the Perses calculation of a Grafana reducer.
+func persesCalculation(reducer string) string {
+	for perses, grafana := range calculations {
+		if grafana == reducer {
+			return perses
+		}
+	}
+	return defaultPersesCalculation
+}
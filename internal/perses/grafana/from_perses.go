@@ -0,0 +1,698 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package grafana
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	grafanaSchemaVersion = 39
+	// grafanaDatasourceVariable is the dashboard variable every translated
+	// query points to, so the datasource can be picked at import time.
+	grafanaDatasourceVariable = "datasource"
+	// grafanaLokiDatasourceDefaultVariable is the datasource variable of the
+	// Loki queries that do not name their datasource.
+	grafanaLokiDatasourceDefaultVariable = "loki"
+	// grafanaMixedDatasource is the panel datasource of panels whose queries
+	// point to different datasources.
+	grafanaMixedDatasource = "-- Mixed --"
+	grafanaUIDMaxLength    = 40
+	// persesAllValue is the Perses placeholder for the "All" list variable option.
+	persesAllValue = "$__all"
+	// persesDashboardViewPath is the console path of the Perses dashboards
+	// the built-in links point to.
+	persesDashboardViewPath = "/monitoring/v2/dashboards/view"
+)
+
+// persesDashboard mirrors the parts of a serialized Perses dashboard that
+// are translated to Grafana. Plugin specs are kept as raw JSON since they are
+// only typed in the Perses plugin modules.
+type persesDashboard struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Display *struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"display"`
+		Duration        string                 `json:"duration"`
+		RefreshInterval string                 `json:"refreshInterval"`
+		Variables       []persesVariable       `json:"variables"`
+		Panels          map[string]persesPanel `json:"panels"`
+		Layouts         []persesLayout         `json:"layouts"`
+	} `json:"spec"`
+}
+
+type persesPlugin struct {
+	Kind string          `json:"kind"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+type persesVariable struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Name    string `json:"name"`
+		Display *struct {
+			Name   string `json:"name"`
+			Hidden bool   `json:"hidden"`
+		} `json:"display"`
+		DefaultValue   json.RawMessage `json:"defaultValue"`
+		AllowAllValue  bool            `json:"allowAllValue"`
+		AllowMultiple  bool            `json:"allowMultiple"`
+		CustomAllValue string          `json:"customAllValue"`
+		Value          string          `json:"value"`
+		Constant       bool            `json:"constant"`
+		Plugin         persesPlugin    `json:"plugin"`
+	} `json:"spec"`
+}
+
+type persesPanel struct {
+	Spec struct {
+		Display *struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"display"`
+		Plugin  persesPlugin `json:"plugin"`
+		Queries []struct {
+			Spec struct {
+				Plugin persesPlugin `json:"plugin"`
+			} `json:"spec"`
+		} `json:"queries"`
+		Links []struct {
+			Name        string `json:"name"`
+			URL         string `json:"url"`
+			TargetBlank bool   `json:"targetBlank"`
+		} `json:"links"`
+	} `json:"spec"`
+}
+
+type persesLayout struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Display *struct {
+			Title    string `json:"title"`
+			Collapse *struct {
+				Open bool `json:"open"`
+			} `json:"collapse"`
+		} `json:"display"`
+		Items []struct {
+			X       int `json:"x"`
+			Y       int `json:"y"`
+			Width   int `json:"width"`
+			Height  int `json:"height"`
+			Content struct {
+				Ref string `json:"$ref"`
+			} `json:"content"`
+		} `json:"items"`
+	} `json:"spec"`
+}
+
+type persesFormat struct {
+	Unit          string `json:"unit"`
+	DecimalPlaces *int   `json:"decimalPlaces"`
+}
+
+type persesThresholds struct {
+	Steps []struct {
+		Value float64 `json:"value"`
+		Color string  `json:"color"`
+	} `json:"steps"`
+}
+
+// FromPerses translates a Perses dashboard into a Grafana dashboard. Time series,
+// stat, gauge, table and markdown panels are translated; other panel kinds are
+// replaced by a text panel pointing at the original Perses dashboard.
+func FromPerses(db *persesapiv1.Dashboard) (*Dashboard, error) {
+	raw, err := json.Marshal(db)
+	if err != nil {
+		return nil, err
+	}
+	var pd persesDashboard
+	if err := json.Unmarshal(raw, &pd); err != nil {
+		return nil, err
+	}
+
+	gd := &Dashboard{
+		UID:           grafanaUID(pd.Metadata.Name),
+		Title:         pd.Metadata.Name,
+		SchemaVersion: grafanaSchemaVersion,
+		Time:          TimeRange{From: "now-1h", To: "now"},
+		Refresh:       pd.Spec.RefreshInterval,
+		Editable:      true,
+	}
+	if pd.Spec.Display != nil {
+		if pd.Spec.Display.Name != "" {
+			gd.Title = pd.Spec.Display.Name
+		}
+		gd.Description = pd.Spec.Display.Description
+	}
+	if pd.Spec.Duration != "" {
+		gd.Time.From = "now-" + pd.Spec.Duration
+	}
+
+	gd.Templating.List = append(gd.Templating.List, Variable{
+		Name:  grafanaDatasourceVariable,
+		Label: "Datasource",
+		Type:  "datasource",
+		Query: "prometheus",
+	})
+	for _, name := range lokiDatasources(&pd) {
+		label := "Loki"
+		if name != "" {
+			label = name
+		}
+		gd.Templating.List = append(gd.Templating.List, Variable{
+			Name:  grafanaLokiDatasourceVariable(name),
+			Label: label,
+			Type:  "datasource",
+			Query: "loki",
+		})
+	}
+	for _, v := range pd.Spec.Variables {
+		gv, err := fromPersesVariable(v)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", v.Spec.Name, err)
+		}
+		gd.Templating.List = append(gd.Templating.List, gv)
+	}
+
+	id := 0
+	y := 0
+	for _, layout := range pd.Spec.Layouts {
+		var row *Panel
+		if layout.Spec.Display != nil {
+			id++
+			row = &Panel{
+				ID:        id,
+				Type:      "row",
+				Title:     layout.Spec.Display.Title,
+				GridPos:   GridPos{X: 0, Y: y, W: grafanaGridWidth, H: 1},
+				Collapsed: layout.Spec.Display.Collapse != nil && !layout.Spec.Display.Collapse.Open,
+			}
+			y++
+		}
+
+		height := 0
+		var panels []Panel
+		for _, item := range layout.Spec.Items {
+			key := strings.TrimPrefix(item.Content.Ref, "#/spec/panels/")
+			pp, ok := pd.Spec.Panels[key]
+			if !ok {
+				return nil, fmt.Errorf("layout references unknown panel %q", item.Content.Ref)
+			}
+			id++
+			gp, err := fromPersesPanel(id, pd.Metadata.Name, pp)
+			if err != nil {
+				return nil, fmt.Errorf("panel %s: %w", key, err)
+			}
+			gp.GridPos = GridPos{X: item.X, Y: y + item.Y, W: item.Width, H: item.Height}
+			height = max(height, item.Y+item.Height)
+			panels = append(panels, gp)
+		}
+		y += height
+
+		// Grafana keeps the panels of a collapsed row inside the row itself.
+		switch {
+		case row != nil && row.Collapsed:
+			row.Panels = panels
+			gd.Panels = append(gd.Panels, *row)
+		case row != nil:
+			gd.Panels = append(gd.Panels, *row)
+			gd.Panels = append(gd.Panels, panels...)
+		default:
+			gd.Panels = append(gd.Panels, panels...)
+		}
+	}
+
+	return gd, nil
+}
+
+func fromPersesVariable(v persesVariable) (Variable, error) {
+	gv := Variable{
+		Name:       v.Spec.Name,
+		Multi:      v.Spec.AllowMultiple,
+		IncludeAll: v.Spec.AllowAllValue,
+		AllValue:   v.Spec.CustomAllValue,
+	}
+	if v.Spec.Display != nil {
+		gv.Label = v.Spec.Display.Name
+		if v.Spec.Display.Hidden {
+			gv.Hide = 2
+		}
+	}
+
+	if v.Kind == "TextVariable" {
+		gv.Type = "textbox"
+		if v.Spec.Constant {
+			gv.Type = "constant"
+		}
+		gv.Query = v.Spec.Value
+		gv.Current = &VariableValue{Text: v.Spec.Value, Value: v.Spec.Value}
+		return gv, nil
+	}
+
+	gv.Type = "query"
+	// Refresh on time range change, as Perses does.
+	gv.Refresh = 2
+	switch v.Spec.Plugin.Kind {
+	case "PrometheusLabelValuesVariable":
+		var spec struct {
+			LabelName string   `json:"labelName"`
+			Matchers  []string `json:"matchers"`
+		}
+		if err := json.Unmarshal(v.Spec.Plugin.Spec, &spec); err != nil {
+			return gv, err
+		}
+		if len(spec.Matchers) > 0 {
+			gv.Query = fmt.Sprintf("label_values(%s, %s)", spec.Matchers[0], spec.LabelName)
+		} else {
+			gv.Query = fmt.Sprintf("label_values(%s)", spec.LabelName)
+		}
+		gv.Datasource = grafanaDatasource()
+	case "PrometheusLabelNamesVariable":
+		gv.Query = "label_names()"
+		gv.Datasource = grafanaDatasource()
+	case "PrometheusPromQLVariable":
+		var spec struct {
+			Expr      string `json:"expr"`
+			LabelName string `json:"labelName"`
+		}
+		if err := json.Unmarshal(v.Spec.Plugin.Spec, &spec); err != nil {
+			return gv, err
+		}
+		gv.Query = fmt.Sprintf("query_result(%s)", spec.Expr)
+		gv.Regex = fmt.Sprintf(`/%s="([^"]+)"/`, spec.LabelName)
+		gv.Datasource = grafanaDatasource()
+	case "StaticListVariable":
+		var spec struct {
+			Values []json.RawMessage `json:"values"`
+		}
+		if err := json.Unmarshal(v.Spec.Plugin.Spec, &spec); err != nil {
+			return gv, err
+		}
+		values := make([]string, 0, len(spec.Values))
+		for _, raw := range spec.Values {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				var kv struct {
+					Value string `json:"value"`
+				}
+				if err := json.Unmarshal(raw, &kv); err != nil {
+					return gv, err
+				}
+				s = kv.Value
+			}
+			values = append(values, s)
+		}
+		gv.Type = "custom"
+		gv.Refresh = 0
+		gv.Query = strings.Join(values, ",")
+	default:
+		return gv, fmt.Errorf("unsupported variable plugin %q", v.Spec.Plugin.Kind)
+	}
+
+	if len(v.Spec.DefaultValue) > 0 {
+		var single string
+		var multiple []string
+		switch {
+		case json.Unmarshal(v.Spec.DefaultValue, &single) == nil:
+			gv.Current = grafanaCurrent(single)
+		case json.Unmarshal(v.Spec.DefaultValue, &multiple) == nil && len(multiple) > 0:
+			if len(multiple) == 1 {
+				gv.Current = grafanaCurrent(multiple[0])
+			} else {
+				gv.Current = &VariableValue{Text: multiple, Value: multiple}
+			}
+		}
+	}
+
+	return gv, nil
+}
+
+func grafanaCurrent(value string) *VariableValue {
+	if value == persesAllValue {
+		return &VariableValue{Text: "All", Value: "$__all"}
+	}
+	return &VariableValue{Text: value, Value: value}
+}
+
+func fromPersesPanel(id int, dashboardName string, pp persesPanel) (Panel, error) {
+	gp := Panel{ID: id}
+	if pp.Spec.Display != nil {
+		gp.Title = pp.Spec.Display.Name
+		gp.Description = pp.Spec.Display.Description
+	}
+	var unsupported []string
+	for _, l := range pp.Spec.Links {
+		href, ok := grafanaLinkURL(l.URL)
+		if !ok {
+			unsupported = append(unsupported, l.Name)
+			continue
+		}
+		gp.Links = append(gp.Links, Link{Title: l.Name, URL: href, TargetBlank: l.TargetBlank})
+	}
+	gp.Description = unsupportedLinksNote(gp.Description, dashboardName, unsupported)
+
+	plugin := pp.Spec.Plugin
+	switch plugin.Kind {
+	case "TimeSeriesChart":
+		var spec struct {
+			YAxis *struct {
+				Format *persesFormat `json:"format"`
+				Min    *float64      `json:"min"`
+				Max    *float64      `json:"max"`
+			} `json:"yAxis"`
+			Visual *struct {
+				Stack string `json:"stack"`
+			} `json:"visual"`
+		}
+		if err := json.Unmarshal(plugin.Spec, &spec); err != nil {
+			return gp, err
+		}
+		gp.Type = "timeseries"
+		gp.FieldConfig = &FieldConfig{Overrides: []Override{}}
+		if spec.YAxis != nil {
+			applyFormat(&gp.FieldConfig.Defaults, spec.YAxis.Format)
+			gp.FieldConfig.Defaults.Min = spec.YAxis.Min
+			gp.FieldConfig.Defaults.Max = spec.YAxis.Max
+		}
+		if spec.Visual != nil && spec.Visual.Stack == "all" {
+			gp.Options = map[string]any{"stacking": map[string]any{"mode": "normal"}}
+		}
+	case "StatChart", "GaugeChart":
+		var spec struct {
+			Calculation string            `json:"calculation"`
+			Format      *persesFormat     `json:"format"`
+			Thresholds  *persesThresholds `json:"thresholds"`
+			Max         *float64          `json:"max"`
+		}
+		if err := json.Unmarshal(plugin.Spec, &spec); err != nil {
+			return gp, err
+		}
+		gp.Type = "stat"
+		if plugin.Kind == "GaugeChart" {
+			gp.Type = "gauge"
+		}
+		gp.FieldConfig = &FieldConfig{Overrides: []Override{}}
+		applyFormat(&gp.FieldConfig.Defaults, spec.Format)
+		gp.FieldConfig.Defaults.Max = spec.Max
+		if spec.Thresholds != nil && len(spec.Thresholds.Steps) > 0 {
+			th := &Thresholds{Mode: "absolute"}
+			for i, step := range spec.Thresholds.Steps {
+				value := step.Value
+				gs := ThresholdStep{Color: step.Color, Value: &value}
+				// The first Grafana step is the base and carries no value.
+				if i == 0 {
+					gs.Value = nil
+				}
+				th.Steps = append(th.Steps, gs)
+			}
+			gp.FieldConfig.Defaults.Thresholds = th
+		}
+		gp.Options = map[string]any{
+			"reduceOptions": map[string]any{"calcs": []string{grafanaCalculation(spec.Calculation)}},
+		}
+	case "Table":
+		var spec struct {
+			ColumnSettings []struct {
+				Name     string        `json:"name"`
+				Header   string        `json:"header"`
+				Hide     bool          `json:"hide"`
+				Format   *persesFormat `json:"format"`
+				DataLink *struct {
+					URL        string `json:"url"`
+					Title      string `json:"title"`
+					OpenNewTab bool   `json:"openNewTab"`
+				} `json:"dataLink"`
+			} `json:"columnSettings"`
+		}
+		if err := json.Unmarshal(plugin.Spec, &spec); err != nil {
+			return gp, err
+		}
+		gp.Type = "table"
+		gp.FieldConfig = &FieldConfig{Overrides: []Override{}}
+		rename := map[string]any{}
+		exclude := map[string]any{}
+		var unsupportedColumns []string
+		for _, col := range spec.ColumnSettings {
+			// Perses names the value columns "value #N" where Grafana uses "Value #<refId>".
+			name := col.Name
+			if n, ok := strings.CutPrefix(name, "value #"); ok {
+				name = "Value #" + refID(n)
+			}
+			if col.Hide {
+				exclude[name] = true
+				continue
+			}
+			if col.Header != "" {
+				rename[name] = col.Header
+			}
+			props := []Property{}
+			if col.Format != nil {
+				var defaults FieldDefaults
+				applyFormat(&defaults, col.Format)
+				if defaults.Unit != "" {
+					props = append(props, Property{ID: "unit", Value: defaults.Unit})
+				}
+				if defaults.Decimals != nil {
+					props = append(props, Property{ID: "decimals", Value: *defaults.Decimals})
+				}
+			}
+			if col.DataLink != nil {
+				if href, ok := grafanaLinkURL(col.DataLink.URL); ok {
+					props = append(props, Property{ID: "links", Value: []Link{{
+						Title:       col.DataLink.Title,
+						URL:         href,
+						TargetBlank: col.DataLink.OpenNewTab,
+					}}})
+				} else {
+					unsupportedColumns = append(unsupportedColumns, col.DataLink.Title)
+				}
+			}
+			if len(props) > 0 {
+				gp.FieldConfig.Overrides = append(gp.FieldConfig.Overrides, Override{
+					Matcher:    Matcher{ID: "byName", Options: name},
+					Properties: props,
+				})
+			}
+		}
+		gp.Description = unsupportedLinksNote(gp.Description, dashboardName, unsupportedColumns)
+		if len(pp.Spec.Queries) > 1 {
+			gp.Transformations = append(gp.Transformations, Transformation{ID: "merge", Options: map[string]any{}})
+		}
+		if len(rename) > 0 || len(exclude) > 0 {
+			gp.Transformations = append(gp.Transformations, Transformation{
+				ID:      "organize",
+				Options: map[string]any{"renameByName": rename, "excludeByName": exclude},
+			})
+		}
+	case "Markdown":
+		var spec struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(plugin.Spec, &spec); err != nil {
+			return gp, err
+		}
+		gp.Type = "text"
+		gp.Options = map[string]any{"mode": "markdown", "content": spec.Text}
+		return gp, nil
+	default:
+		gp.Type = "text"
+		gp.Options = map[string]any{
+			"mode":    "markdown",
+			"content": fmt.Sprintf("%s panels are not translated to Grafana; see the %s Perses dashboard.", plugin.Kind, dashboardName),
+		}
+		return gp, nil
+	}
+
+	gp.Datasource = grafanaDatasource()
+	for i, q := range pp.Spec.Queries {
+		var spec struct {
+			Query            string `json:"query"`
+			SeriesNameFormat string `json:"seriesNameFormat"`
+			Datasource       *struct {
+				Name string `json:"name"`
+			} `json:"datasource"`
+		}
+		if err := json.Unmarshal(q.Spec.Plugin.Spec, &spec); err != nil {
+			return gp, err
+		}
+		target := Target{
+			RefID:        refID(fmt.Sprint(i + 1)),
+			Expr:         spec.Query,
+			LegendFormat: spec.SeriesNameFormat,
+		}
+		switch q.Spec.Plugin.Kind {
+		case "PrometheusTimeSeriesQuery":
+			target.Datasource = grafanaDatasource()
+		case "LokiTimeSeriesQuery":
+			name := ""
+			if spec.Datasource != nil {
+				name = spec.Datasource.Name
+			}
+			target.Datasource = grafanaLokiDatasource(name)
+		default:
+			return gp, fmt.Errorf("unsupported query plugin %q", q.Spec.Plugin.Kind)
+		}
+		if i == 0 {
+			gp.Datasource = target.Datasource
+		} else if *gp.Datasource != *target.Datasource {
+			gp.Datasource = &DatasourceRef{Type: "datasource", UID: grafanaMixedDatasource}
+		}
+		if gp.Type == "table" {
+			target.Format = "table"
+			target.Instant = true
+		}
+		gp.Targets = append(gp.Targets, target)
+	}
+
+	return gp, nil
+}
+
+// refID converts a 1-based Perses query index into a Grafana refId (A, B, ...).
+func refID(index string) string {
+	var n int
+	if _, err := fmt.Sscanf(index, "%d", &n); err != nil || n < 1 || n > 26 {
+		return index
+	}
+	return string(rune('A' + n - 1))
+}
+
+func applyFormat(defaults *FieldDefaults, format *persesFormat) {
+	if format == nil {
+		return
+	}
+	defaults.Unit = grafanaUnit(format.Unit)
+	defaults.Decimals = format.DecimalPlaces
+}
+
+func grafanaDatasource() *DatasourceRef {
+	return &DatasourceRef{Type: "prometheus", UID: "${" + grafanaDatasourceVariable + "}"}
+}
+
+// grafanaLokiDatasource points at the datasource variable standing for the
+// given Perses Loki datasource.
+func grafanaLokiDatasource(name string) *DatasourceRef {
+	return &DatasourceRef{Type: "loki", UID: "${" + grafanaLokiDatasourceVariable(name) + "}"}
+}
+
+// grafanaLokiDatasourceVariable names the Grafana datasource variable of a
+// Perses Loki datasource, e.g. loki-application-datasource becomes
+// loki_application_datasource.
+func grafanaLokiDatasourceVariable(name string) string {
+	if name == "" {
+		return grafanaLokiDatasourceDefaultVariable
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// lokiDatasources returns the names of the Loki datasources queried by the
+// dashboard panels, in a stable order.
+func lokiDatasources(pd *persesDashboard) []string {
+	names := map[string]struct{}{}
+	for _, pp := range pd.Spec.Panels {
+		for _, q := range pp.Spec.Queries {
+			if q.Spec.Plugin.Kind != "LokiTimeSeriesQuery" {
+				continue
+			}
+			var spec struct {
+				Datasource *struct {
+					Name string `json:"name"`
+				} `json:"datasource"`
+			}
+			if err := json.Unmarshal(q.Spec.Plugin.Spec, &spec); err != nil || spec.Datasource == nil {
+				names[""] = struct{}{}
+				continue
+			}
+			names[spec.Datasource.Name] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// grafanaLinkURL translates the URL of a link to a Perses dashboard into the
+// URL of the exported Grafana dashboard. The variables and the table field
+// placeholders, e.g. ${__data.fields["pod"]}, share the Grafana syntax; the
+// time range parameters are renamed and the project is dropped. Links to
+// other console pages, such as the logs, have no Grafana equivalent.
+func grafanaLinkURL(raw string) (string, bool) {
+	path, query, _ := strings.Cut(raw, "?")
+	if path != persesDashboardViewPath {
+		return "", false
+	}
+	var (
+		dashboard string
+		params    []string
+	)
+	for _, param := range strings.Split(query, "&") {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "dashboard":
+			dashboard, _ = url.QueryUnescape(value)
+		case "project":
+			// Grafana dashboards are not grouped in projects.
+		case "start":
+			params = append(params, "from="+value)
+		case "end":
+			params = append(params, "to="+value)
+		default:
+			params = append(params, param)
+		}
+	}
+	if dashboard == "" {
+		return "", false
+	}
+	link := "/d/" + grafanaUID(dashboard)
+	if len(params) > 0 {
+		link += "?" + strings.Join(params, "&")
+	}
+	return link, true
+}
+
+// unsupportedLinksNote appends to the panel description the links that could
+// not be translated, so they are not silently lost.
+func unsupportedLinksNote(description, dashboardName string, titles []string) string {
+	if len(titles) == 0 {
+		return description
+	}
+	quoted := make([]string, 0, len(titles))
+	for _, t := range titles {
+		quoted = append(quoted, strconv.Quote(t))
+	}
+	note := fmt.Sprintf("The %s links are not translated to Grafana; see the %s Perses dashboard.", strings.Join(quoted, ", "), dashboardName)
+	if description == "" {
+		return note
+	}
+	return description + "\n\n" + note
+}
+
+// grafanaUID derives a stable Grafana UID from the dashboard name, which may
+// exceed the 40 characters allowed by Grafana. Long names are truncated and
+// suffixed with a hash to keep them unique.
+func grafanaUID(name string) string {
+	if len(name) <= grafanaUIDMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:4])
+	return name[:grafanaUIDMaxLength-len(suffix)-1] + "-" + suffix
+}
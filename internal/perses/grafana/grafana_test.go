@@ -0,0 +1,185 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const grafanaDashboardJSON = `{
+  "uid": "team-b",
+  "title": "Team B",
+  "schemaVersion": 39,
+  "time": {"from": "now-12h", "to": "now"},
+  "refresh": "30s",
+  "templating": {"list": [
+    {"name": "datasource", "type": "datasource", "query": "prometheus"},
+    {"name": "cluster", "label": "Cluster", "type": "query", "query": {"query": "label_values(up, cluster)", "refId": "A"}, "current": {"value": "local-cluster"}},
+    {"name": "pod", "type": "query", "query": "query_result(sum by (pod) (up{cluster=\"$cluster\"}))", "regex": "/pod=\"([^\"]+)\"/", "multi": true, "includeAll": true, "allValue": ".*", "current": {"value": ["$__all"]}},
+    {"name": "window", "type": "custom", "query": "1h, 6h,1d", "current": {"value": "6h"}},
+    {"name": "threshold", "type": "textbox", "query": "0.9"}
+  ]},
+  "panels": [
+    {"type": "stat", "title": "Targets", "gridPos": {"x": 0, "y": 0, "w": 6, "h": 4},
+     "fieldConfig": {"defaults": {"unit": "percentunit", "decimals": 1}},
+     "options": {"reduceOptions": {"calcs": ["mean"]}},
+     "targets": [{"expr": "avg(up)", "refId": "A"}]},
+    {"type": "row", "title": "Details", "gridPos": {"x": 0, "y": 4, "w": 24, "h": 1}, "collapsed": false},
+    {"type": "timeseries", "title": "Up", "gridPos": {"x": 0, "y": 5, "w": 12, "h": 8},
+     "fieldConfig": {"defaults": {"unit": "short"}},
+     "targets": [{"expr": "up{cluster=\"$cluster\"}", "legendFormat": "{{pod}}"}, {"expr": "hidden", "hide": true}]},
+    {"type": "table", "title": "Pods", "gridPos": {"x": 12, "y": 5, "w": 12, "h": 8},
+     "targets": [{"expr": "up", "format": "table"}],
+     "transformations": [{"id": "organize", "options": {"renameByName": {"Value #A": "Up"}, "excludeByName": {"Time": true}}}]},
+    {"type": "row", "title": "Notes", "gridPos": {"x": 0, "y": 13, "w": 24, "h": 1}, "collapsed": true, "panels": [
+      {"type": "text", "title": "Readme", "gridPos": {"x": 0, "y": 14, "w": 24, "h": 3}, "options": {"content": "# Hello"}},
+      {"type": "heatmap", "title": "Latency", "gridPos": {"x": 0, "y": 17, "w": 24, "h": 3}}
+    ]}
+  ]
+}`
+
+func TestToPerses_Variables(t *testing.T) {
+	var gd Dashboard
+	require.NoError(t, json.Unmarshal([]byte(grafanaDashboardJSON), &gd))
+
+	spec := ToPerses(&gd)
+	data, err := json.Marshal(spec["variables"])
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[
+	  {"kind":"ListVariable","spec":{"name":"cluster","display":{"name":"Cluster","hidden":false},"allowAllValue":false,"allowMultiple":false,"defaultValue":"local-cluster",
+	    "plugin":{"kind":"PrometheusLabelValuesVariable","spec":{"labelName":"cluster","matchers":["up"]}}}},
+	  {"kind":"ListVariable","spec":{"name":"pod","display":{"name":"pod","hidden":false},"allowAllValue":true,"allowMultiple":true,"customAllValue":".*","defaultValue":"$__all",
+	    "plugin":{"kind":"PrometheusPromQLVariable","spec":{"expr":"sum by (pod) (up{cluster=\"$cluster\"})","labelName":"pod"}}}},
+	  {"kind":"ListVariable","spec":{"name":"window","display":{"name":"window","hidden":false},"allowAllValue":false,"allowMultiple":false,"defaultValue":"6h",
+	    "plugin":{"kind":"StaticListVariable","spec":{"values":["1h","6h","1d"]}}}},
+	  {"kind":"TextVariable","spec":{"name":"threshold","display":{"name":"threshold","hidden":false},"value":"0.9"}}
+	]`, string(data))
+}
+
+func TestToPerses_Panels(t *testing.T) {
+	var gd Dashboard
+	require.NoError(t, json.Unmarshal([]byte(grafanaDashboardJSON), &gd))
+
+	panels := ToPerses(&gd)["panels"].(map[string]any)
+	data, err := json.Marshal(map[string]any{"stat": panels["0_0"], "table": panels["1_1"]})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+	  "stat": {"kind":"Panel","spec":{"display":{"name":"Targets","description":""},
+	    "plugin":{"kind":"StatChart","spec":{"calculation":"mean","format":{"unit":"percent-decimal","decimalPlaces":1}}},
+	    "queries":[{"kind":"TimeSeriesQuery","spec":{"plugin":{"kind":"PrometheusTimeSeriesQuery","spec":{"query":"avg(up)"}}}}]}},
+	  "table": {"kind":"Panel","spec":{"display":{"name":"Pods","description":""},
+	    "plugin":{"kind":"Table","spec":{"columnSettings":[{"name":"Time","hide":true},{"name":"value #1","header":"Up"}]}},
+	    "queries":[{"kind":"TimeSeriesQuery","spec":{"plugin":{"kind":"PrometheusTimeSeriesQuery","spec":{"query":"up"}}}}]}}
+	}`, string(data))
+}
+
+func TestFromPerses_Variables(t *testing.T) {
+	tests := []struct {
+		name     string
+		variable string
+		expected Variable
+	}{
+		{
+			name:     "label values",
+			variable: `{"kind":"ListVariable","spec":{"name":"cluster","display":{"name":"Cluster"},"defaultValue":"local-cluster","plugin":{"kind":"PrometheusLabelValuesVariable","spec":{"labelName":"cluster","matchers":["up{job=\"x\"}"]}}}}`,
+			expected: Variable{
+				Name: "cluster", Label: "Cluster", Type: "query", Refresh: 2,
+				Query:      `label_values(up{job="x"}, cluster)`,
+				Datasource: grafanaDatasource(),
+				Current:    &VariableValue{Text: "local-cluster", Value: "local-cluster"},
+			},
+		},
+		{
+			name:     "promql with all value",
+			variable: `{"kind":"ListVariable","spec":{"name":"flavor","defaultValue":["$__all"],"allowAllValue":true,"allowMultiple":true,"customAllValue":".*","plugin":{"kind":"PrometheusPromQLVariable","spec":{"expr":"sum by (flavor) (x)","labelName":"flavor"}}}}`,
+			expected: Variable{
+				Name: "flavor", Type: "query", Refresh: 2, Multi: true, IncludeAll: true, AllValue: ".*",
+				Query:      "query_result(sum by (flavor) (x))",
+				Regex:      `/flavor="([^"]+)"/`,
+				Datasource: grafanaDatasource(),
+				Current:    &VariableValue{Text: "All", Value: "$__all"},
+			},
+		},
+		{
+			name:     "static list",
+			variable: `{"kind":"ListVariable","spec":{"name":"days","defaultValue":"10d","plugin":{"kind":"StaticListVariable","spec":{"values":["1d","10d"]}}}}`,
+			expected: Variable{
+				Name: "days", Type: "custom", Query: "1d,10d",
+				Current: &VariableValue{Text: "10d", Value: "10d"},
+			},
+		},
+		{
+			name:     "text",
+			variable: `{"kind":"TextVariable","spec":{"name":"window","value":"7d"}}`,
+			expected: Variable{
+				Name: "window", Type: "textbox", Query: "7d",
+				Current: &VariableValue{Text: "7d", Value: "7d"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var v persesVariable
+			require.NoError(t, json.Unmarshal([]byte(tc.variable), &v))
+			gv, err := fromPersesVariable(v)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, gv)
+		})
+	}
+}
+
+func TestFromPerses_TableLinks(t *testing.T) {
+	var pp persesPanel
+	require.NoError(t, json.Unmarshal([]byte(`{"kind":"Panel","spec":{"display":{"name":"Pods"},
+	  "plugin":{"kind":"Table","spec":{"columnSettings":[
+	    {"name":"pod","dataLink":{"url":"/monitoring/v2/dashboards/view?dashboard=k8s-compute-resources-pod&project=$__project&var-cluster=$cluster&var-pod=${__data.fields[\"pod\"]}","title":"Drill down","openNewTab":true}},
+	    {"name":"namespace","dataLink":{"url":"/monitoring/logs?tenant=application&q=%7Bk8s_namespace_name%3D%22${__data.fields[\"namespace\"]}%22%7D","title":"View namespace logs"}}
+	  ]}},
+	  "queries":[{"kind":"TimeSeriesQuery","spec":{"plugin":{"kind":"PrometheusTimeSeriesQuery","spec":{"query":"up"}}}}]}}`), &pp))
+
+	gp, err := fromPersesPanel(1, "pods", pp)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Override{{
+		Matcher: Matcher{ID: "byName", Options: "pod"},
+		Properties: []Property{{ID: "links", Value: []Link{{
+			Title:       "Drill down",
+			URL:         `/d/k8s-compute-resources-pod?var-cluster=$cluster&var-pod=${__data.fields["pod"]}`,
+			TargetBlank: true,
+		}}}},
+	}}, gp.FieldConfig.Overrides)
+	assert.Equal(t, `The "View namespace logs" links are not translated to Grafana; see the pods Perses dashboard.`, gp.Description)
+}
+
+func TestGrafanaUID(t *testing.T) {
+	short := "acm-clusters-overview"
+	assert.Equal(t, short, grafanaUID(short))
+
+	a := grafanaUID("acm-openshift-virtualization-single-cluster-view")
+	b := grafanaUID("acm-openshift-virtualization-single-vm-view")
+	assert.Len(t, a, grafanaUIDMaxLength)
+	assert.NotEqual(t, a, b)
+}
+
+func TestUnits(t *testing.T) {
+	for perses, grafana := range units {
+		assert.Equal(t, perses, persesUnit(grafanaUnit(perses)), grafana)
+	}
+	assert.Equal(t, "decimal", persesUnit("none"))
+	assert.Equal(t, "custom", grafanaUnit("custom"))
+
+	for perses := range calculations {
+		assert.Equal(t, perses, persesCalculation(grafanaCalculation(perses)))
+	}
+	assert.Equal(t, "lastNotNull", grafanaCalculation("unknown"))
+	assert.Equal(t, "last-number", persesCalculation("unknown"))
+}
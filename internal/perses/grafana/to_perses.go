@@ -0,0 +1,408 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package grafana
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultDuration = "1h"
+	// grafanaGridWidth is the width of the Grafana grid, which Perses shares.
+	grafanaGridWidth = 24
+)
+
+var (
+	labelValuesQuery = regexp.MustCompile(`^\s*label_values\(\s*(?:(.*)\s*,\s*)?([a-zA-Z_][a-zA-Z0-9_]*)\s*\)\s*$`)
+	labelNamesQuery  = regexp.MustCompile(`^\s*label_names\(\s*\)\s*$`)
+	queryResultQuery = regexp.MustCompile(`^\s*query_result\((.*)\)\s*$`)
+	// labelRegex extracts the label name out of the usual query_result regex, e.g. /pod="([^"]+)"/.
+	labelRegex   = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)="`)
+	durationExpr = regexp.MustCompile(`^[0-9]+(ms|s|m|h|d|w|y)$`)
+)
+
+// ToPerses converts a Grafana dashboard into the JSON representation of a
+// Perses dashboard spec. Time series, graph, stat, gauge, table and text
+// panels are converted; other panels are replaced by a markdown note. Query
+// variables backed by label_values, label_names or query_result become
+// Prometheus variables, other variables become text variables holding their
+// current value so that panel queries still resolve.
+func ToPerses(gd *Dashboard) map[string]any {
+	spec := map[string]any{
+		"duration": defaultDuration,
+	}
+	if gd.Title != "" || gd.Description != "" {
+		spec["display"] = map[string]any{"name": gd.Title, "description": gd.Description}
+	}
+	if d, ok := strings.CutPrefix(gd.Time.From, "now-"); ok && durationExpr.MatchString(d) {
+		spec["duration"] = d
+	}
+	if refresh, ok := gd.Refresh.(string); ok && durationExpr.MatchString(refresh) {
+		spec["refreshInterval"] = refresh
+	}
+
+	variables := []any{}
+	for _, v := range gd.Templating.List {
+		if pv := toPersesVariable(v); pv != nil {
+			variables = append(variables, pv)
+		}
+	}
+	if len(variables) > 0 {
+		spec["variables"] = variables
+	}
+
+	panels := map[string]any{}
+	layouts := []any{}
+	var (
+		items   []any
+		display map[string]any
+		offset  int
+		group   int
+	)
+	flush := func() {
+		if len(items) == 0 && display == nil {
+			return
+		}
+		layoutSpec := map[string]any{"items": items}
+		if display != nil {
+			layoutSpec["display"] = display
+		}
+		layouts = append(layouts, map[string]any{"kind": "Grid", "spec": layoutSpec})
+		items = nil
+		display = nil
+		group++
+	}
+	add := func(gp Panel, top int) {
+		key := fmt.Sprintf("%d_%d", group, len(items))
+		panels[key] = toPersesPanel(gp)
+		width := gp.GridPos.W
+		if width <= 0 || width > grafanaGridWidth {
+			width = grafanaGridWidth
+		}
+		height := max(gp.GridPos.H, 1)
+		items = append(items, map[string]any{
+			"x":       gp.GridPos.X,
+			"y":       max(gp.GridPos.Y-top, 0),
+			"width":   width,
+			"height":  height,
+			"content": map[string]any{"$ref": "#/spec/panels/" + key},
+		})
+	}
+
+	sorted := make([]Panel, len(gd.Panels))
+	copy(sorted, gd.Panels)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].GridPos.Y != sorted[j].GridPos.Y {
+			return sorted[i].GridPos.Y < sorted[j].GridPos.Y
+		}
+		return sorted[i].GridPos.X < sorted[j].GridPos.X
+	})
+	for _, gp := range sorted {
+		if gp.Type != "row" {
+			add(gp, offset)
+			continue
+		}
+
+		flush()
+		display = map[string]any{
+			"title":    gp.Title,
+			"collapse": map[string]any{"open": !gp.Collapsed},
+		}
+		offset = gp.GridPos.Y + 1
+		// Collapsed rows carry their own panels.
+		for _, child := range gp.Panels {
+			add(child, offset)
+		}
+	}
+	flush()
+
+	spec["panels"] = panels
+	spec["layouts"] = layouts
+	return spec
+}
+
+func toPersesPanel(gp Panel) map[string]any {
+	panelSpec := map[string]any{
+		"display": map[string]any{"name": gp.Title, "description": gp.Description},
+	}
+
+	var defaults FieldDefaults
+	if gp.FieldConfig != nil {
+		defaults = gp.FieldConfig.Defaults
+	}
+	var format map[string]any
+	if defaults.Unit != "" || defaults.Decimals != nil {
+		format = map[string]any{}
+		if defaults.Unit != "" {
+			format["unit"] = persesUnit(defaults.Unit)
+		}
+		if defaults.Decimals != nil {
+			format["decimalPlaces"] = *defaults.Decimals
+		}
+	}
+	calculation := defaultPersesCalculation
+	if reduceOptions, ok := gp.Options["reduceOptions"].(map[string]any); ok {
+		if calcs, ok := reduceOptions["calcs"].([]any); ok && len(calcs) > 0 {
+			if c, ok := calcs[0].(string); ok {
+				calculation = persesCalculation(c)
+			}
+		}
+	}
+
+	var plugin map[string]any
+	switch gp.Type {
+	case "timeseries", "graph":
+		pluginSpec := map[string]any{"legend": map[string]any{"position": "bottom", "mode": "list"}}
+		yAxis := map[string]any{}
+		if format != nil {
+			yAxis["format"] = format
+		}
+		if defaults.Min != nil {
+			yAxis["min"] = *defaults.Min
+		}
+		if defaults.Max != nil {
+			yAxis["max"] = *defaults.Max
+		}
+		if len(yAxis) > 0 {
+			pluginSpec["yAxis"] = yAxis
+		}
+		plugin = map[string]any{"kind": "TimeSeriesChart", "spec": pluginSpec}
+	case "stat", "singlestat":
+		pluginSpec := map[string]any{"calculation": calculation}
+		if format != nil {
+			pluginSpec["format"] = format
+		}
+		plugin = map[string]any{"kind": "StatChart", "spec": pluginSpec}
+	case "gauge":
+		pluginSpec := map[string]any{"calculation": calculation}
+		if format != nil {
+			pluginSpec["format"] = format
+		}
+		if defaults.Max != nil {
+			pluginSpec["max"] = *defaults.Max
+		}
+		plugin = map[string]any{"kind": "GaugeChart", "spec": pluginSpec}
+	case "table":
+		var columns []any
+		for _, t := range gp.Transformations {
+			if t.ID != "organize" {
+				continue
+			}
+			rename, _ := t.Options["renameByName"].(map[string]any)
+			exclude, _ := t.Options["excludeByName"].(map[string]any)
+			names := make([]string, 0, len(rename)+len(exclude))
+			for name := range rename {
+				names = append(names, name)
+			}
+			for name, hidden := range exclude {
+				if _, renamed := rename[name]; hidden == true && !renamed {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				column := map[string]any{"name": persesColumnName(name)}
+				if header, ok := rename[name].(string); ok {
+					column["header"] = header
+				}
+				if exclude[name] == true {
+					column["hide"] = true
+				}
+				columns = append(columns, column)
+			}
+		}
+		pluginSpec := map[string]any{}
+		if len(columns) > 0 {
+			pluginSpec["columnSettings"] = columns
+		}
+		plugin = map[string]any{"kind": "Table", "spec": pluginSpec}
+	case "text":
+		return map[string]any{
+			"kind": "Panel",
+			"spec": map[string]any{
+				"display": panelSpec["display"],
+				"plugin":  map[string]any{"kind": "Markdown", "spec": map[string]any{"text": content(gp.Options)}},
+			},
+		}
+	default:
+		return map[string]any{
+			"kind": "Panel",
+			"spec": map[string]any{
+				"display": panelSpec["display"],
+				"plugin": map[string]any{"kind": "Markdown", "spec": map[string]any{
+					"text": fmt.Sprintf("Grafana %q panels are not supported.", gp.Type),
+				}},
+			},
+		}
+	}
+	panelSpec["plugin"] = plugin
+
+	var queries []any
+	for _, t := range gp.Targets {
+		if t.Hide || strings.TrimSpace(t.Expr) == "" {
+			continue
+		}
+		querySpec := map[string]any{"query": t.Expr}
+		if t.LegendFormat != "" && t.LegendFormat != "__auto" {
+			querySpec["seriesNameFormat"] = t.LegendFormat
+		}
+		queries = append(queries, map[string]any{
+			"kind": "TimeSeriesQuery",
+			"spec": map[string]any{
+				"plugin": map[string]any{"kind": "PrometheusTimeSeriesQuery", "spec": querySpec},
+			},
+		})
+	}
+	if len(queries) > 0 {
+		panelSpec["queries"] = queries
+	}
+
+	var links []any
+	for _, l := range gp.Links {
+		links = append(links, map[string]any{"name": l.Title, "url": l.URL, "targetBlank": l.TargetBlank})
+	}
+	if len(links) > 0 {
+		panelSpec["links"] = links
+	}
+
+	return map[string]any{"kind": "Panel", "spec": panelSpec}
+}
+
+// persesColumnName converts the Grafana "Value #<refId>" columns into the
+// "value #<index>" columns used by Perses tables.
+func persesColumnName(name string) string {
+	ref, ok := strings.CutPrefix(name, "Value #")
+	if !ok || len(ref) != 1 || ref[0] < 'A' || ref[0] > 'Z' {
+		return name
+	}
+	return fmt.Sprintf("value #%d", ref[0]-'A'+1)
+}
+
+func toPersesVariable(v Variable) map[string]any {
+	display := map[string]any{"name": v.Label, "hidden": v.Hide == 2}
+	if v.Label == "" {
+		display["name"] = v.Name
+	}
+
+	query := variableQuery(v.Query)
+	current := variableCurrent(v)
+
+	switch v.Type {
+	case "datasource", "adhoc":
+		// The datasource is forced by the addon and ad hoc filters have no Perses equivalent.
+		return nil
+	case "textbox", "constant":
+		value := query
+		if len(current) > 0 {
+			value = current[0]
+		}
+		spec := map[string]any{"name": v.Name, "display": display, "value": value}
+		if v.Type == "constant" {
+			spec["constant"] = true
+		}
+		return map[string]any{"kind": "TextVariable", "spec": spec}
+	}
+
+	var plugin map[string]any
+	switch v.Type {
+	case "custom", "interval":
+		var values []any
+		for _, value := range strings.Split(query, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		plugin = map[string]any{"kind": "StaticListVariable", "spec": map[string]any{"values": values}}
+	case "query":
+		if m := labelValuesQuery.FindStringSubmatch(query); m != nil {
+			pluginSpec := map[string]any{"labelName": m[2]}
+			if strings.TrimSpace(m[1]) != "" {
+				pluginSpec["matchers"] = []any{strings.TrimSpace(m[1])}
+			}
+			plugin = map[string]any{"kind": "PrometheusLabelValuesVariable", "spec": pluginSpec}
+		} else if labelNamesQuery.MatchString(query) {
+			plugin = map[string]any{"kind": "PrometheusLabelNamesVariable", "spec": map[string]any{}}
+		} else if m := queryResultQuery.FindStringSubmatch(query); m != nil {
+			if l := labelRegex.FindStringSubmatch(v.Regex); l != nil {
+				plugin = map[string]any{"kind": "PrometheusPromQLVariable", "spec": map[string]any{"expr": m[1], "labelName": l[1]}}
+			}
+		}
+	}
+
+	if plugin == nil {
+		// Keep the variable resolvable in queries even though it cannot be converted.
+		value := ""
+		if len(current) > 0 {
+			value = current[0]
+		}
+		return map[string]any{
+			"kind": "TextVariable",
+			"spec": map[string]any{"name": v.Name, "display": display, "value": value},
+		}
+	}
+
+	spec := map[string]any{
+		"name":          v.Name,
+		"display":       display,
+		"allowAllValue": v.IncludeAll,
+		"allowMultiple": v.Multi,
+		"plugin":        plugin,
+	}
+	if v.AllValue != "" {
+		spec["customAllValue"] = v.AllValue
+	}
+	switch {
+	case len(current) == 1:
+		spec["defaultValue"] = current[0]
+	case len(current) > 1:
+		spec["defaultValue"] = current
+	}
+
+	return map[string]any{"kind": "ListVariable", "spec": spec}
+}
+
+// content returns the markdown of a text panel.
+func content(options map[string]any) string {
+	text, _ := options["content"].(string)
+	return text
+}
+
+// variableQuery returns the query of a variable, which newer Grafana versions
+// store as an object rather than a string.
+func variableQuery(query any) string {
+	switch q := query.(type) {
+	case string:
+		return q
+	case map[string]any:
+		s, _ := q["query"].(string)
+		return s
+	}
+	return ""
+}
+
+func variableCurrent(v Variable) []string {
+	if v.Current == nil {
+		return nil
+	}
+	switch value := v.Current.Value.(type) {
+	case string:
+		if value == "" {
+			return nil
+		}
+		return []string{value}
+	case []any:
+		var values []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package rightsizing
+
+import (
+	"fmt"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	commonSdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/plugins/prometheus/sdk/go/query"
+	tablePanel "github.com/perses/plugins/table/sdk/go"
+)
+
+// WorkloadDashboardName is the name of the workload right-sizing dashboard,
+// used as the drill-down target from the namespace quota tables.
+const WorkloadDashboardName = "acm-rs-workload-overview"
+
+// workloadDataLink links a namespace row to the workload right-sizing dashboard
+// filtered on the same cluster, profile, window, days and namespace.
+func workloadDataLink(project string) *DataLink {
+	return &DataLink{
+		OpenNewTab: false,
+		Title:      "Workload Right-Sizing",
+		URL: fmt.Sprintf(
+			"/monitoring/v2/dashboards/view?dashboard=%s&project=%s&var-cluster=$cluster&var-profile=$profile&var-aggregation=$aggregation&var-days=$days&var-namespace=${__data.fields[\"namespace\"]}",
+			WorkloadDashboardName, project,
+		),
+	}
+}
+
+var naCellSettings = []tablePanel.CellSettings{
+	{Condition: tablePanel.Condition{Kind: tablePanel.MiscConditionKind, Spec: &tablePanel.MiscConditionSpec{Value: tablePanel.NullValue}}, Text: "N/A"},
+}
+
+// rsTableQueries returns the utilization, usage, request and recommendation queries
+// for the given acm_rs level ("workload" or "container"), grouped by the given labels.
+func rsTableQueries(datasourceName, clusterLabelName, level, resource, by string) []panel.Option {
+	series := func(kind string) string {
+		return fmt.Sprintf(`max_over_time(sum by (%s) (acm_rs:%s:%s_%s{%s="$cluster", profile="$profile", aggregation="$aggregation", namespace=~"$namespace"})[$days:])`,
+			by, level, resource, kind, clusterLabelName)
+	}
+	return []panel.Option{
+		panel.AddQuery(query.PromQL(series("usage")+" / "+series("request"), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(series("usage"), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(series("request"), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(series("recommendation"), dashboards.AddQueryDataSource(datasourceName))),
+	}
+}
+
+// rsValueColumns returns the value columns matching the queries of rsTableQueries.
+func rsValueColumns(prefix string, unit *string) []ColumnSettingsWithLink {
+	return []ColumnSettingsWithLink{
+		nsTblCol("value #1", prefix+" Utilization %", tablePanel.RightAlign,
+			&commonSdk.Format{Unit: &dashboards.PercentDecimalUnit, DecimalPlaces: 2},
+			func(c *ColumnSettingsWithLink) { c.Sort = tablePanel.DescSort }),
+		nsTblCol("value #2", prefix+" Usage", tablePanel.RightAlign,
+			&commonSdk.Format{Unit: unit, DecimalPlaces: 2}),
+		nsTblCol("value #3", prefix+" Request", tablePanel.RightAlign,
+			&commonSdk.Format{Unit: unit, DecimalPlaces: 2}),
+		nsTblCol("value #4", prefix+" Recommendation", tablePanel.RightAlign,
+			&commonSdk.Format{Unit: unit, DecimalPlaces: 2}),
+	}
+}
+
+func workloadTablePanel(datasourceName, clusterLabelName, title, description, resource, prefix string, unit *string) panelgroup.Option {
+	columns := []ColumnSettingsWithLink{
+		{ColumnSettings: tablePanel.ColumnSettings{Name: "timestamp", Hide: true}},
+		nsTblCol("namespace", "Namespace", tablePanel.LeftAlign, nil),
+		nsTblCol("workload", "Workload", tablePanel.LeftAlign, nil),
+		nsTblCol("workload_type", "Type", tablePanel.LeftAlign, nil),
+	}
+	opts := []panel.Option{
+		panel.Description(description),
+		TableWithLinks(TablePluginSpec{
+			ColumnSettings: append(columns, rsValueColumns(prefix, unit)...),
+			CellSettings:   naCellSettings,
+			Transforms: []commonSdk.Transform{
+				{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
+				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{"namespace", "workload", "workload_type"}}},
+			},
+			EnableFiltering: true,
+		}),
+	}
+	opts = append(opts, rsTableQueries(datasourceName, clusterLabelName, "workload", resource, "namespace, workload, workload_type")...)
+	return panelgroup.AddPanel(title, opts...)
+}
+
+func containerTablePanel(datasourceName, clusterLabelName, title, description, resource, prefix string, unit *string) panelgroup.Option {
+	columns := []ColumnSettingsWithLink{
+		{ColumnSettings: tablePanel.ColumnSettings{Name: "timestamp", Hide: true}},
+		nsTblCol("namespace", "Namespace", tablePanel.LeftAlign, nil),
+		nsTblCol("workload", "Workload", tablePanel.LeftAlign, nil),
+		nsTblCol("workload_type", "Type", tablePanel.LeftAlign, nil),
+		nsTblCol("container", "Container", tablePanel.LeftAlign, nil),
+	}
+	opts := []panel.Option{
+		panel.Description(description),
+		TableWithLinks(TablePluginSpec{
+			ColumnSettings: append(columns, rsValueColumns(prefix, unit)...),
+			CellSettings:   naCellSettings,
+			Transforms: []commonSdk.Transform{
+				{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
+				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{"namespace", "workload", "workload_type", "container"}}},
+			},
+			EnableFiltering: true,
+		}),
+	}
+	opts = append(opts, rsTableQueries(datasourceName, clusterLabelName, "container", resource, "namespace, workload, workload_type, container")...)
+	return panelgroup.AddPanel(title, opts...)
+}
+
+func WorkloadCPUTablePanel(datasourceName, clusterLabelName string) panelgroup.Option {
+	return workloadTablePanel(datasourceName, clusterLabelName,
+		"Workload CPU",
+		"CPU utilization, usage, request and recommendation per Deployment, StatefulSet and DaemonSet, summed across replicas",
+		"cpu", "CPU", &dashboards.DecimalUnit)
+}
+
+func WorkloadMemTablePanel(datasourceName, clusterLabelName string) panelgroup.Option {
+	return workloadTablePanel(datasourceName, clusterLabelName,
+		"Workload Memory",
+		"Memory utilization, usage, request and recommendation per Deployment, StatefulSet and DaemonSet, summed across replicas",
+		"memory", "Memory", &dashboards.BytesUnit)
+}
+
+func ContainerCPUTablePanel(datasourceName, clusterLabelName string) panelgroup.Option {
+	return containerTablePanel(datasourceName, clusterLabelName,
+		"Container CPU",
+		"Per-replica CPU utilization, usage, request and recommendation for each container. The recommendation is the value to set in the container's resources.requests.cpu",
+		"cpu", "CPU", &dashboards.DecimalUnit)
+}
+
+func ContainerMemTablePanel(datasourceName, clusterLabelName string) panelgroup.Option {
+	return containerTablePanel(datasourceName, clusterLabelName,
+		"Container Memory",
+		"Per-replica memory utilization, usage, request and recommendation for each container. The recommendation is the value to set in the container's resources.requests.memory",
+		"memory", "Memory", &dashboards.BytesUnit)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+// Package scope narrows the cluster and namespace variables of a dashboard
+// down to the clusters and namespaces of a team.
+package scope
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	clusterVariable   = "cluster"
+	namespaceVariable = "namespace"
+
+	labelValuesVariableKind = "PrometheusLabelValuesVariable"
+)
+
+// Regex returns the regular expression matching exactly the given values.
+func Regex(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, regexp.QuoteMeta(v))
+	}
+	return strings.Join(quoted, "|")
+}
+
+// Dashboard restricts the cluster and namespace variables of the dashboard so
+// that they only list, and their "All" value only stands for, the given
+// clusters and namespaces. An empty list leaves the variable unchanged.
+func Dashboard(spec *persesapiv1.DashboardSpec, clusters, namespaces []string) error {
+	allowed := map[string][]string{
+		clusterVariable:   clusters,
+		namespaceVariable: namespaces,
+	}
+
+	for i := range spec.Variables {
+		switch v := spec.Variables[i].Spec.(type) {
+		case *dashboard.ListVariableSpec:
+			values := allowed[v.Name]
+			if len(values) == 0 {
+				continue
+			}
+			if err := scopeListVariable(v, Regex(values)); err != nil {
+				return fmt.Errorf("failed to scope variable %s: %w", v.Name, err)
+			}
+		case *dashboard.TextVariableSpec:
+			if values := allowed[v.Name]; len(values) > 0 {
+				v.Value = Regex(values)
+			}
+		}
+	}
+
+	return nil
+}
+
+// scopeListVariable adds a matcher on the listed label to the selectors of a
+// label values variable, and filters the values of any other variable.
+func scopeListVariable(v *dashboard.ListVariableSpec, regex string) error {
+	if v.CustomAllValue != "" {
+		v.CustomAllValue = regex
+	}
+
+	spec, ok := v.Plugin.Spec.(map[string]any)
+	if v.Plugin.Kind != labelValuesVariableKind || !ok {
+		v.CapturingRegexp = fmt.Sprintf("^(%s)$", regex)
+		return nil
+	}
+
+	labelName, _ := spec["labelName"].(string)
+	matcher := labels.MustNewMatcher(labels.MatchRegexp, labelName, regex)
+
+	selectors, _ := spec["matchers"].([]any)
+	if len(selectors) == 0 {
+		spec["matchers"] = []any{fmt.Sprintf("{%s}", matcher)}
+		return nil
+	}
+	for i, s := range selectors {
+		selector, _ := s.(string)
+		expr, err := parser.ParseExpr(selector)
+		if err != nil {
+			return err
+		}
+		vs, ok := expr.(*parser.VectorSelector)
+		if !ok {
+			return fmt.Errorf("matcher %q is not a series selector", selector)
+		}
+		vs.LabelMatchers = append(vs.LabelMatchers, matcher)
+		selectors[i] = vs.String()
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package scope
+
+import (
+	"encoding/json"
+	"testing"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dashboardJSON = `{
+  "display": {"name": "Team"},
+  "duration": "1h",
+  "panels": {},
+  "layouts": [],
+  "variables": [
+    {"kind": "ListVariable", "spec": {"name": "cluster", "allowAllValue": true, "customAllValue": ".*",
+      "plugin": {"kind": "PrometheusLabelValuesVariable", "spec": {"labelName": "name", "matchers": ["acm_managed_cluster_labels{openshiftVersion_major!=\"3\"}"]}}}},
+    {"kind": "ListVariable", "spec": {"name": "namespace",
+      "plugin": {"kind": "PrometheusLabelValuesVariable", "spec": {"labelName": "namespace"}}}},
+    {"kind": "ListVariable", "spec": {"name": "pod",
+      "plugin": {"kind": "PrometheusLabelValuesVariable", "spec": {"labelName": "pod"}}}}
+  ]
+}`
+
+func parseDashboard(t *testing.T, data string) *persesapiv1.DashboardSpec {
+	t.Helper()
+	spec := &persesapiv1.DashboardSpec{}
+	require.NoError(t, json.Unmarshal([]byte(data), spec))
+	return spec
+}
+
+func TestRegex(t *testing.T) {
+	assert.Equal(t, `c1|c\.2`, Regex([]string{"c1", "c.2"}))
+}
+
+func TestDashboard(t *testing.T) {
+	spec := parseDashboard(t, dashboardJSON)
+	require.NoError(t, Dashboard(spec, []string{"c1", "c2"}, []string{"team-a"}))
+
+	cluster := spec.Variables[0].Spec.(*dashboard.ListVariableSpec)
+	assert.Equal(t, "c1|c2", cluster.CustomAllValue)
+	assert.Equal(t, []any{`acm_managed_cluster_labels{name=~"c1|c2",openshiftVersion_major!="3"}`}, cluster.Plugin.Spec.(map[string]any)["matchers"])
+
+	namespace := spec.Variables[1].Spec.(*dashboard.ListVariableSpec)
+	assert.Empty(t, namespace.CustomAllValue)
+	assert.Equal(t, []any{`{namespace=~"team-a"}`}, namespace.Plugin.Spec.(map[string]any)["matchers"])
+
+	pod := spec.Variables[2].Spec.(*dashboard.ListVariableSpec)
+	assert.Nil(t, pod.Plugin.Spec.(map[string]any)["matchers"], "other variables are left untouched")
+}
+
+func TestDashboardWithoutNamespaces(t *testing.T) {
+	spec := parseDashboard(t, dashboardJSON)
+	require.NoError(t, Dashboard(spec, []string{"c1"}, nil))
+
+	namespace := spec.Variables[1].Spec.(*dashboard.ListVariableSpec)
+	assert.Nil(t, namespace.Plugin.Spec.(map[string]any)["matchers"])
+}
+
+func TestDashboardOtherVariableKinds(t *testing.T) {
+	spec := parseDashboard(t, `{
+  "display": {"name": "Team"},
+  "duration": "1h",
+  "panels": {},
+  "layouts": [],
+  "variables": [
+    {"kind": "ListVariable", "spec": {"name": "cluster",
+      "plugin": {"kind": "StaticListVariable", "spec": {"values": ["c1", "c2", "c3"]}}}},
+    {"kind": "TextVariable", "spec": {"name": "namespace", "value": ".*"}}
+  ]
+}`)
+	require.NoError(t, Dashboard(spec, []string{"c1", "c2"}, []string{"team-a", "team-b"}))
+
+	cluster := spec.Variables[0].Spec.(*dashboard.ListVariableSpec)
+	assert.Equal(t, "^(c1|c2)$", cluster.CapturingRegexp)
+
+	namespace := spec.Variables[1].Spec.(*dashboard.TextVariableSpec)
+	assert.Equal(t, "team-a|team-b", namespace.Value)
+}
+
+func TestDashboardInvalidMatcher(t *testing.T) {
+	spec := parseDashboard(t, `{
+  "display": {"name": "Team"},
+  "duration": "1h",
+  "panels": {},
+  "layouts": [],
+  "variables": [
+    {"kind": "ListVariable", "spec": {"name": "cluster",
+      "plugin": {"kind": "PrometheusLabelValuesVariable", "spec": {"labelName": "name", "matchers": ["sum(up)"]}}}}
+  ]
+}`)
+	require.Error(t, Dashboard(spec, []string{"c1"}, nil))
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package userdashboards
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/perses/grafana"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const prometheusDatasourceKind = "PrometheusDatasource"
+
+var (
+	ErrUnknownDashboardFormat = errors.New("content is neither a PersesDashboard, a Perses dashboard nor a Grafana dashboard")
+	ErrInvalidDashboardName   = errors.New("invalid dashboard name")
+
+	nonDNSChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// Parse reads a user-supplied dashboard, given as a PersesDashboard resource,
+// a Perses API dashboard or a Grafana dashboard in either JSON or YAML, and
+// returns it as a validated Perses dashboard in the given project with all its
+// Prometheus queries and variables pointing at the given datasource.
+// The key is the ConfigMap key holding the dashboard; it is used as the
+// dashboard name when the content does not carry one.
+func Parse(key string, data string, project string, datasource string) (*persesapiv1.Dashboard, error) {
+	raw, err := yaml.YAMLToJSON([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+
+	var doc struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec          map[string]any `json:"spec"`
+		UID           string         `json:"uid"`
+		Title         string         `json:"title"`
+		Panels        []any          `json:"panels"`
+		SchemaVersion int            `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+
+	var (
+		name string
+		spec map[string]any
+	)
+	switch {
+	case doc.Kind == "PersesDashboard" || doc.Kind == string(persesapiv1.KindDashboard):
+		name, spec = doc.Metadata.Name, doc.Spec
+	case doc.Kind == "" && (doc.Panels != nil || doc.SchemaVersion > 0):
+		var gd grafana.Dashboard
+		if err := json.Unmarshal(raw, &gd); err != nil {
+			return nil, fmt.Errorf("failed to parse Grafana dashboard: %w", err)
+		}
+		name = doc.UID
+		if name == "" {
+			name = doc.Title
+		}
+		spec = grafana.ToPerses(&gd)
+	default:
+		return nil, ErrUnknownDashboardFormat
+	}
+	if spec == nil {
+		return nil, fmt.Errorf("%w: missing spec", ErrUnknownDashboardFormat)
+	}
+
+	if name == "" {
+		name = strings.TrimSuffix(key, path.Ext(key))
+	}
+	name = dnsName(name)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidDashboardName, name, strings.Join(errs, ", "))
+	}
+
+	setDatasource(spec, datasource)
+
+	dbJSON, err := json.Marshal(map[string]any{
+		"kind":     persesapiv1.KindDashboard,
+		"metadata": map[string]any{"name": name, "project": project},
+		"spec":     spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard %s: %w", name, err)
+	}
+	// Unmarshalling runs the Perses model validation, e.g. on panel references.
+	db := &persesapiv1.Dashboard{}
+	if err := json.Unmarshal(dbJSON, db); err != nil {
+		return nil, fmt.Errorf("invalid dashboard %s: %w", name, err)
+	}
+
+	return db, nil
+}
+
+// dnsName lowercases the name and replaces the characters that are not
+// allowed in a Kubernetes object name.
+func dnsName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = nonDNSChars.ReplaceAllString(name, "-")
+	return strings.Trim(name, "-")
+}
+
+// setDatasource points every Prometheus query and variable of the spec at the
+// given datasource, replacing whatever datasource the dashboard came with.
+func setDatasource(spec map[string]any, datasource string) {
+	ref := map[string]any{"kind": prometheusDatasourceKind, "name": datasource}
+
+	if panels, ok := spec["panels"].(map[string]any); ok {
+		for _, p := range panels {
+			panelSpec := nestedMap(p, "spec")
+			queries, _ := panelSpec["queries"].([]any)
+			for _, q := range queries {
+				plugin := nestedMap(q, "spec", "plugin")
+				if kind, _ := plugin["kind"].(string); strings.HasPrefix(kind, "Prometheus") {
+					if pluginSpec := nestedMap(plugin, "spec"); pluginSpec != nil {
+						pluginSpec["datasource"] = ref
+					}
+				}
+			}
+		}
+	}
+
+	variables, _ := spec["variables"].([]any)
+	for _, v := range variables {
+		plugin := nestedMap(v, "spec", "plugin")
+		if kind, _ := plugin["kind"].(string); strings.HasPrefix(kind, "Prometheus") {
+			if pluginSpec := nestedMap(plugin, "spec"); pluginSpec != nil {
+				pluginSpec["datasource"] = ref
+			}
+		}
+	}
+}
+
+func nestedMap(obj any, fields ...string) map[string]any {
+	m, _ := obj.(map[string]any)
+	for _, f := range fields {
+		if m == nil {
+			return nil
+		}
+		m, _ = m[f].(map[string]any)
+	}
+	return m
+}
@@ -0,0 +1,216 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package userdashboards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testProject    = "open-cluster-management-observability"
+	testDatasource = "rbac-query-proxy-datasource"
+)
+
+const persesDashboardYAML = `
+apiVersion: perses.dev/v1alpha1
+kind: PersesDashboard
+metadata:
+  name: team-a-overview
+spec:
+  display:
+    name: Team A
+  duration: 6h
+  variables:
+  - kind: ListVariable
+    spec:
+      name: cluster
+      allowAllValue: false
+      allowMultiple: false
+      plugin:
+        kind: PrometheusLabelValuesVariable
+        spec:
+          datasource:
+            kind: PrometheusDatasource
+            name: team-a-prometheus
+          labelName: cluster
+  panels:
+    up:
+      kind: Panel
+      spec:
+        display:
+          name: Up
+        plugin:
+          kind: TimeSeriesChart
+          spec: {}
+        queries:
+        - kind: TimeSeriesQuery
+          spec:
+            plugin:
+              kind: PrometheusTimeSeriesQuery
+              spec:
+                datasource:
+                  kind: PrometheusDatasource
+                  name: team-a-prometheus
+                query: up{cluster="$cluster"}
+  layouts:
+  - kind: Grid
+    spec:
+      items:
+      - x: 0
+        y: 0
+        width: 24
+        height: 8
+        content:
+          $ref: '#/spec/panels/up'
+`
+
+const grafanaDashboardJSON = `{
+  "uid": "team-b",
+  "title": "Team B",
+  "schemaVersion": 39,
+  "time": {"from": "now-12h", "to": "now"},
+  "refresh": "30s",
+  "templating": {"list": [
+    {"name": "datasource", "type": "datasource", "query": "prometheus"},
+    {"name": "cluster", "label": "Cluster", "type": "query", "query": {"query": "label_values(up, cluster)", "refId": "A"}, "current": {"value": "local-cluster"}},
+    {"name": "pod", "type": "query", "query": "query_result(sum by (pod) (up{cluster=\"$cluster\"}))", "regex": "/pod=\"([^\"]+)\"/", "multi": true, "includeAll": true, "allValue": ".*", "current": {"value": ["$__all"]}},
+    {"name": "window", "type": "custom", "query": "1h, 6h,1d", "current": {"value": "6h"}},
+    {"name": "threshold", "type": "textbox", "query": "0.9"}
+  ]},
+  "panels": [
+    {"type": "stat", "title": "Targets", "gridPos": {"x": 0, "y": 0, "w": 6, "h": 4},
+     "fieldConfig": {"defaults": {"unit": "percentunit", "decimals": 1}},
+     "options": {"reduceOptions": {"calcs": ["mean"]}},
+     "targets": [{"expr": "avg(up)", "refId": "A"}]},
+    {"type": "row", "title": "Details", "gridPos": {"x": 0, "y": 4, "w": 24, "h": 1}, "collapsed": false},
+    {"type": "timeseries", "title": "Up", "gridPos": {"x": 0, "y": 5, "w": 12, "h": 8},
+     "fieldConfig": {"defaults": {"unit": "short"}},
+     "targets": [{"expr": "up{cluster=\"$cluster\"}", "legendFormat": "{{pod}}"}, {"expr": "hidden", "hide": true}]},
+    {"type": "table", "title": "Pods", "gridPos": {"x": 12, "y": 5, "w": 12, "h": 8},
+     "targets": [{"expr": "up", "format": "table"}],
+     "transformations": [{"id": "organize", "options": {"renameByName": {"Value #A": "Up"}, "excludeByName": {"Time": true}}}]},
+    {"type": "row", "title": "Notes", "gridPos": {"x": 0, "y": 13, "w": 24, "h": 1}, "collapsed": true, "panels": [
+      {"type": "text", "title": "Readme", "gridPos": {"x": 0, "y": 14, "w": 24, "h": 3}, "options": {"content": "# Hello"}},
+      {"type": "heatmap", "title": "Latency", "gridPos": {"x": 0, "y": 17, "w": 24, "h": 3}}
+    ]}
+  ]
+}`
+
+func TestParse_PersesDashboard(t *testing.T) {
+	db, err := Parse("team-a.yaml", persesDashboardYAML, testProject, testDatasource)
+	require.NoError(t, err)
+
+	assert.Equal(t, "team-a-overview", db.Metadata.Name)
+	assert.Equal(t, testProject, db.Metadata.Project)
+	assert.Equal(t, "6h", string(db.Spec.Duration))
+	require.Contains(t, db.Spec.Panels, "up")
+
+	data, err := json.Marshal(db.Spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "team-a-prometheus")
+	assert.Contains(t, string(data), `"datasource":{"kind":"PrometheusDatasource","name":"rbac-query-proxy-datasource"}`)
+}
+
+func TestParse_PersesAPIDashboardWithoutName(t *testing.T) {
+	data := `{"kind":"Dashboard","metadata":{},"spec":{"panels":{},"layouts":[]}}`
+
+	db, err := Parse("My_Team.Dashboard.json", data, testProject, testDatasource)
+	require.NoError(t, err)
+	assert.Equal(t, "my-team-dashboard", db.Metadata.Name)
+	assert.Equal(t, "1h", string(db.Spec.Duration))
+}
+
+func TestParse_Grafana(t *testing.T) {
+	db, err := Parse("team-b.json", grafanaDashboardJSON, testProject, testDatasource)
+	require.NoError(t, err)
+
+	assert.Equal(t, "team-b", db.Metadata.Name)
+	assert.Equal(t, "Team B", db.Spec.Display.Name)
+	assert.Equal(t, "12h", string(db.Spec.Duration))
+	assert.Equal(t, "30s", string(db.Spec.RefreshInterval))
+
+	var names []string
+	for _, v := range db.Spec.Variables {
+		names = append(names, v.Spec.GetName())
+	}
+	assert.Equal(t, []string{"cluster", "pod", "window", "threshold"}, names)
+
+	kinds := map[string]string{}
+	for key, p := range db.Spec.Panels {
+		kinds[key] = p.Spec.Plugin.Kind
+	}
+	assert.Equal(t, map[string]string{
+		"0_0": "StatChart",
+		"1_0": "TimeSeriesChart",
+		"1_1": "Table",
+		"2_0": "Markdown",
+		"2_1": "Markdown",
+	}, kinds)
+	require.Len(t, db.Spec.Panels["1_0"].Spec.Queries, 1, "hidden targets are dropped")
+
+	require.Len(t, db.Spec.Layouts, 3)
+	details, ok := db.Spec.Layouts[1].Spec.(*dashboard.GridLayoutSpec)
+	require.True(t, ok)
+	assert.Equal(t, "Details", details.Display.Title)
+	assert.True(t, details.Display.Collapse.Open)
+	assert.Equal(t, 0, details.Items[0].Y)
+	assert.Equal(t, 12, details.Items[1].X)
+	notes, ok := db.Spec.Layouts[2].Spec.(*dashboard.GridLayoutSpec)
+	require.True(t, ok)
+	assert.False(t, notes.Display.Collapse.Open)
+	assert.Equal(t, 3, notes.Items[1].Y)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr error
+	}{
+		{
+			name:        "not a dashboard",
+			data:        `{"foo":"bar"}`,
+			expectedErr: ErrUnknownDashboardFormat,
+		},
+		{
+			name:        "unsupported kind",
+			data:        `{"kind":"Datasource","metadata":{"name":"ds"},"spec":{}}`,
+			expectedErr: ErrUnknownDashboardFormat,
+		},
+		{
+			name:        "missing spec",
+			data:        `{"kind":"PersesDashboard","metadata":{"name":"db"}}`,
+			expectedErr: ErrUnknownDashboardFormat,
+		},
+		{
+			name:        "invalid name",
+			data:        `{"title":"!!!","panels":[]}`,
+			expectedErr: ErrInvalidDashboardName,
+		},
+		{
+			name: "dangling panel reference",
+			data: `{"kind":"Dashboard","metadata":{"name":"db"},"spec":{"panels":{},"layouts":[{"kind":"Grid","spec":{"items":[{"x":0,"y":0,"width":1,"height":1,"content":{"$ref":"#/spec/panels/missing"}}]}}]}}`,
+		},
+		{
+			name: "malformed",
+			data: `{"kind":`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse("!!!.json", tc.data, testProject, testDatasource)
+			require.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
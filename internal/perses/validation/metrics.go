@@ -0,0 +1,245 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+
+	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing"
+	rsnamespace "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/namespace"
+	rsvirtualization "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/virtualization"
+	logging "github.com/stolostron/multicluster-observability-addon/internal/logging/manifests"
+	mhandlers "github.com/stolostron/multicluster-observability-addon/internal/metrics/handlers"
+	netflows "github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	tpanels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/tracing"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Metrics is the set of metric names available to the dashboards on the hub.
+type Metrics struct {
+	names map[string]struct{}
+	// matchers holds the non-equality __name__ matchers of the federation
+	// queries, e.g. {__name__=~"acm_.*"}.
+	matchers []*labels.Matcher
+}
+
+// NewMetrics returns a set holding the given metric names.
+func NewMetrics(names ...string) *Metrics {
+	m := &Metrics{names: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		m.names[name] = struct{}{}
+	}
+	return m
+}
+
+// Has reports whether the metric is part of the set.
+func (m *Metrics) Has(name string) bool {
+	if _, ok := m.names[name]; ok {
+		return true
+	}
+	for _, matcher := range m.matchers {
+		if matcher.Matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of metric names, not counting the name matchers.
+func (m *Metrics) Len() int {
+	return len(m.names)
+}
+
+// scrapeMetrics are the series that Prometheus records for every scrape of a
+// job, next to the scraped ones.
+var scrapeMetrics = []string{
+	"up",
+	"scrape_duration_seconds",
+	"scrape_samples_post_metric_relabeling",
+	"scrape_samples_scraped",
+	"scrape_series_added",
+}
+
+// AddScrapeConfigs adds the metrics federated by the ScrapeConfigs, that is the
+// metric names selected by their match[] parameters, and the series recorded
+// for the scrapes of their jobs.
+func (m *Metrics) AddScrapeConfigs(scrapeConfigs ...*cooprometheusv1alpha1.ScrapeConfig) error {
+	for _, sc := range scrapeConfigs {
+		if sc == nil {
+			continue
+		}
+		for _, name := range scrapeMetrics {
+			m.names[name] = struct{}{}
+		}
+		for _, query := range sc.Spec.Params["match[]"] {
+			expr, err := parser.ParseExpr(query)
+			if err != nil {
+				return fmt.Errorf("failed to parse match[] %q of ScrapeConfig %s: %w", query, sc.Name, err)
+			}
+			for _, selector := range parser.ExtractSelectors(expr) {
+				for _, matcher := range selector {
+					if matcher.Name != labels.MetricName {
+						continue
+					}
+					if matcher.Type == labels.MatchEqual {
+						m.names[matcher.Value] = struct{}{}
+						continue
+					}
+					m.matchers = append(m.matchers, matcher)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// AddRules adds the metrics recorded by the PrometheusRules.
+func (m *Metrics) AddRules(rules ...*prometheusv1.PrometheusRule) {
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		for _, group := range rule.Spec.Groups {
+			for _, r := range group.Rules {
+				if r.Record != "" {
+					m.names[r.Record] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// AddManifests adds the metrics of the ScrapeConfigs and PrometheusRules found
+// in a YAML or JSON stream, as written by "kubectl get -o yaml". Documents may
+// also be lists of objects; other kinds are ignored.
+func (m *Metrics) AddManifests(r io.Reader) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(bytes.TrimSpace(raw)) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+		if err := m.addManifest(raw); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Metrics) addManifest(raw json.RawMessage) error {
+	var obj struct {
+		Kind  string            `json:"kind"`
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	switch obj.Kind {
+	case cooprometheusv1alpha1.ScrapeConfigsKind:
+		sc := &cooprometheusv1alpha1.ScrapeConfig{}
+		if err := json.Unmarshal(raw, sc); err != nil {
+			return fmt.Errorf("failed to decode ScrapeConfig: %w", err)
+		}
+		return m.AddScrapeConfigs(sc)
+	case prometheusv1.PrometheusRuleKind:
+		rule := &prometheusv1.PrometheusRule{}
+		if err := json.Unmarshal(raw, rule); err != nil {
+			return fmt.Errorf("failed to decode PrometheusRule: %w", err)
+		}
+		m.AddRules(rule)
+	default:
+		for _, item := range obj.Items {
+			if err := m.addManifest(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hubMetrics are exposed by the hub itself and select the managed clusters in
+// the cluster variables of every dashboard.
+var hubMetrics = []string{
+	"acm_managed_cluster_labels",
+	"acm_label_names",
+}
+
+// metricsRulesDir holds the recording rules that the addon deploys with the
+// Prometheus of the non-OpenShift clusters, the same ones that the in-cluster
+// monitoring of OpenShift records.
+var metricsRulesDir = path.Join(addoncfg.McoaChartDir, "charts", "metrics", "files")
+
+// AddonMetrics returns the metrics collected by the ScrapeConfigs and recorded
+// by the PrometheusRules that the addon itself generates or ships, using the
+// default configuration of every feature, along with the managed cluster
+// metrics of the hub.
+func AddonMetrics() (*Metrics, error) {
+	m := NewMetrics(hubMetrics...)
+	// The span metrics ScrapeConfig keeps every metric of the collector, of
+	// which the dashboards query the ones of the spanmetrics connector.
+	spanMetrics := tpanels.NewSpanMetrics(tpanels.DefaultSpanMetricsNamespace, "")
+	m.names[spanMetrics.Calls] = struct{}{}
+	m.names[spanMetrics.DurationBucket] = struct{}{}
+	if err := m.AddScrapeConfigs(rightsizing.GenerateScrapeConfig(true, true), netflows.GenerateScrapeConfig(), logging.GenerateScrapeConfig(), mhandlers.GenerateHealthScrapeConfig(addoncfg.InstallNamespace, true)); err != nil {
+		return nil, err
+	}
+
+	configData := rightsizing.RSConfigMapData{PrometheusRuleConfig: rightsizing.GetDefaultRSPrometheusRuleConfig()}
+	nsRule, err := rsnamespace.GeneratePrometheusRule(configData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the namespace right-sizing rules: %w", err)
+	}
+	virtRule, err := rsvirtualization.GeneratePrometheusRule(configData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the virtualization right-sizing rules: %w", err)
+	}
+	m.AddRules(&nsRule, &virtRule)
+
+	if err := m.addRuleFiles(addon.FS, metricsRulesDir); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// addRuleFiles adds the metrics recorded by the rule files of a directory,
+// which hold the groups of a PrometheusRule spec.
+func (m *Metrics) addRuleFiles(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		rule := &prometheusv1.PrometheusRule{}
+		if err := yaml.Unmarshal(data, &rule.Spec); err != nil {
+			return fmt.Errorf("failed to decode the rules of %s: %w", file, err)
+		}
+		m.AddRules(rule)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// IssueKind is the kind of problem found in a dashboard query.
+type IssueKind string
+
+const (
+	// IssueParseError is reported for queries that are not valid PromQL.
+	IssueParseError IssueKind = "ParseError"
+	// IssueUndefinedVariable is reported for queries using a variable that the
+	// dashboard does not declare.
+	IssueUndefinedVariable IssueKind = "UndefinedVariable"
+	// IssueUnknownMetric is reported for queries selecting a metric that is
+	// neither collected nor recorded.
+	IssueUnknownMetric IssueKind = "UnknownMetric"
+)
+
+// Issue is a problem found in a query of a dashboard.
+type Issue struct {
+	Kind      IssueKind
+	Dashboard string
+	// Location is the panel query or variable holding the query, e.g.
+	// "panels/cpu-usage/queries/0" or "variables/cluster".
+	Location string
+	Query    string
+	// Detail is the parse error, the undefined variable or the unknown metric.
+	Detail string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s/%s: %s: %s", i.Dashboard, i.Location, i.Kind, i.Detail)
+}
+
+// placeholder replaces the dashboard variables that have no usable default value.
+const placeholder = "placeholder"
+
+var (
+	// variableRef matches $var, ${var} and ${var:format}. References starting
+	// with a digit, like the $1 of label_replace, are not variables.
+	variableRef = regexp.MustCompile(`\$\{([a-zA-Z_]\w*)(?::[^}]*)?\}|\$([a-zA-Z_]\w*)`)
+
+	// builtinVariables are the variables that Perses provides to every dashboard.
+	builtinVariables = map[string]string{
+		"__interval":      "5m",
+		"__interval_ms":   "300000",
+		"__rate_interval": "5m",
+		"__range":         "1h",
+		"__range_s":       "3600",
+		"__range_ms":      "3600000",
+		"__dashboard":     placeholder,
+		"__project":       placeholder,
+	}
+)
+
+// Validate parses every query of the dashboards, panel queries and variable
+// queries alike, and returns the issues found, in the order of the dashboards.
+// When metrics is not nil, every metric selected by a query must be part of it.
+func Validate(dashboards []persesapiv1.Dashboard, metrics *Metrics) ([]Issue, error) {
+	var issues []Issue
+	for i := range dashboards {
+		dbIssues, err := validateDashboard(&dashboards[i], metrics)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, dbIssues...)
+	}
+	return issues, nil
+}
+
+type dashboardSpec struct {
+	Variables []struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Name           string          `json:"name"`
+			DefaultValue   json.RawMessage `json:"defaultValue"`
+			CustomAllValue string          `json:"customAllValue"`
+			Value          string          `json:"value"`
+			Plugin         struct {
+				Kind string `json:"kind"`
+				Spec struct {
+					Expr     string   `json:"expr"`
+					Matchers []string `json:"matchers"`
+					Values   []any    `json:"values"`
+				} `json:"spec"`
+			} `json:"plugin"`
+		} `json:"spec"`
+	} `json:"variables"`
+	Panels map[string]struct {
+		Spec struct {
+			Queries []struct {
+				Spec struct {
+					Plugin struct {
+						Kind string `json:"kind"`
+						Spec struct {
+							Query string `json:"query"`
+						} `json:"spec"`
+					} `json:"plugin"`
+				} `json:"spec"`
+			} `json:"queries"`
+		} `json:"spec"`
+	} `json:"panels"`
+}
+
+// query is a PromQL expression and where it was found.
+type query struct {
+	location string
+	expr     string
+}
+
+func validateDashboard(db *persesapiv1.Dashboard, metrics *Metrics) ([]Issue, error) {
+	data, err := json.Marshal(db.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard %s: %w", db.Metadata.Name, err)
+	}
+	var spec dashboardSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dashboard %s: %w", db.Metadata.Name, err)
+	}
+
+	values := make(map[string]string, len(builtinVariables)+len(spec.Variables))
+	for name, value := range builtinVariables {
+		values[name] = value
+	}
+
+	var queries []query
+	for _, v := range spec.Variables {
+		values[v.Spec.Name] = variableValue(v.Spec.DefaultValue, v.Spec.CustomAllValue, v.Spec.Value, v.Spec.Plugin.Spec.Values)
+
+		location := "variables/" + v.Spec.Name
+		if v.Spec.Plugin.Spec.Expr != "" {
+			queries = append(queries, query{location: location, expr: v.Spec.Plugin.Spec.Expr})
+		}
+		for _, matcher := range v.Spec.Plugin.Spec.Matchers {
+			queries = append(queries, query{location: location, expr: matcher})
+		}
+	}
+
+	panelKeys := make([]string, 0, len(spec.Panels))
+	for key := range spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	slices.Sort(panelKeys)
+	for _, key := range panelKeys {
+		for i, q := range spec.Panels[key].Spec.Queries {
+			if !strings.HasPrefix(q.Spec.Plugin.Kind, "Prometheus") || q.Spec.Plugin.Spec.Query == "" {
+				continue
+			}
+			queries = append(queries, query{
+				location: fmt.Sprintf("panels/%s/queries/%d", key, i),
+				expr:     q.Spec.Plugin.Spec.Query,
+			})
+		}
+	}
+
+	var issues []Issue
+	for _, q := range queries {
+		newIssue := func(kind IssueKind, detail string) Issue {
+			return Issue{Kind: kind, Dashboard: db.Metadata.Name, Location: q.location, Query: q.expr, Detail: detail}
+		}
+
+		expr, undefined := substituteVariables(q.expr, values)
+		for _, name := range undefined {
+			issues = append(issues, newIssue(IssueUndefinedVariable, name))
+		}
+
+		parsed, err := parser.ParseExpr(expr)
+		if err != nil {
+			issues = append(issues, newIssue(IssueParseError, err.Error()))
+			continue
+		}
+		if metrics == nil {
+			continue
+		}
+		for _, name := range metricNames(parsed) {
+			if !metrics.Has(name) {
+				issues = append(issues, newIssue(IssueUnknownMetric, name))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// variableValue returns the value substituted for a dashboard variable: its
+// default value, the first of its static values or a placeholder. The "all"
+// value stands for the custom all value when set and for ".*" otherwise.
+func variableValue(defaultValue json.RawMessage, customAllValue string, textValue string, staticValues []any) string {
+	if textValue != "" {
+		return textValue
+	}
+
+	var value any
+	_ = json.Unmarshal(defaultValue, &value)
+	if values, ok := value.([]any); ok && len(values) > 0 {
+		value = values[0]
+	}
+	if s, ok := value.(string); ok && s != "" {
+		if s == "$__all" {
+			if customAllValue != "" {
+				return customAllValue
+			}
+			return ".*"
+		}
+		return s
+	}
+
+	if len(staticValues) > 0 {
+		switch v := staticValues[0].(type) {
+		case string:
+			return v
+		case map[string]any:
+			if s, ok := v["value"].(string); ok {
+				return s
+			}
+		}
+	}
+
+	return placeholder
+}
+
+// substituteVariables replaces the variables of the query by their values. It
+// returns the names of the variables without a value, which are replaced by a
+// placeholder.
+func substituteVariables(expr string, values map[string]string) (string, []string) {
+	var undefined []string
+	expr = variableRef.ReplaceAllStringFunc(expr, func(ref string) string {
+		m := variableRef.FindStringSubmatch(ref)
+		name := m[1] + m[2]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		if !slices.Contains(undefined, name) {
+			undefined = append(undefined, name)
+		}
+		return placeholder
+	})
+	return expr, undefined
+}
+
+// metricNames returns the sorted metric names selected by the expression.
+// Selectors without a metric name, with a non-equality one or with one picked
+// by a variable without a default value are skipped.
+func metricNames(expr parser.Expr) []string {
+	var names []string
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		vs, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		name := vs.Name
+		if name == "" {
+			for _, matcher := range vs.LabelMatchers {
+				if matcher.Name == labels.MetricName && matcher.Type == labels.MatchEqual {
+					name = matcher.Value
+				}
+			}
+		}
+		if name != "" && name != placeholder && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	slices.Sort(names)
+	return names
+}
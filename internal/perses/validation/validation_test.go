@@ -0,0 +1,179 @@
+// Copyright (c) Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+// Licensed under the Apache License 2.0
+
+package validation
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	persesapiv1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_AllDashboards(t *testing.T) {
+	dashboards, err := manifests.AllDashboards("my-project", "my-datasource", "")
+	require.NoError(t, err)
+
+	metrics, err := AddonMetrics()
+	require.NoError(t, err)
+	f, err := os.Open("testdata/platform-metrics.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, metrics.AddManifests(f))
+
+	// Every dashboard only queries what the addon collects and records, or
+	// what the platform ScrapeConfigs of the hub federate.
+	issues, err := Validate(dashboards, metrics)
+	require.NoError(t, err)
+	for _, issue := range issues {
+		t.Errorf("%s\n%s", issue, issue.Query)
+	}
+}
+
+func TestValidate_RightSizingDashboards(t *testing.T) {
+	dashboards, err := manifests.AllDashboards("my-project", "my-datasource", "")
+	require.NoError(t, err)
+
+	var rsDashboards []persesapiv1.Dashboard
+	for _, db := range dashboards {
+		if strings.HasPrefix(db.Metadata.Name, "acm-rs-") || strings.HasPrefix(db.Metadata.Name, "acm-rightsizing-") {
+			rsDashboards = append(rsDashboards, db)
+		}
+	}
+	require.Len(t, rsDashboards, 5)
+
+	metrics, err := AddonMetrics()
+	require.NoError(t, err)
+
+	// The right-sizing dashboards only query what the addon federates and records.
+	issues, err := Validate(rsDashboards, metrics)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestValidate(t *testing.T) {
+	spec := `{
+	  "display": {"name": "Test"},
+	  "duration": "1h",
+	  "variables": [
+	    {"kind": "ListVariable", "spec": {"name": "cluster", "allowAllValue": true, "allowMultiple": false, "defaultValue": "$__all",
+	      "plugin": {"kind": "PrometheusLabelValuesVariable", "spec": {"labelName": "cluster", "matchers": ["acm_managed_cluster_labels"]}}}},
+	    {"kind": "ListVariable", "spec": {"name": "health", "allowAllValue": true, "allowMultiple": false, "customAllValue": "<3", "defaultValue": "$__all",
+	      "plugin": {"kind": "StaticListVariable", "spec": {"values": ["==0", "==1"]}}}},
+	    {"kind": "TextVariable", "spec": {"name": "top", "value": "10"}},
+	    {"kind": "ListVariable", "spec": {"name": "metric", "allowAllValue": false, "allowMultiple": false,
+	      "plugin": {"kind": "PrometheusLabelNamesVariable", "spec": {}}}}
+	  ],
+	  "panels": {
+	    "ok": {"kind": "Panel", "spec": {"display": {"name": "ok"}, "plugin": {"kind": "TimeSeriesChart", "spec": {}}, "queries": [
+	      {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {
+	        "query": "topk($top, sum by (cluster) (rate(up{cluster=~\"${cluster:regex}\"}[$__rate_interval]) $health)) * on (cluster) group_left (label_x) label_replace(acm:up, \"label_x\", \"$1\", \"cluster\", \"(.+)\")"}}}}
+	    ]}},
+	    "picked": {"kind": "Panel", "spec": {"display": {"name": "picked"}, "plugin": {"kind": "TimeSeriesChart", "spec": {}}, "queries": [
+	      {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "count({__name__=\"$metric\",cluster=\"$cluster\"})"}}}}
+	    ]}},
+	    "broken": {"kind": "Panel", "spec": {"display": {"name": "broken"}, "plugin": {"kind": "TimeSeriesChart", "spec": {}}, "queries": [
+	      {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "sum(up{cluster=\"$cluster\"} = 1)"}}}}
+	    ]}},
+	    "unknown": {"kind": "Panel", "spec": {"display": {"name": "unknown"}, "plugin": {"kind": "TimeSeriesChart", "spec": {}}, "queries": [
+	      {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "node_cpu_seconds_total{node=\"$node\"} + {__name__=\"kube_node_info\"}"}}}}
+	    ]}}
+	  },
+	  "layouts": []
+	}`
+	db := persesapiv1.Dashboard{Kind: persesapiv1.KindDashboard}
+	db.Metadata.Name = "test"
+	require.NoError(t, json.Unmarshal([]byte(spec), &db.Spec))
+
+	metrics := NewMetrics("acm_managed_cluster_labels", "up", "acm:up")
+	issues, err := Validate([]persesapiv1.Dashboard{db}, metrics)
+	require.NoError(t, err)
+
+	got := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	assert.Equal(t, []string{
+		`test/panels/broken/queries/0: ParseError: 1:22: parse error: unexpected "=" in aggregation`,
+		`test/panels/unknown/queries/0: UndefinedVariable: node`,
+		`test/panels/unknown/queries/0: UnknownMetric: kube_node_info`,
+		`test/panels/unknown/queries/0: UnknownMetric: node_cpu_seconds_total`,
+	}, got)
+
+	// Without a metrics set, only the queries themselves are checked.
+	issues, err = Validate([]persesapiv1.Dashboard{db}, nil)
+	require.NoError(t, err)
+	assert.Len(t, issues, 2)
+}
+
+func TestMetrics_AddManifests(t *testing.T) {
+	manifests := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: monitoring.rhobs/v1alpha1
+  kind: ScrapeConfig
+  metadata:
+    name: platform-metrics-default
+  spec:
+    params:
+      match[]:
+      - '{__name__="cluster:node_cpu:ratio"}'
+      - '{__name__=~"acm_.*"}'
+---
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: platform-rules-default
+spec:
+  groups:
+  - name: acm
+    rules:
+    - record: instance:node_num_cpu:sum
+      expr: count by (instance) (node_cpu_seconds_total)
+    - alert: Down
+      expr: up == 0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+	metrics := NewMetrics()
+	require.NoError(t, metrics.AddManifests(strings.NewReader(manifests)))
+
+	// The scrape series of the ScrapeConfig job are added with the federated metrics.
+	assert.Equal(t, 7, metrics.Len())
+	for _, name := range []string{"up", "scrape_samples_scraped", "cluster:node_cpu:ratio", "instance:node_num_cpu:sum", "acm_managed_cluster_labels"} {
+		assert.True(t, metrics.Has(name), name)
+	}
+	for _, name := range []string{"node_cpu_seconds_total", "Down", "kube_node_info"} {
+		assert.False(t, metrics.Has(name), name)
+	}
+
+	assert.Error(t, metrics.AddManifests(strings.NewReader(`{"kind":"ScrapeConfig","spec":{"params":{"match[]":["{"]}}}`)))
+}
+
+func TestAddonMetrics(t *testing.T) {
+	metrics, err := AddonMetrics()
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"acm_rs:namespace:cpu_recommendation",
+		"acm_rs_vm:cluster:memory_usage",
+		"kubevirt_vm_running_status_last_transition_timestamp_seconds",
+		"netobserv_workload_ingress_bytes_total",
+		"acm_managed_cluster_labels",
+		"node_namespace_pod_container:container_memory_working_set_bytes",
+		"traces_span_metrics_calls_total",
+		"vector_component_sent_events_total",
+	} {
+		assert.True(t, metrics.Has(name), name)
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/profiling/manifests"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	errMissingProfilingConfigRef  = errors.New("missing profiling ConfigMap reference on addon installation")
+	errMultipleProfilingConfigRef = errors.New("multiple profiling ConfigMap references on addon installation")
+	errMissingProfilingEndpoint   = errors.New("missing endpoint in profiling ConfigMap")
+	errInvalidProfilingMode       = errors.New("invalid mode in profiling ConfigMap")
+	errEBPFNotEnabled             = errors.New("ebpf mode in profiling ConfigMap requires the " + addon.KeyUserWorkloadProfilesEBPF + " option")
+	errMissingImage               = errors.New("missing grafana_alloy image in the images ConfigMap")
+)
+
+// GetProfilingConfig returns the configuration of the profiling agent from the
+// ConfigMap labeled with the profiling config label among the addon configs,
+// along with the ConfigMap itself.
+func GetProfilingConfig(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn) (manifests.Config, *corev1.ConfigMap, error) {
+	var cms []*corev1.ConfigMap
+	for _, key := range common.GetObjectKeys(mcAddon.Status.ConfigReferences, "", addoncfg.ConfigMapsResource) {
+		cm := &corev1.ConfigMap{}
+		if err := k8s.Get(ctx, key, cm, &client.GetOptions{}); err != nil {
+			return manifests.Config{}, nil, err
+		}
+		if _, ok := cm.Labels[addoncfg.ProfilingConfigLabelKey]; !ok {
+			continue
+		}
+		cms = append(cms, cm)
+	}
+	switch {
+	case len(cms) == 0:
+		return manifests.Config{}, nil, errMissingProfilingConfigRef
+	case len(cms) > 1:
+		return manifests.Config{}, nil, errMultipleProfilingConfigRef
+	}
+
+	cm := cms[0]
+	config := manifests.Config{
+		Endpoint: cm.Data["endpoint"],
+		Mode:     manifests.Mode(cm.Data["mode"]),
+		Image:    cm.Data["image"],
+		Secret:   cm.Data["secret"],
+	}
+	if config.Endpoint == "" {
+		return config, cm, fmt.Errorf("%w %s/%s", errMissingProfilingEndpoint, cm.Namespace, cm.Name)
+	}
+	switch config.Mode {
+	case "":
+		// eBPF runs the agent privileged, it must be opted in.
+		config.Mode = manifests.ModePull
+	case manifests.ModeEBPF, manifests.ModePull:
+	default:
+		return config, cm, fmt.Errorf("%w %s/%s: %s", errInvalidProfilingMode, cm.Namespace, cm.Name, config.Mode)
+	}
+
+	return config, cm, nil
+}
+
+// BuildOptions returns the options of the profiling agent of the cluster. The
+// image of the agent is the one of the profiling ConfigMap, else the
+// grafana_alloy image of the images ConfigMap. The eBPF mode runs the agent
+// privileged and is only allowed when enabled in the addon options. The TLS secret of the endpoint is looked up in the
+// namespace of the cluster first, then in the namespace of the profiling ConfigMap.
+func BuildOptions(ctx context.Context, k8s client.Client, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, userWorkloads addon.ProfilesOptions, registries []addonapiv1beta1.ImageMirror) (manifests.Options, error) {
+	opts := manifests.Options{
+		ClusterName:   cluster.Name,
+		ClusterID:     common.GetManagedClusterID(cluster),
+		UserWorkloads: userWorkloads,
+	}
+
+	config, cm, err := GetProfilingConfig(ctx, k8s, mcAddon)
+	if err != nil {
+		return opts, err
+	}
+	if config.Mode == manifests.ModeEBPF && !userWorkloads.EBPFEnabled {
+		return opts, fmt.Errorf("%w: %s/%s", errEBPFNotEnabled, cm.Namespace, cm.Name)
+	}
+	opts.Config = config
+
+	if opts.Config.Image == "" {
+		images, err := mconfig.GetImageOverrides(ctx, k8s, registries, klog.Background())
+		if err != nil {
+			return opts, err
+		}
+		if images.GrafanaAlloy == "" {
+			return opts, errMissingImage
+		}
+		opts.Config.Image = images.GrafanaAlloy
+	}
+
+	if config.Secret == "" {
+		return opts, nil
+	}
+
+	secrets, err := common.GetSecrets(ctx, k8s, cm.Namespace, mcAddon.Namespace, []string{config.Secret})
+	if err != nil {
+		return opts, err
+	}
+	opts.Secret = &secrets[0]
+
+	return opts, nil
+}
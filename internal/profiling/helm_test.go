@@ -0,0 +1,288 @@
+package profiling
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/profiling/handlers"
+	"github.com/stolostron/multicluster-observability-addon/internal/profiling/manifests"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"open-cluster-management.io/addon-framework/pkg/addonfactory"
+	"open-cluster-management.io/addon-framework/pkg/addonmanager/addontesting"
+	"open-cluster-management.io/addon-framework/pkg/agent"
+	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func fakeGetValues(k8s client.Client, profiles addon.ProfilesOptions) addonfactory.GetValuesFunc {
+	return func(
+		cluster *clusterv1.ManagedCluster,
+		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
+	) (addonfactory.Values, error) {
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, cluster, mcAddon, profiles, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := manifests.BuildValues(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return addonfactory.JsonStructToValues(values)
+	}
+}
+
+func Test_Profiling_AllResources(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+	managedCluster.Labels = map[string]string{addoncfg.ManagedClusterLabelClusterID: "1234"}
+
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "profiling-tls",
+			Namespace: "open-cluster-management-observability",
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("data"),
+			"ca.crt":  []byte("data"),
+			"tls.key": []byte("data"),
+		},
+	}
+
+	images := map[string]string{
+		"prometheus_config_reloader":    "reloader",
+		"kube_rbac_proxy":               "kube-rbac-proxy",
+		"obo_prometheus_rhel9_operator": "operator",
+		"kube_state_metrics":            "kube-state-metrics",
+		"node_exporter":                 "node-exporter",
+		"prometheus":                    "prometheus",
+		"endpoint_monitoring_operator":  "endpoint-operator",
+	}
+
+	for _, tc := range []struct {
+		name        string
+		data        map[string]string
+		ebpf        bool
+		alloyImage  string
+		objects     int
+		privileged  bool
+		image       string
+		contains    string
+		notContains string
+	}{
+		{
+			name: "ebpf with tls",
+			data: map[string]string{
+				"endpoint": "https://pyroscope.example.com",
+				"mode":     "ebpf",
+				"secret":   "profiling-tls",
+			},
+			ebpf:        true,
+			alloyImage:  "registry.example.com/grafana/alloy:v1.10.0",
+			objects:     8,
+			privileged:  true,
+			image:       "registry.example.com/grafana/alloy:v1.10.0",
+			contains:    `pyroscope.ebpf "pods"`,
+			notContains: `pyroscope.scrape "pods"`,
+		},
+		{
+			name: "pull by default",
+			data: map[string]string{
+				"endpoint": "http://pyroscope.example.com",
+			},
+			alloyImage:  "registry.example.com/grafana/alloy:v1.10.0",
+			objects:     6,
+			image:       "registry.example.com/grafana/alloy:v1.10.0",
+			contains:    `pyroscope.scrape "pods"`,
+			notContains: "tls_config",
+		},
+		{
+			name: "image of the configuration",
+			data: map[string]string{
+				"endpoint": "http://pyroscope.example.com",
+				"image":    "quay.io/example/alloy:latest",
+			},
+			alloyImage:  "registry.example.com/grafana/alloy:v1.10.0",
+			objects:     6,
+			image:       "quay.io/example/alloy:latest",
+			contains:    `pyroscope.scrape "pods"`,
+			notContains: "tls_config",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "profiling",
+					Namespace: "open-cluster-management-observability",
+					Labels:    map[string]string{addoncfg.ProfilingConfigLabelKey: "true"},
+				},
+				Data: tc.data,
+			}
+			// Other ConfigMaps referenced by the addon are ignored.
+			other := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other",
+					Namespace: "open-cluster-management-observability",
+				},
+			}
+			imagesList := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      mconfig.ImagesConfigMapObjKey.Name,
+					Namespace: mconfig.ImagesConfigMapObjKey.Namespace,
+				},
+				Data: maps.Clone(images),
+			}
+			if tc.alloyImage != "" {
+				imagesList.Data["grafana_alloy"] = tc.alloyImage
+			}
+			fakeKubeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tlsSecret, config, other, imagesList).
+				Build()
+
+			managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+			for _, cm := range []*corev1.ConfigMap{config, other} {
+				managedClusterAddOn.Status.ConfigReferences = append(managedClusterAddOn.Status.ConfigReferences, addonapiv1beta1.ConfigReference{
+					ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+						Resource: addoncfg.ConfigMapsResource,
+					},
+					DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+						ConfigReferent: addonapiv1beta1.ConfigReferent{
+							Name:      cm.Name,
+							Namespace: cm.Namespace,
+						},
+					},
+				})
+			}
+
+			profilingAgentAddon, err := addonfactory.NewAgentAddonFactory(addoncfg.Name, addon.FS, addoncfg.ProfilingChartDir).
+				WithGetValuesFuncs(fakeGetValues(fakeKubeClient, addon.ProfilesOptions{CollectionEnabled: true, EBPFEnabled: tc.ebpf})).
+				WithAgentRegistrationOption(&agent.RegistrationOption{}).
+				WithScheme(scheme.Scheme).
+				BuildHelmAgentAddon()
+			require.NoError(t, err)
+
+			objects, err := profilingAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
+			require.NoError(t, err)
+			require.Len(t, objects, tc.objects)
+
+			foundAgent := false
+			for _, obj := range objects {
+				switch obj := obj.(type) {
+				case *appsv1.DaemonSet:
+					// Check name and namespace to make sure that if we change the helm
+					// manifests that we don't break the addon probes
+					require.Equal(t, addoncfg.SpokeProfilingAgentName, obj.Name)
+					require.Equal(t, addoncfg.SpokeProfilingAgentNamespace, obj.Namespace)
+					require.Equal(t, tc.image, obj.Spec.Template.Spec.Containers[0].Image)
+					require.Equal(t, tc.privileged, obj.Spec.Template.Spec.HostPID)
+					foundAgent = true
+				case *corev1.ConfigMap:
+					agentConfig := obj.Data["config.alloy"]
+					require.Contains(t, agentConfig, tc.contains)
+					require.NotContains(t, agentConfig, tc.notContains)
+					require.Contains(t, agentConfig, `url = "`+tc.data["endpoint"]+`"`)
+					require.Contains(t, agentConfig, `"cluster" = "cluster-1"`)
+					require.Contains(t, agentConfig, `"clusterID" = "1234"`)
+				case *corev1.Secret:
+					require.Equal(t, tlsSecret.Data, obj.Data)
+				}
+			}
+			require.True(t, foundAgent)
+		})
+	}
+}
+
+func Test_Profiling_MissingConfig(t *testing.T) {
+	managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+	fakeKubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	_, _, err := handlers.GetProfilingConfig(t.Context(), fakeKubeClient, managedClusterAddOn)
+	require.Error(t, err)
+}
+
+func Test_Profiling_BuildOptionsErrors(t *testing.T) {
+	managedCluster := addontesting.NewManagedCluster("cluster-1")
+
+	for _, tc := range []struct {
+		name     string
+		data     map[string]string
+		profiles addon.ProfilesOptions
+		errMsg   string
+	}{
+		{
+			name: "ebpf not enabled",
+			data: map[string]string{
+				"endpoint": "https://pyroscope.example.com",
+				"mode":     "ebpf",
+				"image":    "quay.io/example/alloy:latest",
+			},
+			profiles: addon.ProfilesOptions{CollectionEnabled: true},
+			errMsg:   "requires the " + addon.KeyUserWorkloadProfilesEBPF + " option",
+		},
+		{
+			name: "missing image",
+			data: map[string]string{
+				"endpoint": "https://pyroscope.example.com",
+			},
+			profiles: addon.ProfilesOptions{CollectionEnabled: true},
+			errMsg:   "missing grafana_alloy image",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "profiling",
+					Namespace: "open-cluster-management-observability",
+					Labels:    map[string]string{addoncfg.ProfilingConfigLabelKey: "true"},
+				},
+				Data: tc.data,
+			}
+			imagesList := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      mconfig.ImagesConfigMapObjKey.Name,
+					Namespace: mconfig.ImagesConfigMapObjKey.Namespace,
+				},
+				Data: map[string]string{
+					"prometheus_config_reloader":    "reloader",
+					"kube_rbac_proxy":               "kube-rbac-proxy",
+					"obo_prometheus_rhel9_operator": "operator",
+					"kube_state_metrics":            "kube-state-metrics",
+					"node_exporter":                 "node-exporter",
+					"prometheus":                    "prometheus",
+					"endpoint_monitoring_operator":  "endpoint-operator",
+				},
+			}
+			fakeKubeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(config, imagesList).
+				Build()
+
+			managedClusterAddOn := addontesting.NewAddon("test", "cluster-1")
+			managedClusterAddOn.Status.ConfigReferences = []addonapiv1beta1.ConfigReference{{
+				ConfigGroupResource: addonapiv1beta1.ConfigGroupResource{
+					Resource: addoncfg.ConfigMapsResource,
+				},
+				DesiredConfig: &addonapiv1beta1.ConfigSpecHash{
+					ConfigReferent: addonapiv1beta1.ConfigReferent{
+						Name:      config.Name,
+						Namespace: config.Namespace,
+					},
+				},
+			}}
+
+			_, err := handlers.BuildOptions(t.Context(), fakeKubeClient, managedCluster, managedClusterAddOn, tc.profiles, nil)
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package manifests
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+)
+
+const (
+	// ScrapeAnnotation and PortNameAnnotation select, in pull mode, the pods
+	// whose pprof endpoints are scraped and the name of their port.
+	ScrapeAnnotation   = "observability.open-cluster-management.io/profiles-scrape"
+	PortNameAnnotation = "observability.open-cluster-management.io/profiles-port-name"
+
+	tlsSecretName = "mcoa-profiling-tls"
+	tlsMountPath  = "/etc/mcoa-profiling/tls"
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// annotationLabel returns the discovery label holding the pod annotation.
+func annotationLabel(annotation string) string {
+	return "__meta_kubernetes_pod_annotation_" + invalidLabelChars.ReplaceAllString(annotation, "_")
+}
+
+// agentConfig discovers the pods of the node the agent runs on, labels their
+// profiles with their namespace, pod and container, and sends them to the
+// profile store with the name and ID of the cluster, like the metrics.
+var agentConfig = template.Must(template.New("config.alloy").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`discovery.kubernetes "pods" {
+  role = "pod"
+  selectors {
+    role  = "pod"
+    field = "spec.nodeName=" + sys.env("NODE_NAME")
+  }
+}
+
+discovery.relabel "pods" {
+  targets = discovery.kubernetes.pods.targets
+  rule {
+    action        = "drop"
+    source_labels = ["__meta_kubernetes_pod_phase"]
+    regex         = "Pending|Succeeded|Failed|Completed"
+  }
+{{- if .Pull }}
+  rule {
+    action        = "keep"
+    source_labels = [{{ quote .ScrapeLabel }}]
+    regex         = "true"
+  }
+  rule {
+    source_labels = [{{ quote .PortNameLabel }}]
+    target_label  = "__tmp_port_name"
+  }
+  rule {
+    action        = "keepequal"
+    source_labels = ["__meta_kubernetes_pod_container_port_name"]
+    target_label  = "__tmp_port_name"
+  }
+{{- end }}
+  rule {
+    source_labels = ["__meta_kubernetes_namespace"]
+    target_label  = "namespace"
+  }
+  rule {
+    source_labels = ["__meta_kubernetes_pod_name"]
+    target_label  = "pod"
+  }
+  rule {
+    source_labels = ["__meta_kubernetes_pod_container_name"]
+    target_label  = "container"
+  }
+  rule {
+    source_labels = ["__meta_kubernetes_pod_node_name"]
+    target_label  = "node"
+  }
+  rule {
+    source_labels = ["__meta_kubernetes_namespace", "__meta_kubernetes_pod_container_name"]
+    separator     = "/"
+    target_label  = "service_name"
+  }
+}
+{{ if .Pull }}
+pyroscope.scrape "pods" {
+  targets    = discovery.relabel.pods.output
+  forward_to = [pyroscope.write.hub.receiver]
+}
+{{- else }}
+pyroscope.ebpf "pods" {
+  targets    = discovery.relabel.pods.output
+  forward_to = [pyroscope.write.hub.receiver]
+}
+{{- end }}
+
+pyroscope.write "hub" {
+  endpoint {
+    url = {{ quote .Endpoint }}
+{{- if .TLS }}
+    tls_config {
+      ca_file   = {{ quote .TLS.CAFile }}
+      cert_file = {{ quote .TLS.CertFile }}
+      key_file  = {{ quote .TLS.KeyFile }}
+    }
+{{- end }}
+  }
+  external_labels = {
+    {{ quote .ClusterNameLabel }} = {{ quote .ClusterName }},
+    {{ quote .ClusterIDLabel }} = {{ quote .ClusterID }},
+  }
+}
+`))
+
+type agentTLS struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+func buildAgentConfig(opts Options) (string, error) {
+	data := struct {
+		Pull             bool
+		ScrapeLabel      string
+		PortNameLabel    string
+		Endpoint         string
+		TLS              *agentTLS
+		ClusterNameLabel string
+		ClusterName      string
+		ClusterIDLabel   string
+		ClusterID        string
+	}{
+		Pull:             opts.Config.Mode == ModePull,
+		ScrapeLabel:      annotationLabel(ScrapeAnnotation),
+		PortNameLabel:    annotationLabel(PortNameAnnotation),
+		Endpoint:         opts.Config.Endpoint,
+		ClusterNameLabel: mconfig.ClusterNameMetricLabel,
+		ClusterName:      opts.ClusterName,
+		ClusterIDLabel:   mconfig.ClusterIDMetricLabel,
+		ClusterID:        opts.ClusterID,
+	}
+	if opts.Secret != nil {
+		data.TLS = &agentTLS{
+			CAFile:   path.Join(tlsMountPath, "ca.crt"),
+			CertFile: path.Join(tlsMountPath, "tls.crt"),
+			KeyFile:  path.Join(tlsMountPath, "tls.key"),
+		}
+	}
+
+	var b strings.Builder
+	if err := agentConfig.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,36 @@
+package manifests
+
+import (
+	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Mode is how the profiling agent collects the profiles.
+type Mode string
+
+const (
+	// ModeEBPF profiles every process of the node with eBPF.
+	ModeEBPF Mode = "ebpf"
+	// ModePull scrapes the pprof endpoints of the annotated pods.
+	ModePull Mode = "pull"
+)
+
+// Config is the configuration of the profiling agent, read from the
+// ConfigMap referenced by the addon configs.
+type Config struct {
+	// Endpoint is the URL of the profile store, e.g. a Pyroscope server.
+	Endpoint string
+	Mode     Mode
+	Image    string
+	// Secret is the name of the secret holding the tls.crt, tls.key and
+	// ca.crt used to connect to the endpoint, if any.
+	Secret string
+}
+
+type Options struct {
+	ClusterName   string
+	ClusterID     string
+	Config        Config
+	Secret        *corev1.Secret
+	UserWorkloads addon.ProfilesOptions
+}
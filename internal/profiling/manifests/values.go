@@ -0,0 +1,46 @@
+package manifests
+
+import (
+	"encoding/json"
+)
+
+type ProfilingValues struct {
+	Enabled bool   `json:"enabled"`
+	Image   string `json:"image"`
+	// EBPF runs the agent privileged in the host PID namespace.
+	EBPF        bool         `json:"ebpf"`
+	AgentConfig string       `json:"agentConfig"`
+	Secret      *SecretValue `json:"secret,omitempty"`
+}
+
+type SecretValue struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+func BuildValues(opts Options) (ProfilingValues, error) {
+	values := ProfilingValues{
+		Enabled: true,
+		Image:   opts.Config.Image,
+		EBPF:    opts.Config.Mode == ModeEBPF,
+	}
+
+	if opts.Secret != nil {
+		dataJSON, err := json.Marshal(opts.Secret.Data)
+		if err != nil {
+			return values, err
+		}
+		values.Secret = &SecretValue{
+			Name: tlsSecretName,
+			Data: string(dataJSON),
+		}
+	}
+
+	agentConfig, err := buildAgentConfig(opts)
+	if err != nil {
+		return values, err
+	}
+	values.AgentConfig = agentConfig
+
+	return values, nil
+}
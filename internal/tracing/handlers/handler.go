@@ -3,17 +3,27 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/go-logr/logr"
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
 	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	gwhandlers "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/handlers"
 	"github.com/stolostron/multicluster-observability-addon/internal/tracing/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,10 +37,13 @@ var (
 	errMultipleOTELInstrRef   = errors.New("multiple Instrumentation references on addon installation")
 )
 
-func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1beta1.ManagedClusterAddOn, userWorkloads addon.TracesOptions) (manifests.Options, error) {
+func BuildOptions(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, addonOpts addon.Options) (manifests.Options, error) {
+	userWorkloads := addonOpts.UserWorkloads.Traces
+	gateway := addonOpts.OTelGateway
 	opts := manifests.Options{
 		ClusterName:   mcAddon.Namespace,
 		UserWorkloads: userWorkloads,
+		Exemplars:     addonOpts.Platform.Metrics.ExemplarsEnabled,
 	}
 
 	klog.Info("Retrieving OpenTelemetry Collector template")
@@ -65,6 +78,25 @@ func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1bet
 		klog.Info("Instrumentation template found")
 	}
 
+	if gateway.Enabled {
+		spoke, secrets, err := gwhandlers.BuildSpoke(ctx, k8s, mcAddon, gateway)
+		if err != nil {
+			return opts, err
+		}
+		opts.Gateway = spoke
+		opts.Secrets = secrets
+	}
+
+	if addonOpts.UserWorkloads.Metrics.OTLPEnabled {
+		if err := buildMetricsExport(ctx, k8s, logger, cluster, mcAddon, addonOpts, &opts); err != nil {
+			return opts, err
+		}
+	}
+
+	if err := buildPolicy(ctx, k8s, logger, cluster, addonOpts.UserWorkloads.Metrics.CollectionEnabled, &opts); err != nil {
+		return opts, err
+	}
+
 	secretNames, err := buildExportersSecrets(otelCol)
 	if err != nil {
 		return opts, nil
@@ -74,11 +106,122 @@ func BuildOptions(ctx context.Context, k8s client.Client, mcAddon *addonapiv1bet
 	if err != nil {
 		return opts, err
 	}
-	opts.Secrets = secrets
+	opts.Secrets = append(opts.Secrets, secrets...)
 
 	return opts, nil
 }
 
+// buildMetricsExport sets the remote write of the OTLP metrics to the hub. It
+// uses the allow-list and the relabelings of the user workloads ScrapeConfigs,
+// and the copies of the hub CA and of the client certificate shared by the
+// managed clusters. When the observability API of the hub is deployed, the
+// metrics of the tenants of the cluster are also written to them with the
+// client certificate of the cluster, like the user workload metrics.
+func buildMetricsExport(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, mcAddon *addonapiv1beta1.ManagedClusterAddOn, addonOpts addon.Options, opts *manifests.Options) error {
+	export := &manifests.MetricsExport{
+		Endpoint:    addonOpts.Platform.Metrics.HubEndpoint,
+		ClusterName: mcAddon.Namespace,
+		ClusterID:   common.GetManagedClusterID(cluster),
+	}
+
+	secrets, err := gwhandlers.CopyHubMTLSSecrets(ctx, k8s)
+	if err != nil {
+		return err
+	}
+	export.CASecret = secrets[0].Name
+	export.CertSecret = secrets[1].Name
+	if opts.Gateway != nil {
+		// The hub CA is already copied for the gateway.
+		secrets = secrets[1:]
+	}
+	opts.Secrets = append(opts.Secrets, secrets...)
+
+	var scrapeConfigs []client.Object
+	for _, key := range common.GetObjectKeys(mcAddon.Status.ConfigReferences, cooprometheusv1alpha1.SchemeGroupVersion.Group, cooprometheusv1alpha1.ScrapeConfigName) {
+		sc := &cooprometheusv1alpha1.ScrapeConfig{}
+		if err := k8s.Get(ctx, key, sc, &client.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		scrapeConfigs = append(scrapeConfigs, sc)
+	}
+
+	for _, sc := range common.FilterResourcesByLabelSelector[*cooprometheusv1alpha1.ScrapeConfig](scrapeConfigs, mconfig.UserWorkloadPrometheusMatchLabels) {
+		export.Selectors = append(export.Selectors, sc.Spec.Params["match[]"]...)
+		export.RelabelConfigs = append(export.RelabelConfigs, sc.Spec.MetricRelabelConfigs...)
+	}
+
+	if addonOpts.HubObsAPI.Enabled {
+		allTenants, err := tenants.GetTenants(ctx, k8s, logger)
+		if err != nil {
+			return err
+		}
+		export.Tenants = tenants.ForCluster(allTenants, cluster)
+	}
+	if len(export.Tenants) > 0 {
+		cert, err := common.EnsureClientCert(ctx, k8s, mcAddon, tenants.ClientCertSecretName, tenants.ClusterGroups(cluster))
+		if err != nil {
+			return err
+		}
+		export.TenantsEndpoint = addonOpts.HubObsAPI.Endpoint
+		export.TenantCertSecret = tenants.ClientCertSecretName
+		opts.Secrets = append(opts.Secrets, v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: tenants.ClientCertSecretName,
+				Annotations: map[string]string{
+					addoncfg.AnnotationOriginalResource: fmt.Sprintf("%s/%s", cert.Namespace, cert.Name),
+				},
+			},
+			Data: cert.Data,
+		})
+	}
+
+	opts.Metrics = export
+	return nil
+}
+
+// buildPolicy sets the sampling and span metrics policy of the cluster. The
+// head sampling is done by the instrumented workloads, so it is left out when
+// the addon does not manage the Instrumentation. The tail sampling needs all
+// the spans of a trace on the same collector, so it is left out when several
+// collectors receive them. The span metrics are scraped by the user workloads
+// Prometheus agent, so they are left out when the user workload metrics are
+// not collected.
+func buildPolicy(ctx context.Context, k8s client.Client, logger logr.Logger, cluster *clusterv1.ManagedCluster, uwlMetrics bool, opts *manifests.Options) error {
+	policies, err := policy.GetPolicies(ctx, k8s, logger)
+	if err != nil {
+		return err
+	}
+	p := policy.ForCluster(policies, cluster)
+	if p == nil {
+		return nil
+	}
+
+	if p.HeadSampling() && opts.Instrumentation == nil {
+		logger.Info("skipping the sampling percentages of the tracing policy as the instrumentation is not enabled", "configmap", p.Name)
+		p.SamplingPercentage = nil
+		p.NamespaceSampling = nil
+	}
+	if p.TailSampling() && !manifests.SingleCollector(opts.OpenTelemetryCollector.Spec) {
+		logger.Info("skipping the tail sampling of the tracing policy as the spans are received by several collectors", "configmap", p.Name)
+		p.KeepErrors = false
+		p.LatencyThreshold = 0
+	}
+	if p.SpanMetrics && !uwlMetrics {
+		logger.Info("skipping the span metrics of the tracing policy as user workload metrics are not collected", "configmap", p.Name)
+		p.SpanMetrics = false
+	}
+	if p.SpanMetrics {
+		opts.ScrapeConfig = manifests.GenerateScrapeConfig()
+	}
+	if p.Sampling() || p.SpanMetrics {
+		opts.Policy = p
+	}
+	return nil
+}
+
 func buildExportersSecrets(otelCol *otelv1beta1.OpenTelemetryCollector) ([]string, error) {
 	exporterSecrets := []string{}
 
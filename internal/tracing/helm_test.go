@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
@@ -37,7 +38,11 @@ func fakeGetValues(k8s client.Client) addonfactory.GetValuesFunc {
 		cluster *clusterv1.ManagedCluster,
 		mcAddon *addonapiv1beta1.ManagedClusterAddOn,
 	) (addonfactory.Values, error) {
-		opts, err := handlers.BuildOptions(context.TODO(), k8s, mcAddon, addon.TracesOptions{InstrumentationEnabled: true})
+		opts, err := handlers.BuildOptions(context.TODO(), k8s, logr.Discard(), cluster, mcAddon, addon.Options{
+			UserWorkloads: addon.UserWorkloadOptions{
+				Traces: addon.TracesOptions{InstrumentationEnabled: true},
+			},
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -140,6 +145,14 @@ func Test_Tracing_AllConfigsTogether_AllResources(t *testing.T) {
 						},
 					},
 				},
+				Service: otelv1beta1.Service{
+					Pipelines: map[string]*otelv1beta1.Pipeline{
+						"traces": {
+							Receivers: []string{"otlp"},
+							Exporters: []string{"otlp"},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -174,6 +187,20 @@ func Test_Tracing_AllConfigsTogether_AllResources(t *testing.T) {
 		},
 	}
 
+	// The head sampling percentage of a namespace gets its own Instrumentation.
+	policyCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "payments",
+			Namespace: "open-cluster-management-observability",
+			Labels: map[string]string{
+				addoncfg.TracingPolicyLabelKey: "true",
+			},
+		},
+		Data: map[string]string{
+			"namespaceSamplingPercentages": "checkout=50",
+		},
+	}
+
 	// This secret will be generated by cert-manager. We need to create it here
 	// to mock the behavior from cert-manager
 	generatedSecret := &corev1.Secret{
@@ -191,7 +218,7 @@ func Test_Tracing_AllConfigsTogether_AllResources(t *testing.T) {
 	// Setup the fake k8s client
 	fakeKubeClient = fake.NewClientBuilder().
 		WithScheme(scheme.Scheme).
-		WithObjects(&otelCol, &instr, authCM, generatedSecret).
+		WithObjects(&otelCol, &instr, authCM, policyCM, generatedSecret).
 		Build()
 
 	// Setup the fake addon client
@@ -212,7 +239,7 @@ func Test_Tracing_AllConfigsTogether_AllResources(t *testing.T) {
 	// Render manifests and return them as k8s runtime objects
 	objects, err := tracingAgentAddon.Manifests(t.Context(), managedCluster, managedClusterAddOn)
 	require.NoError(t, err)
-	require.Len(t, objects, 6)
+	require.Len(t, objects, 7)
 
 	for _, obj := range objects {
 		switch obj := obj.(type) {
@@ -223,7 +250,10 @@ func Test_Tracing_AllConfigsTogether_AllResources(t *testing.T) {
 			require.Equal(t, addoncfg.SpokeOTELColNamespace, obj.Namespace)
 			require.NotEmpty(t, obj.Spec.Config)
 		case *otelv1alpha1.Instrumentation:
-			require.Equal(t, addoncfg.SpokeInstrumentationName, obj.Name)
+			// The Instrumentations of the namespaces are created next to the
+			// one of the addon, so that a missing namespace doesn't fail the
+			// ManifestWork.
+			require.Contains(t, []string{addoncfg.SpokeInstrumentationName, manifests.NamespaceInstrumentationName("checkout")}, obj.Name)
 			require.Equal(t, addoncfg.SpokeOTELColNamespace, obj.Namespace)
 		case *corev1.Secret:
 			if obj.Name == "tracing-otlphttp-auth" {
@@ -0,0 +1,386 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// MetricsPipelineName is the pipeline writing the OTLP metrics of the user
+	// workloads to the hub.
+	MetricsPipelineName = "metrics/mcoa-hub"
+	// MetricsLabelsName turns the data points into the series written by the
+	// remote write exporter before they are matched by the allow-list, the
+	// relabelings and the tenants: the attributes of their resource become
+	// labels, the names of the metrics and labels are normalized, and the
+	// namespace of the resource is set as namespace label.
+	MetricsLabelsName = "transform/mcoa-labels"
+	// MetricsFilterName drops the data points that are not in the allow-list
+	// or that are dropped by the relabelings of the ScrapeConfigs.
+	MetricsFilterName = "filter/mcoa-allow-list"
+	// MetricsTenantsFilterName drops the data points of the namespaces of the
+	// tenants from the default pipeline.
+	MetricsTenantsFilterName = "filter/mcoa-tenants"
+	// MetricsTransformName drops the labels removed by the relabelings of the
+	// ScrapeConfigs and sets the identity of the managed cluster.
+	MetricsTransformName = "transform/mcoa-cluster-identity"
+	// MetricsExporterName is the Prometheus remote write to the hub.
+	MetricsExporterName = "prometheusremotewrite/mcoa-hub"
+
+	// tenantComponentPrefix prefixes the filter, exporter and pipeline of
+	// the metrics tenants.
+	tenantComponentPrefix = "mcoa-hub-"
+
+	// namespaceLabel is the label of the namespace of the user workload
+	// series.
+	namespaceLabel = "namespace"
+
+	metricsCAMountPath         = "/etc/mcoa-metrics/ca"
+	metricsCertMountPath       = "/etc/mcoa-metrics/cert"
+	metricsTenantCertMountPath = "/etc/mcoa-metrics/tenant-cert"
+)
+
+var (
+	errNoOTLPReceiver           = errors.New("no OTLP receiver found in the OpenTelemetryCollector")
+	errUnsupportedRelabelAction = errors.New("unsupported metric relabeling action for OTLP metrics")
+)
+
+// invalidNameChars and invalidLabelChars match the characters that the remote
+// write exporter replaces with underscores in the names of the metrics and
+// of the labels.
+const (
+	invalidNameChars  = "[^a-zA-Z0-9_:]"
+	invalidLabelChars = "[^a-zA-Z0-9_]"
+)
+
+// addMetricsPipeline adds a pipeline writing the metrics received by the OTLP
+// receivers of the template to the hub. The data points are labelled like the
+// series they are written as, go through the allow-list and the relabelings
+// of the user workloads ScrapeConfigs, and are labelled with the identity of
+// the managed cluster like the series written by the Prometheus agent. Like
+// its remote writes, one more pipeline per tenant writes the data points of
+// its namespaces to the tenant, and the default pipeline drops them.
+func addMetricsPipeline(spec *otelv1beta1.OpenTelemetryCollectorSpec, export MetricsExport) error {
+	var receivers []string
+	for _, name := range slices.Sorted(maps.Keys(spec.Config.Receivers.Object)) {
+		if componentType(name) == "otlp" {
+			receivers = append(receivers, name)
+		}
+	}
+	if len(receivers) == 0 {
+		return errNoOTLPReceiver
+	}
+
+	conditions, err := buildDropConditions(export)
+	if err != nil {
+		return err
+	}
+
+	processors := map[string]any{}
+	if spec.Config.Processors != nil {
+		processors = maps.Clone(spec.Config.Processors.Object)
+	}
+	processors[MetricsLabelsName] = map[string]any{
+		"error_mode": "ignore",
+		"metric_statements": []any{
+			map[string]any{
+				"context":    "datapoint",
+				"statements": buildLabelStatements(),
+			},
+		},
+	}
+	processors[MetricsFilterName] = map[string]any{
+		"error_mode": "ignore",
+		"metrics": map[string]any{
+			"datapoint": conditions,
+		},
+	}
+	processors[MetricsTransformName] = map[string]any{
+		"error_mode": "ignore",
+		"metric_statements": []any{
+			map[string]any{
+				"context":    "datapoint",
+				"statements": buildTransformStatements(export),
+			},
+		},
+	}
+
+	caFile := path.Join(mountSecret(spec, export.CASecret, metricsCAMountPath), mconfig.MTLSCASecretKey)
+	certPath := mountSecret(spec, export.CertSecret, metricsCertMountPath)
+
+	exporters := maps.Clone(spec.Config.Exporters.Object)
+	if exporters == nil {
+		exporters = map[string]any{}
+	}
+	exporters[MetricsExporterName] = remoteWriteExporter(export.Endpoint, caFile,
+		path.Join(certPath, mconfig.MTLSCertSecretKey), path.Join(certPath, mconfig.MTLSCertKeySecretKey))
+
+	pipelines := maps.Clone(spec.Config.Service.Pipelines)
+	if pipelines == nil {
+		pipelines = map[string]*otelv1beta1.Pipeline{}
+	}
+	defaultProcessors := []string{MetricsLabelsName, MetricsFilterName, MetricsTransformName}
+
+	if len(export.Tenants) > 0 {
+		tenantCertPath := mountSecret(spec, export.TenantCertSecret, metricsTenantCertMountPath)
+		// A tenant without namespaces takes all the data points of the
+		// cluster.
+		tenantNamespaces := make([]string, 0, len(export.Tenants))
+		for _, tenant := range export.Tenants {
+			name := tenantComponentPrefix + tenant.Name
+			tenantProcessors := []string{MetricsLabelsName, MetricsFilterName}
+			if len(tenant.Namespaces) == 0 {
+				tenantNamespaces = append(tenantNamespaces, ".*")
+			} else {
+				re := strings.Join(tenant.Namespaces, "|")
+				tenantNamespaces = append(tenantNamespaces, re)
+				processors["filter/"+name] = map[string]any{
+					"error_mode": "ignore",
+					"metrics": map[string]any{
+						"datapoint": []any{
+							"not " + matchCondition(labelPath(namespaceLabel), re),
+						},
+					},
+				}
+				tenantProcessors = append(tenantProcessors, "filter/"+name)
+			}
+			u := export.TenantsEndpoint
+			u.Path = fmt.Sprintf("/api/metrics/v1/%s/api/v1/receive", tenant.Name)
+			exporters["prometheusremotewrite/"+name] = remoteWriteExporter(u, caFile,
+				path.Join(tenantCertPath, corev1.TLSCertKey), path.Join(tenantCertPath, corev1.TLSPrivateKeyKey))
+			pipelines["metrics/"+name] = &otelv1beta1.Pipeline{
+				Receivers:  receivers,
+				Processors: append(tenantProcessors, MetricsTransformName),
+				Exporters:  []string{"prometheusremotewrite/" + name},
+			}
+		}
+
+		processors[MetricsTenantsFilterName] = map[string]any{
+			"error_mode": "ignore",
+			"metrics": map[string]any{
+				"datapoint": []any{
+					matchCondition(labelPath(namespaceLabel), strings.Join(tenantNamespaces, "|")),
+				},
+			},
+		}
+		defaultProcessors = []string{MetricsLabelsName, MetricsFilterName, MetricsTenantsFilterName, MetricsTransformName}
+	}
+
+	pipelines[MetricsPipelineName] = &otelv1beta1.Pipeline{
+		Receivers:  receivers,
+		Processors: defaultProcessors,
+		Exporters:  []string{MetricsExporterName},
+	}
+
+	spec.Config.Processors = &otelv1beta1.AnyConfig{Object: processors}
+	spec.Config.Exporters.Object = exporters
+	spec.Config.Service.Pipelines = pipelines
+
+	return nil
+}
+
+// remoteWriteExporter returns a Prometheus remote write exporter to the hub.
+// The resource attributes are already labels of the data points, set by the
+// MetricsLabelsName processor, so that the relabelings apply to them too.
+func remoteWriteExporter(endpoint url.URL, caFile, certFile, keyFile string) map[string]any {
+	return map[string]any{
+		"endpoint": endpoint.String(),
+		// The selectors of the allow-list match the normalized names of the
+		// metrics as received, so no unit or type suffix is added to them.
+		"add_metric_suffixes": false,
+		"tls": map[string]any{
+			"ca_file":   caFile,
+			"cert_file": certFile,
+			"key_file":  keyFile,
+		},
+	}
+}
+
+// mountSecret mounts a secret in the collector unless it already is, e.g. by
+// the export to the gateway, and returns its mount path.
+func mountSecret(spec *otelv1beta1.OpenTelemetryCollectorSpec, name, mountPath string) string {
+	for _, vm := range spec.VolumeMounts {
+		if vm.Name == name {
+			return vm.MountPath
+		}
+	}
+
+	spec.Volumes = append(slices.Clone(spec.Volumes), corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: name,
+			},
+		},
+	})
+	spec.VolumeMounts = append(slices.Clone(spec.VolumeMounts), corev1.VolumeMount{
+		Name:      name,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	return mountPath
+}
+
+// buildLabelStatements returns the OTTL statements labelling the data points
+// like the remote write exporter does: the attributes of the resource are
+// merged into the ones of the data points, service.name and
+// service.instance.id give the job and instance labels, and the invalid
+// characters of the names are replaced with underscores. The namespace of the
+// resource is then the namespace label, like for the scraped series.
+func buildLabelStatements() []any {
+	return []any{
+		`merge_maps(attributes, resource.attributes, "insert")`,
+		`set(attributes["job"], resource.attributes["service.name"]) where resource.attributes["service.name"] != nil and resource.attributes["service.namespace"] == nil`,
+		`set(attributes["job"], Concat([resource.attributes["service.namespace"], resource.attributes["service.name"]], "/")) where resource.attributes["service.name"] != nil and resource.attributes["service.namespace"] != nil`,
+		`set(attributes["instance"], resource.attributes["service.instance.id"]) where resource.attributes["service.instance.id"] != nil`,
+		fmt.Sprintf("replace_pattern(metric.name, %q, \"_\")", invalidNameChars),
+		fmt.Sprintf("replace_all_patterns(attributes, \"key\", %q, \"_\")", invalidLabelChars),
+		fmt.Sprintf("set(attributes[%q], resource.attributes[%q]) where resource.attributes[%q] != nil", namespaceLabel, namespaceAttribute, namespaceAttribute),
+	}
+}
+
+// buildDropConditions returns the OTTL conditions of the data points to drop:
+// the ones matching none of the selectors of the allow-list and the ones
+// dropped by the keep and drop relabelings. Like the federation of the
+// Prometheus agent, nothing is kept without selectors. The relabelings that
+// can't be applied to the data points are rejected.
+func buildDropConditions(export MetricsExport) ([]any, error) {
+	selectors := make([]string, 0, len(export.Selectors))
+	for _, sel := range export.Selectors {
+		matchers, err := parser.ParseMetricSelector(sel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metric selector %q: %w", sel, err)
+		}
+		conds := make([]string, 0, len(matchers))
+		for _, m := range matchers {
+			conds = append(conds, matcherCondition(m))
+		}
+		selectors = append(selectors, "("+strings.Join(conds, " and ")+")")
+	}
+
+	conditions := []any{"true"}
+	if len(selectors) > 0 {
+		conditions = []any{"not (" + strings.Join(selectors, " or ") + ")"}
+	}
+
+	for _, cfg := range export.RelabelConfigs {
+		switch strings.ToLower(cfg.Action) {
+		case "keep":
+			conditions = append(conditions, "not "+relabelCondition(cfg))
+		case "drop":
+			conditions = append(conditions, relabelCondition(cfg))
+		case "labelkeep", "labeldrop":
+		default:
+			return nil, fmt.Errorf("%w: %q", errUnsupportedRelabelAction, cfg.Action)
+		}
+	}
+
+	return conditions, nil
+}
+
+// buildTransformStatements returns the OTTL statements of the labelkeep and
+// labeldrop relabelings, followed by the ones setting the identity of the
+// managed cluster.
+func buildTransformStatements(export MetricsExport) []any {
+	var statements []any
+	for _, cfg := range export.RelabelConfigs {
+		switch strings.ToLower(cfg.Action) {
+		case "labelkeep":
+			statements = append(statements, fmt.Sprintf("keep_matching_keys(attributes, %s)", anchoredRegex(cfg.Regex)))
+		case "labeldrop":
+			statements = append(statements, fmt.Sprintf("delete_matching_keys(attributes, %s)", anchoredRegex(cfg.Regex)))
+		}
+	}
+
+	return append(statements,
+		fmt.Sprintf("set(attributes[%s], %s)", strconv.Quote(mconfig.ClusterNameMetricLabel), strconv.Quote(export.ClusterName)),
+		fmt.Sprintf("set(attributes[%s], %s)", strconv.Quote(mconfig.ClusterIDMetricLabel), strconv.Quote(export.ClusterID)),
+	)
+}
+
+// matcherCondition returns the OTTL condition of a label matcher. As in
+// Prometheus, an empty value matches a missing label.
+func matcherCondition(m *labels.Matcher) string {
+	field := labelPath(m.Name)
+	switch m.Type {
+	case labels.MatchEqual:
+		if m.Value == "" {
+			return field + " == nil"
+		}
+		return field + " == " + strconv.Quote(m.Value)
+	case labels.MatchNotEqual:
+		if m.Value == "" {
+			return field + " != nil"
+		}
+		return field + " != " + strconv.Quote(m.Value)
+	case labels.MatchRegexp:
+		return matchCondition(field, m.Value)
+	default:
+		return "not " + matchCondition(field, m.Value)
+	}
+}
+
+// relabelCondition returns the OTTL condition of the data points matched by
+// a keep or drop relabeling.
+func relabelCondition(cfg cooprometheusv1.RelabelConfig) string {
+	if len(cfg.SourceLabels) == 1 {
+		return matchCondition(labelPath(string(cfg.SourceLabels[0])), cfg.Regex)
+	}
+	sep := ";"
+	if cfg.Separator != nil {
+		sep = *cfg.Separator
+	}
+	fields := make([]string, 0, len(cfg.SourceLabels))
+	for _, l := range cfg.SourceLabels {
+		fields = append(fields, labelPath(string(l)))
+	}
+	return fmt.Sprintf("IsMatch(Concat([%s], %s), %s)", strings.Join(fields, ", "), strconv.Quote(sep), anchoredRegex(cfg.Regex))
+}
+
+// matchCondition returns the OTTL condition of a field matching a regular
+// expression. IsMatch is false on a missing field, while Prometheus matches
+// a missing label as an empty value, so the missing field is matched too when
+// the expression matches an empty value.
+func matchCondition(field, re string) string {
+	cond := fmt.Sprintf("IsMatch(%s, %s)", field, anchoredRegex(re))
+	if matched, err := regexp.MatchString(unquotedAnchoredRegex(re), ""); err == nil && matched {
+		return fmt.Sprintf("(%s == nil or %s)", field, cond)
+	}
+	return cond
+}
+
+// labelPath returns the OTTL path of a label of a data point. The name of the
+// metric is the __name__ label.
+func labelPath(name string) string {
+	if name == labels.MetricName {
+		return "metric.name"
+	}
+	return fmt.Sprintf("attributes[%s]", strconv.Quote(name))
+}
+
+// anchoredRegex anchors a regular expression like Prometheus does, the
+// default one matching everything, and quotes it for OTTL.
+func anchoredRegex(re string) string {
+	return strconv.Quote(unquotedAnchoredRegex(re))
+}
+
+func unquotedAnchoredRegex(re string) string {
+	if re == "" {
+		re = "(.*)"
+	}
+	return "^(?:" + re + ")$"
+}
@@ -0,0 +1,229 @@
+package manifests
+
+import (
+	"net/url"
+	"testing"
+
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stretchr/testify/require"
+)
+
+func newCollector() *otelv1beta1.OpenTelemetryCollector {
+	return &otelv1beta1.OpenTelemetryCollector{
+		Spec: otelv1beta1.OpenTelemetryCollectorSpec{
+			Config: otelv1beta1.Config{
+				Receivers: otelv1beta1.AnyConfig{Object: map[string]any{
+					"otlp":        map[string]any{},
+					"jaeger":      map[string]any{},
+					"otlp/legacy": map[string]any{},
+				}},
+				Processors: &otelv1beta1.AnyConfig{Object: map[string]any{
+					"batch": map[string]any{},
+				}},
+				Exporters: otelv1beta1.AnyConfig{Object: map[string]any{
+					"otlp/tempo": map[string]any{},
+				}},
+				Service: otelv1beta1.Service{
+					Pipelines: map[string]*otelv1beta1.Pipeline{
+						"traces": {
+							Receivers:  []string{"otlp", "jaeger"},
+							Processors: []string{"batch"},
+							Exporters:  []string{"otlp/tempo"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildOTELColSpec_Metrics(t *testing.T) {
+	export := &MetricsExport{
+		Endpoint: url.URL{
+			Scheme: "https",
+			Host:   "observatorium-api.example.com",
+			Path:   "/api/metrics/v1/default/api/v1/receive",
+		},
+		ClusterName: "cluster-1",
+		ClusterID:   "2f9e3c4a",
+		CASecret:    "hub-mtls-ca-123",
+		CertSecret:  "hub-mtls-cert-123",
+		Selectors: []string{
+			`{__name__="http_requests_total",namespace!="kube-system"}`,
+			`{__name__=~"payments_.+",service_name=~"checkout|"}`,
+		},
+		RelabelConfigs: []cooprometheusv1.RelabelConfig{
+			{Action: "drop", SourceLabels: []cooprometheusv1.LabelName{"le"}, Regex: "\\+Inf"},
+			{Action: "keep", SourceLabels: []cooprometheusv1.LabelName{"tier"}, Regex: "|gold"},
+			{Action: "labeldrop", Regex: "pod_uid"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		gateway         *gwmanifests.Spoke
+		expectedPath    string
+		expectedVolumes int
+	}{
+		{
+			name:            "metrics only",
+			expectedPath:    "/etc/mcoa-metrics/ca/ca.crt",
+			expectedVolumes: 2,
+		},
+		{
+			// The hub CA mounted for the gateway is reused, the client
+			// certificate of the cluster is not.
+			name: "with the gateway",
+			gateway: &gwmanifests.Spoke{
+				Endpoint:   url.URL{Scheme: "https", Host: "otel-gateway.example.com"},
+				CASecret:   "hub-mtls-ca-123",
+				CertSecret: "mcoa-otel-gateway-client-cert",
+			},
+			expectedPath:    "/etc/mcoa-gateway/ca/ca.crt",
+			expectedVolumes: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			otelCol := newCollector()
+			spec, err := buildOTELColSpec(Options{
+				OpenTelemetryCollector: otelCol,
+				Gateway:                tc.gateway,
+				Metrics:                export,
+			})
+			require.NoError(t, err)
+
+			pipeline := spec.Config.Service.Pipelines[MetricsPipelineName]
+			require.Equal(t, []string{"otlp", "otlp/legacy"}, pipeline.Receivers)
+			require.Equal(t, []string{MetricsLabelsName, MetricsFilterName, MetricsTransformName}, pipeline.Processors)
+			require.Equal(t, []string{MetricsExporterName}, pipeline.Exporters)
+
+			// The data points are labelled like the written series before
+			// they are filtered, the namespace of the resource being the
+			// namespace label.
+			labels := spec.Config.Processors.Object[MetricsLabelsName].(map[string]any)
+			require.Equal(t, []any{
+				`merge_maps(attributes, resource.attributes, "insert")`,
+				`set(attributes["job"], resource.attributes["service.name"]) where resource.attributes["service.name"] != nil and resource.attributes["service.namespace"] == nil`,
+				`set(attributes["job"], Concat([resource.attributes["service.namespace"], resource.attributes["service.name"]], "/")) where resource.attributes["service.name"] != nil and resource.attributes["service.namespace"] != nil`,
+				`set(attributes["instance"], resource.attributes["service.instance.id"]) where resource.attributes["service.instance.id"] != nil`,
+				`replace_pattern(metric.name, "[^a-zA-Z0-9_:]", "_")`,
+				`replace_all_patterns(attributes, "key", "[^a-zA-Z0-9_]", "_")`,
+				`set(attributes["namespace"], resource.attributes["k8s.namespace.name"]) where resource.attributes["k8s.namespace.name"] != nil`,
+			}, labels["metric_statements"].([]any)[0].(map[string]any)["statements"])
+
+			// Like in Prometheus, a missing label is matched as an empty
+			// value.
+			filter := spec.Config.Processors.Object[MetricsFilterName].(map[string]any)
+			require.Equal(t, []any{
+				`not ((metric.name == "http_requests_total" and attributes["namespace"] != "kube-system") or (IsMatch(metric.name, "^(?:payments_.+)$") and (attributes["service_name"] == nil or IsMatch(attributes["service_name"], "^(?:checkout|)$"))))`,
+				`IsMatch(attributes["le"], "^(?:\\+Inf)$")`,
+				`not (attributes["tier"] == nil or IsMatch(attributes["tier"], "^(?:|gold)$"))`,
+			}, filter["metrics"].(map[string]any)["datapoint"])
+
+			transform := spec.Config.Processors.Object[MetricsTransformName].(map[string]any)
+			require.Equal(t, []any{
+				`delete_matching_keys(attributes, "^(?:pod_uid)$")`,
+				`set(attributes["cluster"], "cluster-1")`,
+				`set(attributes["clusterID"], "2f9e3c4a")`,
+			}, transform["metric_statements"].([]any)[0].(map[string]any)["statements"])
+
+			exporter := spec.Config.Exporters.Object[MetricsExporterName].(map[string]any)
+			require.Equal(t, "https://observatorium-api.example.com/api/metrics/v1/default/api/v1/receive", exporter["endpoint"])
+			require.Equal(t, tc.expectedPath, exporter["tls"].(map[string]any)["ca_file"])
+			require.Equal(t, "/etc/mcoa-metrics/cert/tls.crt", exporter["tls"].(map[string]any)["cert_file"])
+			require.NotContains(t, exporter, "resource_to_telemetry_conversion")
+			require.Len(t, spec.Volumes, tc.expectedVolumes)
+
+			// The template is left untouched.
+			require.Len(t, otelCol.Spec.Config.Service.Pipelines, 1)
+			require.Len(t, otelCol.Spec.Config.Processors.Object, 1)
+			require.Empty(t, otelCol.Spec.Volumes)
+		})
+	}
+}
+
+func TestBuildOTELColSpec_MetricsTenants(t *testing.T) {
+	spec, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: newCollector(),
+		Metrics: &MetricsExport{
+			Endpoint:         url.URL{Scheme: "https", Host: "observatorium-api.example.com", Path: "/api/metrics/v1/default/api/v1/receive"},
+			ClusterName:      "cluster-1",
+			ClusterID:        "2f9e3c4a",
+			CASecret:         "hub-mtls-ca-123",
+			CertSecret:       "hub-mtls-cert-123",
+			Selectors:        []string{`{__name__="http_requests_total"}`},
+			Tenants:          []tenants.Tenant{{Name: "team-a", ClusterSets: []string{"payments"}}, {Name: "team-b", Namespaces: []string{"checkout", "billing-.*"}}},
+			TenantsEndpoint:  url.URL{Scheme: "https", Host: "mcoa-observatorium-api.example.com"},
+			TenantCertSecret: "mcoa-metrics-tenant-client-cert",
+		},
+	})
+	require.NoError(t, err)
+
+	// The default pipeline drops the data points of the tenants, all of them
+	// when a tenant has no namespaces.
+	require.Equal(t, []string{MetricsLabelsName, MetricsFilterName, MetricsTenantsFilterName, MetricsTransformName}, spec.Config.Service.Pipelines[MetricsPipelineName].Processors)
+	filter := spec.Config.Processors.Object[MetricsTenantsFilterName].(map[string]any)
+	require.Equal(t, []any{`(attributes["namespace"] == nil or IsMatch(attributes["namespace"], "^(?:.*|checkout|billing-.*)$"))`}, filter["metrics"].(map[string]any)["datapoint"])
+
+	pipeline := spec.Config.Service.Pipelines["metrics/mcoa-hub-team-b"]
+	require.NotNil(t, pipeline)
+	require.Equal(t, []string{"otlp", "otlp/legacy"}, pipeline.Receivers)
+	require.Equal(t, []string{MetricsLabelsName, MetricsFilterName, "filter/mcoa-hub-team-b", MetricsTransformName}, pipeline.Processors)
+	require.Equal(t, []string{"prometheusremotewrite/mcoa-hub-team-b"}, pipeline.Exporters)
+
+	filter = spec.Config.Processors.Object["filter/mcoa-hub-team-b"].(map[string]any)
+	require.Equal(t, []any{`not IsMatch(attributes["namespace"], "^(?:checkout|billing-.*)$")`}, filter["metrics"].(map[string]any)["datapoint"])
+	// A tenant without namespaces gets all the data points of the cluster.
+	require.NotContains(t, spec.Config.Processors.Object, "filter/mcoa-hub-team-a")
+	require.Equal(t, []string{MetricsLabelsName, MetricsFilterName, MetricsTransformName}, spec.Config.Service.Pipelines["metrics/mcoa-hub-team-a"].Processors)
+
+	exporter := spec.Config.Exporters.Object["prometheusremotewrite/mcoa-hub-team-b"].(map[string]any)
+	require.Equal(t, "https://mcoa-observatorium-api.example.com/api/metrics/v1/team-b/api/v1/receive", exporter["endpoint"])
+	require.Equal(t, map[string]any{
+		"ca_file":   "/etc/mcoa-metrics/ca/ca.crt",
+		"cert_file": "/etc/mcoa-metrics/tenant-cert/tls.crt",
+		"key_file":  "/etc/mcoa-metrics/tenant-cert/tls.key",
+	}, exporter["tls"])
+	require.Len(t, spec.Volumes, 3)
+}
+
+func TestBuildOTELColSpec_MetricsWithoutSelectors(t *testing.T) {
+	spec, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: newCollector(),
+		Metrics:                &MetricsExport{ClusterName: "cluster-1", ClusterID: "2f9e3c4a"},
+	})
+	require.NoError(t, err)
+
+	// Like the federation of the Prometheus agent, nothing is written without
+	// an allow-list.
+	filter := spec.Config.Processors.Object[MetricsFilterName].(map[string]any)
+	require.Equal(t, []any{"true"}, filter["metrics"].(map[string]any)["datapoint"])
+}
+
+func TestBuildOTELColSpec_MetricsUnsupportedRelabeling(t *testing.T) {
+	_, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: newCollector(),
+		Metrics: &MetricsExport{
+			ClusterName: "cluster-1",
+			ClusterID:   "2f9e3c4a",
+			RelabelConfigs: []cooprometheusv1.RelabelConfig{
+				{Action: "replace", SourceLabels: []cooprometheusv1.LabelName{"pod"}, TargetLabel: "instance"},
+			},
+		},
+	})
+	require.ErrorIs(t, err, errUnsupportedRelabelAction)
+}
+
+func TestBuildOTELColSpec_MetricsWithoutOTLPReceiver(t *testing.T) {
+	otelCol := newCollector()
+	otelCol.Spec.Config.Receivers.Object = map[string]any{"jaeger": map[string]any{}}
+
+	_, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: otelCol,
+		Metrics:                &MetricsExport{},
+	})
+	require.ErrorIs(t, err, errNoOTLPReceiver)
+}
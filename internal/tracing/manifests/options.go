@@ -1,9 +1,16 @@
 package manifests
 
 import (
+	"net/url"
+
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stolostron/multicluster-observability-addon/internal/addon"
+	"github.com/stolostron/multicluster-observability-addon/internal/metrics/tenants"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
 	corev1 "k8s.io/api/core/v1"
 	addonapiv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 )
@@ -15,4 +22,41 @@ type Options struct {
 	Instrumentation        *otelv1alpha1.Instrumentation
 	AddOnDeploymentConfig  *addonapiv1beta1.AddOnDeploymentConfig
 	UserWorkloads          addon.TracesOptions
+	// Gateway is set when the traces are sent to the gateway on the hub.
+	Gateway *gwmanifests.Spoke
+	// Metrics is set when the collector also receives the OTLP metrics of the
+	// user workloads and writes them to the hub.
+	Metrics *MetricsExport
+	// Policy is the sampling and span metrics policy of the cluster, if any.
+	Policy *policy.Policy
+	// Exemplars attaches the trace ids of the spans to the span metrics.
+	Exemplars bool
+	// ScrapeConfig scrapes the span metrics through the user workloads
+	// PrometheusAgent.
+	ScrapeConfig *cooprometheusv1alpha1.ScrapeConfig
+}
+
+// MetricsExport is the remote write of the OTLP metrics of the user workloads
+// to the hub. The metrics are filtered and labelled like the ones collected by
+// the user workloads Prometheus agent.
+type MetricsExport struct {
+	Endpoint    url.URL
+	ClusterName string
+	ClusterID   string
+	// CASecret and CertSecret are the copies of the hub CA and of the client
+	// certificate.
+	CASecret   string
+	CertSecret string
+	// Selectors are the match[] selectors of the user workloads ScrapeConfigs.
+	// A data point is written when it matches any of them.
+	Selectors []string
+	// RelabelConfigs are the metric relabelings of the user workloads
+	// ScrapeConfigs. Only keep, drop, labelkeep and labeldrop are supported.
+	RelabelConfigs []cooprometheusv1.RelabelConfig
+	// Tenants are the metrics tenants of the cluster, written to the
+	// observability API of the hub at TenantsEndpoint with the client
+	// certificate of the cluster in TenantCertSecret.
+	Tenants          []tenants.Tenant
+	TenantsEndpoint  url.URL
+	TenantCertSecret string
 }
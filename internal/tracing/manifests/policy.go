@@ -0,0 +1,202 @@
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
+)
+
+const (
+	// SamplingProcessorName keeps the traces of the traces pipelines of the
+	// template matching the tail sampling rules of the policy.
+	SamplingProcessorName = "tail_sampling/mcoa-policy"
+	// SpanMetricsTracesPipelineName is the pipeline feeding all the spans,
+	// before sampling, to the spanmetrics connector.
+	SpanMetricsTracesPipelineName = "traces/mcoa-span-metrics"
+	// SpanMetricsConnectorName derives request, error and duration metrics
+	// from the spans.
+	SpanMetricsConnectorName = "spanmetrics/mcoa"
+	// SpanMetricsPipelineName is the pipeline exposing the span metrics.
+	SpanMetricsPipelineName = "metrics/mcoa-span-metrics"
+	// SpanMetricsExporterName exposes the span metrics to the user workloads
+	// Prometheus agent.
+	SpanMetricsExporterName = "prometheus/mcoa-span-metrics"
+	// SpanMetricsPort is the port the span metrics are exposed on.
+	SpanMetricsPort = 8889
+
+	// namespaceAttribute is the resource attribute holding the namespace of
+	// the workload emitting the spans or the metrics.
+	namespaceAttribute = "k8s.namespace.name"
+)
+
+var errNoTracesPipeline = errors.New("no traces pipeline found in the OpenTelemetryCollector")
+
+// applyPolicy adds the sampling and span metrics components of the policy to
+// the collector. It must run once the traces pipelines export to their final
+// destination so that the span metrics pipeline added here is left out.
+func applyPolicy(spec *otelv1beta1.OpenTelemetryCollectorSpec, p policy.Policy, exemplars bool) error {
+	var tracesPipelines []string
+	for _, name := range slices.Sorted(maps.Keys(spec.Config.Service.Pipelines)) {
+		if spec.Config.Service.Pipelines[name] != nil && componentType(name) == "traces" {
+			tracesPipelines = append(tracesPipelines, name)
+		}
+	}
+	if len(tracesPipelines) == 0 {
+		return errNoTracesPipeline
+	}
+
+	pipelines := maps.Clone(spec.Config.Service.Pipelines)
+
+	if p.SpanMetrics {
+		// The span metrics are derived from all the spans, so they are
+		// computed in their own pipeline, next to the sampled ones.
+		var receivers []string
+		for _, name := range tracesPipelines {
+			for _, receiver := range pipelines[name].Receivers {
+				if !slices.Contains(receivers, receiver) {
+					receivers = append(receivers, receiver)
+				}
+			}
+		}
+		slices.Sort(receivers)
+		pipelines[SpanMetricsTracesPipelineName] = &otelv1beta1.Pipeline{
+			Receivers: receivers,
+			Exporters: []string{SpanMetricsConnectorName},
+		}
+		pipelines[SpanMetricsPipelineName] = &otelv1beta1.Pipeline{
+			Receivers: []string{SpanMetricsConnectorName},
+			Exporters: []string{SpanMetricsExporterName},
+		}
+
+		connectors := map[string]any{}
+		if spec.Config.Connectors != nil {
+			connectors = maps.Clone(spec.Config.Connectors.Object)
+		}
+		connector := map[string]any{
+			"dimensions": []any{
+				map[string]any{"name": namespaceAttribute},
+			},
+		}
+		exporter := map[string]any{
+			"endpoint": fmt.Sprintf("0.0.0.0:%d", SpanMetricsPort),
+		}
+		if exemplars {
+			// The span metrics are scraped directly, so their exemplars, with
+			// the trace id of their span, reach the hub. They are only exposed
+			// in the OpenMetrics format.
+			connector["exemplars"] = map[string]any{"enabled": true}
+			exporter["enable_open_metrics"] = true
+		}
+		connectors[SpanMetricsConnectorName] = connector
+		spec.Config.Connectors = &otelv1beta1.AnyConfig{Object: connectors}
+
+		exporters := maps.Clone(spec.Config.Exporters.Object)
+		if exporters == nil {
+			exporters = map[string]any{}
+		}
+		exporters[SpanMetricsExporterName] = exporter
+		spec.Config.Exporters.Object = exporters
+	}
+
+	if p.TailSampling() {
+		for _, name := range tracesPipelines {
+			pipeline := *pipelines[name]
+			pipeline.Processors = insertSamplingProcessor(pipeline.Processors)
+			pipelines[name] = &pipeline
+		}
+
+		processors := map[string]any{}
+		if spec.Config.Processors != nil {
+			processors = maps.Clone(spec.Config.Processors.Object)
+		}
+		processors[SamplingProcessorName] = map[string]any{
+			"policies": samplingPolicies(p),
+		}
+		spec.Config.Processors = &otelv1beta1.AnyConfig{Object: processors}
+	}
+
+	spec.Config.Service.Pipelines = pipelines
+	return nil
+}
+
+// insertSamplingProcessor replaces the tail sampling processors of a pipeline
+// with the one of the policy, placed before the first batch processor so that
+// the batches hold sampled traces.
+func insertSamplingProcessor(processors []string) []string {
+	processors = slices.DeleteFunc(slices.Clone(processors), func(name string) bool {
+		return componentType(name) == "tail_sampling"
+	})
+	idx := slices.IndexFunc(processors, func(name string) bool {
+		return componentType(name) == "batch"
+	})
+	if idx == -1 {
+		return append(processors, SamplingProcessorName)
+	}
+	return slices.Insert(processors, idx, SamplingProcessorName)
+}
+
+// samplingPolicies returns the policies of the tail sampling processor. A
+// trace is kept when any of them samples it: when it has an error or lasts
+// longer than the threshold.
+func samplingPolicies(p policy.Policy) []any {
+	var policies []any
+	if p.KeepErrors {
+		policies = append(policies, map[string]any{
+			"name": "errors",
+			"type": "status_code",
+			"status_code": map[string]any{
+				"status_codes": []any{"ERROR"},
+			},
+		})
+	}
+	if p.LatencyThreshold > 0 {
+		policies = append(policies, map[string]any{
+			"name": "latency",
+			"type": "latency",
+			"latency": map[string]any{
+				"threshold_ms": p.LatencyThreshold.Milliseconds(),
+			},
+		})
+	}
+	return policies
+}
+
+// SingleCollector returns true when all the spans are received by a single
+// collector, so that the tail sampling decides on complete traces. Daemonset
+// and sidecar collectors, like the scaled ones, only receive a part of them.
+func SingleCollector(spec otelv1beta1.OpenTelemetryCollectorSpec) bool {
+	switch spec.Mode {
+	case "", otelv1beta1.ModeDeployment, otelv1beta1.ModeStatefulSet:
+	default:
+		return false
+	}
+	if spec.Autoscaler != nil && spec.Autoscaler.MaxReplicas != nil && *spec.Autoscaler.MaxReplicas > 1 {
+		return false
+	}
+	return spec.Replicas == nil || *spec.Replicas <= 1
+}
+
+// headSampled returns the Instrumentation spec starting the percentage of the
+// traces. The spans of the started traces follow the decision of their parent
+// so that the traces are complete.
+func headSampled(spec otelv1alpha1.InstrumentationSpec, percentage float64) otelv1alpha1.InstrumentationSpec {
+	spec.Sampler = otelv1alpha1.Sampler{
+		Type:     otelv1alpha1.ParentBasedTraceIDRatio,
+		Argument: strconv.FormatFloat(percentage/100, 'f', -1, 64),
+	}
+	return spec
+}
+
+// componentType returns the type of a collector component from its ID,
+// e.g. otlphttp for otlphttp/tempo.
+func componentType(id string) string {
+	t, _, _ := strings.Cut(id, "/")
+	return t
+}
@@ -0,0 +1,192 @@
+package manifests
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildOTELColSpec_Policy(t *testing.T) {
+	otelCol := newCollector()
+	otelCol.Spec.Config.Processors.Object["tail_sampling"] = map[string]any{}
+	otelCol.Spec.Config.Service.Pipelines["traces"].Processors = []string{"tail_sampling", "batch"}
+
+	spec, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: otelCol,
+		Gateway: &gwmanifests.Spoke{
+			Endpoint:   url.URL{Scheme: "https", Host: "otel-gateway.example.com"},
+			CASecret:   "hub-mtls-ca-123",
+			CertSecret: "mcoa-otel-gateway-client-cert",
+		},
+		Policy: &policy.Policy{
+			SamplingPercentage: ptr.To(10.0),
+			NamespaceSampling: []policy.NamespaceSampling{
+				{Namespace: "checkout", Percentage: 50},
+				{Namespace: "billing", Percentage: 25},
+			},
+			KeepErrors:       true,
+			LatencyThreshold: 2 * time.Second,
+			SpanMetrics:      true,
+		},
+	})
+	require.NoError(t, err)
+
+	// The sampling processor of the template is replaced by the policy, ahead
+	// of the batches.
+	traces := spec.Config.Service.Pipelines["traces"]
+	require.Equal(t, []string{SamplingProcessorName, "batch"}, traces.Processors)
+	require.Equal(t, []string{gwmanifests.ExporterName}, traces.Exporters)
+
+	sampling := spec.Config.Processors.Object[SamplingProcessorName].(map[string]any)
+	policies := sampling["policies"].([]any)
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.(map[string]any)["name"].(string))
+	}
+	// The sampling percentages are left to the instrumented workloads.
+	require.Equal(t, []string{"errors", "latency"}, names)
+	require.Equal(t, int64(2000), policies[1].(map[string]any)["latency"].(map[string]any)["threshold_ms"])
+
+	// The span metrics are computed from all the spans, and not sent to the
+	// gateway.
+	require.Equal(t, &otelv1beta1.Pipeline{
+		Receivers: []string{"jaeger", "otlp"},
+		Exporters: []string{SpanMetricsConnectorName},
+	}, spec.Config.Service.Pipelines[SpanMetricsTracesPipelineName])
+	require.Equal(t, &otelv1beta1.Pipeline{
+		Receivers: []string{SpanMetricsConnectorName},
+		Exporters: []string{SpanMetricsExporterName},
+	}, spec.Config.Service.Pipelines[SpanMetricsPipelineName])
+	require.NotContains(t, spec.Config.Connectors.Object[SpanMetricsConnectorName], "exemplars")
+	require.Equal(t, map[string]any{"endpoint": "0.0.0.0:8889"}, spec.Config.Exporters.Object[SpanMetricsExporterName])
+
+	// The template is left untouched.
+	require.Equal(t, []string{"tail_sampling", "batch"}, otelCol.Spec.Config.Service.Pipelines["traces"].Processors)
+	require.Len(t, otelCol.Spec.Config.Service.Pipelines, 1)
+	require.Nil(t, otelCol.Spec.Config.Connectors)
+}
+
+func TestBuildOTELColSpec_PolicySpanMetricsExemplars(t *testing.T) {
+	spec, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: newCollector(),
+		Policy:                 &policy.Policy{SpanMetrics: true},
+		Exemplars:              true,
+	})
+	require.NoError(t, err)
+
+	// The trace ids are attached to the span metrics and exposed with them.
+	connector := spec.Config.Connectors.Object[SpanMetricsConnectorName].(map[string]any)
+	require.Equal(t, map[string]any{"enabled": true}, connector["exemplars"])
+	exporter := spec.Config.Exporters.Object[SpanMetricsExporterName].(map[string]any)
+	require.Equal(t, true, exporter["enable_open_metrics"])
+}
+
+func TestBuildOTELColSpec_PolicyHeadSamplingOnly(t *testing.T) {
+	spec, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: newCollector(),
+		Policy:                 &policy.Policy{SamplingPercentage: ptr.To(1.0)},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"batch"}, spec.Config.Service.Pipelines["traces"].Processors)
+	require.NotContains(t, spec.Config.Processors.Object, SamplingProcessorName)
+	require.NotContains(t, spec.Config.Service.Pipelines, SpanMetricsPipelineName)
+	require.Nil(t, spec.Config.Connectors)
+}
+
+func TestBuildInstrumentations(t *testing.T) {
+	instr := &otelv1alpha1.Instrumentation{
+		Spec: otelv1alpha1.InstrumentationSpec{
+			Exporter: otelv1alpha1.Exporter{Endpoint: "http://mcoa-instance-collector.mcoa-opentelemetry.svc:4318"},
+			Sampler:  otelv1alpha1.Sampler{Type: otelv1alpha1.AlwaysOn},
+		},
+	}
+
+	sampler := func(spec string) otelv1alpha1.Sampler {
+		var s otelv1alpha1.InstrumentationSpec
+		require.NoError(t, json.Unmarshal([]byte(spec), &s))
+		return s.Sampler
+	}
+
+	// The template is used as is without a policy.
+	spec, namespaces, err := buildInstrumentations(Options{Instrumentation: instr})
+	require.NoError(t, err)
+	require.Equal(t, otelv1alpha1.Sampler{Type: otelv1alpha1.AlwaysOn}, sampler(spec))
+	require.Empty(t, namespaces)
+
+	spec, namespaces, err = buildInstrumentations(Options{
+		Instrumentation: instr,
+		Policy: &policy.Policy{
+			SamplingPercentage: ptr.To(10.0),
+			NamespaceSampling: []policy.NamespaceSampling{
+				{Namespace: "checkout", Percentage: 50},
+				{Namespace: "mcoa-opentelemetry", Percentage: 100},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The percentage of the addon namespace wins over the default one.
+	require.Equal(t, otelv1alpha1.Sampler{Type: otelv1alpha1.ParentBasedTraceIDRatio, Argument: "1"}, sampler(spec))
+	require.Len(t, namespaces, 1)
+	require.Equal(t, "mcoa-instance-checkout", namespaces[0].Name)
+	require.Equal(t, otelv1alpha1.Sampler{Type: otelv1alpha1.ParentBasedTraceIDRatio, Argument: "0.5"}, sampler(namespaces[0].Spec))
+
+	// The template is left untouched.
+	require.Equal(t, otelv1alpha1.Sampler{Type: otelv1alpha1.AlwaysOn}, instr.Spec.Sampler)
+}
+
+func TestSingleCollector(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec otelv1beta1.OpenTelemetryCollectorSpec
+		want bool
+	}{
+		{name: "default", want: true},
+		{
+			name: "single statefulset",
+			spec: otelv1beta1.OpenTelemetryCollectorSpec{
+				Mode:                      otelv1beta1.ModeStatefulSet,
+				OpenTelemetryCommonFields: otelv1beta1.OpenTelemetryCommonFields{Replicas: ptr.To[int32](1)},
+			},
+			want: true,
+		},
+		{
+			name: "replicas",
+			spec: otelv1beta1.OpenTelemetryCollectorSpec{
+				OpenTelemetryCommonFields: otelv1beta1.OpenTelemetryCommonFields{Replicas: ptr.To[int32](2)},
+			},
+		},
+		{
+			name: "autoscaler",
+			spec: otelv1beta1.OpenTelemetryCollectorSpec{
+				Autoscaler: &otelv1beta1.AutoscalerSpec{MaxReplicas: ptr.To[int32](3)},
+			},
+		},
+		{name: "daemonset", spec: otelv1beta1.OpenTelemetryCollectorSpec{Mode: otelv1beta1.ModeDaemonSet}},
+		{name: "sidecar", spec: otelv1beta1.OpenTelemetryCollectorSpec{Mode: otelv1beta1.ModeSidecar}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, SingleCollector(tc.spec))
+		})
+	}
+}
+
+func TestBuildOTELColSpec_PolicyWithoutTracesPipeline(t *testing.T) {
+	otelCol := newCollector()
+	otelCol.Spec.Config.Service.Pipelines = map[string]*otelv1beta1.Pipeline{}
+
+	_, err := buildOTELColSpec(Options{
+		OpenTelemetryCollector: otelCol,
+		Policy:                 &policy.Policy{SpanMetrics: true},
+	})
+	require.ErrorIs(t, err, errNoTracesPipeline)
+}
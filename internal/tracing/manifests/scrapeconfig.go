@@ -0,0 +1,65 @@
+package manifests
+
+import (
+	"fmt"
+
+	cooprometheusv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	cooprometheusv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	mconfig "github.com/stolostron/multicluster-observability-addon/internal/metrics/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ScrapeConfigName is the name of the span metrics ScrapeConfig
+	ScrapeConfigName = "user-workload-metrics-span-metrics"
+
+	// ScrapeConfigJobName is the job name of the span metrics scrape job
+	ScrapeConfigJobName = "span-metrics"
+)
+
+// GenerateScrapeConfig generates the ScrapeConfig scraping the span metrics
+// exposed by the collector through the user workloads PrometheusAgent. The
+// namespace of the spans becomes the namespace label of the series so that
+// they are routed to the tenants of their namespace.
+func GenerateScrapeConfig() *cooprometheusv1alpha1.ScrapeConfig {
+	target := fmt.Sprintf("%s-collector.%s.svc:%d", addoncfg.SpokeOTELColName, addoncfg.SpokeOTELColNamespace, SpanMetricsPort)
+
+	return &cooprometheusv1alpha1.ScrapeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ScrapeConfig",
+			APIVersion: "monitoring.rhobs/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ScrapeConfigName,
+			Labels: map[string]string{
+				addoncfg.ComponentK8sLabelKey: mconfig.UserWorkloadPrometheusMatchLabels[addoncfg.ComponentK8sLabelKey],
+				addoncfg.PartOfK8sLabelKey:    addoncfg.Name,
+				addoncfg.ManagedByK8sLabelKey: addoncfg.Name,
+			},
+		},
+		Spec: cooprometheusv1alpha1.ScrapeConfigSpec{
+			JobName: ptr.To(ScrapeConfigJobName),
+			Scheme:  ptr.To(cooprometheusv1.Scheme("HTTP")),
+			StaticConfigs: []cooprometheusv1alpha1.StaticConfig{
+				{
+					Targets: []cooprometheusv1alpha1.Target{
+						cooprometheusv1alpha1.Target(target),
+					},
+				},
+			},
+			MetricRelabelConfigs: []cooprometheusv1.RelabelConfig{
+				{
+					Action:       "replace",
+					SourceLabels: []cooprometheusv1.LabelName{"k8s_namespace_name"},
+					TargetLabel:  "namespace",
+				},
+				{
+					Action: "labeldrop",
+					Regex:  "k8s_namespace_name",
+				},
+			},
+		},
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	gwmanifests "github.com/stolostron/multicluster-observability-addon/internal/otelgateway/manifests"
+	"github.com/stolostron/multicluster-observability-addon/internal/tracing/policy"
 )
 
 func buildSecrets(resources Options) ([]SecretValue, error) {
@@ -22,8 +25,62 @@ func buildSecrets(resources Options) ([]SecretValue, error) {
 	return secretsValue, nil
 }
 
+// buildInstrumentations returns the spec of the Instrumentation of the addon
+// and the Instrumentations of the namespaces with a sampling percentage of
+// their own in the policy. They are all created in the namespace of the
+// addon, which always exists, and the instrumented workloads of a namespace
+// reference the Instrumentation named after it.
+func buildInstrumentations(opts Options) (string, []InstrumentationValue, error) {
+	spec := opts.Instrumentation.Spec
+	var namespaces []policy.NamespaceSampling
+	if opts.Policy != nil {
+		if opts.Policy.SamplingPercentage != nil {
+			spec = headSampled(spec, *opts.Policy.SamplingPercentage)
+		}
+		namespaces = opts.Policy.NamespaceSampling
+	}
+
+	var values []InstrumentationValue
+	for _, ns := range namespaces {
+		if ns.Namespace == addoncfg.SpokeOTELColNamespace {
+			spec = headSampled(spec, ns.Percentage)
+			continue
+		}
+		b, err := json.Marshal(headSampled(opts.Instrumentation.Spec, ns.Percentage))
+		if err != nil {
+			return "", nil, err
+		}
+		values = append(values, InstrumentationValue{Name: NamespaceInstrumentationName(ns.Namespace), Spec: string(b)})
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(b), values, nil
+}
+
+// NamespaceInstrumentationName returns the name of the Instrumentation of the
+// head sampling percentage of a namespace.
+func NamespaceInstrumentationName(namespace string) string {
+	return addoncfg.SpokeInstrumentationName + "-" + namespace
+}
+
 func buildOTELColSpec(opts Options) (*otelv1beta1.OpenTelemetryCollectorSpec, error) {
 	otelColSpec := opts.OpenTelemetryCollector.Spec
 	otelColSpec.ManagementState = otelv1beta1.ManagementStateManaged
+	if opts.Gateway != nil {
+		gwmanifests.ExportToGateway(&otelColSpec, *opts.Gateway)
+	}
+	if opts.Metrics != nil {
+		if err := addMetricsPipeline(&otelColSpec, *opts.Metrics); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Policy != nil {
+		if err := applyPolicy(&otelColSpec, *opts.Policy, opts.Exemplars); err != nil {
+			return nil, err
+		}
+	}
 	return &otelColSpec, nil
 }
@@ -5,11 +5,18 @@ import (
 )
 
 type TracingValues struct {
-	Enabled                bool          `json:"enabled"`
-	InstrumentationEnabled bool          `json:"instrumentationEnabled"`
-	OTELColSpec            string        `json:"otelColSpec"`
-	InstrumenationSpec     string        `json:"instrumentationSpec"`
-	Secrets                []SecretValue `json:"secrets"`
+	Enabled                bool `json:"enabled"`
+	InstrumentationEnabled bool `json:"instrumentationEnabled"`
+	// SubscriptionChannel overrides the channel of the operator Subscription
+	// set in the values of the chart.
+	SubscriptionChannel string `json:"subscriptionChannel,omitempty"`
+	OTELColSpec         string `json:"otelColSpec"`
+	InstrumenationSpec  string `json:"instrumentationSpec"`
+	// NamespaceInstrumentations are the Instrumentations of the namespaces
+	// with a sampling percentage of their own, next to the one of the addon.
+	NamespaceInstrumentations []InstrumentationValue `json:"namespaceInstrumentations,omitempty"`
+	Secrets                   []SecretValue          `json:"secrets"`
+	ScrapeConfig              *ScrapeConfigValue     `json:"scrapeConfig,omitempty"`
 }
 
 type SecretValue struct {
@@ -17,9 +24,21 @@ type SecretValue struct {
 	Data string `json:"data"`
 }
 
+type InstrumentationValue struct {
+	Name string `json:"name"`
+	Spec string `json:"spec"`
+}
+
+type ScrapeConfigValue struct {
+	Name   string            `json:"name"`
+	Data   string            `json:"data"`
+	Labels map[string]string `json:"labels"`
+}
+
 func BuildValues(opts Options) (TracingValues, error) {
 	values := TracingValues{
-		Enabled: true,
+		Enabled:             true,
+		SubscriptionChannel: opts.UserWorkloads.SubscriptionChannel,
 	}
 
 	secrets, err := buildSecrets(opts)
@@ -41,11 +60,24 @@ func BuildValues(opts Options) (TracingValues, error) {
 
 	if opts.Instrumentation != nil {
 		values.InstrumentationEnabled = true
-		b, err = json.Marshal(opts.Instrumentation.Spec)
+		spec, namespaceInstrumentations, err := buildInstrumentations(opts)
 		if err != nil {
 			return values, err
 		}
-		values.InstrumenationSpec = string(b)
+		values.InstrumenationSpec = spec
+		values.NamespaceInstrumentations = namespaceInstrumentations
+	}
+
+	if opts.ScrapeConfig != nil {
+		b, err = json.Marshal(opts.ScrapeConfig.Spec)
+		if err != nil {
+			return values, err
+		}
+		values.ScrapeConfig = &ScrapeConfigValue{
+			Name:   opts.ScrapeConfig.Name,
+			Data:   string(b),
+			Labels: opts.ScrapeConfig.Labels,
+		}
 	}
 
 	return values, nil
@@ -0,0 +1,230 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stolostron/multicluster-observability-addon/internal/addon/common"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// The keys of a policy ConfigMap.
+	clusterSetsKey                 = "clusterSets"
+	samplingPercentageKey          = "samplingPercentage"
+	namespaceSamplingPercentageKey = "namespaceSamplingPercentages"
+	keepErrorsKey                  = "keepErrors"
+	latencyThresholdKey            = "latencyThreshold"
+	spanMetricsKey                 = "spanMetrics"
+)
+
+// Policy is the sampling and span metrics policy of the user workload traces
+// of some clusters.
+type Policy struct {
+	// Name is the name of the ConfigMap of the policy.
+	Name string
+	// ClusterSets restricts the policy to the clusters of these
+	// ManagedClusterSets, all the clusters when empty.
+	ClusterSets []string
+	// SamplingPercentage is the percentage of the traces started by the
+	// instrumented workloads of the namespaces without a percentage of their
+	// own. All of them are started when unset.
+	SamplingPercentage *float64
+	// NamespaceSampling are the percentages of the traces started per
+	// namespace.
+	NamespaceSampling []NamespaceSampling
+	// KeepErrors keeps, among the started traces, the ones with an error.
+	KeepErrors bool
+	// LatencyThreshold keeps, among the started traces, the ones lasting
+	// longer. Disabled when zero.
+	LatencyThreshold time.Duration
+	// SpanMetrics derives request, error and duration metrics from the spans
+	// before they are sampled.
+	SpanMetrics bool
+}
+
+// NamespaceSampling is the percentage of the traces started by the
+// instrumented workloads of a namespace.
+type NamespaceSampling struct {
+	Namespace  string
+	Percentage float64
+}
+
+// Matches returns true when the policy applies to the cluster.
+func (p Policy) Matches(cluster *clusterv1.ManagedCluster) bool {
+	return len(p.ClusterSets) == 0 || slices.Contains(p.ClusterSets, cluster.Labels[clusterv1beta2.ClusterSetLabel])
+}
+
+// Sampling returns true when the policy samples the traces.
+func (p Policy) Sampling() bool {
+	return p.HeadSampling() || p.TailSampling()
+}
+
+// HeadSampling returns true when the policy sets the percentage of the traces
+// started by the instrumented workloads.
+func (p Policy) HeadSampling() bool {
+	return p.SamplingPercentage != nil || len(p.NamespaceSampling) > 0
+}
+
+// TailSampling returns true when the policy keeps the traces once complete,
+// according to their errors or latency.
+func (p Policy) TailSampling() bool {
+	return p.KeepErrors || p.LatencyThreshold > 0
+}
+
+// GetPolicies collects the policies configured through the labeled ConfigMaps
+// of the hub install namespace, sorted by name. Invalid policies are logged
+// and skipped.
+func GetPolicies(ctx context.Context, k8s client.Client, logger logr.Logger) ([]Policy, error) {
+	cms := &corev1.ConfigMapList{}
+	if err := k8s.List(ctx, cms, client.InNamespace(addoncfg.InstallNamespace), client.HasLabels{addoncfg.TracingPolicyLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list tracing policy ConfigMaps: %w", err)
+	}
+	sort.Slice(cms.Items, func(i, j int) bool { return cms.Items[i].Name < cms.Items[j].Name })
+
+	var policies []Policy
+	for _, cm := range cms.Items {
+		policy, err := parsePolicy(cm)
+		if err != nil {
+			logger.Info("skipping invalid tracing policy", "configmap", cm.Name, "error", err.Error())
+			continue
+		}
+		if !policy.Sampling() && !policy.SpanMetrics {
+			logger.Info("skipping tracing policy without sampling nor span metrics", "configmap", cm.Name)
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// ForCluster returns the policy applying to the cluster, nil when there is
+// none. The first policy of the cluster set of the cluster wins over the
+// first policy applying to all the clusters.
+func ForCluster(policies []Policy, cluster *clusterv1.ManagedCluster) *Policy {
+	var fleetWide *Policy
+	for i, policy := range policies {
+		if !policy.Matches(cluster) {
+			continue
+		}
+		if len(policy.ClusterSets) > 0 {
+			return &policies[i]
+		}
+		if fleetWide == nil {
+			fleetWide = &policies[i]
+		}
+	}
+	return fleetWide
+}
+
+func parsePolicy(cm corev1.ConfigMap) (Policy, error) {
+	policy := Policy{
+		Name:        cm.Name,
+		ClusterSets: common.SplitList(cm.Data[clusterSetsKey]),
+	}
+
+	if v := cm.Data[samplingPercentageKey]; v != "" {
+		percentage, err := parsePercentage(v)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", samplingPercentageKey, err)
+		}
+		policy.SamplingPercentage = &percentage
+	}
+
+	for _, item := range common.SplitList(cm.Data[namespaceSamplingPercentageKey]) {
+		namespace, v, ok := strings.Cut(item, "=")
+		namespace = strings.TrimSpace(namespace)
+		if !ok || namespace == "" {
+			return policy, fmt.Errorf("invalid %s: %q is not namespace=percentage", namespaceSamplingPercentageKey, item)
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return policy, fmt.Errorf("invalid %s: namespace %q: %s", namespaceSamplingPercentageKey, namespace, strings.Join(errs, ", "))
+		}
+		if slices.ContainsFunc(policy.NamespaceSampling, func(ns NamespaceSampling) bool { return ns.Namespace == namespace }) {
+			return policy, fmt.Errorf("invalid %s: namespace %s is set twice", namespaceSamplingPercentageKey, namespace)
+		}
+		percentage, err := parsePercentage(v)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s of namespace %s: %w", namespaceSamplingPercentageKey, namespace, err)
+		}
+		policy.NamespaceSampling = append(policy.NamespaceSampling, NamespaceSampling{Namespace: namespace, Percentage: percentage})
+	}
+
+	var err error
+	if policy.KeepErrors, err = parseBool(cm.Data[keepErrorsKey]); err != nil {
+		return policy, fmt.Errorf("invalid %s: %w", keepErrorsKey, err)
+	}
+	if policy.SpanMetrics, err = parseBool(cm.Data[spanMetricsKey]); err != nil {
+		return policy, fmt.Errorf("invalid %s: %w", spanMetricsKey, err)
+	}
+
+	if v := cm.Data[latencyThresholdKey]; v != "" {
+		threshold, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", latencyThresholdKey, err)
+		}
+		if threshold < time.Millisecond {
+			return policy, fmt.Errorf("invalid %s: %s is less than a millisecond", latencyThresholdKey, v)
+		}
+		policy.LatencyThreshold = threshold
+	}
+
+	return policy, nil
+}
+
+func parsePercentage(s string) (float64, error) {
+	percentage, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if percentage < 0 || percentage > 100 {
+		return 0, fmt.Errorf("%s is not between 0 and 100", s)
+	}
+	return percentage, nil
+}
+
+func parseBool(s string) (bool, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+// ConfigMapPredicate filters ConfigMap events down to the policy ConfigMaps.
+// Updates are also let through when the label is removed so the policies get
+// pruned.
+func ConfigMapPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isPolicyConfigMap(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isPolicyConfigMap(e.ObjectOld) || isPolicyConfigMap(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isPolicyConfigMap(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func isPolicyConfigMap(obj client.Object) bool {
+	if obj.GetNamespace() != addoncfg.InstallNamespace {
+		return false
+	}
+	_, ok := obj.GetLabels()[addoncfg.TracingPolicyLabelKey]
+	return ok
+}
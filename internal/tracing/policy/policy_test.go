@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func policyConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: addoncfg.InstallNamespace,
+			Labels: map[string]string{
+				addoncfg.TracingPolicyLabelKey: "true",
+			},
+		},
+		Data: data,
+	}
+}
+
+func TestGetPolicies(t *testing.T) {
+	objs := []client.Object{
+		policyConfigMap("payments", map[string]string{
+			"clusterSets":                  "payments",
+			"samplingPercentage":           "5",
+			"namespaceSamplingPercentages": "checkout=50\nbilling=12.5",
+			"keepErrors":                   "true",
+			"latencyThreshold":             "1.5s",
+		}),
+		policyConfigMap("fleet", map[string]string{"samplingPercentage": "10", "spanMetrics": "true"}),
+		policyConfigMap("fleet-2", map[string]string{"samplingPercentage": "20"}),
+		// Invalid policies
+		policyConfigMap("empty", map[string]string{"clusterSets": "payments"}),
+		policyConfigMap("percentage", map[string]string{"samplingPercentage": "120"}),
+		policyConfigMap("namespace", map[string]string{"namespaceSamplingPercentages": "billing-.*=10"}),
+		policyConfigMap("duplicate", map[string]string{"namespaceSamplingPercentages": "billing=10,billing=20"}),
+		policyConfigMap("entry", map[string]string{"namespaceSamplingPercentages": "checkout"}),
+		policyConfigMap("errors", map[string]string{"keepErrors": "yes"}),
+		policyConfigMap("latency", map[string]string{"latencyThreshold": "10us"}),
+	}
+	k8s := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+
+	policies, err := GetPolicies(t.Context(), k8s, logr.Discard())
+	require.NoError(t, err)
+	require.Equal(t, []Policy{
+		{Name: "fleet", SamplingPercentage: ptr.To(10.0), SpanMetrics: true},
+		{Name: "fleet-2", SamplingPercentage: ptr.To(20.0)},
+		{
+			Name:               "payments",
+			ClusterSets:        []string{"payments"},
+			SamplingPercentage: ptr.To(5.0),
+			NamespaceSampling: []NamespaceSampling{
+				{Namespace: "checkout", Percentage: 50},
+				{Namespace: "billing", Percentage: 12.5},
+			},
+			KeepErrors:       true,
+			LatencyThreshold: 1500 * time.Millisecond,
+		},
+	}, policies)
+
+	// The first policy of the cluster set wins over the fleet-wide ones.
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-1"}}
+	require.Equal(t, &policies[0], ForCluster(policies, cluster))
+	cluster.Labels = map[string]string{clusterv1beta2.ClusterSetLabel: "payments"}
+	require.Equal(t, &policies[2], ForCluster(policies, cluster))
+	require.Nil(t, ForCluster(nil, cluster))
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"strings"
 
 	"github.com/ViaQ/logerr/v2/log"
 	"github.com/go-logr/logr"
@@ -30,9 +31,17 @@ import (
 	uiplugin "github.com/rhobs/observability-operator/pkg/apis/uiplugin/v1alpha1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	addoncfg "github.com/stolostron/multicluster-observability-addon/internal/addon/config"
+	rsexport "github.com/stolostron/multicluster-observability-addon/internal/analytics/rightsizing/export"
 	addonctrl "github.com/stolostron/multicluster-observability-addon/internal/controllers/addon"
 	"github.com/stolostron/multicluster-observability-addon/internal/controllers/resourcecreator"
+	rsexportctrl "github.com/stolostron/multicluster-observability-addon/internal/controllers/rsexport"
 	"github.com/stolostron/multicluster-observability-addon/internal/controllers/watcher"
+	cmanifests "github.com/stolostron/multicluster-observability-addon/internal/coo/manifests"
+	lokistack "github.com/stolostron/multicluster-observability-addon/internal/lokistack/manifests"
+	netflows "github.com/stolostron/multicluster-observability-addon/internal/netflows/manifests"
+	persesexport "github.com/stolostron/multicluster-observability-addon/internal/perses/export"
+	persesvalidation "github.com/stolostron/multicluster-observability-addon/internal/perses/validation"
 	tlshelper "github.com/stolostron/multicluster-observability-addon/pkg/util"
 	thanosv1alpha1 "github.com/thanos-community/thanos-operator/api/v1alpha1"
 	crdClientSet "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -49,6 +58,7 @@ import (
 	addonv1beta1 "open-cluster-management.io/api/addon/v1beta1"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 	workv1 "open-cluster-management.io/api/work/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,6 +79,7 @@ func init() {
 	utilruntime.Must(operatorsv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.Install(scheme))
 	utilruntime.Must(clusterv1beta1.Install(scheme))
+	utilruntime.Must(clusterv1beta2.Install(scheme))
 	utilruntime.Must(operatorv1.AddToScheme(scheme))
 	utilruntime.Must(cooprometheusv1.AddToScheme(scheme))
 	utilruntime.Must(cooprometheusv1alpha1.AddToScheme(scheme)) // Adds prometheusAgent and scrapeConfig
@@ -81,6 +92,9 @@ func init() {
 	utilruntime.Must(addonv1beta1.Install(scheme))
 	utilruntime.Must(thanosv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(configv1.AddToScheme(scheme))
+	utilruntime.Must(rsexport.AddToScheme(scheme))  // Adds VerticalPodAutoscaler
+	utilruntime.Must(netflows.AddToScheme(scheme))  // Adds FlowCollector
+	utilruntime.Must(lokistack.AddToScheme(scheme)) // Adds LokiStack
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -124,6 +138,7 @@ func newCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newControllerCommand())
+	cmd.AddCommand(newDashboardsCommand())
 
 	return cmd
 }
@@ -141,6 +156,126 @@ func newControllerCommand() *cobra.Command {
 	return cmd
 }
 
+func newDashboardsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboards",
+		Short: "Work with the dashboards shipped by the addon",
+	}
+	cmd.AddCommand(newDashboardsExportCommand())
+	cmd.AddCommand(newDashboardsValidateCommand())
+
+	return cmd
+}
+
+func newDashboardsExportCommand() *cobra.Command {
+	var (
+		project          string
+		datasource       string
+		clusterLabelName string
+		format           string
+		outputDir        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Render every built-in dashboard as PersesDashboard YAML, a Perses API bundle or Grafana JSON",
+		Args:  cobra.NoArgs,
+		// Errors are reported by main.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dashboards, err := cmanifests.AllDashboards(project, datasource, clusterLabelName)
+			if err != nil {
+				return fmt.Errorf("failed to build dashboards: %w", err)
+			}
+			if outputDir != "" {
+				return persesexport.WriteDir(outputDir, persesexport.Format(format), dashboards)
+			}
+			return persesexport.Write(cmd.OutOrStdout(), persesexport.Format(format), dashboards)
+		},
+	}
+
+	formats := make([]string, 0, len(persesexport.Formats))
+	for _, f := range persesexport.Formats {
+		formats = append(formats, string(f))
+	}
+
+	cmd.Flags().StringVar(&project, "project", addoncfg.InstallNamespace, "Perses project, also used as the namespace of the PersesDashboards.")
+	cmd.Flags().StringVar(&datasource, "datasource", "rbac-query-proxy-datasource", "Name of the Prometheus datasource queried by the dashboards.")
+	cmd.Flags().StringVar(&clusterLabelName, "cluster-label", "", "Name of the label identifying the cluster of a series.")
+	cmd.Flags().StringVar(&format, "format", string(persesexport.FormatPersesDashboard), fmt.Sprintf("Output format, one of: %s.", strings.Join(formats, ", ")))
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write one file per dashboard into this directory instead of a single document to stdout.")
+
+	return cmd
+}
+
+func newDashboardsValidateCommand() *cobra.Command {
+	var metricsFiles []string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Parse every PromQL query of the built-in dashboards and check the metrics they select",
+		Long: `Parse every PromQL query of the built-in dashboards, after substituting their variables.
+
+When --metrics-from is set, the metrics selected by the queries must also be
+federated by the given ScrapeConfigs or recorded by the given PrometheusRules,
+on top of the ones generated by the addon itself. The files hold the objects as
+written by "kubectl get -o yaml", e.g. the platform ScrapeConfigs and
+PrometheusRules of the hub.`,
+		Args: cobra.NoArgs,
+		// Errors are reported by main.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dashboards, err := cmanifests.AllDashboards(addoncfg.InstallNamespace, cmanifests.ThanosDatasourceName, "")
+			if err != nil {
+				return fmt.Errorf("failed to build dashboards: %w", err)
+			}
+
+			var metrics *persesvalidation.Metrics
+			if len(metricsFiles) > 0 {
+				if metrics, err = persesvalidation.AddonMetrics(); err != nil {
+					return fmt.Errorf("failed to list the addon metrics: %w", err)
+				}
+				for _, path := range metricsFiles {
+					if err := addMetricsFromFile(metrics, path); err != nil {
+						return err
+					}
+				}
+			}
+
+			issues, err := persesvalidation.Validate(dashboards, metrics)
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				fmt.Fprintln(cmd.OutOrStdout(), issue.String())
+			}
+			if len(issues) > 0 {
+				return fmt.Errorf("found %d issues in %d dashboards", len(issues), len(dashboards))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&metricsFiles, "metrics-from", nil, "YAML or JSON files holding the ScrapeConfigs and PrometheusRules that provide the metrics queried by the dashboards.")
+
+	return cmd
+}
+
+func addMetricsFromFile(metrics *persesvalidation.Metrics, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := metrics.AddManifests(f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
 func runControllers(ctx context.Context, kubeConfig *rest.Config) error {
 	logger := log.NewLogger("mcoa", log.WithVerbosity(logVerbosity))
 	ctrl.SetLogger(logger)
@@ -241,6 +376,10 @@ func runControllers(ctx context.Context, kubeConfig *rest.Config) error {
 		return fmt.Errorf("unable to create resource creator controller: %w", err)
 	}
 
+	if err = rsexportctrl.SetupWithManager(sharedMgr, logger); err != nil {
+		return fmt.Errorf("unable to create right-sizing export controller: %w", err)
+	}
+
 	go func() {
 		logger.Info("Starting shared controller-runtime manager")
 		if startErr := sharedMgr.Start(ctx); startErr != nil {
@@ -8,6 +8,7 @@ import (
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
@@ -34,61 +35,12 @@ func withAlertTimeSeriesGroup(datasource string, labelMatcher *labels.Matcher) d
 }
 
 func BuildACMAlertsByCluster(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcherV2(clusterLabelName)
 	return dashboard.New("acm-alerts-by-cluster",
 		dashboard.ProjectName(project),
 		dashboard.Name("Alerts by Cluster"),
-		dashboard.AddVariable("acm_label_names",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("label_name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_label_names",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Label"),
-				listVar.DefaultValue("cloud"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
-		dashboard.AddVariable("value",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("$acm_label_names",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Value"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels",
-							[]promql.LabelMatcher{
-								{Name: "$acm_label_names", Type: "=~", Value: "$value"},
-							},
-						),
-					),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
+		vars.Clusters(),
 		dashboard.AddVariable("severity",
 			listVar.List(
 				labelValuesVar.PrometheusLabelValues("severity",
@@ -97,7 +49,7 @@ func BuildACMAlertsByCluster(project string, datasource string, clusterLabelName
 						promql.SetLabelMatchers(
 							"ALERTS",
 							[]promql.LabelMatcher{
-								{Name: "cluster", Type: "=", Value: "$cluster"},
+								vars.ClusterMatcher(),
 							},
 						),
 					),
@@ -2,12 +2,10 @@ package acm
 
 import (
 	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
@@ -39,64 +37,14 @@ func withCapacityGroup(datasource string, labelMatcher *labels.Matcher) dashboar
 }
 
 func BuildACMClustersOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcherV2(clusterLabelName)
 	return dashboard.New("acm-clusters-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Clusters Overview"),
-		dashboard.AddVariable("acm_label_names",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("label_name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_label_names",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Label"),
-				listVar.DefaultValue("name"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
-		dashboard.AddVariable("value",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("$acm_label_names",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Value"),
-				listVar.AllowAllValue(true),
-				listVar.AllowMultiple(true),
-			),
-		),
 
 		// Cluster variable - third level (depends on acm_label_names and value)
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels",
-							[]promql.LabelMatcher{
-								{Name: "$acm_label_names", Type: "=~", Value: "$value"},
-							},
-						),
-					),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(true),
-				listVar.AllowMultiple(true),
-				listVar.Hidden(true),
-			),
-		),
+		vars.Clusters(variables.Multi(), variables.Hidden()),
 
 		withControlPlaneHealthGroup(datasource, clusterLabelMatcher),
 		withOptimizationGroup(datasource, clusterLabelMatcher),
@@ -3,18 +3,20 @@ package acm
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
-func BuildACMMetricsCardinalityCluster(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildACMMetricsCardinalityCluster(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-metrics-cardinality-cluster",
 		dashboard.ProjectName(project),
 		dashboard.Name("Metrics Cardinality / Cluster / Namespace / Pod"),
 
 		// Variables: cluster → namespace → pod → metric_name
-		GetCardinalityClusterVariable(datasource),
-		GetCardinalityNamespaceVariable(datasource),
-		GetCardinalityPodVariable(datasource),
+		vars.Clusters(variables.ClustersWith("cluster:cardinality")),
+		vars.Namespaces(),
+		vars.Pods(),
 		GetCardinalityPodMetricVariable(datasource),
 
 		// By Namespace
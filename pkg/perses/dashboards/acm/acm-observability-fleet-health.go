@@ -0,0 +1,48 @@
+package acm
+
+import (
+	"github.com/perses/perses/go-sdk/dashboard"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
+)
+
+func withFleetSummaryGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Summary",
+		panelgroup.PanelsPerLine(3),
+		panels.FleetAddonAvailability(datasource),
+		panels.FleetRemoteWriteFailures(datasource),
+		panels.FleetClustersNotSendingData(datasource),
+	)
+}
+
+func withFleetAddonGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Addon",
+		panelgroup.PanelsPerLine(2),
+		panels.FleetAddonStatus(datasource),
+		panels.FleetCollectorStack(datasource),
+	)
+}
+
+func withFleetDataFlowGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Metrics Delivery",
+		panelgroup.PanelsPerLine(3),
+		panels.FleetTimeSinceLastSample(datasource),
+		panels.FleetRemoteWriteLag(datasource),
+		panels.FleetRemoteWriteFailedSamples(datasource),
+	)
+}
+
+// BuildACMObservabilityFleetHealth returns the dashboard of the health of the
+// observability addon and of the delivery of the metrics of every cluster.
+func BuildACMObservabilityFleetHealth(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New("acm-observability-fleet-health",
+		dashboard.ProjectName(project),
+		dashboard.Name("ACM Observability Fleet Health"),
+		vars.Clusters(variables.Multi()),
+		withFleetSummaryGroup(datasource),
+		withFleetAddonGroup(datasource),
+		withFleetDataFlowGroup(datasource),
+	)
+}
@@ -0,0 +1,21 @@
+package acm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildACMObservabilityFleetHealth(t *testing.T) {
+	b, err := BuildACMObservabilityFleetHealth("test-project", "test-datasource", "")
+	require.NoError(t, err)
+	assert.Len(t, b.Dashboard.Spec.Panels, 8)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `component=\"ClusterLogForwarder\"`)
+	assert.Contains(t, string(data), `dashboard=k8s-compute-resources-cluster`)
+	assert.Contains(t, string(data), `/multicloud/infrastructure/clusters/details/${__data.fields[\"name\"]}`)
+}
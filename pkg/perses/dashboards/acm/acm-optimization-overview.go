@@ -2,12 +2,10 @@ package acm
 
 import (
 	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
@@ -65,26 +63,13 @@ func withNetworkingGroup(datasource string, labelMatcher *labels.Matcher) dashbo
 }
 
 func BuildACMOptimizationOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcherV2(clusterLabelName)
 	return dashboard.New("acm-optimization-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Resource Optimization / Cluster"),
 
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels{openshiftVersion_major!=\"3\"}",
-							[]promql.LabelMatcher{},
-						)),
-				),
-				listVar.DisplayName("cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
+		vars.Clusters(),
 		withCPUStatsGroup(datasource, clusterLabelMatcher),
 		withCPUUsageGroup(datasource, clusterLabelMatcher),
 		withCPUQuotaGroup(datasource, clusterLabelMatcher),
@@ -7,8 +7,7 @@ import (
 	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
@@ -52,27 +51,14 @@ func withClusterDiskSpaceResourceGroup(datasource string, labelMatcher promql.La
 }
 
 func BuildClusterResourceUse(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcher(clusterLabelName)
 	return dashboard.New("acm-cluster-rsrc-use",
 		dashboard.ProjectName(project),
 		dashboard.Name("USE Method / Cluster"),
 		withDescription("http://www.brendangregg.com/USEmethod/use-linux.html"),
 		dashboard.Duration(time.Hour*3),
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels{openshiftVersion_major!=\"3\"}",
-							[]promql.LabelMatcher{},
-						)),
-				),
-				listVar.DisplayName("cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
+		vars.Clusters(),
 		withClusterCPUResourceGroup(datasource, clusterLabelMatcher),
 		withClusterMemoryResourceGroup(datasource, clusterLabelMatcher),
 		withClusterNetworkResourceGroup(datasource, clusterLabelMatcher),
@@ -8,6 +8,7 @@ import (
 	k8sApiServer "github.com/perses/community-mixins/pkg/dashboards/kubernetes/apiserver"
 	k8sComputeResources "github.com/perses/community-mixins/pkg/dashboards/kubernetes/compute_resources"
 	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -25,40 +26,41 @@ func init() {
 // Upstream dashboards imported from the community-dashboards repository. https://github.com/perses/community-dashboards/tree/main/pkg/dashboards/kubernetes
 func BuildK8sDashboards(project string, datasource string, clusterLabelName string) (obj []runtime.Object, err error) {
 	dashboardWriter := dashboards.NewDashboardWriter()
+	vars := variables.New(datasource, clusterLabelName)
 
 	dashboardVars := []dashboard.Option{
-		GetClusterVariable(datasource),
-		GetNodeVariable(datasource),
+		vars.Clusters(),
+		vars.Nodes(),
 	}
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesNodeResourcesOverview(project, datasource, clusterLabelName, dashboardVars...))
 
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesMultiClusterOverview(project, datasource, clusterLabelName))
 
 	dashboardVars = []dashboard.Option{
-		GetClusterVariable(datasource),
-		GetNamespaceVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
 	}
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesNamespaceOverview(project, datasource, clusterLabelName, dashboardVars...))
 
 	dashboardVars = []dashboard.Option{
-		GetClusterVariable(datasource),
-		GetNamespaceVariable(datasource),
-		GetPodVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Pods(),
 	}
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesPodOverview(project, datasource, clusterLabelName, dashboardVars...))
 
 	dashboardVars = []dashboard.Option{
-		GetClusterVariable(datasource),
-		GetNamespaceVariable(datasource),
-		GetWorkloadVariable(datasource),
-		GetTypeVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Workloads(),
+		vars.WorkloadTypes(),
 	}
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesWorkloadOverview(project, datasource, clusterLabelName, dashboardVars...))
 	dashboardWriter.Add(k8sComputeResources.BuildKubernetesWorkloadNamespaceOverview(project, datasource, clusterLabelName, dashboardVars...))
 
 	dashboardVars = []dashboard.Option{
-		GetClusterVariable(datasource),
-		GetInstanceVariable(datasource),
+		vars.Clusters(),
+		vars.Instances("process_resident_memory_bytes"),
 	}
 	dashboardWriter.Add(k8sApiServer.BuildAPIServerOverview(project, datasource, clusterLabelName, dashboardVars...))
 
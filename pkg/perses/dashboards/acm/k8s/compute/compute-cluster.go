@@ -4,7 +4,9 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
 )
 
 func withClusterHeadlinesGroup(datasource string) dashboard.Option {
@@ -35,11 +37,11 @@ func withClusterCPUGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withClusterCPUQuotaGroup(datasource string) dashboard.Option {
+func withClusterCPUQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("CPU Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(14),
-		panels.ClusterCPUQuota(datasource),
+		panels.ClusterCPUQuota(datasource, logs),
 	)
 }
 
@@ -51,25 +53,27 @@ func withClusterMemoryGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withClusterMemoryQuotaGroup(datasource string) dashboard.Option {
+func withClusterMemoryQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("Memory Requests",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(12),
-		panels.ClusterMemoryQuota(datasource),
+		panels.ClusterMemoryQuota(datasource, logs),
 	)
 }
 
-func BuildComputeCluster(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputeCluster(project string, datasource string, clusterLabelName string, logs dl.LogLinks) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-cluster",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Cluster"),
 
-		acm.GetClusterVariable(datasource),
+		vars.Clusters(),
+		vars.ClusterIDs(),
 
 		withClusterHeadlinesGroup(datasource),
 		withClusterCPUGroup(datasource),
-		withClusterCPUQuotaGroup(datasource),
+		withClusterCPUQuotaGroup(datasource, logs),
 		withClusterMemoryGroup(datasource),
-		withClusterMemoryQuotaGroup(datasource),
+		withClusterMemoryQuotaGroup(datasource, logs),
 	)
 }
@@ -4,7 +4,9 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
 )
 
 func withNamespacePodsHeadlinesGroup(datasource string) dashboard.Option {
@@ -31,11 +33,11 @@ func withNamespacePodsCPUUsageGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withNamespacePodsCPUQuotaGroup(datasource string) dashboard.Option {
+func withNamespacePodsCPUQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("CPU Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(8),
-		panels.NamespacePodsCPUQuota(datasource),
+		panels.NamespacePodsCPUQuota(datasource, logs),
 	)
 }
 
@@ -47,26 +49,28 @@ func withNamespacePodsMemoryUsageGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withNamespacePodsMemoryQuotaGroup(datasource string) dashboard.Option {
+func withNamespacePodsMemoryQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("Memory Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(8),
-		panels.NamespacePodsMemoryQuota(datasource),
+		panels.NamespacePodsMemoryQuota(datasource, logs),
 	)
 }
 
-func BuildComputeNamespacePods(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputeNamespacePods(project string, datasource string, clusterLabelName string, logs dl.LogLinks) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-namespace-pods",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Namespace (Pods)"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.ClusterIDs(),
 
 		withNamespacePodsHeadlinesGroup(datasource),
 		withNamespacePodsCPUUsageGroup(datasource),
-		withNamespacePodsCPUQuotaGroup(datasource),
+		withNamespacePodsCPUQuotaGroup(datasource, logs),
 		withNamespacePodsMemoryUsageGroup(datasource),
-		withNamespacePodsMemoryQuotaGroup(datasource),
+		withNamespacePodsMemoryQuotaGroup(datasource, logs),
 	)
 }
@@ -3,8 +3,9 @@ package compute
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
 )
 
 func withNamespaceWorkloadsCPUUsageGroup(datasource string) dashboard.Option {
@@ -15,11 +16,11 @@ func withNamespaceWorkloadsCPUUsageGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withNamespaceWorkloadsCPUQuotaGroup(datasource string) dashboard.Option {
+func withNamespaceWorkloadsCPUQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("CPU Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(12),
-		panels.NamespaceWorkloadsCPUQuota(datasource),
+		panels.NamespaceWorkloadsCPUQuota(datasource, logs),
 	)
 }
 
@@ -31,26 +32,28 @@ func withNamespaceWorkloadsMemoryUsageGroup(datasource string) dashboard.Option
 	)
 }
 
-func withNamespaceWorkloadsMemoryQuotaGroup(datasource string) dashboard.Option {
+func withNamespaceWorkloadsMemoryQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("Memory Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(11),
-		panels.NamespaceWorkloadsMemoryQuota(datasource),
+		panels.NamespaceWorkloadsMemoryQuota(datasource, logs),
 	)
 }
 
-func BuildComputeNamespaceWorkloads(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputeNamespaceWorkloads(project string, datasource string, clusterLabelName string, logs dl.LogLinks) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-namespace-workloads",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Namespace (Workloads)"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
-		acm.GetTypeVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.WorkloadTypes(),
+		vars.ClusterIDs(),
 
 		withNamespaceWorkloadsCPUUsageGroup(datasource),
-		withNamespaceWorkloadsCPUQuotaGroup(datasource),
+		withNamespaceWorkloadsCPUQuotaGroup(datasource, logs),
 		withNamespaceWorkloadsMemoryUsageGroup(datasource),
-		withNamespaceWorkloadsMemoryQuotaGroup(datasource),
+		withNamespaceWorkloadsMemoryQuotaGroup(datasource, logs),
 	)
 }
@@ -3,7 +3,7 @@ package compute
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
 )
 
@@ -39,13 +39,14 @@ func withNodePodsMemoryQuotaGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildComputeNodePods(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputeNodePods(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-node-pods",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Node (Pods)"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNodeVariable(datasource),
+		vars.Clusters(),
+		vars.Nodes(),
 
 		withNodePodsCPUUsageGroup(datasource),
 		withNodePodsCPUQuotaGroup(datasource),
@@ -3,7 +3,7 @@ package compute
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
 )
 
@@ -47,14 +47,15 @@ func withPodMemoryQuotaGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildComputePod(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputePod(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-pod",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Pod"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
-		acm.GetPodVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Pods(),
 
 		withPodCPUUsageGroup(datasource),
 		withPodCPUThrottlingGroup(datasource),
@@ -3,8 +3,9 @@ package compute
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/compute"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
 )
 
 func withWorkloadCPUUsageGroup(datasource string) dashboard.Option {
@@ -15,11 +16,11 @@ func withWorkloadCPUUsageGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withWorkloadCPUQuotaGroup(datasource string) dashboard.Option {
+func withWorkloadCPUQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("CPU Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(7),
-		panels.WorkloadCPUQuota(datasource),
+		panels.WorkloadCPUQuota(datasource, logs),
 	)
 }
 
@@ -31,27 +32,29 @@ func withWorkloadMemoryUsageGroup(datasource string) dashboard.Option {
 	)
 }
 
-func withWorkloadMemoryQuotaGroup(datasource string) dashboard.Option {
+func withWorkloadMemoryQuotaGroup(datasource string, logs dl.LogLinks) dashboard.Option {
 	return dashboard.AddPanelGroup("Memory Quota",
 		panelgroup.PanelsPerLine(1),
 		panelgroup.PanelHeight(7),
-		panels.WorkloadMemoryQuota(datasource),
+		panels.WorkloadMemoryQuota(datasource, logs),
 	)
 }
 
-func BuildComputeWorkload(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildComputeWorkload(project string, datasource string, clusterLabelName string, logs dl.LogLinks) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-compute-resources-workload",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Compute Resources / Workload"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
-		acm.GetWorkloadVariable(datasource),
-		acm.GetTypeVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Workloads(),
+		vars.WorkloadTypes(),
+		vars.ClusterIDs(),
 
 		withWorkloadCPUUsageGroup(datasource),
-		withWorkloadCPUQuotaGroup(datasource),
+		withWorkloadCPUQuotaGroup(datasource, logs),
 		withWorkloadMemoryUsageGroup(datasource),
-		withWorkloadMemoryQuotaGroup(datasource),
+		withWorkloadMemoryQuotaGroup(datasource, logs),
 	)
 }
@@ -0,0 +1,97 @@
+package compute
+
+import (
+	"net/url"
+	"testing"
+
+	tablePanel "github.com/perses/plugins/table/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+)
+
+var viaQLogs = dl.LogLinks{Tenant: "application", Labels: dl.ViaQLogLabels}
+
+func TestBuildComputeNamespacePods_Links(t *testing.T) {
+	b, err := BuildComputeNamespacePods("test-project", "test-datasource", "", viaQLogs)
+	require.NoError(t, err)
+
+	var clusterID bool
+	for _, v := range b.Dashboard.Spec.Variables {
+		if v.Spec.GetName() == "clusterID" {
+			clusterID = true
+		}
+	}
+	assert.True(t, clusterID, "the log links need the clusterID variable")
+
+	quota := b.Dashboard.Spec.Panels["2_0"]
+	require.Equal(t, "CPU Quota", quota.Spec.Display.Name)
+
+	spec, ok := quota.Spec.Plugin.Spec.(tablePanel.PluginSpec)
+	require.True(t, ok)
+	require.Equal(t, "value #1", spec.ColumnSettings[1].Name)
+	assert.Equal(t,
+		`/monitoring/logs?end=$__to&q=%7Bopenshift_cluster_id%3D%22$clusterID%22%2Ckubernetes_namespace_name%3D%22$namespace%22%2Ckubernetes_pod_name%3D%22${__data.fields["pod"]}%22%7D&start=$__from&tenant=application`,
+		spec.ColumnSettings[1].DataLink.URL,
+	)
+
+	require.Len(t, quota.Spec.Links, 2)
+	assert.Equal(t, "/monitoring/v2/dashboards/view?dashboard=acm-tracing-traces&end=$__to&project=$__project&start=$__from&var-cluster=$cluster&var-namespace=$namespace&var-service=.%2B", quota.Spec.Links[0].URL)
+	assert.Equal(t, "/multicloud/infrastructure/clusters/details/$cluster/$cluster/overview", quota.Spec.Links[1].URL)
+}
+
+func TestBuildComputeNamespacePods_LogLinks(t *testing.T) {
+	b, err := BuildComputeNamespacePods("test-project", "test-datasource", "", dl.LogLinks{Tenant: "application", Labels: dl.OTelLogLabels})
+	require.NoError(t, err)
+
+	spec, ok := b.Dashboard.Spec.Panels["2_0"].Spec.Plugin.Spec.(tablePanel.PluginSpec)
+	require.True(t, ok)
+	assert.Contains(t, spec.ColumnSettings[1].DataLink.URL, "q=%7Bopenshift_cluster_uid%3D%22$clusterID%22%2Ck8s_namespace_name%3D%22$namespace%22%2Ck8s_pod_name%3D%22${__data.fields[\"pod\"]}%22%7D")
+
+	b, err = BuildComputeNamespacePods("test-project", "test-datasource", "", dl.LogLinks{})
+	require.NoError(t, err)
+
+	spec, ok = b.Dashboard.Spec.Panels["2_0"].Spec.Plugin.Spec.(tablePanel.PluginSpec)
+	require.True(t, ok)
+	assert.Nil(t, spec.ColumnSettings[1].DataLink, "no log links without a LokiStack")
+}
+
+func TestBuildComputeNamespaceWorkloads_Links(t *testing.T) {
+	b, err := BuildComputeNamespaceWorkloads("test-project", "test-datasource", "", viaQLogs)
+	require.NoError(t, err)
+
+	for _, p := range b.Dashboard.Spec.Panels {
+		spec, ok := p.Spec.Plugin.Spec.(tablePanel.PluginSpec)
+		if !ok {
+			continue
+		}
+		assert.Equal(t,
+			`/monitoring/v2/dashboards/view?dashboard=acm-tracing-traces&end=$__to&project=$__project&start=$__from&var-cluster=$cluster&var-namespace=$namespace&var-service=${__data.fields["workload"]}`,
+			spec.ColumnSettings[2].DataLink.URL,
+		)
+
+		// The pods of the workload are matched up to their generated suffix.
+		logsURL, err := url.Parse(spec.ColumnSettings[1].DataLink.URL)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{openshift_cluster_id="$clusterID",kubernetes_namespace_name="$namespace",kubernetes_pod_name=~"${__data.fields["workload"]}-(([bcdfghjklmnpqrstvwxz2456789]{1,10}-)?[bcdfghjklmnpqrstvwxz2456789]{5}|[0-9]+)"}`,
+			logsURL.Query().Get("q"),
+		)
+	}
+}
+
+func TestBuildComputePod_Links(t *testing.T) {
+	b, err := BuildComputePod("test-project", "test-datasource", "")
+	require.NoError(t, err)
+
+	cpu := b.Dashboard.Spec.Panels["0_0"]
+	require.Equal(t, "CPU Usage", cpu.Spec.Display.Name)
+	require.Len(t, cpu.Spec.Links, 1)
+	assert.Equal(t, "/monitoring/v2/dashboards/view?dashboard=acm-profiling-pod&end=$__to&project=$__project&start=$__from&var-cluster=$cluster&var-namespace=$namespace&var-pod=$pod&var-profile_type=process_cpu%3Acpu%3Ananoseconds%3Acpu%3Ananoseconds", cpu.Spec.Links[0].URL)
+
+	memory := b.Dashboard.Spec.Panels["3_0"]
+	require.Equal(t, "Memory Usage", memory.Spec.Display.Name)
+	require.Len(t, memory.Spec.Links, 1)
+	assert.Contains(t, memory.Spec.Links[0].URL, "var-profile_type=memory%3Ainuse_space%3Abytes%3Aspace%3Abytes")
+}
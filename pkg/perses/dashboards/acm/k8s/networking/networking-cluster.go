@@ -4,6 +4,7 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/networking"
 )
 
@@ -50,12 +51,22 @@ func withClusterErrorsGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildNetworkingCluster(project string, datasource string, _ string) (dashboard.Builder, error) {
+func withClusterNetworkFlowsGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Network Flows",
+		panelgroup.PanelsPerLine(1),
+		panelgroup.PanelHeight(9),
+		panels.ClusterFlowBytes(datasource),
+		panels.ClusterFlowDroppedPackets(datasource),
+	)
+}
+
+func BuildNetworkingCluster(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-networking-cluster",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Networking / Cluster"),
 
-		acm.GetClusterVariable(datasource),
+		vars.Clusters(),
 		acm.AddTextVariable("interval", "4h", "Interval"),
 		acm.AddTextVariable("resolution", "5m", "Resolution"),
 
@@ -63,5 +74,6 @@ func BuildNetworkingCluster(project string, datasource string, _ string) (dashbo
 		withClusterBandwidthHistoryGroup(datasource),
 		withClusterPacketsGroup(datasource),
 		withClusterErrorsGroup(datasource),
+		withClusterNetworkFlowsGroup(datasource),
 	)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/networking"
 )
 
@@ -48,13 +49,23 @@ func withNamespacePodsErrorsGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildNetworkingNamespacePods(project string, datasource string, _ string) (dashboard.Builder, error) {
+func withNamespacePodsNetworkFlowsGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Network Flows",
+		panelgroup.PanelsPerLine(2),
+		panelgroup.PanelHeight(10),
+		panels.NamespacePodsFlowIncomingBytes(datasource),
+		panels.NamespacePodsFlowOutgoingBytes(datasource),
+	)
+}
+
+func BuildNetworkingNamespacePods(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-networking-namespace-pods",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Networking / Namespace (Pods)"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
 		acm.AddTextVariable("resolution", "5m", "Resolution"),
 		acm.AddTextVariable("interval", "4h", "Interval"),
 
@@ -62,5 +73,6 @@ func BuildNetworkingNamespacePods(project string, datasource string, _ string) (
 		withNamespacePodsBandwidthGroup(datasource),
 		withNamespacePodsPacketsGroup(datasource),
 		withNamespacePodsErrorsGroup(datasource),
+		withNamespacePodsNetworkFlowsGroup(datasource),
 	)
 }
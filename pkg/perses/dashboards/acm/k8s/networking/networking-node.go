@@ -4,6 +4,7 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/networking"
 )
 
@@ -32,16 +33,27 @@ func withNodeErrorsGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildNetworkingNode(project string, datasource string, _ string) (dashboard.Builder, error) {
+func withNodeNetworkFlowsGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Network Flows",
+		panelgroup.PanelsPerLine(2),
+		panelgroup.PanelHeight(9),
+		panels.NodeFlowReceivedBytes(datasource),
+		panels.NodeFlowTransmittedBytes(datasource),
+	)
+}
+
+func BuildNetworkingNode(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-networking-node",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Networking / Node"),
 
-		acm.GetClusterVariable(datasource),
+		vars.Clusters(),
 		acm.AddTextVariable("interval", "4h", "Interval"),
 		acm.AddTextVariable("resolution", "5m", "Resolution"),
 
 		withNodeCurrentBandwidthGroup(datasource),
 		withNodeErrorsGroup(datasource),
+		withNodeNetworkFlowsGroup(datasource),
 	)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/networking"
 )
 
@@ -43,14 +44,15 @@ func withPodErrorsGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildNetworkingPod(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildNetworkingPod(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-networking-pod",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Networking / Pod"),
 
-		acm.GetClusterVariable(datasource),
-		acm.GetNamespaceVariable(datasource),
-		acm.GetPodVariable(datasource),
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Pods(),
 		acm.AddTextVariable("resolution", "5m", "Resolution"),
 		acm.AddTextVariable("interval", "4h", "Interval"),
 
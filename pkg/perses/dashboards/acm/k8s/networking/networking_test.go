@@ -0,0 +1,54 @@
+package networking
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNetworking_NetworkFlows(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		build  func(project, datasource, clusterLabelName string) (dashboard.Builder, error)
+		panels []string
+	}{
+		{
+			name:   "cluster",
+			build:  BuildNetworkingCluster,
+			panels: []string{"Top Namespace-to-Namespace Traffic", "Top Namespace-to-Namespace Dropped Packets"},
+		},
+		{
+			name:   "namespace pods",
+			build:  BuildNetworkingNamespacePods,
+			panels: []string{"Top Incoming Traffic by Source Workload", "Top Outgoing Traffic by Destination Workload"},
+		},
+		{
+			name:   "node",
+			build:  BuildNetworkingNode,
+			panels: []string{"Flow Traffic Received by Node", "Flow Traffic Transmitted by Node"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.build("test-project", "test-datasource", "")
+			require.NoError(t, err)
+
+			// The network flows group comes last so that the panels of the
+			// other groups keep their keys.
+			group := len(b.Dashboard.Spec.Layouts) - 1
+			for i, name := range tc.panels {
+				p, ok := b.Dashboard.Spec.Panels[fmt.Sprintf("%d_%d", group, i)]
+				require.True(t, ok)
+				assert.Equal(t, name, p.Spec.Display.Name)
+
+				require.Len(t, p.Spec.Queries, 1)
+				raw, err := json.Marshal(p.Spec.Queries[0])
+				require.NoError(t, err)
+				assert.Contains(t, string(raw), "netobserv_")
+			}
+		})
+	}
+}
@@ -1,13 +1,10 @@
 package slo
 
 import (
-	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/slo"
 )
 
@@ -53,27 +50,13 @@ func withTrendGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildSLOAPIServerCluster(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildSLOAPIServerCluster(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-slo-api-server-cluster",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Service-Level Overview / API Server / Cluster"),
 
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("cluster",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"sli:apiserver_request_duration_seconds:trend:1m",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
+		vars.Clusters(variables.ClustersWith("sli:apiserver_request_duration_seconds:trend:1m")),
 
 		withSLOOverviewGroup(datasource),
 		withErrorBudget7dGroup(datasource),
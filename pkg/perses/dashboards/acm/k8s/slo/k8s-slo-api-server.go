@@ -1,12 +1,9 @@
 package slo
 
 import (
-	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	acm "github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/acm"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm/k8s/slo"
 )
 
@@ -34,27 +31,13 @@ func withFleetSLITrendGroup(datasource string) dashboard.Option {
 	)
 }
 
-func BuildSLOAPIServer(project string, datasource string, _ string) (dashboard.Builder, error) {
+func BuildSLOAPIServer(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("k8s-slo-api-server",
 		dashboard.ProjectName(project),
 		dashboard.Name("Kubernetes / Service-Level Overview / API Server"),
 
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("cluster",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"sli:apiserver_request_duration_seconds:trend:1m",
-							[]promql.LabelMatcher{},
-						),
-					),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(true),
-				listVar.AllowMultiple(true),
-			),
-		),
+		vars.Clusters(variables.Multi(), variables.ClustersWith("sli:apiserver_request_duration_seconds:trend:1m")),
 
 		acm.AddTextVariable("window", "7d", "Window"),
 		acm.AddTextVariable("top", "20", "Top"),
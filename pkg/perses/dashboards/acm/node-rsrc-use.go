@@ -7,9 +7,8 @@ import (
 	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	"github.com/perses/perses/pkg/model/api/v1/common"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/acm"
 )
 
@@ -63,42 +62,15 @@ func withDescription(description string) dashboard.Option {
 }
 
 func BuildNodeResourceUse(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcher(clusterLabelName)
 	return dashboard.New("acm-node-rsrc-use",
 		dashboard.ProjectName(project),
 		dashboard.Name("USE Method / Node"),
 		withDescription("http://www.brendangregg.com/USEmethod/use-linux.html"),
 		dashboard.Duration(time.Hour*3),
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels{openshiftVersion_major!=\"3\"}",
-							[]promql.LabelMatcher{},
-						)),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
-		dashboard.AddVariable("instance",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("instance",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"up{cluster=\"$cluster\", job=\"node-exporter\"}",
-							[]promql.LabelMatcher{},
-						)),
-				),
-				listVar.DisplayName("Instance"),
-				listVar.AllowAllValue(true),
-				listVar.AllowMultiple(true),
-			),
-		),
+		vars.Clusters(),
+		vars.Instances(`up{job="node-exporter"}`, variables.Multi()),
 		withCPUResourceGroup(datasource, clusterLabelMatcher),
 		withMemoryResourceGroup(datasource, clusterLabelMatcher),
 		withNetworkResourceGroup(datasource, clusterLabelMatcher),
@@ -5,8 +5,7 @@ import (
 	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/incident-management"
 )
 
@@ -19,26 +18,13 @@ func withIncidentsGroup(datasource string, labelMatcher promql.LabelMatcher) das
 }
 
 func BuildACMIncidentsOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	clusterLabelMatcher := dashboards.GetClusterLabelMatcher(clusterLabelName)
 	return dashboard.New("acm-incidents-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("ACM Incidents Overview"),
 
-		dashboard.AddVariable("cluster",
-			listVar.List(
-				labelValuesVar.PrometheusLabelValues("name",
-					dashboards.AddVariableDatasource(datasource),
-					labelValuesVar.Matchers(
-						promql.SetLabelMatchers(
-							"acm_managed_cluster_labels",
-							[]promql.LabelMatcher{},
-						)),
-				),
-				listVar.DisplayName("Cluster"),
-				listVar.AllowAllValue(false),
-				listVar.AllowMultiple(false),
-			),
-		),
+		vars.Clusters(),
 
 		withIncidentsGroup(datasource, clusterLabelMatcher),
 	)
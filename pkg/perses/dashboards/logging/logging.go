@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"github.com/perses/perses/go-sdk/dashboard"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	textVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/logging"
+)
+
+func withLogVolumeGroup(tenants []panels.TenantDatasource, labels panels.StreamLabels) dashboard.Option {
+	return dashboard.AddPanelGroup("Log Volume by Cluster",
+		panelgroup.PanelsPerLine(1),
+		panels.LogLinesByCluster(tenants, labels),
+		panels.LogBytesByCluster(tenants, labels),
+	)
+}
+
+func withErrorRateGroup(tenants []panels.TenantDatasource, labels panels.StreamLabels) dashboard.Option {
+	return dashboard.AddPanelGroup("Errors by Namespace",
+		panelgroup.PanelsPerLine(1),
+		panels.ErrorLinesByNamespace(tenants, labels),
+		panels.ErrorRatioByNamespace(tenants, labels),
+	)
+}
+
+func withCollectorThroughputGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Throughput",
+		panelgroup.PanelsPerLine(2),
+		panels.CollectorReceivedEvents(datasource),
+		panels.CollectorSentEvents(datasource),
+	)
+}
+
+func withCollectorErrorsGroup(datasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Errors and Backpressure",
+		panelgroup.PanelsPerLine(3),
+		panels.CollectorErrors(datasource),
+		panels.CollectorDiscardedEvents(datasource),
+		panels.CollectorBufferedEvents(datasource),
+	)
+}
+
+// BuildLogVolumeByCluster returns the dashboard of the log volume stored by
+// each cluster in every LokiStack tenant.
+func BuildLogVolumeByCluster(project string, tenants []panels.TenantDatasource, labels panels.StreamLabels) (dashboard.Builder, error) {
+	return dashboard.New("acm-logs-volume-by-cluster",
+		dashboard.ProjectName(project),
+		dashboard.Name("Logs / Volume by Cluster"),
+		withLogVolumeGroup(tenants, labels),
+	)
+}
+
+// BuildErrorRateByNamespace returns the dashboard of the error log lines of
+// each namespace in the application and infrastructure tenants.
+func BuildErrorRateByNamespace(project string, tenants []panels.TenantDatasource, labels panels.StreamLabels) (dashboard.Builder, error) {
+	return dashboard.New("acm-logs-error-rate-by-namespace",
+		dashboard.ProjectName(project),
+		dashboard.Name("Logs / Error Rate by Namespace"),
+		dashboard.AddVariable("namespace",
+			textVar.Text(".+",
+				textVar.DisplayName("namespace (regex)"),
+			),
+		),
+		withErrorRateGroup(tenants, labels),
+	)
+}
+
+// BuildCollectorHealth returns the dashboard of the log collectors of the
+// managed clusters, based on the metrics they expose.
+func BuildCollectorHealth(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New("acm-logs-collector-health",
+		dashboard.ProjectName(project),
+		dashboard.Name("Logs / Collector Health"),
+		vars.Clusters(variables.Multi()),
+		withCollectorThroughputGroup(datasource),
+		withCollectorErrorsGroup(datasource),
+	)
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"encoding/json"
+	"testing"
+
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tenants = []panels.TenantDatasource{
+	{Tenant: "application", Datasource: "loki-application-datasource"},
+	{Tenant: "infrastructure", Datasource: "loki-infrastructure-datasource"},
+}
+
+func TestBuildLogVolumeByCluster(t *testing.T) {
+	b, err := BuildLogVolumeByCluster("test-project", tenants, panels.ViaQStreamLabels)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"kind":"LokiTimeSeriesQuery"`)
+	assert.Contains(t, string(data), `"name":"loki-infrastructure-datasource"`)
+	assert.Contains(t, string(data), `sum by (openshift_cluster_id) (rate({log_type=\"application\"}[5m]))`)
+}
+
+func TestBuildErrorRateByNamespace(t *testing.T) {
+	b, err := BuildErrorRateByNamespace("test-project", tenants, panels.OTelStreamLabels)
+	require.NoError(t, err)
+
+	require.Len(t, b.Dashboard.Spec.Variables, 1)
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `{openshift_log_type=\"application\", k8s_namespace_name=~\"$namespace\"}`)
+}
+
+func TestBuildErrorRateByNamespace_ClusterTenants(t *testing.T) {
+	clusters := []panels.TenantDatasource{
+		{Tenant: "spoke-1", Datasource: "loki-spoke-1-datasource", LogTypes: []string{"application", "infrastructure"}},
+	}
+	b, err := BuildErrorRateByNamespace("test-project", clusters, panels.GatewayStreamLabels)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name":"loki-spoke-1-datasource"`)
+	assert.Contains(t, string(data), `{openshift_log_type=~\"application|infrastructure\", k8s_namespace_name=~\"$namespace\"}`)
+}
+
+func TestBuildCollectorHealth(t *testing.T) {
+	b, err := BuildCollectorHealth("test-project", "test-datasource", "")
+	require.NoError(t, err)
+	assert.Len(t, b.Dashboard.Spec.Panels, 5)
+}
@@ -0,0 +1,43 @@
+package profiling
+
+import (
+	"github.com/perses/perses/go-sdk/dashboard"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/virtualization"
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/profiling"
+)
+
+func withProfilesGroup(pyroscopeDatasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Profiles",
+		panelgroup.PanelsPerLine(1),
+		panelgroup.PanelHeight(16),
+		panels.PodFlameGraph(pyroscopeDatasource),
+	)
+}
+
+func profileTypeVariable() dashboard.Option {
+	values := make([]virtualization.StaticListValue, 0, len(panels.ProfileTypes))
+	for _, pt := range panels.ProfileTypes {
+		values = append(values, virtualization.StaticListValue{Label: pt.Label, Value: pt.ID})
+	}
+	return virtualization.AddStaticListVariable("profile_type", "profile type", "", values, panels.ProfileTypes[0].ID, false, false, "")
+}
+
+// BuildPodProfiles returns the dashboard of the profiles stored in Pyroscope
+// for the pods of a cluster and namespace. The cluster, namespace and pod
+// variables list the pods known to the metrics datasource.
+func BuildPodProfiles(project string, datasource string, clusterLabelName string, pyroscopeDatasource string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New("acm-profiling-pod",
+		dashboard.ProjectName(project),
+		dashboard.Name("Profiling / Pod"),
+
+		vars.Clusters(),
+		vars.Namespaces(),
+		vars.Pods(),
+		profileTypeVariable(),
+
+		withProfilesGroup(pyroscopeDatasource),
+	)
+}
@@ -0,0 +1,28 @@
+package profiling
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPodProfiles(t *testing.T) {
+	b, err := BuildPodProfiles("test-project", "test-datasource", "", "pyroscope-datasource")
+	require.NoError(t, err)
+
+	var names []string
+	for _, v := range b.Dashboard.Spec.Variables {
+		names = append(names, v.Spec.GetName())
+	}
+	assert.Contains(t, names, "pod")
+	assert.Contains(t, names, "profile_type")
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"kind":"PyroscopeProfileQuery"`)
+	assert.Contains(t, string(data), `"name":"pyroscope-datasource"`)
+	assert.Contains(t, string(data), `"profileType":"$profile_type"`)
+	assert.Contains(t, string(data), `{"label":"pod","labelValue":"$pod","operator":"=~"}`)
+}
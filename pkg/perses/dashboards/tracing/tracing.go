@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"github.com/perses/community-mixins/pkg/dashboards"
+	"github.com/perses/community-mixins/pkg/promql"
+	"github.com/perses/perses/go-sdk/dashboard"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	textVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/tracing"
+)
+
+func withClustersGroup(datasource, clusterLabelName string, sm panels.SpanMetrics) dashboard.Option {
+	return dashboard.AddPanelGroup("Clusters",
+		panelgroup.PanelsPerLine(2),
+		panels.SpanRateByCluster(datasource, clusterLabelName, sm),
+		panels.ErrorRateByCluster(datasource, clusterLabelName, sm),
+	)
+}
+
+func withServicesGroup(datasource, clusterLabelName string, sm panels.SpanMetrics) dashboard.Option {
+	return dashboard.AddPanelGroup("Services",
+		panelgroup.PanelsPerLine(3),
+		panels.SpanRateByService(datasource, clusterLabelName, sm),
+		panels.ErrorRateByService(datasource, clusterLabelName, sm),
+		panels.LatencyByService(datasource, clusterLabelName, sm),
+	)
+}
+
+func withTracesGroup(tempoDatasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Traces",
+		panelgroup.PanelsPerLine(1),
+		panels.TraceDurations(tempoDatasource),
+	)
+}
+
+func withTraceListsGroup(tempoDatasource string) dashboard.Option {
+	return dashboard.AddPanelGroup("Error and Slow Traces",
+		panelgroup.PanelsPerLine(2),
+		panels.ErrorTraces(tempoDatasource),
+		panels.SlowTraces(tempoDatasource),
+	)
+}
+
+// BuildREDMetrics returns the dashboard of the span rate, error rate and
+// latency of the services of each cluster, based on the metrics of the
+// spanmetrics connector.
+func BuildREDMetrics(project string, datasource string, clusterLabelName string, sm panels.SpanMetrics) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New("acm-tracing-red-metrics",
+		dashboard.ProjectName(project),
+		dashboard.Name("Tracing / RED Metrics by Service"),
+
+		vars.Clusters(),
+		dashboard.AddVariable("service",
+			listVar.List(
+				labelValuesVar.PrometheusLabelValues("service_name",
+					labelValuesVar.Matchers(
+						promql.SetLabelMatchers(
+							sm.Calls,
+							[]promql.LabelMatcher{vars.ClusterMatcher()},
+						),
+					),
+					dashboards.AddVariableDatasource(datasource),
+				),
+				listVar.DisplayName("service"),
+				listVar.AllowAllValue(true),
+				listVar.AllowMultiple(true),
+			),
+		),
+
+		withClustersGroup(datasource, vars.ClusterLabel(), sm),
+		withServicesGroup(datasource, vars.ClusterLabel(), sm),
+	)
+}
+
+// BuildTraces returns the dashboard of the traces stored in Tempo for the
+// services of a cluster and namespace. Spans are matched on their
+// k8s.cluster.name, k8s.namespace.name and service.name resource attributes.
+func BuildTraces(project string, datasource string, clusterLabelName string, tempoDatasource string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
+	return dashboard.New("acm-tracing-traces",
+		dashboard.ProjectName(project),
+		dashboard.Name("Tracing / Traces by Service"),
+
+		vars.Clusters(),
+		vars.Namespaces(),
+		dashboard.AddVariable("service",
+			textVar.Text(".+",
+				textVar.DisplayName("service (regex)"),
+			),
+		),
+
+		withTracesGroup(tempoDatasource),
+		withTraceListsGroup(tempoDatasource),
+	)
+}
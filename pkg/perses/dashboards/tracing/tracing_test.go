@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"encoding/json"
+	"testing"
+
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildREDMetrics(t *testing.T) {
+	b, err := BuildREDMetrics("test-project", "test-datasource", "", panels.NewSpanMetrics(panels.DefaultSpanMetricsNamespace, ""))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "traces_span_metrics_calls_total")
+	assert.Contains(t, string(data), "traces_span_metrics_duration_milliseconds_bucket")
+	assert.Contains(t, string(data), `sum by (cluster) (rate(traces_span_metrics_calls_total[5m]))`)
+
+	links := b.Dashboard.Spec.Panels["1_0"].Spec.Links
+	require.Len(t, links, 2)
+	assert.Equal(t, "/monitoring/v2/dashboards/view?dashboard=k8s-compute-resources-namespace-pods&project=$__project&var-cluster=$cluster&var-namespace=$namespace", links[1].URL)
+}
+
+func TestBuildREDMetrics_LatencyTracesLink(t *testing.T) {
+	b, err := BuildREDMetrics("test-project", "test-datasource", "", panels.NewSpanMetrics(panels.DefaultSpanMetricsNamespace, ""))
+	require.NoError(t, err)
+
+	// The latency spikes link to the traces of the services in the time range.
+	for _, p := range b.Dashboard.Spec.Panels {
+		if p.Spec.Display.Name != "Latency (p95)" {
+			continue
+		}
+		require.Len(t, p.Spec.Links, 3)
+		assert.Equal(t, "Traces", p.Spec.Links[0].Name)
+		assert.Contains(t, p.Spec.Links[0].URL, "var-service=$service")
+		return
+	}
+	t.Fatal("no latency panel")
+}
+
+func TestBuildREDMetrics_ClusterLabel(t *testing.T) {
+	b, err := BuildREDMetrics("test-project", "test-datasource", "managed_cluster", panels.NewSpanMetrics(panels.DefaultSpanMetricsNamespace, ""))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `sum by (managed_cluster) (rate(traces_span_metrics_calls_total[5m]))`)
+	assert.Contains(t, string(data), `"seriesNameFormat":"{{managed_cluster}}"`)
+	assert.Contains(t, string(data), `managed_cluster=\"$cluster\", service_name=~\"$service\"`)
+	assert.NotContains(t, string(data), `{cluster=`)
+}
+
+func TestBuildTraces(t *testing.T) {
+	b, err := BuildTraces("test-project", "test-datasource", "", "tempo-datasource")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(b.Dashboard.Spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"kind":"TempoTraceQuery"`)
+	assert.Contains(t, string(data), `"name":"tempo-datasource"`)
+	assert.Contains(t, string(data), `resource.k8s.cluster.name=\"$cluster\"`)
+}
+
+func TestNewSpanMetrics(t *testing.T) {
+	assert.Equal(t, panels.SpanMetrics{
+		Calls:          "calls_total",
+		DurationBucket: "duration_seconds_bucket",
+		DurationUnit:   "seconds",
+	}, panels.NewSpanMetrics("", "s"))
+}
@@ -0,0 +1,321 @@
+// Package variables defines the dashboard variables shared by every
+// dashboard. The cluster variable lists the managed clusters, narrowed down by
+// the cluster set and cluster label variables, and the node, namespace,
+// workload and pod variables cascade from the selected cluster.
+package variables
+
+import (
+	"fmt"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	"github.com/perses/community-mixins/pkg/promql"
+	"github.com/perses/perses/go-sdk/dashboard"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	v1variable "github.com/perses/perses/pkg/model/api/v1/variable"
+	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	promqlVar "github.com/perses/plugins/prometheus/sdk/go/variable/promql"
+)
+
+// Names of the variables, as referenced by the panel queries and the links
+// between dashboards.
+const (
+	ClusterSet        = "clusterset"
+	ClusterLabel      = "cluster_label"
+	ClusterLabelValue = "cluster_label_value"
+	Cluster           = "cluster"
+	ClusterID         = "clusterID"
+	Node              = "node"
+	Instance          = "instance"
+	Namespace         = "namespace"
+	Workload          = "workload"
+	WorkloadType      = "type"
+	Pod               = "pod"
+)
+
+const (
+	managedClusterLabels = "acm_managed_cluster_labels"
+	clusterLabelNames    = "acm_label_names"
+	// clusterSetLabel is the cluster.open-cluster-management.io/clusterset
+	// label of the ManagedClusters, as exposed by acm_managed_cluster_labels.
+	clusterSetLabel = "cluster_open_cluster_management_io_clusterset"
+
+	defaultClusterLabelName = "cluster"
+	allValues               = ".*"
+
+	podInfo          = "kube_pod_info"
+	workloadPodOwner = "namespace_workload_pod:kube_pod_owner:relabel"
+)
+
+// Set builds the variables of the dashboards querying the datasource, whose
+// series carry the name of their cluster in the cluster label.
+type Set struct {
+	datasource   string
+	clusterLabel string
+}
+
+// New returns the variables of the dashboards querying the datasource. The
+// cluster label defaults to "cluster".
+func New(datasource, clusterLabelName string) Set {
+	if clusterLabelName == "" {
+		clusterLabelName = defaultClusterLabelName
+	}
+	return Set{datasource: datasource, clusterLabel: clusterLabelName}
+}
+
+// Option customizes a variable.
+type Option func(*options)
+
+type options struct {
+	multi        bool
+	hidden       bool
+	clustersWith string
+}
+
+// Multi lets several values, or all of them, be selected. All values are
+// selected by default.
+func Multi() Option {
+	return func(o *options) { o.multi = true }
+}
+
+// Hidden hides the variable, for dashboards driven by its parent variables.
+func Hidden() Option {
+	return func(o *options) { o.hidden = true }
+}
+
+// ClustersWith only lists the clusters sending the series of the selector,
+// e.g. the clusters running OpenShift Virtualization.
+func ClustersWith(selector string) Option {
+	return func(o *options) { o.clustersWith = selector }
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) listOptions(displayName string) []listVar.Option {
+	listOptions := []listVar.Option{
+		listVar.DisplayName(displayName),
+		listVar.AllowAllValue(o.multi),
+		listVar.AllowMultiple(o.multi),
+		listVar.Hidden(o.hidden),
+		listVar.SortingBy(v1variable.SortAlphabeticalAsc),
+	}
+	if o.multi {
+		listOptions = append(listOptions, listVar.DefaultValue("$__all"))
+	}
+	return listOptions
+}
+
+// ClusterLabel returns the label carrying the name of the cluster of the
+// series.
+func (s Set) ClusterLabel() string {
+	return s.clusterLabel
+}
+
+// ClusterMatcher selects the series of the selected clusters, for the
+// variables specific to a dashboard.
+func (s Set) ClusterMatcher() promql.LabelMatcher {
+	return promql.LabelMatcher{Name: s.clusterLabel, Type: "=~", Value: "$" + Cluster}
+}
+
+// Clusters adds the cluster set, cluster label and cluster variables. The
+// clusters are filtered by the selected cluster set and by the selected value
+// of a label of the ManagedClusters, e.g. environment=prod. The "All" value of
+// the cluster variable stands for the listed clusters only, so that the
+// filters also apply when every cluster is selected.
+func (s Set) Clusters(opts ...Option) dashboard.Option {
+	o := newOptions(opts)
+	return func(b *dashboard.Builder) error {
+		for _, add := range []dashboard.Option{
+			s.clusterSetVariable(),
+			s.clusterLabelVariable(),
+			s.clusterLabelValueVariable(),
+			s.clusterVariable(o),
+		} {
+			if err := add(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (s Set) clusterSetVariable() dashboard.Option {
+	return dashboard.AddVariable(ClusterSet,
+		listVar.List(
+			labelValuesVar.PrometheusLabelValues(clusterSetLabel,
+				dashboards.AddVariableDatasource(s.datasource),
+				labelValuesVar.Matchers(managedClusterLabels+"{}"),
+			),
+			listVar.DisplayName("cluster set"),
+			listVar.AllowAllValue(true),
+			listVar.AllowMultiple(true),
+			listVar.CustomAllValue(allValues),
+			listVar.DefaultValue("$__all"),
+			listVar.SortingBy(v1variable.SortAlphabeticalAsc),
+		),
+	)
+}
+
+func (s Set) clusterLabelVariable() dashboard.Option {
+	return dashboard.AddVariable(ClusterLabel,
+		listVar.List(
+			labelValuesVar.PrometheusLabelValues("label_name",
+				dashboards.AddVariableDatasource(s.datasource),
+				labelValuesVar.Matchers(clusterLabelNames+"{}"),
+			),
+			listVar.DisplayName("cluster label"),
+			listVar.DefaultValue("name"),
+			listVar.AllowAllValue(false),
+			listVar.AllowMultiple(false),
+			listVar.SortingBy(v1variable.SortAlphabeticalAsc),
+		),
+	)
+}
+
+func (s Set) clusterLabelValueVariable() dashboard.Option {
+	return dashboard.AddVariable(ClusterLabelValue,
+		listVar.List(
+			labelValuesVar.PrometheusLabelValues("$"+ClusterLabel,
+				dashboards.AddVariableDatasource(s.datasource),
+				labelValuesVar.Matchers(
+					promql.SetLabelMatchers(
+						managedClusterLabels,
+						[]promql.LabelMatcher{{Name: clusterSetLabel, Type: "=~", Value: "$" + ClusterSet}},
+					),
+				),
+			),
+			listVar.DisplayName("cluster label value"),
+			listVar.AllowAllValue(true),
+			listVar.AllowMultiple(true),
+			listVar.CustomAllValue(allValues),
+			listVar.DefaultValue("$__all"),
+			listVar.SortingBy(v1variable.SortAlphabeticalAsc),
+		),
+	)
+}
+
+// clusterSelector selects the labels of the clusters matching the cluster set
+// and cluster label variables.
+func clusterSelector() string {
+	return promql.SetLabelMatchers(
+		managedClusterLabels+`{openshiftVersion_major!="3"}`,
+		[]promql.LabelMatcher{
+			{Name: clusterSetLabel, Type: "=~", Value: "$" + ClusterSet},
+			{Name: "$" + ClusterLabel, Type: "=~", Value: "$" + ClusterLabelValue},
+		},
+	)
+}
+
+func (s Set) clusterVariable(o options) dashboard.Option {
+	listOptions := o.listOptions("cluster")
+	if o.clustersWith == "" {
+		return dashboard.AddVariable(Cluster,
+			listVar.List(append([]listVar.Option{
+				labelValuesVar.PrometheusLabelValues("name",
+					dashboards.AddVariableDatasource(s.datasource),
+					labelValuesVar.Matchers(clusterSelector()),
+				),
+			}, listOptions...)...),
+		)
+	}
+
+	query := fmt.Sprintf(`group by (name) (%s) and on (name) label_replace(group by (%[2]s) (%[3]s), "name", "$1", "%[2]s", "(.+)")`,
+		clusterSelector(), s.clusterLabel, o.clustersWith)
+	return dashboard.AddVariable(Cluster,
+		listVar.List(append([]listVar.Option{
+			promqlVar.PrometheusPromQL(query,
+				promqlVar.LabelName("name"),
+				promqlVar.Datasource(s.datasource),
+			),
+		}, listOptions...)...),
+	)
+}
+
+// ClusterIDs adds the hidden variable resolving the ID of the selected
+// cluster, which the log streams carry instead of the cluster name.
+func (s Set) ClusterIDs() dashboard.Option {
+	return dashboard.AddVariable(ClusterID,
+		listVar.List(
+			labelValuesVar.PrometheusLabelValues("clusterID",
+				dashboards.AddVariableDatasource(s.datasource),
+				labelValuesVar.Matchers(
+					promql.SetLabelMatchers(
+						managedClusterLabels,
+						[]promql.LabelMatcher{{Name: "name", Type: "=~", Value: "$" + Cluster}},
+					),
+				),
+			),
+			listVar.DisplayName("cluster ID"),
+			listVar.Hidden(true),
+		),
+	)
+}
+
+// labelValues adds a variable listing the values of a label of the series of
+// the selector, filtered by the parent variables the dashboard declares.
+func (s Set) labelValues(name, labelName, selector string, parents []string, o options) dashboard.Option {
+	return func(b *dashboard.Builder) error {
+		declared := map[string]bool{}
+		for _, v := range b.Dashboard.Spec.Variables {
+			declared[v.Spec.GetName()] = true
+		}
+
+		var matchers []promql.LabelMatcher
+		for _, parent := range parents {
+			if !declared[parent] {
+				continue
+			}
+			parentLabel := parent
+			if parent == Cluster {
+				parentLabel = s.clusterLabel
+			}
+			matchers = append(matchers, promql.LabelMatcher{Name: parentLabel, Type: "=~", Value: "$" + parent})
+		}
+
+		return dashboard.AddVariable(name,
+			listVar.List(append([]listVar.Option{
+				labelValuesVar.PrometheusLabelValues(labelName,
+					dashboards.AddVariableDatasource(s.datasource),
+					labelValuesVar.Matchers(promql.SetLabelMatchers(selector, matchers)),
+				),
+			}, o.listOptions(name)...)...),
+		)(b)
+	}
+}
+
+// Nodes adds the variable listing the nodes of the selected cluster.
+func (s Set) Nodes(opts ...Option) dashboard.Option {
+	return s.labelValues(Node, "node", podInfo, []string{Cluster}, newOptions(opts))
+}
+
+// Instances adds the variable listing the instance label of the series of the
+// selector in the selected cluster, e.g. the API servers or node exporters.
+func (s Set) Instances(selector string, opts ...Option) dashboard.Option {
+	return s.labelValues(Instance, "instance", selector, []string{Cluster}, newOptions(opts))
+}
+
+// Namespaces adds the variable listing the namespaces of the selected cluster.
+func (s Set) Namespaces(opts ...Option) dashboard.Option {
+	return s.labelValues(Namespace, "namespace", podInfo, []string{Cluster}, newOptions(opts))
+}
+
+// Workloads adds the variable listing the workloads of the selected namespace.
+func (s Set) Workloads(opts ...Option) dashboard.Option {
+	return s.labelValues(Workload, "workload", workloadPodOwner, []string{Cluster, Namespace}, newOptions(opts))
+}
+
+// WorkloadTypes adds the variable listing the types of the workloads of the
+// selected namespace, or of the selected workload.
+func (s Set) WorkloadTypes(opts ...Option) dashboard.Option {
+	return s.labelValues(WorkloadType, "workload_type", workloadPodOwner, []string{Cluster, Namespace, Workload}, newOptions(opts))
+}
+
+// Pods adds the variable listing the pods of the selected namespace or node.
+func (s Set) Pods(opts ...Option) dashboard.Option {
+	return s.labelValues(Pod, "pod", podInfo, []string{Cluster, Node, Namespace}, newOptions(opts))
+}
@@ -0,0 +1,68 @@
+package variables
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// matchers returns the selectors of the label values variables, by name.
+func matchers(t *testing.T, b dashboard.Builder) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(b.Dashboard.Spec.Variables)
+	require.NoError(t, err)
+
+	var vars []struct {
+		Spec struct {
+			Name   string `json:"name"`
+			Plugin struct {
+				Spec map[string]any `json:"spec"`
+			} `json:"plugin"`
+		} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(data, &vars))
+
+	result := map[string]any{}
+	for _, v := range vars {
+		if m, ok := v.Spec.Plugin.Spec["matchers"]; ok {
+			result[v.Spec.Name] = m
+		} else {
+			result[v.Spec.Name] = v.Spec.Plugin.Spec["expr"]
+		}
+	}
+	return result
+}
+
+func TestClusters(t *testing.T) {
+	vars := New("test-datasource", "")
+	b, err := dashboard.New("test", vars.Clusters(Multi()), vars.Namespaces(), vars.Pods())
+	require.NoError(t, err)
+
+	m := matchers(t, b)
+	assert.Equal(t, []any{`acm_managed_cluster_labels{cluster_open_cluster_management_io_clusterset=~"$clusterset"}`}, m[ClusterLabelValue])
+	assert.Equal(t, []any{`acm_managed_cluster_labels{"$cluster_label"=~"$cluster_label_value",cluster_open_cluster_management_io_clusterset=~"$clusterset",openshiftVersion_major!="3"}`}, m[Cluster])
+	assert.Equal(t, []any{`kube_pod_info{cluster=~"$cluster"}`}, m[Namespace])
+	assert.Equal(t, []any{`kube_pod_info{cluster=~"$cluster",namespace=~"$namespace"}`}, m[Pod], "the pods cascade from the declared variables only")
+}
+
+func TestClusterLabelName(t *testing.T) {
+	vars := New("test-datasource", "managed_cluster")
+	b, err := dashboard.New("test", vars.Clusters(), vars.Namespaces(), vars.Workloads(), vars.WorkloadTypes())
+	require.NoError(t, err)
+
+	m := matchers(t, b)
+	assert.Equal(t, []any{`namespace_workload_pod:kube_pod_owner:relabel{managed_cluster=~"$cluster",namespace=~"$namespace",workload=~"$workload"}`}, m[WorkloadType])
+	assert.Equal(t, "managed_cluster", vars.ClusterMatcher().Name)
+}
+
+func TestClustersWith(t *testing.T) {
+	vars := New("test-datasource", "")
+	b, err := dashboard.New("test", vars.Clusters(ClustersWith("kubevirt_vm_info")))
+	require.NoError(t, err)
+
+	m := matchers(t, b)
+	assert.Contains(t, m[Cluster], `and on (name) label_replace(group by (cluster) (kubevirt_vm_info), "name", "$1", "cluster", "(.+)")`)
+}
@@ -1,13 +1,11 @@
 package virtualization
 
 import (
-	"github.com/perses/community-mixins/pkg/dashboards"
-	"github.com/perses/community-mixins/pkg/promql"
 	"github.com/perses/perses/go-sdk/dashboard"
 	panelgroup "github.com/perses/perses/go-sdk/panel-group"
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	promqlVar "github.com/perses/plugins/prometheus/sdk/go/variable/promql"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
@@ -17,48 +15,11 @@ func addCustomPanelGroupWithCollapse(title string, collapseOpen bool, positions
 	return addCustomPanelGroupImpl(title, collapseOpen, positions, panelOpts...)
 }
 
-func nodeMemoryClusterVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("node_memory_MemTotal_bytes"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.AllowAllValue(true),
-			listVar.AllowMultiple(true),
-			listVar.CustomAllValue(".*"),
-			listVar.DefaultValues("$__all"),
-		),
-	)
-}
-
-func nodeMemoryNodeVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("node",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("node",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers(
-					promql.SetLabelMatchers(
-						"kube_node_role",
-						[]promql.LabelMatcher{{Name: "cluster", Type: "=~", Value: "$cluster"}},
-					),
-				),
-			),
-			listVar.DisplayName("Node"),
-			listVar.AllowAllValue(true),
-			listVar.AllowMultiple(false),
-			listVar.CustomAllValue(".*"),
-			listVar.DefaultValue("$__all"),
-		),
-	)
-}
-
-func nodeMemoryRoleVariable(datasource string) dashboard.Option {
+func nodeMemoryRoleVariable(datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable("role",
 		listVar.List(
 			promqlVar.PrometheusPromQL(
-				`kube_node_role{cluster=~"$cluster"}`,
+				panels.WithClusterLabel(`kube_node_role{cluster=~"$cluster"}`, clusterLabelName),
 				promqlVar.LabelName("role"),
 				promqlVar.Datasource(datasource),
 			),
@@ -70,7 +31,7 @@ func nodeMemoryRoleVariable(datasource string) dashboard.Option {
 	)
 }
 
-func withNodeMemorySummary(datasource string) dashboard.Option {
+func withNodeMemorySummary(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Summary", true,
 		[]GridItem{
 			{X: 0, Y: 0, W: 10, H: 7},
@@ -82,18 +43,18 @@ func withNodeMemorySummary(datasource string) dashboard.Option {
 			{X: 10, Y: 14, W: 5, H: 7},
 			{X: 15, Y: 14, W: 7, H: 7},
 		},
-		panels.NodeMemoryClusterUtilizationNow(datasource),
-		panels.NodeMemoryClusterVirtualCommittedNow(datasource),
-		panels.NodeMemoryVmVirtualCommittedNow(datasource),
-		panels.NodeMemoryClusterUtilizationHistorySummary(datasource),
-		panels.NodeMemoryNodeUtilizationMinNow(datasource),
-		panels.NodeMemoryNodeUtilizationMaxNow(datasource),
-		panels.NodeMemoryNodePressureMaxNow(datasource),
-		panels.NodeMemoryNodeSystemExceedsReservationAlertNow(datasource),
+		panels.NodeMemoryClusterUtilizationNow(datasource, clusterLabelName),
+		panels.NodeMemoryClusterVirtualCommittedNow(datasource, clusterLabelName),
+		panels.NodeMemoryVmVirtualCommittedNow(datasource, clusterLabelName),
+		panels.NodeMemoryClusterUtilizationHistorySummary(datasource, clusterLabelName),
+		panels.NodeMemoryNodeUtilizationMinNow(datasource, clusterLabelName),
+		panels.NodeMemoryNodeUtilizationMaxNow(datasource, clusterLabelName),
+		panels.NodeMemoryNodePressureMaxNow(datasource, clusterLabelName),
+		panels.NodeMemoryNodeSystemExceedsReservationAlertNow(datasource, clusterLabelName),
 	)
 }
 
-func withNodeMemoryCluster(datasource string) dashboard.Option {
+func withNodeMemoryCluster(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Cluster", false,
 		[]GridItem{
 			{X: 0, Y: 0, W: 15, H: 7},
@@ -103,16 +64,16 @@ func withNodeMemoryCluster(datasource string) dashboard.Option {
 			{X: 0, Y: 14, W: 15, H: 5},
 			{X: 0, Y: 19, W: 15, H: 6},
 		},
-		panels.NodeMemoryClusterUtilizationHistory(datasource),
-		panels.NodeMemoryClusterUtilizationNow(datasource),
-		panels.NodeMemoryClusterVirtualCommittedHistory(datasource),
-		panels.NodeMemoryClusterVirtualCommittedNow(datasource),
-		panels.NodeMemoryClusterPressure(datasource),
-		panels.NodeMemorySwap(datasource),
+		panels.NodeMemoryClusterUtilizationHistory(datasource, clusterLabelName),
+		panels.NodeMemoryClusterUtilizationNow(datasource, clusterLabelName),
+		panels.NodeMemoryClusterVirtualCommittedHistory(datasource, clusterLabelName),
+		panels.NodeMemoryClusterVirtualCommittedNow(datasource, clusterLabelName),
+		panels.NodeMemoryClusterPressure(datasource, clusterLabelName),
+		panels.NodeMemorySwap(datasource, clusterLabelName),
 	)
 }
 
-func withNodeMemoryNodes(datasource string) dashboard.Option {
+func withNodeMemoryNodes(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Nodes", false,
 		[]GridItem{
 			{X: 0, Y: 0, W: 15, H: 8},
@@ -123,30 +84,30 @@ func withNodeMemoryNodes(datasource string) dashboard.Option {
 			{X: 15, Y: 16, W: 7, H: 8},
 			{X: 0, Y: 24, W: 22, H: 6},
 		},
-		panels.NodeMemoryNodeUtilizationHistory(datasource),
-		panels.NodeMemoryNodeUtilizationMinNow(datasource),
-		panels.NodeMemoryNodeRequestsHistory(datasource),
-		panels.NodeMemoryNodeRequestsMinmaxNow(datasource),
-		panels.NodeMemoryUtilizationDistribution(datasource),
-		panels.NodeMemoryPlanMinmax(datasource),
-		panels.NodeMemoryNodePressureHistory(datasource),
+		panels.NodeMemoryNodeUtilizationHistory(datasource, clusterLabelName),
+		panels.NodeMemoryNodeUtilizationMinNow(datasource, clusterLabelName),
+		panels.NodeMemoryNodeRequestsHistory(datasource, clusterLabelName),
+		panels.NodeMemoryNodeRequestsMinmaxNow(datasource, clusterLabelName),
+		panels.NodeMemoryUtilizationDistribution(datasource, clusterLabelName),
+		panels.NodeMemoryPlanMinmax(datasource, clusterLabelName),
+		panels.NodeMemoryNodePressureHistory(datasource, clusterLabelName),
 	)
 }
 
-func withNodeMemorySystemReserved(datasource string) dashboard.Option {
+func withNodeMemorySystemReserved(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("System Reserved", false,
 		[]GridItem{
 			{X: 0, Y: 0, W: 9, H: 8},
 			{X: 9, Y: 0, W: 9, H: 8},
 			{X: 18, Y: 0, W: 6, H: 8},
 		},
-		panels.NodeMemoryNodeSystemReservedUtilizationHistory(datasource),
-		panels.NodeMemoryNodeSystemReservedMinmaxHistory(datasource),
-		panels.NodeMemoryNodeSystemExceedsReservationAlertNow(datasource),
+		panels.NodeMemoryNodeSystemReservedUtilizationHistory(datasource, clusterLabelName),
+		panels.NodeMemoryNodeSystemReservedMinmaxHistory(datasource, clusterLabelName),
+		panels.NodeMemoryNodeSystemExceedsReservationAlertNow(datasource, clusterLabelName),
 	)
 }
 
-func withNodeMemoryWorkloads(datasource string) dashboard.Option {
+func withNodeMemoryWorkloads(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Workloads", false,
 		[]GridItem{
 			{X: 0, Y: 0, W: 14, H: 6},
@@ -154,27 +115,28 @@ func withNodeMemoryWorkloads(datasource string) dashboard.Option {
 			{X: 0, Y: 6, W: 14, H: 6},
 			{X: 14, Y: 6, W: 7, H: 6},
 		},
-		panels.NodeMemoryVMs(datasource),
-		panels.NodeMemoryVmVirtualCommittedNow(datasource),
-		panels.NodeMemoryVMVirtualMemoryUtilization(datasource),
-		panels.NodeMemoryNumberOfRunningVMs(datasource),
+		panels.NodeMemoryVMs(datasource, clusterLabelName),
+		panels.NodeMemoryVmVirtualCommittedNow(datasource, clusterLabelName),
+		panels.NodeMemoryVMVirtualMemoryUtilization(datasource, clusterLabelName),
+		panels.NodeMemoryNumberOfRunningVMs(datasource, clusterLabelName),
 	)
 }
 
 // BuildNodeMemoryOverview builds the acm-node-memory-overview Perses dashboard.
-func BuildNodeMemoryOverview(project string, datasource string) (dashboard.Builder, error) {
+func BuildNodeMemoryOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-node-memory-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Nodes Memory"),
 
-		nodeMemoryClusterVariable(datasource),
-		nodeMemoryNodeVariable(datasource),
-		nodeMemoryRoleVariable(datasource),
+		vars.Clusters(variables.Multi(), variables.ClustersWith("node_memory_MemTotal_bytes")),
+		vars.Nodes(variables.Multi()),
+		nodeMemoryRoleVariable(datasource, clusterLabelName),
 
-		withNodeMemorySummary(datasource),
-		withNodeMemoryCluster(datasource),
-		withNodeMemoryNodes(datasource),
-		withNodeMemorySystemReserved(datasource),
-		withNodeMemoryWorkloads(datasource),
+		withNodeMemorySummary(datasource, clusterLabelName),
+		withNodeMemoryCluster(datasource, clusterLabelName),
+		withNodeMemoryNodes(datasource, clusterLabelName),
+		withNodeMemorySystemReserved(datasource, clusterLabelName),
+		withNodeMemoryWorkloads(datasource, clusterLabelName),
 	)
 }
@@ -8,24 +8,10 @@ import (
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	variable "github.com/perses/perses/pkg/model/api/v1/variable"
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func singleClusterClusterVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("kubevirt_hyperconverged_operator_health_status"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.AllowAllValue(false),
-			listVar.AllowMultiple(false),
-			listVar.SortingBy(variable.SortAlphabeticalAsc),
-		),
-	)
-}
-
 func singleClusterHealthImpactVariable(datasource string) dashboard.Option {
 	return dashboard.AddVariable("health_impact",
 		listVar.List(
@@ -60,7 +46,7 @@ func singleClusterSeverityVariable(datasource string) dashboard.Option {
 	)
 }
 
-func withSingleClusterGeneralInformation(datasource, project string) dashboard.Option {
+func withSingleClusterGeneralInformation(datasource, clusterLabelName, project string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("General Information", true,
 		[]GridItem{
 			// Row 1: eight equal stat panels (W:3 each = 24)
@@ -85,39 +71,39 @@ func withSingleClusterGeneralInformation(datasource, project string) dashboard.O
 			{X: 0, Y: 6, W: 12, H: 7},  // Running VMs by OS
 			{X: 12, Y: 6, W: 12, H: 7}, // Recent VMs Started
 		},
-		panels.SingleClusterClusterName(datasource),
-		panels.SingleClusterOpenshiftVirtVersion(datasource),
-		panels.SingleClusterProvider(datasource),
-		panels.SingleClusterOpenshiftVersion(datasource),
-		panels.SingleClusterOperatorStatus(datasource),
-		panels.SingleClusterOperatorConditions(datasource),
-		panels.SingleClusterTotalNodes(datasource),
-		panels.SingleClusterTotalVMs(datasource, project),
-		panels.SingleClusterVMsRunning(datasource, project),
-		panels.SingleClusterVMsInError(datasource, project),
-		panels.SingleClusterVMsStopped(datasource, project),
-		panels.SingleClusterVMsStarting(datasource, project),
-		panels.SingleClusterVMsMigrating(datasource, project),
-		panels.SingleClusterVMsRunningPercent(datasource, project),
-		panels.SingleClusterVMsNotEvictable(datasource, project),
-		panels.SingleClusterVMsCreatedLast24h(datasource),
-		panels.SingleClusterRunningVMsByOS(datasource),
-		panels.SingleClusterRecentVMsStarted(datasource, project),
+		panels.SingleClusterClusterName(datasource, clusterLabelName),
+		panels.SingleClusterOpenshiftVirtVersion(datasource, clusterLabelName),
+		panels.SingleClusterProvider(datasource, clusterLabelName),
+		panels.SingleClusterOpenshiftVersion(datasource, clusterLabelName),
+		panels.SingleClusterOperatorStatus(datasource, clusterLabelName),
+		panels.SingleClusterOperatorConditions(datasource, clusterLabelName),
+		panels.SingleClusterTotalNodes(datasource, clusterLabelName),
+		panels.SingleClusterTotalVMs(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsRunning(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsInError(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsStopped(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsStarting(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsMigrating(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsRunningPercent(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsNotEvictable(datasource, clusterLabelName, project),
+		panels.SingleClusterVMsCreatedLast24h(datasource, clusterLabelName),
+		panels.SingleClusterRunningVMsByOS(datasource, clusterLabelName),
+		panels.SingleClusterRecentVMsStarted(datasource, clusterLabelName, project),
 	)
 }
 
-func withSingleClusterAdditionalVMDetails(datasource string) dashboard.Option {
+func withSingleClusterAdditionalVMDetails(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Additional Virtual Machines Details", false,
 		[]GridItem{
 			{X: 0, Y: 15, W: 12, H: 8},
 			{X: 12, Y: 15, W: 12, H: 8},
 		},
-		panels.SingleClusterVMsRunningByNode(datasource),
-		panels.SingleClusterVMsByStatus(datasource),
+		panels.SingleClusterVMsRunningByNode(datasource, clusterLabelName),
+		panels.SingleClusterVMsByStatus(datasource, clusterLabelName),
 	)
 }
 
-func withSingleClusterCPUTop20(datasource string) dashboard.Option {
+func withSingleClusterCPUTop20(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("CPU Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 16, W: 12, H: 7},
@@ -127,16 +113,16 @@ func withSingleClusterCPUTop20(datasource string) dashboard.Option {
 			{X: 0, Y: 30, W: 12, H: 7},
 			{X: 12, Y: 30, W: 12, H: 7},
 		},
-		panels.SingleClusterNodesCPUUtilization(datasource),
-		panels.SingleClusterVMsTotalCPUUsage(datasource),
-		panels.SingleClusterNodesCPUUsagePercent(datasource),
-		panels.SingleClusterVMsCPUUsagePercent(datasource),
-		panels.SingleClusterNodesCPUStealPercent(datasource),
-		panels.SingleClusterVMsCPUReadyPercent(datasource),
+		panels.SingleClusterNodesCPUUtilization(datasource, clusterLabelName),
+		panels.SingleClusterVMsTotalCPUUsage(datasource, clusterLabelName),
+		panels.SingleClusterNodesCPUUsagePercent(datasource, clusterLabelName),
+		panels.SingleClusterVMsCPUUsagePercent(datasource, clusterLabelName),
+		panels.SingleClusterNodesCPUStealPercent(datasource, clusterLabelName),
+		panels.SingleClusterVMsCPUReadyPercent(datasource, clusterLabelName),
 	)
 }
 
-func withSingleClusterMemoryTop20(datasource string) dashboard.Option {
+func withSingleClusterMemoryTop20(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Memory Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 17, W: 12, H: 7},
@@ -144,14 +130,14 @@ func withSingleClusterMemoryTop20(datasource string) dashboard.Option {
 			{X: 0, Y: 24, W: 12, H: 7},
 			{X: 12, Y: 24, W: 12, H: 7},
 		},
-		panels.SingleClusterNodesMemoryUsage(datasource),
-		panels.SingleClusterVMsMemoryUsage(datasource),
-		panels.SingleClusterNodesMemoryUsagePercent(datasource),
-		panels.SingleClusterVMsMemoryUsagePercent(datasource),
+		panels.SingleClusterNodesMemoryUsage(datasource, clusterLabelName),
+		panels.SingleClusterVMsMemoryUsage(datasource, clusterLabelName),
+		panels.SingleClusterNodesMemoryUsagePercent(datasource, clusterLabelName),
+		panels.SingleClusterVMsMemoryUsagePercent(datasource, clusterLabelName),
 	)
 }
 
-func withSingleClusterNetworkTop20(datasource string) dashboard.Option {
+func withSingleClusterNetworkTop20(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Network Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 18, W: 12, H: 6},
@@ -159,14 +145,14 @@ func withSingleClusterNetworkTop20(datasource string) dashboard.Option {
 			{X: 0, Y: 24, W: 12, H: 6},
 			{X: 12, Y: 24, W: 12, H: 6},
 		},
-		panels.SingleClusterNodesNetworkReceived(datasource),
-		panels.SingleClusterVMsNetworkReceived(datasource),
-		panels.SingleClusterNodesNetworkTransmitted(datasource),
-		panels.SingleClusterVMsNetworkTransmitted(datasource),
+		panels.SingleClusterNodesNetworkReceived(datasource, clusterLabelName),
+		panels.SingleClusterVMsNetworkReceived(datasource, clusterLabelName),
+		panels.SingleClusterNodesNetworkTransmitted(datasource, clusterLabelName),
+		panels.SingleClusterVMsNetworkTransmitted(datasource, clusterLabelName),
 	)
 }
 
-func withSingleClusterStorageTop20(datasource string) dashboard.Option {
+func withSingleClusterStorageTop20(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Storage Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 19, W: 12, H: 7},
@@ -174,14 +160,14 @@ func withSingleClusterStorageTop20(datasource string) dashboard.Option {
 			{X: 0, Y: 26, W: 12, H: 7},
 			{X: 12, Y: 26, W: 12, H: 7},
 		},
-		panels.SingleClusterNodesVMStorageIOPS(datasource),
-		panels.SingleClusterVMsStorageIOPS(datasource),
-		panels.SingleClusterNodesVMStorageTraffic(datasource),
-		panels.SingleClusterVMsStorageTraffic(datasource),
+		panels.SingleClusterNodesVMStorageIOPS(datasource, clusterLabelName),
+		panels.SingleClusterVMsStorageIOPS(datasource, clusterLabelName),
+		panels.SingleClusterNodesVMStorageTraffic(datasource, clusterLabelName),
+		panels.SingleClusterVMsStorageTraffic(datasource, clusterLabelName),
 	)
 }
 
-func withSingleClusterAlerts(datasource string) dashboard.Option {
+func withSingleClusterAlerts(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Alerts", false,
 		[]GridItem{
 			{X: 0, Y: 20, W: 8, H: 3},
@@ -191,32 +177,33 @@ func withSingleClusterAlerts(datasource string) dashboard.Option {
 			{X: 16, Y: 23, W: 8, H: 5},
 			{X: 0, Y: 28, W: 24, H: 11},
 		},
-		panels.SingleClusterCriticalSeverityAlerts(datasource),
-		panels.SingleClusterWarningSeverityAlerts(datasource),
-		panels.SingleClusterInfoSeverityAlerts(datasource),
-		panels.SingleClusterOperatorHealthImpactAlertsTable(datasource),
-		panels.SingleClusterOperatorCSVIssuesTable(datasource),
-		panels.SingleClusterAllAlertsTable(datasource),
+		panels.SingleClusterCriticalSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleClusterWarningSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleClusterInfoSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleClusterOperatorHealthImpactAlertsTable(datasource, clusterLabelName),
+		panels.SingleClusterOperatorCSVIssuesTable(datasource, clusterLabelName),
+		panels.SingleClusterAllAlertsTable(datasource, clusterLabelName),
 	)
 }
 
 // BuildSingleClusterView builds the acm-openshift-virtualization-single-cluster-view Perses dashboard.
-func BuildSingleClusterView(project string, datasource string) (dashboard.Builder, error) {
+func BuildSingleClusterView(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-openshift-virtualization-single-cluster-view",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Cluster Details"),
 		dashboard.Duration(time.Hour),
 
-		singleClusterClusterVariable(datasource),
+		vars.Clusters(variables.ClustersWith(hcoHealthStatus)),
 		singleClusterHealthImpactVariable(datasource),
 		singleClusterSeverityVariable(datasource),
 
-		withSingleClusterGeneralInformation(datasource, project),
-		withSingleClusterAdditionalVMDetails(datasource),
-		withSingleClusterCPUTop20(datasource),
-		withSingleClusterMemoryTop20(datasource),
-		withSingleClusterNetworkTop20(datasource),
-		withSingleClusterStorageTop20(datasource),
-		withSingleClusterAlerts(datasource),
+		withSingleClusterGeneralInformation(datasource, clusterLabelName, project),
+		withSingleClusterAdditionalVMDetails(datasource, clusterLabelName),
+		withSingleClusterCPUTop20(datasource, clusterLabelName),
+		withSingleClusterMemoryTop20(datasource, clusterLabelName),
+		withSingleClusterNetworkTop20(datasource, clusterLabelName),
+		withSingleClusterStorageTop20(datasource, clusterLabelName),
+		withSingleClusterAlerts(datasource, clusterLabelName),
 	)
 }
@@ -6,31 +6,16 @@ import (
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	v1variable "github.com/perses/perses/pkg/model/api/v1/variable"
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func singleVMViewClusterVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("kubevirt_vm_info"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.Description("Filter the virtual machine by its cluster"),
-			listVar.AllowAllValue(false),
-			listVar.AllowMultiple(false),
-			listVar.SortingBy(v1variable.SortAlphabeticalAsc),
-		),
-	)
-}
-
-func singleVMViewNamespaceVariable(datasource string) dashboard.Option {
+func singleVMViewNamespaceVariable(datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable("namespace",
 		listVar.List(
 			labelValuesVar.PrometheusLabelValues("namespace",
 				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers(`kubevirt_vm_info{cluster="$cluster"}`),
+				labelValuesVar.Matchers(panels.WithClusterLabel(`kubevirt_vm_info{cluster="$cluster"}`, clusterLabelName)),
 			),
 			listVar.DisplayName("Namespace"),
 			listVar.Description("Filter the Virtual Machine by its Namespace"),
@@ -41,12 +26,12 @@ func singleVMViewNamespaceVariable(datasource string) dashboard.Option {
 	)
 }
 
-func singleVMViewNameVariable(datasource string) dashboard.Option {
+func singleVMViewNameVariable(datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable("name",
 		listVar.List(
 			labelValuesVar.PrometheusLabelValues("name",
 				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers(`kubevirt_vm_info{cluster="$cluster", namespace="$namespace"}`),
+				labelValuesVar.Matchers(panels.WithClusterLabel(`kubevirt_vm_info{cluster="$cluster", namespace="$namespace"}`, clusterLabelName)),
 			),
 			listVar.DisplayName("VM Name"),
 			listVar.Description("Filter the Virtual Machine by its name"),
@@ -57,7 +42,7 @@ func singleVMViewNameVariable(datasource string) dashboard.Option {
 	)
 }
 
-func withSingleVMGeneralInformation(datasource string) dashboard.Option {
+func withSingleVMGeneralInformation(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("General Information", true,
 		[]GridItem{
 			{X: 0, Y: 1, W: 4, H: 4},
@@ -72,32 +57,32 @@ func withSingleVMGeneralInformation(datasource string) dashboard.Option {
 			{X: 20, Y: 6, W: 4, H: 5},
 			{X: 16, Y: 11, W: 8, H: 7},
 		},
-		panels.SingleVMStatus(datasource),
-		panels.SingleVMCriticalSeverityAlerts(datasource),
-		panels.SingleVMWarningSeverityAlerts(datasource),
-		panels.SingleVMInfoSeverityAlerts(datasource),
-		panels.SingleVMMemoryUsagePercentGauge(datasource),
-		panels.SingleVMCPUUsagePercentGauge(datasource),
-		panels.SingleVMVMInformationTable(datasource),
-		panels.SingleVMGeneralInformationTable(datasource),
-		panels.SingleVMFilesystemUsagePercentGauge(datasource),
-		panels.SingleVMCPUDelayPercentGauge(datasource),
-		panels.SingleVMAllocatedResourcesTable(datasource),
+		panels.SingleVMStatus(datasource, clusterLabelName),
+		panels.SingleVMCriticalSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleVMWarningSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleVMInfoSeverityAlerts(datasource, clusterLabelName),
+		panels.SingleVMMemoryUsagePercentGauge(datasource, clusterLabelName),
+		panels.SingleVMCPUUsagePercentGauge(datasource, clusterLabelName),
+		panels.SingleVMVMInformationTable(datasource, clusterLabelName),
+		panels.SingleVMGeneralInformationTable(datasource, clusterLabelName),
+		panels.SingleVMFilesystemUsagePercentGauge(datasource, clusterLabelName),
+		panels.SingleVMCPUDelayPercentGauge(datasource, clusterLabelName),
+		panels.SingleVMAllocatedResourcesTable(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMAdditionalDetails(datasource string) dashboard.Option {
+func withSingleVMAdditionalDetails(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Additional Virtual Machines Details", false,
 		[]GridItem{
 			{X: 0, Y: 14, W: 12, H: 5},
 			{X: 12, Y: 14, W: 12, H: 5},
 		},
-		panels.SingleVMNetworkTable(datasource),
-		panels.SingleVMSnapshotsTable(datasource),
+		panels.SingleVMNetworkTable(datasource, clusterLabelName),
+		panels.SingleVMSnapshotsTable(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMCPUUtilization(datasource string) dashboard.Option {
+func withSingleVMCPUUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("CPU Utilization", false,
 		[]GridItem{
 			{X: 0, Y: 17, W: 12, H: 6},
@@ -105,25 +90,25 @@ func withSingleVMCPUUtilization(datasource string) dashboard.Option {
 			{X: 0, Y: 23, W: 12, H: 6},
 			{X: 12, Y: 23, W: 12, H: 6},
 		},
-		panels.SingleVMTotalCPUUsage(datasource),
-		panels.SingleVMCPUUsagePercentTimeSeries(datasource),
-		panels.SingleVMCPUReadyTime(datasource),
-		panels.SingleVMCPUDelayPercentTimeSeries(datasource),
+		panels.SingleVMTotalCPUUsage(datasource, clusterLabelName),
+		panels.SingleVMCPUUsagePercentTimeSeries(datasource, clusterLabelName),
+		panels.SingleVMCPUReadyTime(datasource, clusterLabelName),
+		panels.SingleVMCPUDelayPercentTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMMemoryUtilization(datasource string) dashboard.Option {
+func withSingleVMMemoryUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Memory Utilization", false,
 		[]GridItem{
 			{X: 0, Y: 18, W: 12, H: 6},
 			{X: 12, Y: 18, W: 12, H: 6},
 		},
-		panels.SingleVMMemoryUsage(datasource),
-		panels.SingleVMMemoryUsagePercentTimeSeries(datasource),
+		panels.SingleVMMemoryUsage(datasource, clusterLabelName),
+		panels.SingleVMMemoryUsagePercentTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMNetworkUtilization(datasource string) dashboard.Option {
+func withSingleVMNetworkUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Network Utilization", false,
 		[]GridItem{
 			{X: 0, Y: 17, W: 12, H: 6},
@@ -131,61 +116,62 @@ func withSingleVMNetworkUtilization(datasource string) dashboard.Option {
 			{X: 0, Y: 23, W: 12, H: 6},
 			{X: 12, Y: 23, W: 12, H: 6},
 		},
-		panels.SingleVMNetworkTransmit(datasource),
-		panels.SingleVMNetworkReceive(datasource),
-		panels.SingleVMNetworkTransmitPacketsDropped(datasource),
-		panels.SingleVMNetworkReceivePacketsDropped(datasource),
+		panels.SingleVMNetworkTransmit(datasource, clusterLabelName),
+		panels.SingleVMNetworkReceive(datasource, clusterLabelName),
+		panels.SingleVMNetworkTransmitPacketsDropped(datasource, clusterLabelName),
+		panels.SingleVMNetworkReceivePacketsDropped(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMStorageUtilization(datasource string) dashboard.Option {
+func withSingleVMStorageUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Storage Utilization", false,
 		[]GridItem{
 			{X: 0, Y: 20, W: 12, H: 6},
 			{X: 12, Y: 20, W: 12, H: 6},
 		},
-		panels.SingleVMStorageTraffic(datasource),
-		panels.SingleVMStorageIOPs(datasource),
+		panels.SingleVMStorageTraffic(datasource, clusterLabelName),
+		panels.SingleVMStorageIOPs(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMFilesystemUtilization(datasource string) dashboard.Option {
+func withSingleVMFilesystemUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("File System Utilization", false,
 		[]GridItem{
 			{X: 0, Y: 21, W: 12, H: 6},
 			{X: 12, Y: 21, W: 12, H: 6},
 		},
-		panels.SingleVMFilesystemUsage(datasource),
-		panels.SingleVMFilesystemUsagePercentTimeSeries(datasource),
+		panels.SingleVMFilesystemUsage(datasource, clusterLabelName),
+		panels.SingleVMFilesystemUsagePercentTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withSingleVMAlerts(datasource string) dashboard.Option {
+func withSingleVMAlerts(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Alerts", false,
 		[]GridItem{
 			{X: 0, Y: 19, W: 24, H: 6},
 		},
-		panels.SingleVMVMAlertsTable(datasource),
+		panels.SingleVMVMAlertsTable(datasource, clusterLabelName),
 	)
 }
 
 // BuildSingleVMView builds the acm-openshift-virtualization-single-vm-view Perses dashboard.
-func BuildSingleVMView(project string, datasource string) (dashboard.Builder, error) {
+func BuildSingleVMView(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-openshift-virtualization-single-vm-view",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Virtual Machine Details"),
 
-		singleVMViewClusterVariable(datasource),
-		singleVMViewNamespaceVariable(datasource),
-		singleVMViewNameVariable(datasource),
+		vars.Clusters(variables.ClustersWith(vmInfo)),
+		singleVMViewNamespaceVariable(datasource, clusterLabelName),
+		singleVMViewNameVariable(datasource, clusterLabelName),
 
-		withSingleVMGeneralInformation(datasource),
-		withSingleVMAdditionalDetails(datasource),
-		withSingleVMCPUUtilization(datasource),
-		withSingleVMMemoryUtilization(datasource),
-		withSingleVMNetworkUtilization(datasource),
-		withSingleVMStorageUtilization(datasource),
-		withSingleVMFilesystemUtilization(datasource),
-		withSingleVMAlerts(datasource),
+		withSingleVMGeneralInformation(datasource, clusterLabelName),
+		withSingleVMAdditionalDetails(datasource, clusterLabelName),
+		withSingleVMCPUUtilization(datasource, clusterLabelName),
+		withSingleVMMemoryUtilization(datasource, clusterLabelName),
+		withSingleVMNetworkUtilization(datasource, clusterLabelName),
+		withSingleVMStorageUtilization(datasource, clusterLabelName),
+		withSingleVMFilesystemUtilization(datasource, clusterLabelName),
+		withSingleVMAlerts(datasource, clusterLabelName),
 	)
 }
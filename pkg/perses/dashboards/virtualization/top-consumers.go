@@ -4,6 +4,7 @@ import (
 	"github.com/perses/perses/go-sdk/dashboard"
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	promqlVar "github.com/perses/plugins/prometheus/sdk/go/variable/promql"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
@@ -17,10 +18,10 @@ var topConsumersPairedGrid = []GridItem{
 // PrometheusPromQL query. It evaluates expr (typically topk($topn, ...)),
 // extracts the "name" label from the results, selects all values, and
 // joins them into a pipe-separated regex for use in name=~"$varName".
-func topNHiddenVariable(varName, expr, datasource string) dashboard.Option {
+func topNHiddenVariable(varName, expr, datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable(varName,
 		listVar.List(
-			promqlVar.PrometheusPromQL(expr,
+			promqlVar.PrometheusPromQL(panels.WithClusterLabel(expr, clusterLabelName),
 				promqlVar.LabelName("name"),
 				promqlVar.Datasource(datasource),
 			),
@@ -32,59 +33,59 @@ func topNHiddenVariable(varName, expr, datasource string) dashboard.Option {
 	)
 }
 
-func withTopConsumersMemoryGroup(datasource, project string) dashboard.Option {
+func withTopConsumersMemoryGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Memory",
 		topConsumersPairedGrid,
-		panels.TopConsumersMemoryTable(datasource, project),
-		panels.TopConsumersMemoryTimeSeries(datasource),
+		panels.TopConsumersMemoryTable(datasource, clusterLabelName, project),
+		panels.TopConsumersMemoryTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersCPUGroup(datasource, project string) dashboard.Option {
+func withTopConsumersCPUGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("CPU",
 		topConsumersPairedGrid,
-		panels.TopConsumersCPUTable(datasource, project),
-		panels.TopConsumersCPUTimeSeries(datasource),
+		panels.TopConsumersCPUTable(datasource, clusterLabelName, project),
+		panels.TopConsumersCPUTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersStorageTrafficGroup(datasource, project string) dashboard.Option {
+func withTopConsumersStorageTrafficGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Storage Traffic",
 		topConsumersPairedGrid,
-		panels.TopConsumersStorageTrafficTable(datasource, project),
-		panels.TopConsumersStorageTrafficTimeSeries(datasource),
+		panels.TopConsumersStorageTrafficTable(datasource, clusterLabelName, project),
+		panels.TopConsumersStorageTrafficTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersStorageIOPSGroup(datasource, project string) dashboard.Option {
+func withTopConsumersStorageIOPSGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Storage IOPS",
 		topConsumersPairedGrid,
-		panels.TopConsumersStorageIOPSTable(datasource, project),
-		panels.TopConsumersStorageIOPSTimeSeries(datasource),
+		panels.TopConsumersStorageIOPSTable(datasource, clusterLabelName, project),
+		panels.TopConsumersStorageIOPSTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersNetworkTrafficGroup(datasource, project string) dashboard.Option {
+func withTopConsumersNetworkTrafficGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Network Traffic",
 		topConsumersPairedGrid,
-		panels.TopConsumersNetworkTrafficTable(datasource, project),
-		panels.TopConsumersNetworkTrafficTimeSeries(datasource),
+		panels.TopConsumersNetworkTrafficTable(datasource, clusterLabelName, project),
+		panels.TopConsumersNetworkTrafficTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersVCPUWaitGroup(datasource, project string) dashboard.Option {
+func withTopConsumersVCPUWaitGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("vCPU Wait",
 		topConsumersPairedGrid,
-		panels.TopConsumersVCPUWaitTable(datasource, project),
-		panels.TopConsumersVCPUWaitTimeSeries(datasource),
+		panels.TopConsumersVCPUWaitTable(datasource, clusterLabelName, project),
+		panels.TopConsumersVCPUWaitTimeSeries(datasource, clusterLabelName),
 	)
 }
 
-func withTopConsumersMemorySwapGroup(datasource, project string) dashboard.Option {
+func withTopConsumersMemorySwapGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Memory Swap Traffic",
 		topConsumersPairedGrid,
-		panels.TopConsumersMemorySwapTable(datasource, project),
-		panels.TopConsumersMemorySwapTimeSeries(datasource),
+		panels.TopConsumersMemorySwapTable(datasource, clusterLabelName, project),
+		panels.TopConsumersMemorySwapTimeSeries(datasource, clusterLabelName),
 	)
 }
 
@@ -92,13 +93,14 @@ func withTopConsumersMemorySwapGroup(datasource, project string) dashboard.Optio
 // resource consumers across clusters, adapted from the Grafana
 // "KubeVirt / Infrastructure Resources / Top Consumers" dashboard.
 // Each resource type is a collapsible group with a table and time-series pair.
-func BuildTopConsumers(project string, datasource string) (dashboard.Builder, error) {
+func BuildTopConsumers(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-virtual-machines-top-consumers",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Top Consumers"),
 
-		VMClusterVariable(datasource),
-		VMNamespaceVariable(datasource),
+		vars.Clusters(variables.ClustersWith(vmInfo)),
+		VMNamespaceVariable(datasource, clusterLabelName),
 		AddStaticListVariable("topn", "Top N", "Number of top consumers to display",
 			[]StaticListValue{
 				{Label: "5", Value: "5"},
@@ -109,20 +111,20 @@ func BuildTopConsumers(project string, datasource string) (dashboard.Builder, er
 			"5", false, false, "",
 		),
 
-		topNHiddenVariable(panels.TopNMemoryVarName, panels.TopNMemoryVarExpr, datasource),
-		topNHiddenVariable(panels.TopNCPUVarName, panels.TopNCPUVarExpr, datasource),
-		topNHiddenVariable(panels.TopNStorageTrafficVarName, panels.TopNStorageTrafficVarExpr, datasource),
-		topNHiddenVariable(panels.TopNStorageIOPSVarName, panels.TopNStorageIOPSVarExpr, datasource),
-		topNHiddenVariable(panels.TopNNetworkTrafficVarName, panels.TopNNetworkTrafficVarExpr, datasource),
-		topNHiddenVariable(panels.TopNVCPUWaitVarName, panels.TopNVCPUWaitVarExpr, datasource),
-		topNHiddenVariable(panels.TopNMemorySwapVarName, panels.TopNMemorySwapVarExpr, datasource),
+		topNHiddenVariable(panels.TopNMemoryVarName, panels.TopNMemoryVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNCPUVarName, panels.TopNCPUVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNStorageTrafficVarName, panels.TopNStorageTrafficVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNStorageIOPSVarName, panels.TopNStorageIOPSVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNNetworkTrafficVarName, panels.TopNNetworkTrafficVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNVCPUWaitVarName, panels.TopNVCPUWaitVarExpr, datasource, clusterLabelName),
+		topNHiddenVariable(panels.TopNMemorySwapVarName, panels.TopNMemorySwapVarExpr, datasource, clusterLabelName),
 
-		withTopConsumersMemoryGroup(datasource, project),
-		withTopConsumersCPUGroup(datasource, project),
-		withTopConsumersStorageTrafficGroup(datasource, project),
-		withTopConsumersStorageIOPSGroup(datasource, project),
-		withTopConsumersNetworkTrafficGroup(datasource, project),
-		withTopConsumersVCPUWaitGroup(datasource, project),
-		withTopConsumersMemorySwapGroup(datasource, project),
+		withTopConsumersMemoryGroup(datasource, clusterLabelName, project),
+		withTopConsumersCPUGroup(datasource, clusterLabelName, project),
+		withTopConsumersStorageTrafficGroup(datasource, clusterLabelName, project),
+		withTopConsumersStorageIOPSGroup(datasource, clusterLabelName, project),
+		withTopConsumersNetworkTrafficGroup(datasource, clusterLabelName, project),
+		withTopConsumersVCPUWaitGroup(datasource, clusterLabelName, project),
+		withTopConsumersMemorySwapGroup(datasource, clusterLabelName, project),
 	)
 }
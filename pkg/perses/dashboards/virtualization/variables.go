@@ -7,39 +7,18 @@ import (
 	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
 	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
 	promqlVar "github.com/perses/plugins/prometheus/sdk/go/variable/promql"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
+	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func VMClusterVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("kubevirt_vm_info"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.AllowAllValue(false),
-			listVar.AllowMultiple(false),
-		),
-	)
-}
-
-func VMClusterVariableMulti(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("kubevirt_vm_info"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.AllowAllValue(true),
-			listVar.AllowMultiple(true),
-			listVar.CustomAllValue(".*"),
-			listVar.DefaultValues("$__all"),
-		),
-	)
-}
+// The series listing the clusters of the cluster variables: the clusters
+// running virtual machines, or OpenShift Virtualization.
+const (
+	vmInfo          = "kubevirt_vm_info"
+	hcoHealthStatus = "kubevirt_hyperconverged_operator_health_status"
+)
 
-func VMNamespaceVariable(datasource string) dashboard.Option {
+func VMNamespaceVariable(datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable("namespace",
 		listVar.List(
 			labelValuesVar.PrometheusLabelValues("namespace",
@@ -47,7 +26,7 @@ func VMNamespaceVariable(datasource string) dashboard.Option {
 				labelValuesVar.Matchers(
 					promql.SetLabelMatchers(
 						"kubevirt_vm_info",
-						[]promql.LabelMatcher{{Name: "cluster", Type: "=~", Value: "$cluster"}},
+						[]promql.LabelMatcher{variables.New(datasource, clusterLabelName).ClusterMatcher()},
 					),
 				),
 			),
@@ -61,7 +40,7 @@ func VMNamespaceVariable(datasource string) dashboard.Option {
 	)
 }
 
-func VMNameVariable(datasource string) dashboard.Option {
+func VMNameVariable(datasource, clusterLabelName string) dashboard.Option {
 	return dashboard.AddVariable("name",
 		listVar.List(
 			labelValuesVar.PrometheusLabelValues("name",
@@ -70,7 +49,7 @@ func VMNameVariable(datasource string) dashboard.Option {
 					promql.SetLabelMatchers(
 						"kubevirt_vm_info",
 						[]promql.LabelMatcher{
-							{Name: "cluster", Type: "=~", Value: "$cluster"},
+							variables.New(datasource, clusterLabelName).ClusterMatcher(),
 							{Name: "namespace", Type: "=~", Value: "$namespace"},
 						},
 					),
@@ -120,17 +99,17 @@ func VMStatusVariableStaticSingleSelect() dashboard.Option {
 //
 // See: https://perses.dev/perses/docs/api/variable/
 // Upstream limitation: https://github.com/perses/perses/issues/2016
-func VMStatusVariableJoinExpr() dashboard.Option {
+func VMStatusVariableJoinExpr(clusterLabelName string) dashboard.Option {
 	return AddStaticListVariable("status", "Status", "Filter virtual machines by status",
 		[]StaticListValue{
-			{Label: "All", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{})))`},
-			{Label: "Stopped", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="non_running"}>0)))`},
-			{Label: "Starting", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="starting"}>0)))`},
-			{Label: "Migrating", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="migrating"}>0)))`},
-			{Label: "Error", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="error"}>0)))`},
-			{Label: "Running", Value: `on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="running"}>0)))`},
+			{Label: "All", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{})))`, clusterLabelName)},
+			{Label: "Stopped", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="non_running"}>0)))`, clusterLabelName)},
+			{Label: "Starting", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="starting"}>0)))`, clusterLabelName)},
+			{Label: "Migrating", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="migrating"}>0)))`, clusterLabelName)},
+			{Label: "Error", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="error"}>0)))`, clusterLabelName)},
+			{Label: "Running", Value: panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{status_group="running"}>0)))`, clusterLabelName)},
 		},
-		`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{})))`,
+		panels.WithClusterLabel(`on(cluster,name,namespace) group_left()(0*(sum by(cluster,namespace,name)(kubevirt_vm_info{})))`, clusterLabelName),
 		false, false, "",
 	)
 }
@@ -139,14 +118,14 @@ func VMStatusVariableJoinExpr() dashboard.Option {
 // of a VMI label from kubevirt_vmi_info. expr overrides the default
 // per-label query when the variable needs a custom metric union (e.g. guest OS
 // fields that also appear in kubevirt_vm_info).
-func vmiLabelListVariable(name, displayName, datasource, expr string) dashboard.Option {
+func vmiLabelListVariable(name, displayName, datasource, clusterLabelName, expr string) dashboard.Option {
 	if expr == "" {
 		expr = `sum by (` + name + `)(kubevirt_vmi_info{cluster=~"$cluster", namespace=~"$namespace",` + name + `!="<none>"})`
 	}
 	return dashboard.AddVariable(name,
 		listVar.List(
 			promqlVar.PrometheusPromQL(
-				expr,
+				panels.WithClusterLabel(expr, clusterLabelName),
 				promqlVar.LabelName(name),
 				promqlVar.Datasource(datasource),
 			),
@@ -159,28 +138,28 @@ func vmiLabelListVariable(name, displayName, datasource, expr string) dashboard.
 	)
 }
 
-func VMFlavorVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("flavor", "Flavor", datasource, "")
+func VMFlavorVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("flavor", "Flavor", datasource, clusterLabelName, "")
 }
 
-func VMWorkloadVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("workload", "Workload", datasource, "")
+func VMWorkloadVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("workload", "Workload", datasource, clusterLabelName, "")
 }
 
-func VMInstanceTypeVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("instance_type", "Instance Type", datasource, "")
+func VMInstanceTypeVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("instance_type", "Instance Type", datasource, clusterLabelName, "")
 }
 
-func VMPreferenceVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("preference", "Preference", datasource, "")
+func VMPreferenceVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("preference", "Preference", datasource, clusterLabelName, "")
 }
 
-func VMGuestOSNameVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("guest_os_name", "OS Name", datasource,
+func VMGuestOSNameVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("guest_os_name", "OS Name", datasource, clusterLabelName,
 		`sum by (guest_os_name)(kubevirt_vm_info{cluster=~"$cluster", namespace=~"$namespace",guest_os_name!="<none>"} or kubevirt_vmi_info{cluster=~"$cluster", namespace=~"$namespace",guest_os_name!="<none>"})`)
 }
 
-func VMGuestOSVersionVariable(datasource string) dashboard.Option {
-	return vmiLabelListVariable("guest_os_version_id", "OS Version", datasource,
+func VMGuestOSVersionVariable(datasource, clusterLabelName string) dashboard.Option {
+	return vmiLabelListVariable("guest_os_version_id", "OS Version", datasource, clusterLabelName,
 		`sum by (guest_os_version_id)(kubevirt_vm_info{cluster=~"$cluster", namespace=~"$namespace",guest_os_version_id!="<none>"} or kubevirt_vmi_info{cluster=~"$cluster", namespace=~"$namespace",guest_os_version_id!="<none>"})`)
 }
@@ -1,32 +1,11 @@
 package virtualization
 
 import (
-	"github.com/perses/community-mixins/pkg/dashboards"
 	"github.com/perses/perses/go-sdk/dashboard"
-	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
-	"github.com/perses/perses/pkg/model/api/v1/variable"
-	labelValuesVar "github.com/perses/plugins/prometheus/sdk/go/variable/label-values"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func virtOverviewClusterVariable(datasource string) dashboard.Option {
-	return dashboard.AddVariable("cluster",
-		listVar.List(
-			labelValuesVar.PrometheusLabelValues("cluster",
-				dashboards.AddVariableDatasource(datasource),
-				labelValuesVar.Matchers("kubevirt_hyperconverged_operator_health_status"),
-			),
-			listVar.DisplayName("Cluster"),
-			listVar.Hidden(false),
-			listVar.AllowAllValue(true),
-			listVar.AllowMultiple(true),
-			listVar.CustomAllValue(".*"),
-			listVar.DefaultValues("$__all"),
-			listVar.SortingBy(variable.SortAlphabeticalAsc),
-		),
-	)
-}
-
 // NOTE: The "operator_health" variable values are literal PromQL comparison
 // operator strings (e.g. "==0", "==1", "==2", "<3"). Consuming queries
 // concatenate these directly into metric selectors, e.g.:
@@ -52,7 +31,7 @@ func virtOverviewOperatorHealthVariable() dashboard.Option {
 	)
 }
 
-func withVirtOverviewGeneralInformation(datasource, project string) dashboard.Option {
+func withVirtOverviewGeneralInformation(datasource, clusterLabelName, project string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("General Information", true,
 		[]GridItem{
 			// Row 1: five stat panels, W:4 each
@@ -72,32 +51,32 @@ func withVirtOverviewGeneralInformation(datasource, project string) dashboard.Op
 			{X: 6, Y: 7, W: 9, H: 7},
 			{X: 15, Y: 7, W: 9, H: 7},
 		},
-		panels.OverviewTotalClusters(datasource),
-		panels.OverviewClustersCriticalHealth(datasource),
-		panels.OverviewClustersWarningHealth(datasource),
-		panels.OverviewTotalAllocatableNodes(datasource),
-		panels.OverviewTotalVMsStat(datasource, project),
-		panels.OverviewVMsRunning(datasource, project),
-		panels.OverviewVMsInErrorStat(datasource, project),
-		panels.OverviewVMsStopped(datasource, project),
-		panels.OverviewVMsStarting(datasource, project),
-		panels.OverviewVMsMigrating(datasource, project),
-		panels.OverviewVMsStartedLast7Days(datasource, project),
-		panels.OverviewClustersByOperatorVersion(datasource),
-		panels.OverviewClustersByOpenShiftVersion(datasource),
+		panels.OverviewTotalClusters(datasource, clusterLabelName),
+		panels.OverviewClustersCriticalHealth(datasource, clusterLabelName),
+		panels.OverviewClustersWarningHealth(datasource, clusterLabelName),
+		panels.OverviewTotalAllocatableNodes(datasource, clusterLabelName),
+		panels.OverviewTotalVMsStat(datasource, clusterLabelName, project),
+		panels.OverviewVMsRunning(datasource, clusterLabelName, project),
+		panels.OverviewVMsInErrorStat(datasource, clusterLabelName, project),
+		panels.OverviewVMsStopped(datasource, clusterLabelName, project),
+		panels.OverviewVMsStarting(datasource, clusterLabelName, project),
+		panels.OverviewVMsMigrating(datasource, clusterLabelName, project),
+		panels.OverviewVMsStartedLast7Days(datasource, clusterLabelName, project),
+		panels.OverviewClustersByOperatorVersion(datasource, clusterLabelName),
+		panels.OverviewClustersByOpenShiftVersion(datasource, clusterLabelName),
 	)
 }
 
-func withVirtOverviewOperatorHealth(datasource, project string) dashboard.Option {
+func withVirtOverviewOperatorHealth(datasource, clusterLabelName, project string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Operator Health", false,
 		[]GridItem{
 			{X: 0, Y: 15, W: 24, H: 8},
 		},
-		panels.OverviewOperatorHealthByCluster(datasource, project),
+		panels.OverviewOperatorHealthByCluster(datasource, clusterLabelName, project),
 	)
 }
 
-func withVirtOverviewAdditionalVMDetails(datasource string) dashboard.Option {
+func withVirtOverviewAdditionalVMDetails(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Additional Virtual Machines Details", false,
 		[]GridItem{
 			{X: 0, Y: 16, W: 12, H: 7},
@@ -105,72 +84,73 @@ func withVirtOverviewAdditionalVMDetails(datasource string) dashboard.Option {
 			{X: 0, Y: 23, W: 12, H: 7},
 			{X: 12, Y: 23, W: 12, H: 7},
 		},
-		panels.OverviewRunningVMsByOS(datasource),
-		panels.OverviewRunningVMsByClusterTop20(datasource),
-		panels.OverviewVMsByStatusTimeSeries(datasource),
-		panels.OverviewRunningVMsByNodeTop20(datasource),
+		panels.OverviewRunningVMsByOS(datasource, clusterLabelName),
+		panels.OverviewRunningVMsByClusterTop20(datasource, clusterLabelName),
+		panels.OverviewVMsByStatusTimeSeries(datasource, clusterLabelName),
+		panels.OverviewRunningVMsByNodeTop20(datasource, clusterLabelName),
 	)
 }
 
-func withVirtOverviewCPUUtilization(datasource string) dashboard.Option {
+func withVirtOverviewCPUUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("CPU Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 17, W: 12, H: 7},
 			{X: 12, Y: 17, W: 12, H: 7},
 		},
-		panels.OverviewCPUUsageByCluster(datasource),
-		panels.OverviewCPUUsagePercentByCluster(datasource),
+		panels.OverviewCPUUsageByCluster(datasource, clusterLabelName),
+		panels.OverviewCPUUsagePercentByCluster(datasource, clusterLabelName),
 	)
 }
 
-func withVirtOverviewMemoryUtilization(datasource string) dashboard.Option {
+func withVirtOverviewMemoryUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Memory Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 18, W: 12, H: 7},
 			{X: 12, Y: 18, W: 12, H: 7},
 		},
-		panels.OverviewMemoryUsageByCluster(datasource),
-		panels.OverviewMemoryUsagePercentByCluster(datasource),
+		panels.OverviewMemoryUsageByCluster(datasource, clusterLabelName),
+		panels.OverviewMemoryUsagePercentByCluster(datasource, clusterLabelName),
 	)
 }
 
-func withVirtOverviewNetworkUtilization(datasource string) dashboard.Option {
+func withVirtOverviewNetworkUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Network Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 19, W: 12, H: 7},
 			{X: 12, Y: 19, W: 12, H: 7},
 		},
-		panels.OverviewNetworkReceivedByCluster(datasource),
-		panels.OverviewNetworkTransmittedByCluster(datasource),
+		panels.OverviewNetworkReceivedByCluster(datasource, clusterLabelName),
+		panels.OverviewNetworkTransmittedByCluster(datasource, clusterLabelName),
 	)
 }
 
-func withVirtOverviewStorageUtilization(datasource string) dashboard.Option {
+func withVirtOverviewStorageUtilization(datasource, clusterLabelName string) dashboard.Option {
 	return addCustomPanelGroupWithCollapse("Storage Utilization - Top 20", false,
 		[]GridItem{
 			{X: 0, Y: 20, W: 12, H: 7},
 			{X: 12, Y: 20, W: 12, H: 7},
 		},
-		panels.OverviewStorageTrafficByCluster(datasource),
-		panels.OverviewStorageIOPsByCluster(datasource),
+		panels.OverviewStorageTrafficByCluster(datasource, clusterLabelName),
+		panels.OverviewStorageIOPsByCluster(datasource, clusterLabelName),
 	)
 }
 
 // BuildVirtOverview builds the acm-openshift-virtualization-overview Perses dashboard.
-func BuildVirtOverview(project string, datasource string) (dashboard.Builder, error) {
+func BuildVirtOverview(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-openshift-virtualization-overview",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Clusters Overview"),
 
-		virtOverviewClusterVariable(datasource),
+		vars.Clusters(variables.Multi(), variables.ClustersWith(hcoHealthStatus)),
 		virtOverviewOperatorHealthVariable(),
 
-		withVirtOverviewGeneralInformation(datasource, project),
-		withVirtOverviewOperatorHealth(datasource, project),
-		withVirtOverviewAdditionalVMDetails(datasource),
-		withVirtOverviewCPUUtilization(datasource),
-		withVirtOverviewMemoryUtilization(datasource),
-		withVirtOverviewNetworkUtilization(datasource),
-		withVirtOverviewStorageUtilization(datasource),
+		withVirtOverviewGeneralInformation(datasource, clusterLabelName, project),
+		withVirtOverviewOperatorHealth(datasource, clusterLabelName, project),
+		withVirtOverviewAdditionalVMDetails(datasource, clusterLabelName),
+		withVirtOverviewCPUUtilization(datasource, clusterLabelName),
+		withVirtOverviewMemoryUtilization(datasource, clusterLabelName),
+		withVirtOverviewNetworkUtilization(datasource, clusterLabelName),
+		withVirtOverviewStorageUtilization(datasource, clusterLabelName),
 	)
 }
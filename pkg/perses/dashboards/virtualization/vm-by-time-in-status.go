@@ -2,10 +2,11 @@ package virtualization
 
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func withTimeInStatusStatsAndTable(datasource, project string) dashboard.Option {
+func withTimeInStatusStatsAndTable(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Time In Status",
 		[]GridItem{
 			{X: 0, Y: 0, W: 8, H: 3},
@@ -13,27 +14,28 @@ func withTimeInStatusStatsAndTable(datasource, project string) dashboard.Option
 			{X: 16, Y: 0, W: 8, H: 3},
 			{X: 0, Y: 3, W: 24, H: 16},
 		},
-		panels.TotalAllocatedCPU(datasource),
-		panels.TotalAllocatedMemory(datasource),
-		panels.TotalAllocatedDisk(datasource),
-		panels.TimeInStatusTable(datasource, project),
+		panels.TotalAllocatedCPU(datasource, clusterLabelName),
+		panels.TotalAllocatedMemory(datasource, clusterLabelName),
+		panels.TotalAllocatedDisk(datasource, clusterLabelName),
+		panels.TimeInStatusTable(datasource, clusterLabelName, project),
 	)
 }
 
-func BuildVMByTimeInStatus(project string, datasource string) (dashboard.Builder, error) {
+func BuildVMByTimeInStatus(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-virtual-machines-by-time-in-status",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Virtual Machines Time in Status"),
 
-		VMClusterVariableMulti(datasource),
-		VMNamespaceVariable(datasource),
-		VMNameVariable(datasource),
+		vars.Clusters(variables.Multi(), variables.ClustersWith(vmInfo)),
+		VMNamespaceVariable(datasource, clusterLabelName),
+		VMNameVariable(datasource, clusterLabelName),
 		VMStatusVariableStaticSingleSelect(),
 		AddTextVariable("days_in_status_gt", "0", "Days in Status >",
 			"Filter the Virtual Machines that are in the specific status for more than the selected number of days"),
 		AddTextVariable("days_in_status_lt", "1000", "Days in Status <",
 			"Filter the Virtual Machines that are in the specific status for less than the selected number of days"),
 
-		withTimeInStatusStatsAndTable(datasource, project),
+		withTimeInStatusStatsAndTable(datasource, clusterLabelName, project),
 	)
 }
@@ -2,34 +2,36 @@ package virtualization
 
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func withInventoryGroup(datasource, project string) dashboard.Option {
+func withInventoryGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Virtual Machines Inventory",
 		[]GridItem{
 			{X: 0, Y: 0, W: 24, H: 24},
 		},
-		panels.VMInventoryTable(datasource, project),
+		panels.VMInventoryTable(datasource, clusterLabelName, project),
 	)
 }
 
-func BuildVMInventory(project string, datasource string) (dashboard.Builder, error) {
+func BuildVMInventory(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-virtual-machines-inventory",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Virtual Machines Inventory"),
 
-		VMClusterVariable(datasource),
-		VMNamespaceVariable(datasource),
-		VMNameVariable(datasource),
+		vars.Clusters(variables.ClustersWith(vmInfo)),
+		VMNamespaceVariable(datasource, clusterLabelName),
+		VMNameVariable(datasource, clusterLabelName),
 		VMStatusVariableStatic(),
-		VMFlavorVariable(datasource),
-		VMWorkloadVariable(datasource),
-		VMInstanceTypeVariable(datasource),
-		VMPreferenceVariable(datasource),
-		VMGuestOSNameVariable(datasource),
-		VMGuestOSVersionVariable(datasource),
+		VMFlavorVariable(datasource, clusterLabelName),
+		VMWorkloadVariable(datasource, clusterLabelName),
+		VMInstanceTypeVariable(datasource, clusterLabelName),
+		VMPreferenceVariable(datasource, clusterLabelName),
+		VMGuestOSNameVariable(datasource, clusterLabelName),
+		VMGuestOSVersionVariable(datasource, clusterLabelName),
 
-		withInventoryGroup(datasource, project),
+		withInventoryGroup(datasource, clusterLabelName, project),
 	)
 }
@@ -2,10 +2,11 @@ package virtualization
 
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func withServiceLevelStatsAndTable(datasource, project string) dashboard.Option {
+func withServiceLevelStatsAndTable(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Service Level",
 		[]GridItem{
 			{X: 0, Y: 0, W: 8, H: 3},
@@ -16,26 +17,27 @@ func withServiceLevelStatsAndTable(datasource, project string) dashboard.Option
 			{X: 16, Y: 3, W: 8, H: 3},
 			{X: 0, Y: 6, W: 24, H: 16},
 		},
-		panels.TotalUptimePercent(datasource),
-		panels.TotalPlannedDowntimePercent(datasource),
-		panels.TotalUnplannedDowntimePercent(datasource),
-		panels.TotalUptimeHours(datasource),
-		panels.TotalPlannedDowntimeHours(datasource),
-		panels.TotalUnplannedDowntimeHours(datasource),
-		panels.ServiceLevelTable(datasource, project),
+		panels.TotalUptimePercent(datasource, clusterLabelName),
+		panels.TotalPlannedDowntimePercent(datasource, clusterLabelName),
+		panels.TotalUnplannedDowntimePercent(datasource, clusterLabelName),
+		panels.TotalUptimeHours(datasource, clusterLabelName),
+		panels.TotalPlannedDowntimeHours(datasource, clusterLabelName),
+		panels.TotalUnplannedDowntimeHours(datasource, clusterLabelName),
+		panels.ServiceLevelTable(datasource, clusterLabelName, project),
 	)
 }
 
-func BuildVMServiceLevel(project string, datasource string) (dashboard.Builder, error) {
+func BuildVMServiceLevel(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-virtual-machines-service-level",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Virtual Machines Service Level"),
 
-		VMClusterVariableMulti(datasource),
-		VMNamespaceVariable(datasource),
-		VMNameVariable(datasource),
+		vars.Clusters(variables.Multi(), variables.ClustersWith(vmInfo)),
+		VMNamespaceVariable(datasource, clusterLabelName),
+		VMNameVariable(datasource, clusterLabelName),
 		VMStatusVariableStaticSingleSelect(),
 
-		withServiceLevelStatsAndTable(datasource, project),
+		withServiceLevelStatsAndTable(datasource, clusterLabelName, project),
 	)
 }
@@ -2,28 +2,30 @@ package virtualization
 
 import (
 	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/stolostron/multicluster-observability-addon/pkg/perses/dashboards/variables"
 	panels "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/virtualization"
 )
 
-func withUtilizationGroup(datasource, project string) dashboard.Option {
+func withUtilizationGroup(datasource, clusterLabelName, project string) dashboard.Option {
 	return AddCustomPanelGroup("Virtual Machines Utilization",
 		[]GridItem{
 			{X: 0, Y: 0, W: 24, H: 24},
 		},
-		panels.VMUtilizationTable(datasource, project),
+		panels.VMUtilizationTable(datasource, clusterLabelName, project),
 	)
 }
 
-func BuildVMUtilization(project string, datasource string) (dashboard.Builder, error) {
+func BuildVMUtilization(project string, datasource string, clusterLabelName string) (dashboard.Builder, error) {
+	vars := variables.New(datasource, clusterLabelName)
 	return dashboard.New("acm-virtual-machines-utilization",
 		dashboard.ProjectName(project),
 		dashboard.Name("Virtualization / Virtual Machines Utilization"),
 
-		VMClusterVariable(datasource),
-		VMNamespaceVariable(datasource),
-		VMNameVariable(datasource),
+		vars.Clusters(variables.ClustersWith(vmInfo)),
+		VMNamespaceVariable(datasource, clusterLabelName),
+		VMNameVariable(datasource, clusterLabelName),
 		VMStatusVariableStatic(),
 
-		withUtilizationGroup(datasource, project),
+		withUtilizationGroup(datasource, clusterLabelName, project),
 	)
 }
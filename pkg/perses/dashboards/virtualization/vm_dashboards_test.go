@@ -2,55 +2,57 @@ package virtualization
 
 import (
 	"encoding/json"
+	"regexp"
 	"testing"
 
+	"github.com/perses/perses/go-sdk/dashboard"
 	"github.com/perses/perses/pkg/model/api/v1/variable"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestBuildVMInventory(t *testing.T) {
-	_, err := BuildVMInventory("test-project", "test-datasource")
+	_, err := BuildVMInventory("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildVMUtilization(t *testing.T) {
-	_, err := BuildVMUtilization("test-project", "test-datasource")
+	_, err := BuildVMUtilization("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildVMServiceLevel(t *testing.T) {
-	_, err := BuildVMServiceLevel("test-project", "test-datasource")
+	_, err := BuildVMServiceLevel("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildVMByTimeInStatus(t *testing.T) {
-	_, err := BuildVMByTimeInStatus("test-project", "test-datasource")
+	_, err := BuildVMByTimeInStatus("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildNodeMemoryOverview(t *testing.T) {
-	_, err := BuildNodeMemoryOverview("test-project", "test-datasource")
+	_, err := BuildNodeMemoryOverview("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildVirtOverview(t *testing.T) {
-	_, err := BuildVirtOverview("test-project", "test-datasource")
+	_, err := BuildVirtOverview("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildSingleClusterView(t *testing.T) {
-	_, err := BuildSingleClusterView("test-project", "test-datasource")
+	_, err := BuildSingleClusterView("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildSingleVMView(t *testing.T) {
-	_, err := BuildSingleVMView("test-project", "test-datasource")
+	_, err := BuildSingleVMView("test-project", "test-datasource", "")
 	require.NoError(t, err)
 }
 
 func TestBuildTopConsumers(t *testing.T) {
-	b, err := BuildTopConsumers("test-project", "test-datasource")
+	b, err := BuildTopConsumers("test-project", "test-datasource", "")
 	require.NoError(t, err)
 
 	spec := b.Dashboard.Spec
@@ -87,9 +89,10 @@ func TestBuildTopConsumers(t *testing.T) {
 	}
 	assert.Equal(t, []string{"5", "10", "20", "50"}, topnValues, "topn variable values")
 
-	// 3 visible variables (cluster, namespace, topn) + 7 hidden topN
-	// variables (one per resource category).
-	assert.Len(t, spec.Variables, 10, "expected 10 variables (3 visible + 7 hidden)")
+	// 6 visible variables (clusterset, cluster_label, cluster_label_value,
+	// cluster, namespace, topn) + 7 hidden topN variables (one per resource
+	// category).
+	assert.Len(t, spec.Variables, 13, "expected 13 variables (6 visible + 7 hidden)")
 
 	// Verify all 7 hidden topN variables exist by name.
 	hiddenVarNames := map[string]bool{}
@@ -115,3 +118,40 @@ func TestBuildTopConsumers(t *testing.T) {
 		assert.True(t, hiddenVarNames[name], "missing hidden variable %s", name)
 	}
 }
+
+func TestBuildVirtualizationDashboards_ClusterLabel(t *testing.T) {
+	clusterLabelRef := regexp.MustCompile(`(^|[^$\w-])cluster\b`)
+	for name, build := range map[string]func(string, string, string) (dashboard.Builder, error){
+		"NodeMemoryOverview": BuildNodeMemoryOverview,
+		"SingleClusterView":  BuildSingleClusterView,
+		"SingleVMView":       BuildSingleVMView,
+		"TopConsumers":       BuildTopConsumers,
+		"VirtOverview":       BuildVirtOverview,
+		"VMByTimeInStatus":   BuildVMByTimeInStatus,
+		"VMInventory":        BuildVMInventory,
+		"VMServiceLevel":     BuildVMServiceLevel,
+		"VMUtilization":      BuildVMUtilization,
+	} {
+		t.Run(name, func(t *testing.T) {
+			b, err := build("test-project", "test-datasource", "managed_cluster")
+			require.NoError(t, err)
+
+			var queries int
+			for ref, p := range b.Dashboard.Spec.Panels {
+				for _, q := range p.Spec.Queries {
+					raw, err := json.Marshal(q.Spec.Plugin.Spec)
+					require.NoError(t, err)
+					var spec struct {
+						Query            string `json:"query"`
+						SeriesNameFormat string `json:"seriesNameFormat"`
+					}
+					require.NoError(t, json.Unmarshal(raw, &spec))
+					assert.NotRegexp(t, clusterLabelRef, spec.Query, "panel %s", ref)
+					assert.NotRegexp(t, clusterLabelRef, spec.SeriesNameFormat, "panel %s", ref)
+					queries++
+				}
+			}
+			assert.Positive(t, queries)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package acm
+
+import (
+	"fmt"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	"github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/plugins/prometheus/sdk/go/query"
+	statPanel "github.com/perses/plugins/statchart/sdk/go"
+	tablePanel "github.com/perses/plugins/table/sdk/go"
+	tsPanel "github.com/perses/plugins/timeserieschart/sdk/go"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+)
+
+// managedClusters keeps the series of the clusters still managed by the hub,
+// as the addon status metrics of a detached cluster are only dropped when
+// the addon manager restarts.
+const managedClusters = `and on (cluster) label_replace(acm_managed_cluster_labels{name=~"$cluster"}, "cluster", "$1", "name", "(.+)")`
+
+// addonStatus selects an addon status metric by its managed_cluster label and
+// copies it to the cluster label, which holds the hub name once the metric is
+// forwarded to the hub Thanos.
+func addonStatus(metric, matchers string) string {
+	return `label_replace(` + metric + `{managed_cluster=~"$cluster"` + matchers + `}, "cluster", "$1", "managed_cluster", "(.+)")`
+}
+
+var FleetHealthQueries = map[string]string{
+	"AddonAvailable":       `max by (cluster) (` + addonStatus("acm_observability_addon_available", "") + `) ` + managedClusters,
+	"ComponentHealthy":     `max by (cluster) (` + addonStatus("acm_observability_addon_component_healthy", `,component="%s"`) + `) ` + managedClusters,
+	"AddonInfo":            `max by (cluster, prometheus_operator, prometheus_operator_version, tls_profile) (` + addonStatus("acm_observability_addon_info", "") + `) ` + managedClusters,
+	"AvailableRatio":       `sum(max by (cluster) (` + addonStatus("acm_observability_addon_available", "") + `) ` + managedClusters + `) / count(max by (cluster) (` + addonStatus("acm_observability_addon_available", "") + `) ` + managedClusters + `)`,
+	"ClustersNotSending":   `max by (name) (acm_managed_cluster_labels{name=~"$cluster"}) unless on (name) label_replace(max by (cluster) (up{cluster=~"$cluster"}), "name", "$1", "cluster", "(.+)")`,
+	"TimeSinceLastSample":  `time() - max by (cluster) (max_over_time(timestamp(up{cluster=~"$cluster"})[1d:5m]))`,
+	"RemoteWriteLag":       `max by (cluster) (prometheus_remote_storage_highest_timestamp_in_seconds{cluster=~"$cluster"} - ignoring (remote_name, url) group_right () prometheus_remote_storage_queue_highest_sent_timestamp_seconds{cluster=~"$cluster"})`,
+	"RemoteWriteFailures":  `sum by (cluster) (rate(prometheus_remote_storage_samples_failed_total{cluster=~"$cluster"}[5m]))`,
+	"RemoteWriteFailTotal": `sum(rate(prometheus_remote_storage_samples_failed_total{cluster=~"$cluster"}[5m]))`,
+}
+
+// The components whose health the addon reports, with their column header.
+var fleetHealthComponents = []struct {
+	Name   string
+	Header string
+}{
+	{"PrometheusAgent", "Prometheus Agent"},
+	{"ClusterLogForwarder", "Log Forwarder"},
+	{"OpenTelemetryCollector", "OpenTelemetry Collector"},
+	{"EventsCollector", "Events Collector"},
+	{"ProfilingAgent", "Profiling Agent"},
+	{"FlowCollector", "Flow Collector"},
+}
+
+var healthCellSettings = []tablePanel.CellSettings{
+	{
+		Condition: tablePanel.Condition{
+			Kind: tablePanel.ValueConditionKind,
+			Spec: &tablePanel.ValueConditionSpec{Value: "1"},
+		},
+		Text:            "Healthy",
+		BackgroundColor: "green",
+	},
+	{
+		Condition: tablePanel.Condition{
+			Kind: tablePanel.ValueConditionKind,
+			Spec: &tablePanel.ValueConditionSpec{Value: "0"},
+		},
+		Text:            "Unhealthy",
+		BackgroundColor: "red",
+	},
+}
+
+func FleetAddonAvailability(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Addon Availability",
+		panel.Description("Share of the selected clusters whose observability addon passed its last health check"),
+		statPanel.Chart(
+			statPanel.Calculation(common.LastNumberCalculation),
+			statPanel.Format(common.Format{
+				Unit: &dashboards.PercentDecimalUnit,
+			}),
+			statPanel.Thresholds(common.Thresholds{
+				Steps: []common.StepOption{
+					{Value: 0, Color: "red"},
+					{Value: 0.9, Color: "#EAB839"},
+					{Value: 1, Color: "green"},
+				},
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["AvailableRatio"],
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func FleetRemoteWriteFailures(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Remote Write Failures",
+		panel.Description("Rate of the samples the metrics collectors of the selected clusters failed to send to the hub"),
+		statPanel.Chart(
+			statPanel.Calculation(common.LastNumberCalculation),
+			statPanel.Format(common.Format{
+				Unit: &dashboards.RequestsPerSecondsUnit,
+			}),
+			statPanel.Thresholds(common.Thresholds{
+				Steps: []common.StepOption{
+					{Value: 0, Color: "green"},
+					{Value: 1, Color: "red"},
+				},
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["RemoteWriteFailTotal"],
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func FleetAddonStatus(datasource string) panelgroup.Option {
+	columns := []tablePanel.ColumnSettings{
+		{
+			Name:     "cluster",
+			Header:   "Cluster",
+			Align:    tablePanel.LeftAlign,
+			DataLink: dl.NewTableLink("k8s-compute-resources-cluster", "cluster", "Drill down to cluster"),
+		},
+		{
+			Name:   "value #1",
+			Header: "Addon Available",
+			Align:  tablePanel.LeftAlign,
+		},
+	}
+	options := []panel.Option{
+		panel.Description("Result of the last health check of the observability addon and of the collectors it deployed, per cluster. Collectors that are not enabled on a cluster are left empty."),
+	}
+	queries := []panel.Option{
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["AddonAvailable"],
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	}
+	for i, c := range fleetHealthComponents {
+		columns = append(columns, tablePanel.ColumnSettings{
+			Name:   fmt.Sprintf("value #%d", i+2),
+			Header: c.Header,
+			Align:  tablePanel.LeftAlign,
+		})
+		queries = append(queries, panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf(FleetHealthQueries["ComponentHealthy"], c.Name),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		))
+	}
+
+	options = append(options, tablePanel.Table(
+		tablePanel.Transform([]common.Transform{
+			{
+				Kind: common.MergeIndexedColumnsKind,
+				Spec: common.MergeIndexedColumnsSpec{
+					Column: "cluster",
+				},
+			},
+			{
+				Kind: common.JoinByColumValueKind,
+				Spec: common.JoinByColumnValueSpec{
+					Columns: []string{"cluster"},
+				},
+			},
+		}),
+		tablePanel.WithDefaultColumnHidden(true),
+		tablePanel.WithColumnSettings(columns),
+		tablePanel.WithCellSettings(healthCellSettings),
+		tablePanel.WithDensity("compact"),
+	))
+	return panelgroup.AddPanel("Addon Status by Cluster", append(options, queries...)...)
+}
+
+func FleetCollectorStack(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Collector Stack by Cluster",
+		panel.Description("The prometheus-operator running the metrics collectors, either detected from COO or deployed by the addon, and the TLS profile of each cluster"),
+		tablePanel.Table(
+			tablePanel.WithDefaultColumnHidden(true),
+			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
+				{
+					Name:     "cluster",
+					Header:   "Cluster",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewTableLink("k8s-compute-resources-cluster", "cluster", "Drill down to cluster"),
+				},
+				{
+					Name:   "prometheus_operator",
+					Header: "Prometheus Operator",
+					Align:  tablePanel.LeftAlign,
+				},
+				{
+					Name:   "prometheus_operator_version",
+					Header: "Operator Version",
+					Align:  tablePanel.LeftAlign,
+				},
+				{
+					Name:   "tls_profile",
+					Header: "TLS Profile",
+					Align:  tablePanel.LeftAlign,
+				},
+			}),
+			tablePanel.WithDensity("compact"),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["AddonInfo"],
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func FleetClustersNotSendingData(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Clusters Not Sending Metrics",
+		panel.Description("Managed clusters without any metric received by the hub in the last 5 minutes"),
+		tablePanel.Table(
+			tablePanel.WithDefaultColumnHidden(true),
+			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
+				{
+					Name:     "name",
+					Header:   "Cluster",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewClusterDetailsLink("name"),
+				},
+			}),
+			tablePanel.WithDensity("compact"),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["ClustersNotSending"],
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func fleetTimeSeries(unit string) panel.Option {
+	return tsPanel.Chart(
+		tsPanel.WithYAxis(tsPanel.YAxis{
+			Show: true,
+			Format: &common.Format{
+				Unit: &unit,
+			},
+		}),
+		tsPanel.WithLegend(tsPanel.Legend{
+			Position: tsPanel.RightPosition,
+			Mode:     tsPanel.TableMode,
+		}),
+	)
+}
+
+func FleetTimeSinceLastSample(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Time Since Last Sample",
+		panel.Description("Age of the most recent sample received by the hub from each cluster"),
+		fleetTimeSeries(dashboards.SecondsUnit),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["TimeSinceLastSample"],
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func FleetRemoteWriteLag(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Remote Write Lag",
+		panel.Description("Delay between the newest sample scraped by the metrics collectors of each cluster and the newest sample they sent to the hub"),
+		fleetTimeSeries(dashboards.SecondsUnit),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["RemoteWriteLag"],
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func FleetRemoteWriteFailedSamples(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Remote Write Failed Samples",
+		panel.Description("Rate of the samples the metrics collectors of each cluster failed to send to the hub"),
+		fleetTimeSeries(dashboards.RequestsPerSecondsUnit),
+		panel.AddQuery(
+			query.PromQL(
+				FleetHealthQueries["RemoteWriteFailures"],
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
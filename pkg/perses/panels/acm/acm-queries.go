@@ -28,7 +28,7 @@ var ACMCommonPanelQueries = map[string]parser.Expr{
 			Op: parser.COUNT_VALUES,
 			Expr: promqlbuilder.Round(promqlbuilder.Mul(promqlbuilder.Div(
 				promqlbuilder.Sum(
-					promqlbuilder.Eql(
+					promqlbuilder.Eqlc(
 						vector.New(
 							vector.WithMetricName("up"),
 							vector.WithLabelMatchers(
@@ -187,8 +187,9 @@ func ClusterCPUUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func ClusterCPUQuota(datasource string) panelgroup.Option {
+func ClusterCPUQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Quota",
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -213,18 +214,20 @@ func ClusterCPUQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-namespace-pods", "namespace", "Drill down to pods"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Pods",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "Pods",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewNamespaceLogsLink(logs, "namespace"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 0,
 					},
 				},
 				{
-					Name:   "value #2",
-					Header: "Workloads",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #2",
+					Header:   "Workloads",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewNamespaceTracesLink("namespace"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 0,
@@ -352,8 +355,9 @@ func ClusterMemoryUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func ClusterMemoryQuota(datasource string) panelgroup.Option {
+func ClusterMemoryQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("Requests by Namespace",
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -378,18 +382,20 @@ func ClusterMemoryQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-namespace-pods", "namespace", "Drill down to pods"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Pods",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "Pods",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewNamespaceLogsLink(logs, "namespace"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 0,
 					},
 				},
 				{
-					Name:   "value #2",
-					Header: "Workloads",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #2",
+					Header:   "Workloads",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewNamespaceTracesLink("namespace"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 0,
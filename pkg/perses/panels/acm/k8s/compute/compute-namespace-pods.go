@@ -153,8 +153,10 @@ func NamespacePodsCPUUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func NamespacePodsCPUQuota(datasource string) panelgroup.Option {
+func NamespacePodsCPUQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Quota",
+		dl.TracesPanelLink(".+"),
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -179,8 +181,9 @@ func NamespacePodsCPUQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-pod", "pod", "Drill down"),
 				},
 				{
-					Name:   "value #1",
-					Header: "CPU Usage",
+					Name:     "value #1",
+					Header:   "CPU Usage",
+					DataLink: dl.NewPodLogsLink(logs, "$namespace", "pod"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 2,
@@ -297,8 +300,10 @@ func NamespacePodsMemoryUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func NamespacePodsMemoryQuota(datasource string) panelgroup.Option {
+func NamespacePodsMemoryQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Quota",
+		dl.TracesPanelLink(".+"),
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -323,8 +328,9 @@ func NamespacePodsMemoryQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-pod", "pod", "Drill down"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Memory Usage",
+					Name:     "value #1",
+					Header:   "Memory Usage",
+					DataLink: dl.NewPodLogsLink(logs, "$namespace", "pod"),
 					Format: &common.Format{
 						Unit:          &dashboards.BytesUnit,
 						DecimalPlaces: 2,
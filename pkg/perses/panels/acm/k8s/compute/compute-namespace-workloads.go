@@ -42,8 +42,9 @@ func NamespaceWorkloadsCPUUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func NamespaceWorkloadsCPUQuota(datasource string) panelgroup.Option {
+func NamespaceWorkloadsCPUQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Quota",
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -68,17 +69,19 @@ func NamespaceWorkloadsCPUQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-workload", "workload", "Drill down to workload"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Running Pods",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "Running Pods",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewWorkloadLogsLink(logs, "$namespace", "workload"),
 					Format: &common.Format{
 						DecimalPlaces: 0,
 					},
 				},
 				{
-					Name:   "value #2",
-					Header: "CPU Usage",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #2",
+					Header:   "CPU Usage",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewServiceTracesLink("$namespace", "workload"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 2,
@@ -191,8 +194,9 @@ func NamespaceWorkloadsMemoryUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func NamespaceWorkloadsMemoryQuota(datasource string) panelgroup.Option {
+func NamespaceWorkloadsMemoryQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Quota",
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -217,17 +221,19 @@ func NamespaceWorkloadsMemoryQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLink("k8s-compute-resources-workload", "workload", "Drill down to workload"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Running Pods",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "Running Pods",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewWorkloadLogsLink(logs, "$namespace", "workload"),
 					Format: &common.Format{
 						DecimalPlaces: 0,
 					},
 				},
 				{
-					Name:   "value #2",
-					Header: "Memory Usage",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #2",
+					Header:   "Memory Usage",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewServiceTracesLink("$namespace", "workload"),
 					Format: &common.Format{
 						Unit:          &dashboards.BytesUnit,
 						DecimalPlaces: 2,
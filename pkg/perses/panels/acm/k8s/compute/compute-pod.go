@@ -8,12 +8,15 @@ import (
 	"github.com/perses/plugins/prometheus/sdk/go/query"
 	tablePanel "github.com/perses/plugins/table/sdk/go"
 	tsPanel "github.com/perses/plugins/timeserieschart/sdk/go"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+	profiling "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/profiling"
 )
 
 // Pod dashboard panels
 
 func PodCPUUsage(datasource string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Usage",
+		dl.ProfilesPanelLink(profiling.CPUProfileType),
 		tsPanel.Chart(
 			tsPanel.WithYAxis(tsPanel.YAxis{
 				Show: true,
@@ -191,6 +194,7 @@ func PodCPUQuota(datasource string) panelgroup.Option {
 
 func PodMemoryUsage(datasource string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage",
+		dl.ProfilesPanelLink(profiling.MemoryInUseProfileType),
 		tsPanel.Chart(
 			tsPanel.WithYAxis(tsPanel.YAxis{
 				Show: true,
@@ -42,8 +42,10 @@ func WorkloadCPUUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func WorkloadCPUQuota(datasource string) panelgroup.Option {
+func WorkloadCPUQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Quota",
+		dl.TracesPanelLink("$workload"),
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -68,9 +70,10 @@ func WorkloadCPUQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLinkNewTab("k8s-compute-resources-pod", "pod", "Drill down to pod"),
 				},
 				{
-					Name:   "value #1",
-					Header: "CPU Usage",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "CPU Usage",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewPodLogsLink(logs, "$namespace", "pod"),
 					Format: &common.Format{
 						Unit:          &dashboards.DecimalUnit,
 						DecimalPlaces: 2,
@@ -177,8 +180,10 @@ func WorkloadMemoryUsage(datasource string) panelgroup.Option {
 	)
 }
 
-func WorkloadMemoryQuota(datasource string) panelgroup.Option {
+func WorkloadMemoryQuota(datasource string, logs dl.LogLinks) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Quota",
+		dl.TracesPanelLink("$workload"),
+		dl.ClusterDetailsPanelLink(),
 		tablePanel.Table(
 			tablePanel.Transform([]common.Transform{
 				{
@@ -203,9 +208,10 @@ func WorkloadMemoryQuota(datasource string) panelgroup.Option {
 					DataLink: dl.NewTableLinkNewTab("k8s-compute-resources-pod", "pod", "Drill down to pod"),
 				},
 				{
-					Name:   "value #1",
-					Header: "Memory Usage",
-					Align:  tablePanel.LeftAlign,
+					Name:     "value #1",
+					Header:   "Memory Usage",
+					Align:    tablePanel.LeftAlign,
+					DataLink: dl.NewPodLogsLink(logs, "$namespace", "pod"),
 					Format: &common.Format{
 						Unit:          &dashboards.BytesUnit,
 						DecimalPlaces: 2,
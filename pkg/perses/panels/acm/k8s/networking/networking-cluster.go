@@ -394,3 +394,57 @@ func ClusterTCPSynRetransmits(datasource string) panelgroup.Option {
 		),
 	)
 }
+
+func ClusterFlowBytes(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Top Namespace-to-Namespace Traffic",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.BytesPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				ClusterQueries["FlowBytesByNamespacePair"].Pretty(0),
+				query.SeriesNameFormat("{{ SrcK8S_Namespace }} -> {{ DstK8S_Namespace }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func ClusterFlowDroppedPackets(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Top Namespace-to-Namespace Dropped Packets",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.PacketsPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				ClusterQueries["FlowDroppedPacketsByNamespacePair"].Pretty(0),
+				query.SeriesNameFormat("{{ SrcK8S_Namespace }} -> {{ DstK8S_Namespace }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
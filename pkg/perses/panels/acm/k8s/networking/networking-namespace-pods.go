@@ -352,3 +352,57 @@ func NamespacePodsTransmittedPacketsDropped(datasource string) panelgroup.Option
 		),
 	)
 }
+
+func NamespacePodsFlowIncomingBytes(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Top Incoming Traffic by Source Workload",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.BytesPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				NamespacePodsQueries["FlowIncomingBytesByWorkload"].Pretty(0),
+				query.SeriesNameFormat("{{ SrcK8S_Namespace }}/{{ SrcK8S_OwnerName }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func NamespacePodsFlowOutgoingBytes(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Top Outgoing Traffic by Destination Workload",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.BytesPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				NamespacePodsQueries["FlowOutgoingBytesByWorkload"].Pretty(0),
+				query.SeriesNameFormat("{{ DstK8S_Namespace }}/{{ DstK8S_OwnerName }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
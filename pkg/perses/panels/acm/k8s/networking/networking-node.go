@@ -269,3 +269,57 @@ func NodeTCPSynRetransmits(datasource string) panelgroup.Option {
 		),
 	)
 }
+
+func NodeFlowReceivedBytes(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Flow Traffic Received by Node",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.BytesPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				NodeQueries["FlowReceivedBytesByNode"].Pretty(0),
+				query.SeriesNameFormat("{{ DstK8S_HostName }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
+
+func NodeFlowTransmittedBytes(datasource string) panelgroup.Option {
+	return panelgroup.AddPanel("Flow Traffic Transmitted by Node",
+		tsPanel.Chart(
+			tsPanel.WithYAxis(tsPanel.YAxis{
+				Show: true,
+				Format: &common.Format{
+					Unit: &dashboards.BytesPerSecondsUnit,
+				},
+			}),
+			tsPanel.WithVisual(tsPanel.Visual{
+				AreaOpacity: 1,
+			}),
+			tsPanel.WithLegend(tsPanel.Legend{
+				Position: tsPanel.RightPosition,
+				Mode:     tsPanel.TableMode,
+			}),
+		),
+		panel.AddQuery(
+			query.PromQL(
+				NodeQueries["FlowTransmittedBytesByNode"].Pretty(0),
+				query.SeriesNameFormat("{{ SrcK8S_HostName }}"),
+				dashboards.AddQueryDataSource(datasource),
+			),
+		),
+	)
+}
@@ -189,6 +189,39 @@ var ClusterQueries = map[string]parser.Expr{
 			),
 		).By("instance"),
 	),
+	// Network flows queries, federated from the FlowCollector of the cluster
+	"FlowBytesByNamespacePair": promqlbuilder.TopK(
+		promqlbuilder.Sum(
+			promqlbuilder.Rate(
+				matrix.New(
+					vector.New(
+						vector.WithMetricName("netobserv_namespace_ingress_bytes_total"),
+						vector.WithLabelMatchers(
+							label.New("cluster").Equal("$cluster"),
+						),
+					),
+					matrix.WithRangeAsVariable("$interval:$resolution"),
+				),
+			),
+		).By("SrcK8S_Namespace", "DstK8S_Namespace"),
+		10,
+	),
+	"FlowDroppedPacketsByNamespacePair": promqlbuilder.TopK(
+		promqlbuilder.Sum(
+			promqlbuilder.Rate(
+				matrix.New(
+					vector.New(
+						vector.WithMetricName("netobserv_namespace_drop_packets_total"),
+						vector.WithLabelMatchers(
+							label.New("cluster").Equal("$cluster"),
+						),
+					),
+					matrix.WithRangeAsVariable("$interval:$resolution"),
+				),
+			),
+		).By("SrcK8S_Namespace", "DstK8S_Namespace"),
+		10,
+	),
 }
 
 // Namespace (Pods) queries (by pod, filtered by namespace)
@@ -310,6 +343,41 @@ var NamespacePodsQueries = map[string]parser.Expr{
 			),
 		),
 	),
+	// Network flows queries, federated from the FlowCollector of the cluster
+	"FlowIncomingBytesByWorkload": promqlbuilder.TopK(
+		promqlbuilder.Sum(
+			promqlbuilder.Rate(
+				matrix.New(
+					vector.New(
+						vector.WithMetricName("netobserv_workload_ingress_bytes_total"),
+						vector.WithLabelMatchers(
+							label.New("cluster").Equal("$cluster"),
+							label.New("DstK8S_Namespace").EqualRegexp("$namespace"),
+						),
+					),
+					matrix.WithRangeAsVariable("$interval:$resolution"),
+				),
+			),
+		).By("SrcK8S_Namespace", "SrcK8S_OwnerName"),
+		10,
+	),
+	"FlowOutgoingBytesByWorkload": promqlbuilder.TopK(
+		promqlbuilder.Sum(
+			promqlbuilder.Rate(
+				matrix.New(
+					vector.New(
+						vector.WithMetricName("netobserv_workload_egress_bytes_total"),
+						vector.WithLabelMatchers(
+							label.New("cluster").Equal("$cluster"),
+							label.New("SrcK8S_Namespace").EqualRegexp("$namespace"),
+						),
+					),
+					matrix.WithRangeAsVariable("$interval:$resolution"),
+				),
+			),
+		).By("DstK8S_Namespace", "DstK8S_OwnerName"),
+		10,
+	),
 }
 
 // Node queries (by instance)
@@ -347,6 +415,33 @@ var NodeQueries = map[string]parser.Expr{
 			),
 		),
 	).By("instance"),
+	// Network flows queries, federated from the FlowCollector of the cluster
+	"FlowReceivedBytesByNode": promqlbuilder.Sum(
+		promqlbuilder.Rate(
+			matrix.New(
+				vector.New(
+					vector.WithMetricName("netobserv_node_ingress_bytes_total"),
+					vector.WithLabelMatchers(
+						label.New("cluster").Equal("$cluster"),
+					),
+				),
+				matrix.WithRangeAsVariable("$interval:$resolution"),
+			),
+		),
+	).By("DstK8S_HostName"),
+	"FlowTransmittedBytesByNode": promqlbuilder.Sum(
+		promqlbuilder.Rate(
+			matrix.New(
+				vector.New(
+					vector.WithMetricName("netobserv_node_egress_bytes_total"),
+					vector.WithLabelMatchers(
+						label.New("cluster").Equal("$cluster"),
+					),
+				),
+				matrix.WithRangeAsVariable("$interval:$resolution"),
+			),
+		),
+	).By("SrcK8S_HostName"),
 }
 
 // Pod queries (filtered by namespace and pod)
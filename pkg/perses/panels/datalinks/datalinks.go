@@ -2,30 +2,77 @@ package datalinks
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
 	tablePanel "github.com/perses/plugins/table/sdk/go"
 )
 
-const dashboardViewBase = "/monitoring/v2/dashboards/view"
+const (
+	dashboardViewBase  = "/monitoring/v2/dashboards/view"
+	consoleLogsBase    = "/monitoring/logs"
+	clusterDetailsBase = "/multicloud/infrastructure/clusters/details"
 
+	// tracesDashboard is the tracing dashboard listing the traces of a service.
+	tracesDashboard = "acm-tracing-traces"
+	// profilesDashboard is the profiling dashboard showing the profiles of a pod.
+	profilesDashboard = "acm-profiling-pod"
+)
+
+// DashboardURL returns the URL of the dashboard of the current project, with
+// the given "name=value" query parameters.
 func DashboardURL(dashboard string, params ...string) string {
-	var url strings.Builder
-	fmt.Fprintf(&url, "%s?dashboard=%s&project=$__project", dashboardViewBase, dashboard)
-	for _, p := range params {
-		url.WriteString("&" + p)
-	}
-	return url.String()
+	values := url.Values{}
+	values.Set("dashboard", dashboard)
+	values.Set("project", "$__project")
+	addParams(values, params...)
+	return dashboardViewBase + "?" + encodeParams(values)
 }
 
 func FieldParam(varName, fieldName string) string {
-	return fmt.Sprintf(`var-%s=${__data.fields["%s"]}`, varName, fieldName)
+	return fmt.Sprintf(`var-%s=%s`, varName, FieldValue(fieldName))
 }
 
 func StaticParam(varName, value string) string {
 	return fmt.Sprintf("var-%s=%s", varName, value)
 }
 
+// FieldValue returns the placeholder of a field of the table row the link is clicked on.
+func FieldValue(fieldName string) string {
+	return fmt.Sprintf(`${__data.fields["%s"]}`, fieldName)
+}
+
+// TimeRangeParams keep the time range of the current dashboard.
+func TimeRangeParams() []string {
+	return []string{"start=$__from", "end=$__to"}
+}
+
+func addParams(values url.Values, params ...string) {
+	for _, p := range params {
+		name, value, _ := strings.Cut(p, "=")
+		values.Add(name, value)
+	}
+}
+
+// encodedPlaceholder matches the encoded dashboard variables and table fields
+// placeholders.
+var encodedPlaceholder = regexp.MustCompile(`%24(%7B__data\.fields%5B%22[\w.-]+%22%5D%7D|\w+)`)
+
+// encodeParams encodes the query parameters but keeps the placeholders as-is,
+// since Perses only replaces them in their raw form.
+func encodeParams(values url.Values) string {
+	return encodedPlaceholder.ReplaceAllStringFunc(values.Encode(), func(s string) string {
+		raw, err := url.QueryUnescape(s)
+		if err != nil {
+			return s
+		}
+		return raw
+	})
+}
+
 func NewTableLink(dashboard, fieldName, title string) *tablePanel.DataLink {
 	return &tablePanel.DataLink{
 		URL:   DashboardURL(dashboard, FieldParam(fieldName, fieldName)),
@@ -48,3 +95,194 @@ func NewTableLinkCustomVar(dashboard, varName, fieldName, title string) *tablePa
 		OpenNewTab: true,
 	}
 }
+
+// LogMatcher selects the log streams whose label equals, or matches when
+// Regex is set, the value.
+type LogMatcher struct {
+	Label string
+	Value string
+	Regex bool
+}
+
+func (m LogMatcher) String() string {
+	op := "="
+	if m.Regex {
+		op = "=~"
+	}
+	return fmt.Sprintf(`%s%s"%s"`, m.Label, op, m.Value)
+}
+
+// LogLabels names the Loki stream labels the log links filter on.
+type LogLabels struct {
+	ClusterID string
+	Namespace string
+	Pod       string
+}
+
+// ViaQLogLabels are the stream labels of the ViaQ data model, the default of
+// the lokiStack outputs of a ClusterLogForwarder.
+var ViaQLogLabels = LogLabels{
+	ClusterID: "openshift_cluster_id",
+	Namespace: "kubernetes_namespace_name",
+	Pod:       "kubernetes_pod_name",
+}
+
+// OTelLogLabels are the stream labels of the OpenTelemetry data model.
+var OTelLogLabels = LogLabels{
+	ClusterID: "openshift_cluster_uid",
+	Namespace: "k8s_namespace_name",
+	Pod:       "k8s_pod_name",
+}
+
+// LogLinks locates the workload logs: the LokiStack tenant storing them and
+// the stream labels of its data model. The zero value disables the log links.
+type LogLinks struct {
+	Tenant string
+	Labels LogLabels
+}
+
+// LogsURL returns the URL of the console log view showing the logs of the
+// LokiStack tenant selected by the matchers.
+func LogsURL(tenant string, matchers ...LogMatcher) string {
+	selectors := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		selectors = append(selectors, m.String())
+	}
+	values := url.Values{}
+	values.Set("tenant", tenant)
+	values.Set("q", fmt.Sprintf("{%s}", strings.Join(selectors, ",")))
+	addParams(values, TimeRangeParams()...)
+	return consoleLogsBase + "?" + encodeParams(values)
+}
+
+// link returns the data link to the logs selected by the matchers, or nil
+// when the log links are disabled.
+func (l LogLinks) link(title string, matchers ...LogMatcher) *tablePanel.DataLink {
+	if l.Tenant == "" {
+		return nil
+	}
+	// The log streams carry the cluster ID rather than its name, hence the
+	// clusterID dashboard variable.
+	matchers = append([]LogMatcher{{Label: l.Labels.ClusterID, Value: "$clusterID"}}, matchers...)
+	return &tablePanel.DataLink{
+		URL:        LogsURL(l.Tenant, matchers...),
+		Title:      title,
+		OpenNewTab: true,
+	}
+}
+
+// NewPodLogsLink links a table row to the logs of the pod in the given field.
+func NewPodLogsLink(logs LogLinks, namespace, podField string) *tablePanel.DataLink {
+	return logs.link("View pod logs",
+		LogMatcher{Label: logs.Labels.Namespace, Value: namespace},
+		LogMatcher{Label: logs.Labels.Pod, Value: FieldValue(podField)},
+	)
+}
+
+// workloadPodSuffix matches the suffix that the workload controllers append to
+// the names of their pods: the ordinal of a StatefulSet, or the random suffix
+// of a DaemonSet or Job pod, preceded by the pod template hash of the
+// ReplicaSet for a Deployment. The hashes and suffixes use the alphabet of the
+// Kubernetes random strings, which has no vowels.
+const workloadPodSuffix = `-(([bcdfghjklmnpqrstvwxz2456789]{1,10}-)?[bcdfghjklmnpqrstvwxz2456789]{5}|[0-9]+)`
+
+// NewWorkloadLogsLink links a table row to the logs of the pods of the
+// workload in the given field. LogQL regexes are anchored, so the pods of
+// another workload whose name starts with the same prefix are not matched.
+func NewWorkloadLogsLink(logs LogLinks, namespace, workloadField string) *tablePanel.DataLink {
+	return logs.link("View workload logs",
+		LogMatcher{Label: logs.Labels.Namespace, Value: namespace},
+		LogMatcher{Label: logs.Labels.Pod, Value: FieldValue(workloadField) + workloadPodSuffix, Regex: true},
+	)
+}
+
+// NewNamespaceLogsLink links a table row to the logs of the namespace in the given field.
+func NewNamespaceLogsLink(logs LogLinks, namespaceField string) *tablePanel.DataLink {
+	return logs.link("View namespace logs",
+		LogMatcher{Label: logs.Labels.Namespace, Value: FieldValue(namespaceField)},
+	)
+}
+
+// TracesURL returns the URL of the tracing dashboard listing the traces of
+// the service, in the current time range.
+func TracesURL(cluster, namespace, service string) string {
+	params := []string{
+		StaticParam("cluster", cluster),
+		StaticParam("namespace", namespace),
+		StaticParam("service", service),
+	}
+	return DashboardURL(tracesDashboard, append(params, TimeRangeParams()...)...)
+}
+
+// NewServiceTracesLink links a table row to the traces of the service named
+// after the workload in the given field.
+func NewServiceTracesLink(namespace, serviceField string) *tablePanel.DataLink {
+	return &tablePanel.DataLink{
+		URL:        TracesURL("$cluster", namespace, FieldValue(serviceField)),
+		Title:      "View service traces",
+		OpenNewTab: true,
+	}
+}
+
+// NewNamespaceTracesLink links a table row to the traces of the namespace in the given field.
+func NewNamespaceTracesLink(namespaceField string) *tablePanel.DataLink {
+	return &tablePanel.DataLink{
+		URL:        TracesURL("$cluster", FieldValue(namespaceField), ".+"),
+		Title:      "View namespace traces",
+		OpenNewTab: true,
+	}
+}
+
+// ClusterDetailsURL returns the URL of the cluster page of the ACM console.
+func ClusterDetailsURL(cluster string) string {
+	return fmt.Sprintf("%s/%[2]s/%[2]s/overview", clusterDetailsBase, cluster)
+}
+
+// NewClusterDetailsLink links a table row to the ACM console page of the cluster in the given field.
+func NewClusterDetailsLink(clusterField string) *tablePanel.DataLink {
+	return &tablePanel.DataLink{
+		URL:        ClusterDetailsURL(FieldValue(clusterField)),
+		Title:      "View cluster details",
+		OpenNewTab: true,
+	}
+}
+
+// ClusterDetailsPanelLink links a panel to the ACM console page of the selected cluster.
+func ClusterDetailsPanelLink() panel.Option {
+	return panel.AddLink(ClusterDetailsURL("$cluster"),
+		link.Name("Cluster details"),
+		link.RenderVariable(true),
+		link.TargetBlank(true),
+	)
+}
+
+// TracesPanelLink links a panel to the traces of the selected namespace and service.
+func TracesPanelLink(service string) panel.Option {
+	return panel.AddLink(TracesURL("$cluster", "$namespace", service),
+		link.Name("Traces"),
+		link.RenderVariable(true),
+		link.TargetBlank(true),
+	)
+}
+
+// ProfilesURL returns the URL of the profiling dashboard showing the profiles
+// of the given type of the pod, in the current time range.
+func ProfilesURL(cluster, namespace, pod, profileType string) string {
+	params := []string{
+		StaticParam("cluster", cluster),
+		StaticParam("namespace", namespace),
+		StaticParam("pod", pod),
+		StaticParam("profile_type", profileType),
+	}
+	return DashboardURL(profilesDashboard, append(params, TimeRangeParams()...)...)
+}
+
+// ProfilesPanelLink links a panel to the profiles of the given type of the
+// selected pod.
+func ProfilesPanelLink(profileType string) panel.Option {
+	return panel.AddLink(ProfilesURL("$cluster", "$namespace", "$pod", profileType),
+		link.Name("Profiles"),
+		link.RenderVariable(true),
+		link.TargetBlank(true),
+	)
+}
@@ -0,0 +1,192 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	commonSdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/plugins/prometheus/sdk/go/query"
+	timeSeriesPanel "github.com/perses/plugins/timeserieschart/sdk/go"
+)
+
+// errorLineFilter keeps the log lines that look like errors.
+const errorLineFilter = `|~ "(?i)(error|fatal|panic)"`
+
+func timeSeriesChart(unit string, stack bool) panel.Option {
+	visual := timeSeriesPanel.Visual{
+		Display:      timeSeriesPanel.LineDisplay,
+		ConnectNulls: false,
+		LineWidth:    0.25,
+		AreaOpacity:  0.5,
+		Palette:      &timeSeriesPanel.Palette{Mode: timeSeriesPanel.AutoMode},
+	}
+	if stack {
+		visual.AreaOpacity = 1
+		visual.Stack = timeSeriesPanel.AllStack
+	}
+	return timeSeriesPanel.Chart(
+		timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
+			Format: &commonSdk.Format{
+				Unit: &unit,
+			},
+		}),
+		timeSeriesPanel.WithLegend(timeSeriesPanel.Legend{
+			Position: timeSeriesPanel.BottomPosition,
+			Mode:     timeSeriesPanel.TableMode,
+			Size:     timeSeriesPanel.SmallSize,
+		}),
+		timeSeriesPanel.WithVisual(visual),
+	)
+}
+
+// tenantSelector returns the stream selector of the logs queried in a
+// LokiStack tenant.
+func tenantSelector(t TenantDatasource, labels StreamLabels, extra string) string {
+	matcher := fmt.Sprintf(`%s="%s"`, labels.LogType, t.Tenant)
+	if len(t.LogTypes) > 0 {
+		matcher = fmt.Sprintf(`%s=~"%s"`, labels.LogType, strings.Join(t.LogTypes, "|"))
+	}
+	if extra == "" {
+		return fmt.Sprintf(`{%s}`, matcher)
+	}
+	return fmt.Sprintf(`{%s, %s}`, matcher, extra)
+}
+
+func LogLinesByCluster(tenants []TenantDatasource, labels StreamLabels) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the rate of log lines stored per cluster and tenant"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, true),
+	}
+	for _, t := range tenants {
+		options = append(options, panel.AddQuery(
+			LogQL(
+				fmt.Sprintf("sum by (%s) (rate(%s[5m]))", labels.Cluster, tenantSelector(t, labels, "")),
+				t.Datasource,
+			),
+		))
+	}
+	return panelgroup.AddPanel("Log Lines Rate", options...)
+}
+
+func LogBytesByCluster(tenants []TenantDatasource, labels StreamLabels) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the volume of logs stored per cluster and tenant"),
+		timeSeriesChart(string(commonSdk.BytesPerSecondsUnit), true),
+	}
+	for _, t := range tenants {
+		options = append(options, panel.AddQuery(
+			LogQL(
+				fmt.Sprintf("sum by (%s) (bytes_rate(%s[5m]))", labels.Cluster, tenantSelector(t, labels, "")),
+				t.Datasource,
+			),
+		))
+	}
+	return panelgroup.AddPanel("Log Volume", options...)
+}
+
+func ErrorLinesByNamespace(tenants []TenantDatasource, labels StreamLabels) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the rate of log lines mentioning an error, fatal or panic per namespace"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, false),
+	}
+	for _, t := range tenants {
+		selector := tenantSelector(t, labels, fmt.Sprintf(`%s=~"$namespace"`, labels.Namespace))
+		options = append(options, panel.AddQuery(
+			LogQL(
+				fmt.Sprintf("topk(20, sum by (%s) (rate(%s %s [5m])))", labels.Namespace, selector, errorLineFilter),
+				t.Datasource,
+			),
+		))
+	}
+	return panelgroup.AddPanel("Error Lines Rate", options...)
+}
+
+func ErrorRatioByNamespace(tenants []TenantDatasource, labels StreamLabels) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the share of the log lines of each namespace that mention an error, fatal or panic"),
+		timeSeriesChart(dashboards.PercentDecimalUnit, false),
+	}
+	for _, t := range tenants {
+		selector := tenantSelector(t, labels, fmt.Sprintf(`%s=~"$namespace"`, labels.Namespace))
+		options = append(options, panel.AddQuery(
+			LogQL(
+				fmt.Sprintf("topk(20, sum by (%[1]s) (rate(%[2]s %[3]s [5m])) / sum by (%[1]s) (rate(%[2]s[5m])))", labels.Namespace, selector, errorLineFilter),
+				t.Datasource,
+			),
+		))
+	}
+	return panelgroup.AddPanel("Error Ratio", options...)
+}
+
+func CollectorReceivedEvents(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Received Events",
+		panel.Description("Shows the rate of log events read by the collectors of each cluster"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, true),
+		panel.AddQuery(
+			query.PromQL(
+				"sum by (cluster) (rate(vector_component_received_events_total{cluster=~\"$cluster\",component_kind=\"source\"}[5m]))",
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func CollectorSentEvents(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Sent Events",
+		panel.Description("Shows the rate of log events sent by the collectors of each cluster to their outputs"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, true),
+		panel.AddQuery(
+			query.PromQL(
+				"sum by (cluster) (rate(vector_component_sent_events_total{cluster=~\"$cluster\",component_kind=\"sink\"}[5m]))",
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func CollectorErrors(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Component Errors",
+		panel.Description("Shows the rate of errors raised by the collector components, per cluster and component"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, false),
+		panel.AddQuery(
+			query.PromQL(
+				"sum by (cluster, component_id) (rate(vector_component_errors_total{cluster=~\"$cluster\"}[5m])) > 0",
+				query.SeriesNameFormat("{{cluster}} {{component_id}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func CollectorDiscardedEvents(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Discarded Events",
+		panel.Description("Shows the rate of log events dropped by the collectors of each cluster"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit, false),
+		panel.AddQuery(
+			query.PromQL(
+				"sum by (cluster) (rate(vector_component_discarded_events_total{cluster=~\"$cluster\"}[5m]))",
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func CollectorBufferedEvents(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Buffered Events",
+		panel.Description("Shows the number of log events waiting in the output buffers of the collectors of each cluster"),
+		timeSeriesChart(string(commonSdk.DecimalUnit), false),
+		panel.AddQuery(
+			query.PromQL(
+				"sum by (cluster) (vector_buffer_events{cluster=~\"$cluster\"})",
+				query.SeriesNameFormat("{{cluster}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
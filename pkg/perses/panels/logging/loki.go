@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/plugin"
+)
+
+// The Perses Loki plugin ships no Go SDK, so its query is built by hand.
+const (
+	LokiDatasourceKind      = "LokiDatasource"
+	LokiTimeSeriesQueryKind = "LokiTimeSeriesQuery"
+)
+
+type lokiQuerySpec struct {
+	Datasource *datasource.Selector `json:"datasource,omitempty" yaml:"datasource,omitempty"`
+	Query      string               `json:"query" yaml:"query"`
+}
+
+// LogQL returns a time series query evaluating the LogQL expression against
+// the given Loki datasource.
+func LogQL(expr string, datasourceName string) query.Option {
+	spec := lokiQuerySpec{Query: expr}
+	if datasourceName != "" {
+		spec.Datasource = &datasource.Selector{Kind: LokiDatasourceKind, Name: datasourceName}
+	}
+	return query.Option{
+		Kind: plugin.KindTimeSeriesQuery,
+		Plugin: common.Plugin{
+			Kind: LokiTimeSeriesQueryKind,
+			Spec: spec,
+		},
+	}
+}
+
+// TenantDatasource is the Loki datasource querying one LokiStack tenant.
+type TenantDatasource struct {
+	Tenant     string
+	Datasource string
+	// LogTypes are the types of the logs queried in the tenant. When empty,
+	// the tenant only stores the logs of its own type.
+	LogTypes []string
+}
+
+// StreamLabels names the Loki stream labels the logging panels group by,
+// which depend on the data model of the LokiStack output.
+type StreamLabels struct {
+	Cluster   string
+	Namespace string
+	LogType   string
+}
+
+var (
+	// ViaQStreamLabels are the stream labels of the ViaQ data model.
+	ViaQStreamLabels = StreamLabels{
+		Cluster:   "openshift_cluster_id",
+		Namespace: "kubernetes_namespace_name",
+		LogType:   "log_type",
+	}
+	// OTelStreamLabels are the stream labels of the OpenTelemetry data model.
+	OTelStreamLabels = StreamLabels{
+		Cluster:   "openshift_cluster_uid",
+		Namespace: "k8s_namespace_name",
+		LogType:   "openshift_log_type",
+	}
+	// GatewayStreamLabels are the stream labels of the logs written by the
+	// OpenTelemetry gateway of the hub, which sets the name of the cluster.
+	GatewayStreamLabels = StreamLabels{
+		Cluster:   "k8s_cluster_name",
+		Namespace: "k8s_namespace_name",
+		LogType:   "openshift_log_type",
+	}
+)
@@ -0,0 +1,62 @@
+package profiling
+
+import (
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+)
+
+// The labels the profiling agent sets on the profiles.
+const (
+	clusterLabel   = "cluster"
+	namespaceLabel = "namespace"
+	podLabel       = "pod"
+)
+
+// The Pyroscope identifiers of the CPU and memory in use profile types.
+const (
+	CPUProfileType         = "process_cpu:cpu:nanoseconds:cpu:nanoseconds"
+	MemoryInUseProfileType = "memory:inuse_space:bytes:space:bytes"
+)
+
+// ProfileTypes are the profile types the profiling agent collects, by their
+// Pyroscope identifier. The eBPF agent only collects CPU profiles.
+var ProfileTypes = []struct {
+	ID    string
+	Label string
+}{
+	{CPUProfileType, "CPU"},
+	{MemoryInUseProfileType, "Memory in use"},
+	{"memory:alloc_space:bytes:space:bytes", "Memory allocated"},
+	{"goroutines:goroutine:count:goroutine:count", "Goroutines"},
+	{"mutex:delay:nanoseconds:mutex:count", "Mutex delay"},
+	{"block:delay:nanoseconds:contentions:count", "Block delay"},
+}
+
+// podFilters select the profiles of the pods of the selected cluster,
+// namespace and pod variables.
+func podFilters() []LabelFilter {
+	return []LabelFilter{
+		{Label: clusterLabel, LabelValue: "$cluster", Operator: "=~"},
+		{Label: namespaceLabel, LabelValue: "$namespace", Operator: "=~"},
+		{Label: podLabel, LabelValue: "$pod", Operator: "=~"},
+	}
+}
+
+func computePodLink() panel.Option {
+	return panel.AddLink(
+		dl.DashboardURL("k8s-compute-resources-pod", "var-cluster=$cluster", "var-namespace=$namespace", "var-pod=$pod"),
+		link.Name("Compute resources"),
+		link.RenderVariable(true),
+	)
+}
+
+func PodFlameGraph(datasourceName string) panelgroup.Option {
+	return panelgroup.AddPanel("Flame Graph",
+		panel.Description("Shows the profiles of the selected type of the selected pods, merged over the time range"),
+		flameChart(),
+		panel.AddQuery(ProfileQuery("$profile_type", datasourceName, podFilters()...)),
+		computePodLink(),
+	)
+}
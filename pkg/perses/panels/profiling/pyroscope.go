@@ -0,0 +1,57 @@
+package profiling
+
+import (
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/panel"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/plugin"
+)
+
+// The Perses Pyroscope plugin ships no Go SDK, so its query and panel are built by hand.
+const (
+	PyroscopeDatasourceKind   = "PyroscopeDatasource"
+	PyroscopeProfileQueryKind = "PyroscopeProfileQuery"
+
+	flameChartKind = "FlameChart"
+)
+
+// LabelFilter selects the profiles whose label equals, or matches when the
+// operator is "=~", the value.
+type LabelFilter struct {
+	Label      string `json:"label" yaml:"label"`
+	LabelValue string `json:"labelValue" yaml:"labelValue"`
+	Operator   string `json:"operator" yaml:"operator"`
+}
+
+type pyroscopeQuerySpec struct {
+	Datasource  *datasource.Selector `json:"datasource,omitempty" yaml:"datasource,omitempty"`
+	ProfileType string               `json:"profileType" yaml:"profileType"`
+	Filters     []LabelFilter        `json:"filters,omitempty" yaml:"filters,omitempty"`
+}
+
+// ProfileQuery returns a profile query merging the profiles of the given type
+// selected by the filters from the given Pyroscope datasource.
+func ProfileQuery(profileType string, datasourceName string, filters ...LabelFilter) query.Option {
+	spec := pyroscopeQuerySpec{ProfileType: profileType, Filters: filters}
+	if datasourceName != "" {
+		spec.Datasource = &datasource.Selector{Kind: PyroscopeDatasourceKind, Name: datasourceName}
+	}
+	return query.Option{
+		Kind: plugin.KindProfileQuery,
+		Plugin: common.Plugin{
+			Kind: PyroscopeProfileQueryKind,
+			Spec: spec,
+		},
+	}
+}
+
+func flameChart() panel.Option {
+	return panel.Plugin(common.Plugin{Kind: flameChartKind, Spec: map[string]any{
+		"palette":        "package-name",
+		"showSettings":   true,
+		"showSeries":     true,
+		"showTable":      true,
+		"showFlameGraph": true,
+	}})
+}
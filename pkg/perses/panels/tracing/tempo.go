@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/panel"
+	"github.com/perses/perses/go-sdk/query"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/plugin"
+)
+
+// The Perses Tempo plugin ships no Go SDK, so its query and panels are built by hand.
+const (
+	TempoDatasourceKind = "TempoDatasource"
+	TempoTraceQueryKind = "TempoTraceQuery"
+
+	scatterChartKind = "ScatterChart"
+	traceTableKind   = "TraceTable"
+)
+
+type tempoQuerySpec struct {
+	Datasource *datasource.Selector `json:"datasource,omitempty" yaml:"datasource,omitempty"`
+	Query      string               `json:"query" yaml:"query"`
+	Limit      int                  `json:"limit,omitempty" yaml:"limit,omitempty"`
+}
+
+// TraceQL returns a trace query evaluating the TraceQL expression against the
+// given Tempo datasource, returning at most limit traces.
+func TraceQL(expr string, datasourceName string, limit int) query.Option {
+	spec := tempoQuerySpec{Query: expr, Limit: limit}
+	if datasourceName != "" {
+		spec.Datasource = &datasource.Selector{Kind: TempoDatasourceKind, Name: datasourceName}
+	}
+	return query.Option{
+		Kind: plugin.KindTraceQuery,
+		Plugin: common.Plugin{
+			Kind: TempoTraceQueryKind,
+			Spec: spec,
+		},
+	}
+}
+
+func scatterChart() panel.Option {
+	return panel.Plugin(common.Plugin{Kind: scatterChartKind, Spec: map[string]any{}})
+}
+
+func traceTable() panel.Option {
+	return panel.Plugin(common.Plugin{Kind: traceTableKind, Spec: map[string]any{}})
+}
@@ -0,0 +1,213 @@
+package tracing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/community-mixins/pkg/dashboards"
+	commonSdk "github.com/perses/perses/go-sdk/common"
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/plugins/prometheus/sdk/go/query"
+	timeSeriesPanel "github.com/perses/plugins/timeserieschart/sdk/go"
+	dl "github.com/stolostron/multicluster-observability-addon/pkg/perses/panels/datalinks"
+)
+
+const (
+	// DefaultSpanMetricsNamespace is the metric namespace of the spanmetrics connector
+	// when its configuration does not set one.
+	DefaultSpanMetricsNamespace = "traces.span.metrics"
+
+	statusCodeError = "STATUS_CODE_ERROR"
+
+	// The resource attributes the trace panels filter on.
+	clusterAttribute   = "resource.k8s.cluster.name"
+	namespaceAttribute = "resource.k8s.namespace.name"
+	serviceAttribute   = "resource.service.name"
+)
+
+// SpanMetrics names the metrics generated by the spanmetrics connector of the
+// OpenTelemetry collector.
+type SpanMetrics struct {
+	Calls          string
+	DurationBucket string
+	// DurationUnit is the Perses unit of the duration histogram.
+	DurationUnit string
+}
+
+// NewSpanMetrics returns the Prometheus names of the spanmetrics connector
+// metrics for the given metric namespace and histogram unit, "ms" or "s".
+func NewSpanMetrics(namespace, histogramUnit string) SpanMetrics {
+	prefix := strings.NewReplacer(".", "_", "-", "_").Replace(namespace)
+	if prefix != "" {
+		prefix += "_"
+	}
+	if histogramUnit == "s" {
+		return SpanMetrics{
+			Calls:          prefix + "calls_total",
+			DurationBucket: prefix + "duration_seconds_bucket",
+			DurationUnit:   string(commonSdk.SecondsUnit),
+		}
+	}
+	return SpanMetrics{
+		Calls:          prefix + "calls_total",
+		DurationBucket: prefix + "duration_milliseconds_bucket",
+		DurationUnit:   string(commonSdk.MilliSecondsUnit),
+	}
+}
+
+func timeSeriesChart(unit string) panel.Option {
+	return timeSeriesPanel.Chart(
+		timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
+			Format: &commonSdk.Format{
+				Unit: &unit,
+			},
+		}),
+		timeSeriesPanel.WithLegend(timeSeriesPanel.Legend{
+			Position: timeSeriesPanel.BottomPosition,
+			Mode:     timeSeriesPanel.TableMode,
+			Size:     timeSeriesPanel.SmallSize,
+		}),
+		timeSeriesPanel.WithVisual(timeSeriesPanel.Visual{
+			Display:      timeSeriesPanel.LineDisplay,
+			ConnectNulls: false,
+			LineWidth:    0.25,
+			AreaOpacity:  0.5,
+			Palette:      &timeSeriesPanel.Palette{Mode: timeSeriesPanel.AutoMode},
+		}),
+	)
+}
+
+// computeLinks link a panel to the compute resources dashboards of the
+// selected cluster and namespace.
+func computeLinks() []panel.Option {
+	return []panel.Option{
+		panel.AddLink(dl.DashboardURL("k8s-compute-resources-cluster", dl.StaticParam("cluster", "$cluster")),
+			link.Name("Compute Resources / Cluster"),
+			link.RenderVariable(true),
+			link.TargetBlank(true),
+		),
+		panel.AddLink(dl.DashboardURL("k8s-compute-resources-namespace-pods", dl.StaticParam("cluster", "$cluster"), dl.StaticParam("namespace", "$namespace")),
+			link.Name("Compute Resources / Namespace (Pods)"),
+			link.RenderVariable(true),
+			link.TargetBlank(true),
+		),
+	}
+}
+
+func SpanRateByCluster(datasourceName, clusterLabelName string, sm SpanMetrics) panelgroup.Option {
+	return panelgroup.AddPanel("Span Rate by Cluster",
+		panel.Description("Shows the rate of spans recorded by the spanmetrics connector of each cluster"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit),
+		panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf("sum by (%s) (rate(%s[5m]))", clusterLabelName, sm.Calls),
+				query.SeriesNameFormat("{{"+clusterLabelName+"}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func ErrorRateByCluster(datasourceName, clusterLabelName string, sm SpanMetrics) panelgroup.Option {
+	return panelgroup.AddPanel("Error Rate by Cluster",
+		panel.Description("Shows the share of the spans of each cluster that ended with an error status"),
+		timeSeriesChart(string(commonSdk.PercentDecimalUnit)),
+		panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf(`sum by (%[3]s) (rate(%[1]s{status_code="%[2]s"}[5m])) / sum by (%[3]s) (rate(%[1]s[5m]))`, sm.Calls, statusCodeError, clusterLabelName),
+				query.SeriesNameFormat("{{"+clusterLabelName+"}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	)
+}
+
+func SpanRateByService(datasourceName, clusterLabelName string, sm SpanMetrics) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the rate of spans of each service of the selected cluster"),
+		timeSeriesChart(dashboards.RequestsPerSecondsUnit),
+		panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf(`sum by (service_name) (rate(%s{%s="$cluster", service_name=~"$service"}[5m]))`, sm.Calls, clusterLabelName),
+				query.SeriesNameFormat("{{service_name}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	}
+	return panelgroup.AddPanel("Span Rate", append(options, computeLinks()...)...)
+}
+
+func ErrorRateByService(datasourceName, clusterLabelName string, sm SpanMetrics) panelgroup.Option {
+	selector := clusterLabelName + `="$cluster", service_name=~"$service"`
+	options := []panel.Option{
+		panel.Description("Shows the share of the spans of each service of the selected cluster that ended with an error status"),
+		timeSeriesChart(string(commonSdk.PercentDecimalUnit)),
+		panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf(`sum by (service_name) (rate(%[1]s{%[2]s, status_code="%[3]s"}[5m])) / sum by (service_name) (rate(%[1]s{%[2]s}[5m]))`, sm.Calls, selector, statusCodeError),
+				query.SeriesNameFormat("{{service_name}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	}
+	return panelgroup.AddPanel("Error Rate", append(options, computeLinks()...)...)
+}
+
+func LatencyByService(datasourceName, clusterLabelName string, sm SpanMetrics) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the 95th percentile of the span duration of each service of the selected cluster"),
+		timeSeriesChart(sm.DurationUnit),
+		panel.AddQuery(
+			query.PromQL(
+				fmt.Sprintf(`histogram_quantile(0.95, sum by (le, service_name) (rate(%s{%s="$cluster", service_name=~"$service"}[5m])))`, sm.DurationBucket, clusterLabelName),
+				query.SeriesNameFormat("{{service_name}}"),
+				dashboards.AddQueryDataSource(datasourceName),
+			),
+		),
+	}
+	// Perses doesn't render exemplars, so the panel links to the traces of the
+	// services in the current time range instead: zooming on a spike narrows
+	// the traces to the ones that caused it.
+	options = append(options, dl.TracesPanelLink("$service"))
+	return panelgroup.AddPanel("Latency (p95)", append(options, computeLinks()...)...)
+}
+
+// traceSelector selects the spans of the services of the selected cluster
+// and namespace, plus the given conditions.
+func traceSelector(conditions ...string) string {
+	filters := append([]string{
+		clusterAttribute + `="$cluster"`,
+		namespaceAttribute + `=~"$namespace"`,
+		serviceAttribute + `=~"$service"`,
+	}, conditions...)
+	return "{" + strings.Join(filters, " && ") + "}"
+}
+
+func TraceDurations(datasourceName string) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Shows the duration of the traces of the selected services, errors included"),
+		scatterChart(),
+		panel.AddQuery(TraceQL(traceSelector(), datasourceName, 100)),
+	}
+	return panelgroup.AddPanel("Trace Durations", append(options, computeLinks()...)...)
+}
+
+func ErrorTraces(datasourceName string) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Lists the latest traces of the selected services with a span in error"),
+		traceTable(),
+		panel.AddQuery(TraceQL(traceSelector("status=error"), datasourceName, 20)),
+	}
+	return panelgroup.AddPanel("Error Traces", append(options, computeLinks()...)...)
+}
+
+func SlowTraces(datasourceName string) panelgroup.Option {
+	options := []panel.Option{
+		panel.Description("Lists the latest traces of the selected services with a span longer than a second"),
+		traceTable(),
+		panel.AddQuery(TraceQL(traceSelector("duration>1s"), datasourceName, 20)),
+	}
+	return panelgroup.AddPanel("Slow Traces", append(options, computeLinks()...)...)
+}
@@ -1,5 +1,34 @@
 package virtualization
 
+import "regexp"
+
+// defaultClusterLabelName is the label carrying the name of the cluster of
+// the series in the queries below.
+const defaultClusterLabelName = "cluster"
+
+// clusterLabelRef matches the references to the cluster label in the queries
+// and legends: matchers, groupings and label_replace arguments, but not the
+// $cluster variable.
+var clusterLabelRef = regexp.MustCompile(`(^|[^$\w-])cluster\b`)
+
+// clusterLabel returns the label carrying the name of the cluster of the
+// series, "cluster" by default.
+func clusterLabel(clusterLabelName string) string {
+	if clusterLabelName == "" {
+		return defaultClusterLabelName
+	}
+	return clusterLabelName
+}
+
+// WithClusterLabel renames the cluster label of the query or legend to the
+// configured cluster label.
+func WithClusterLabel(expr, clusterLabelName string) string {
+	if clusterLabel(clusterLabelName) == defaultClusterLabelName {
+		return expr
+	}
+	return clusterLabelRef.ReplaceAllString(expr, "${1}"+clusterLabelName)
+}
+
 // Shared PromQL sub-expressions used across multiple dashboards.
 
 // vmInfoStatusExpr converts status_group to a human-readable status label.
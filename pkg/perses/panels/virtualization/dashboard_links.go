@@ -16,17 +16,17 @@ func dashboardLinkURL(dashboard, project string, vars ...string) string {
 	return b.String()
 }
 
-func clusterDetailsDashboardLinkURL(project string) string {
+func clusterDetailsDashboardLinkURL(clusterLabelName, project string) string {
 	return dashboardLinkURL(
 		"acm-openshift-virtualization-single-cluster-view", project,
-		"cluster", `${__data.fields["cluster"]}`,
+		"cluster", `${__data.fields["`+clusterLabel(clusterLabelName)+`"]}`,
 	)
 }
 
-func vmDetailsDashboardLinkByValueURL(project string) string {
+func vmDetailsDashboardLinkByValueURL(clusterLabelName, project string) string {
 	return dashboardLinkURL(
 		"acm-openshift-virtualization-single-vm-view", project,
-		"cluster", `${__data.fields["cluster"]}`,
+		"cluster", `${__data.fields["`+clusterLabel(clusterLabelName)+`"]}`,
 		"namespace", `${__data.fields["namespace"]}`,
 		"name", `${__data.fields["name"]}`,
 	)
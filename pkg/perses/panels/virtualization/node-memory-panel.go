@@ -20,7 +20,7 @@ func memoryTSBottomLegend() timeSeriesPanel.Legend {
 }
 
 // NodeMemoryClusterUtilizationNow is a gauge for aggregated physical memory utilization.
-func NodeMemoryClusterUtilizationNow(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterUtilizationNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster Utilization",
 		panel.Description("This panel is providing the aggregated memory utilization of all nodes of the cluster. This value is more helpful, the more balanced the memory utilization of all nodes is.\nKeep it green."),
 		gaugePanel.Chart(
@@ -35,7 +35,7 @@ func NodeMemoryClusterUtilizationNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationNowPhysicalRatio,
+			WithClusterLabel(nodeMemoryClusterUtilizationNowPhysicalRatio, clusterLabelName),
 			query.SeriesNameFormat("Physical Memory utilization"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -43,7 +43,7 @@ func NodeMemoryClusterUtilizationNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryClusterVirtualCommittedNow is a gauge for virtual memory commitment vs allocatable.
-func NodeMemoryClusterVirtualCommittedNow(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterVirtualCommittedNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster Virtual Committed",
 		panel.Description("This panel shows the amount of committed virtual memory as a percentage of the allocatable physical memory. Overcommit occurs whenever the values goes beyond 100%."),
 		gaugePanel.Chart(
@@ -58,7 +58,7 @@ func NodeMemoryClusterVirtualCommittedNow(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterVirtualCommittedNowRatio,
+			WithClusterLabel(nodeMemoryClusterVirtualCommittedNowRatio, clusterLabelName),
 			query.SeriesNameFormat("Virtual Memory commitment"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -66,7 +66,7 @@ func NodeMemoryClusterVirtualCommittedNow(datasourceName string) panelgroup.Opti
 }
 
 // NodeMemoryVmVirtualCommittedNow is a gauge for average VM overcommit ratio.
-func NodeMemoryVmVirtualCommittedNow(datasourceName string) panelgroup.Option {
+func NodeMemoryVmVirtualCommittedNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Virtual Committed",
 		gaugePanel.Chart(
 			gaugePanel.Calculation("last-number"),
@@ -80,7 +80,7 @@ func NodeMemoryVmVirtualCommittedNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryVMVirtualCommittedNowAvgOvercommit,
+			WithClusterLabel(nodeMemoryVMVirtualCommittedNowAvgOvercommit, clusterLabelName),
 			query.SeriesNameFormat("Average VM overcommit ratio"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -88,7 +88,7 @@ func NodeMemoryVmVirtualCommittedNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeUtilizationMinNow is a gauge for the lowest node memory utilization.
-func NodeMemoryNodeUtilizationMinNow(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeUtilizationMinNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node Utilization - min",
 		panel.Description("This panel is showing the node memory utilization of the node with the lowest memory utilization in the cluster."),
 		gaugePanel.Chart(
@@ -103,7 +103,7 @@ func NodeMemoryNodeUtilizationMinNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeUtilizationMinNow,
+			WithClusterLabel(nodeMemoryNodeUtilizationMinNow, clusterLabelName),
 			query.SeriesNameFormat("Min"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -111,7 +111,7 @@ func NodeMemoryNodeUtilizationMinNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeUtilizationMaxNow is a gauge for the highest node memory utilization.
-func NodeMemoryNodeUtilizationMaxNow(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeUtilizationMaxNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node Utilization - max",
 		gaugePanel.Chart(
 			gaugePanel.Calculation("last-number"),
@@ -125,7 +125,7 @@ func NodeMemoryNodeUtilizationMaxNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeUtilizationMaxNow,
+			WithClusterLabel(nodeMemoryNodeUtilizationMaxNow, clusterLabelName),
 			query.SeriesNameFormat("Max"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -133,7 +133,7 @@ func NodeMemoryNodeUtilizationMaxNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodePressureMaxNow is a gauge for peak memory PSI (pressure stall information).
-func NodeMemoryNodePressureMaxNow(datasourceName string) panelgroup.Option {
+func NodeMemoryNodePressureMaxNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node PSI - max",
 		panel.Description("Memory PSI (Pressure Stall Information)"),
 		panel.AddLink("https://access.redhat.com/solutions/6987181",
@@ -159,7 +159,7 @@ func NodeMemoryNodePressureMaxNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodePressureMaxNow,
+			WithClusterLabel(nodeMemoryNodePressureMaxNow, clusterLabelName),
 			query.SeriesNameFormat("{{instance}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -167,7 +167,7 @@ func NodeMemoryNodePressureMaxNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeRequestsMinmaxNow shows min/max pod memory requests as a fraction of allocatable.
-func NodeMemoryNodeRequestsMinmaxNow(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeRequestsMinmaxNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node Requests - min/max",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -181,12 +181,12 @@ func NodeMemoryNodeRequestsMinmaxNow(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeRequestsMinNow,
+			WithClusterLabel(nodeMemoryNodeRequestsMinNow, clusterLabelName),
 			query.SeriesNameFormat("Min"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeRequestsMaxNow,
+			WithClusterLabel(nodeMemoryNodeRequestsMaxNow, clusterLabelName),
 			query.SeriesNameFormat("Max"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -194,7 +194,7 @@ func NodeMemoryNodeRequestsMinmaxNow(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryPlanMinmax shows min/max virtual commit level across nodes.
-func NodeMemoryPlanMinmax(datasourceName string) panelgroup.Option {
+func NodeMemoryPlanMinmax(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node Virtual - min/max",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -209,12 +209,12 @@ func NodeMemoryPlanMinmax(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryPlanMinVirtualCommitLevel,
+			WithClusterLabel(nodeMemoryPlanMinVirtualCommitLevel, clusterLabelName),
 			query.SeriesNameFormat("min {{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryPlanMaxVirtualCommitLevel,
+			WithClusterLabel(nodeMemoryPlanMaxVirtualCommitLevel, clusterLabelName),
 			query.SeriesNameFormat("max {{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -222,7 +222,7 @@ func NodeMemoryPlanMinmax(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeSystemExceedsReservationAlertNow shows the share of nodes firing SystemMemoryExceedsReservation.
-func NodeMemoryNodeSystemExceedsReservationAlertNow(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeSystemExceedsReservationAlertNow(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("System Exceeding Reservation",
 		panel.Description("This panel provides the percentage of nodes where core system (hypervisor) components are utilizing more than reserved memory. This should not happen, and is specifically critical for clusters under load. Keep it green."),
 		panel.AddLink("https://access.redhat.com/solutions/5788171",
@@ -243,7 +243,7 @@ func NodeMemoryNodeSystemExceedsReservationAlertNow(datasourceName string) panel
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeSystemExceedsReservationAlertNow,
+			WithClusterLabel(nodeMemoryNodeSystemExceedsReservationAlertNow, clusterLabelName),
 			query.SeriesNameFormat("{{alertname}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -251,7 +251,7 @@ func NodeMemoryNodeSystemExceedsReservationAlertNow(datasourceName string) panel
 }
 
 // NodeMemoryClusterPressure plots cluster memory pressure (waiting vs stalled).
-func NodeMemoryClusterPressure(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterPressure(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster - Memory Pressure",
 		panel.Description("The pressure is indicating if workloads are waiting for memory."),
 		timeSeriesPanel.Chart(
@@ -273,12 +273,12 @@ func NodeMemoryClusterPressure(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterPressureWaiting,
+			WithClusterLabel(nodeMemoryClusterPressureWaiting, clusterLabelName),
 			query.SeriesNameFormat("Waiting"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterPressureStalled,
+			WithClusterLabel(nodeMemoryClusterPressureStalled, clusterLabelName),
 			query.SeriesNameFormat("Stalled"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -286,7 +286,7 @@ func NodeMemoryClusterPressure(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryClusterUtilizationHistory shows capacity, utilization, and planned requests over time.
-func NodeMemoryClusterUtilizationHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterUtilizationHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Physical Memory Utilization & Requests",
 		panel.Description("This is showing the total system utilization (split between virt and non virt workloads). In addition the current plan (requests) are shown in order to to show how much available memory the scheduler is seeing."),
 		timeSeriesPanel.Chart(
@@ -306,22 +306,22 @@ func NodeMemoryClusterUtilizationHistory(datasourceName string) panelgroup.Optio
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistoryCapacity,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistoryCapacity, clusterLabelName),
 			query.SeriesNameFormat("Node memory capacity"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistoryUtilWithVirt,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistoryUtilWithVirt, clusterLabelName),
 			query.SeriesNameFormat("Utilization - Node memory utilization (with virt)"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistoryUtilWithoutVirt,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistoryUtilWithoutVirt, clusterLabelName),
 			query.SeriesNameFormat("Utilization - Node memory utilization (without virt)"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistoryPlanRequests,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistoryPlanRequests, clusterLabelName),
 			query.SeriesNameFormat("Plan - Memory requests"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -329,7 +329,7 @@ func NodeMemoryClusterUtilizationHistory(datasourceName string) panelgroup.Optio
 }
 
 // NodeMemoryClusterUtilizationHistorySummary summarizes allocatable, VM plan, and utilization (worst-case view).
-func NodeMemoryClusterUtilizationHistorySummary(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterUtilizationHistorySummary(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster Utilization",
 		panel.Description("The virtual memory assignment is showing the worst-case scenario if all virtual memory was used right now. The assigned virtual memory is shown on top of the non virtualization related memory utilization. The current utilization plus worst-case virtual memory utilization is indicating the worst case memory overcommitment."),
 		timeSeriesPanel.Chart(
@@ -356,27 +356,27 @@ func NodeMemoryClusterUtilizationHistorySummary(datasourceName string) panelgrou
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistorySummaryAllocatablePlusSwap,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistorySummaryAllocatablePlusSwap, clusterLabelName),
 			query.SeriesNameFormat("Cluster allocatable memory + swap capacity"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistorySummaryAllocatable,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistorySummaryAllocatable, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 			query.SeriesNameFormat("Cluster allocatable memory capacity"),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistorySummaryPlanVMAssigned,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistorySummaryPlanVMAssigned, clusterLabelName),
 			query.SeriesNameFormat("Plan - VM assigned virtual memory"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistorySummaryUtilizationCluster,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistorySummaryUtilizationCluster, clusterLabelName),
 			query.SeriesNameFormat("Utilization - Cluster memory utilization"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterUtilizationHistorySummaryPlanMaxVMAssigned,
+			WithClusterLabel(nodeMemoryClusterUtilizationHistorySummaryPlanMaxVMAssigned, clusterLabelName),
 			query.SeriesNameFormat("Plan - Maximum VM assigned virtual memory"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -384,7 +384,7 @@ func NodeMemoryClusterUtilizationHistorySummary(datasourceName string) panelgrou
 }
 
 // NodeMemoryClusterVirtualCommittedHistory shows virtual memory assignment vs capacity over time.
-func NodeMemoryClusterVirtualCommittedHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryClusterVirtualCommittedHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Memory Assignment",
 		panel.Description("The virtual memory assignment is showing the worst-case scenario if all virtual memory was used right now. The assigned virtual memory is shown on top of the non virtualization related memory utilization. The current utilization plus worst-case virtual memory utilization is indicating the worst case memory overcommitment."),
 		timeSeriesPanel.Chart(
@@ -410,17 +410,17 @@ func NodeMemoryClusterVirtualCommittedHistory(datasourceName string) panelgroup.
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterVirtualCommittedHistoryNodeCapacity,
+			WithClusterLabel(nodeMemoryClusterVirtualCommittedHistoryNodeCapacity, clusterLabelName),
 			query.SeriesNameFormat("Node capacity"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterVirtualCommittedHistoryPlanVMAssigned,
+			WithClusterLabel(nodeMemoryClusterVirtualCommittedHistoryPlanVMAssigned, clusterLabelName),
 			query.SeriesNameFormat("Plan - VM assigned virtual memory"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryClusterVirtualCommittedHistoryUtilWithoutVirt,
+			WithClusterLabel(nodeMemoryClusterVirtualCommittedHistoryUtilWithoutVirt, clusterLabelName),
 			query.SeriesNameFormat("Utilization - Node memory utilization (without virt)"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -428,7 +428,7 @@ func NodeMemoryClusterVirtualCommittedHistory(datasourceName string) panelgroup.
 }
 
 // NodeMemoryNodePressureHistory plots memory PSI waiting rate per instance.
-func NodeMemoryNodePressureHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryNodePressureHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Node - Pressure",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -443,7 +443,7 @@ func NodeMemoryNodePressureHistory(datasourceName string) panelgroup.Option {
 			timeSeriesPanel.WithLegend(memoryTSBottomLegend()),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodePressureHistory,
+			WithClusterLabel(nodeMemoryNodePressureHistory, clusterLabelName),
 			query.SeriesNameFormat("{{instance}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -451,7 +451,7 @@ func NodeMemoryNodePressureHistory(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeRequestsHistory shows pod memory requests as a fraction of node allocatable.
-func NodeMemoryNodeRequestsHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeRequestsHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Plan - Pod Requests per Node",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -469,7 +469,7 @@ func NodeMemoryNodeRequestsHistory(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeRequestsHistory,
+			WithClusterLabel(nodeMemoryNodeRequestsHistory, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -477,7 +477,7 @@ func NodeMemoryNodeRequestsHistory(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeUtilizationHistory shows actual memory overcommit level per node.
-func NodeMemoryNodeUtilizationHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeUtilizationHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Utilization - Actual Overcommit Level",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -495,7 +495,7 @@ func NodeMemoryNodeUtilizationHistory(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeUtilizationHistory,
+			WithClusterLabel(nodeMemoryNodeUtilizationHistory, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -503,7 +503,7 @@ func NodeMemoryNodeUtilizationHistory(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryUtilizationDistribution plots per-node virtual memory commit level.
-func NodeMemoryUtilizationDistribution(datasourceName string) panelgroup.Option {
+func NodeMemoryUtilizationDistribution(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Plan - Virtual Memory Commit Level",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -522,7 +522,7 @@ func NodeMemoryUtilizationDistribution(datasourceName string) panelgroup.Option
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryUtilizationDistribution,
+			WithClusterLabel(nodeMemoryUtilizationDistribution, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -530,7 +530,7 @@ func NodeMemoryUtilizationDistribution(datasourceName string) panelgroup.Option
 }
 
 // NodeMemorySwap shows aggregated cluster swap available vs used.
-func NodeMemorySwap(datasourceName string) panelgroup.Option {
+func NodeMemorySwap(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster - Aggregated Swap",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -546,12 +546,12 @@ func NodeMemorySwap(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemorySwapAvailableBytes,
+			WithClusterLabel(nodeMemorySwapAvailableBytes, clusterLabelName),
 			query.SeriesNameFormat("Available"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemorySwapUsedBytes,
+			WithClusterLabel(nodeMemorySwapUsedBytes, clusterLabelName),
 			query.SeriesNameFormat("Used"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -559,7 +559,7 @@ func NodeMemorySwap(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryNodeSystemReservedUtilizationHistory shows top nodes by system reserved memory utilization.
-func NodeMemoryNodeSystemReservedUtilizationHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeSystemReservedUtilizationHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Utilization - Reserved System Memory",
 		panel.Description("This graph is showing the utilization of the hypervisor system reserved memory. The utilization must stay below 100%, otherwise the hypervisor is using more memory for system processes than what was reserved. This is putting system processes at risk."),
 		timeSeriesPanel.Chart(
@@ -578,7 +578,7 @@ func NodeMemoryNodeSystemReservedUtilizationHistory(datasourceName string) panel
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeSystemReservedUtilizationHistory,
+			WithClusterLabel(nodeMemoryNodeSystemReservedUtilizationHistory, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -586,7 +586,7 @@ func NodeMemoryNodeSystemReservedUtilizationHistory(datasourceName string) panel
 }
 
 // NodeMemoryNodeSystemReservedMinmaxHistory shows min and max system reserved utilization across nodes.
-func NodeMemoryNodeSystemReservedMinmaxHistory(datasourceName string) panelgroup.Option {
+func NodeMemoryNodeSystemReservedMinmaxHistory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Utilization - min/max",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -602,12 +602,12 @@ func NodeMemoryNodeSystemReservedMinmaxHistory(datasourceName string) panelgroup
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeSystemReservedMinHistory,
+			WithClusterLabel(nodeMemoryNodeSystemReservedMinHistory, clusterLabelName),
 			query.SeriesNameFormat("min {{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNodeSystemReservedMaxHistory,
+			WithClusterLabel(nodeMemoryNodeSystemReservedMaxHistory, clusterLabelName),
 			query.SeriesNameFormat("max {{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -615,7 +615,7 @@ func NodeMemoryNodeSystemReservedMinmaxHistory(datasourceName string) panelgroup
 }
 
 // NodeMemoryVMs plots per-VM memory overcommit ratio (domain + overhead vs request).
-func NodeMemoryVMs(datasourceName string) panelgroup.Option {
+func NodeMemoryVMs(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Overcommit Ratio",
 		panel.Description("Any value larger than 1 shows that the VM is using more memory than it requested"),
 		timeSeriesPanel.Chart(
@@ -633,7 +633,7 @@ func NodeMemoryVMs(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryVMsOvercommitRatio,
+			WithClusterLabel(nodeMemoryVMsOvercommitRatio, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}}/{{label_vm_kubevirt_io_name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -641,7 +641,7 @@ func NodeMemoryVMs(datasourceName string) panelgroup.Option {
 }
 
 // NodeMemoryVMVirtualMemoryUtilization compares VM guest memory used to launcher usage (top 10).
-func NodeMemoryVMVirtualMemoryUtilization(datasourceName string) panelgroup.Option {
+func NodeMemoryVMVirtualMemoryUtilization(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Virtual Memory Utilization vs Host VM Utilization",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithYAxis(timeSeriesPanel.YAxis{
@@ -666,7 +666,7 @@ func NodeMemoryVMVirtualMemoryUtilization(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryVMVirtualMemoryUtilizationHostVMRatio,
+			WithClusterLabel(nodeMemoryVMVirtualMemoryUtilizationHostVMRatio, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}}/{{label_vm_kubevirt_io_name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -674,11 +674,11 @@ func NodeMemoryVMVirtualMemoryUtilization(datasourceName string) panelgroup.Opti
 }
 
 // NodeMemoryNumberOfRunningVMs counts running VMs via kubevirt_vmi_memory_domain_bytes.
-func NodeMemoryNumberOfRunningVMs(datasourceName string) panelgroup.Option {
+func NodeMemoryNumberOfRunningVMs(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Number of Running VMs",
 		timeSeriesPanel.Chart(),
 		panel.AddQuery(query.PromQL(
-			nodeMemoryNumberOfRunningVMs,
+			WithClusterLabel(nodeMemoryNumberOfRunningVMs, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -39,7 +39,7 @@ func mergePluginSpecFields(extra map[string]any) panel.Option {
 }
 
 // OverviewTotalClusters (0_0)
-func OverviewTotalClusters(datasourceName string) panelgroup.Option {
+func OverviewTotalClusters(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Clusters",
 		panel.Description("Total clusters with OpenShift Virtualization."),
 		statPanel.Chart(
@@ -48,14 +48,14 @@ func OverviewTotalClusters(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			overviewTotalClusters,
+			WithClusterLabel(overviewTotalClusters, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewClustersCriticalHealth (0_1)
-func OverviewClustersCriticalHealth(datasourceName string) panelgroup.Option {
+func OverviewClustersCriticalHealth(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters in Critical Health",
 		panel.Description("Total number of clusters with critical issues that impact the operator health, which are based on alerts and the operator conditions. This means there is a risk of core functionality loss for your clusters."),
 		statPanel.Chart(
@@ -68,14 +68,14 @@ func OverviewClustersCriticalHealth(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			overviewClustersCriticalHealth,
+			WithClusterLabel(overviewClustersCriticalHealth, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewTotalAllocatableNodes (0_2)
-func OverviewTotalAllocatableNodes(datasourceName string) panelgroup.Option {
+func OverviewTotalAllocatableNodes(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Allocatable Nodes",
 		panel.Description("Total number of nodes that are available to host virtual machines."),
 		statPanel.Chart(
@@ -85,14 +85,14 @@ func OverviewTotalAllocatableNodes(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none", "metricLabel": ""}),
 		panel.AddQuery(query.PromQL(
-			overviewTotalAllocatableNodes,
+			WithClusterLabel(overviewTotalAllocatableNodes, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewTotalVMsStat (0_3)
-func OverviewTotalVMsStat(datasourceName, project string) panelgroup.Option {
+func OverviewTotalVMsStat(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Total VMs",
 		panel.Description("Total number of virtual machines."),
 		statPanel.Chart(
@@ -108,14 +108,14 @@ func OverviewTotalVMsStat(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewTotalVMs,
+			WithClusterLabel(overviewTotalVMs, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewVMsRunning (0_4a)
-func OverviewVMsRunning(datasourceName, project string) panelgroup.Option {
+func OverviewVMsRunning(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs",
 		panel.Description("Number of virtual machines currently running."),
 		statPanel.Chart(
@@ -128,7 +128,7 @@ func OverviewVMsRunning(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusStatRunning,
+			WithClusterLabel(overviewVMsByStatusStatRunning, clusterLabelName),
 			query.SeriesNameFormat("Running"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -136,7 +136,7 @@ func OverviewVMsRunning(datasourceName, project string) panelgroup.Option {
 }
 
 // OverviewVMsInErrorStat (0_4b) — red when ≥1.
-func OverviewVMsInErrorStat(datasourceName, project string) panelgroup.Option {
+func OverviewVMsInErrorStat(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs in Error",
 		panel.Description("Number of virtual machines currently in an error state. Any value above zero requires attention."),
 		statPanel.Chart(
@@ -155,7 +155,7 @@ func OverviewVMsInErrorStat(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusStatError,
+			WithClusterLabel(overviewVMsByStatusStatError, clusterLabelName),
 			query.SeriesNameFormat("Error"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -163,7 +163,7 @@ func OverviewVMsInErrorStat(datasourceName, project string) panelgroup.Option {
 }
 
 // OverviewVMsStopped (0_4c)
-func OverviewVMsStopped(datasourceName, project string) panelgroup.Option {
+func OverviewVMsStopped(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Stopped VMs",
 		panel.Description("Number of virtual machines currently stopped."),
 		statPanel.Chart(
@@ -176,7 +176,7 @@ func OverviewVMsStopped(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusStatStopped,
+			WithClusterLabel(overviewVMsByStatusStatStopped, clusterLabelName),
 			query.SeriesNameFormat("Stopped"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -184,7 +184,7 @@ func OverviewVMsStopped(datasourceName, project string) panelgroup.Option {
 }
 
 // OverviewVMsStarting (0_4d)
-func OverviewVMsStarting(datasourceName, project string) panelgroup.Option {
+func OverviewVMsStarting(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Starting VMs",
 		panel.Description("Number of virtual machines currently starting up."),
 		statPanel.Chart(
@@ -197,7 +197,7 @@ func OverviewVMsStarting(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusStatStarting,
+			WithClusterLabel(overviewVMsByStatusStatStarting, clusterLabelName),
 			query.SeriesNameFormat("Starting"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -205,7 +205,7 @@ func OverviewVMsStarting(datasourceName, project string) panelgroup.Option {
 }
 
 // OverviewVMsMigrating (0_4e)
-func OverviewVMsMigrating(datasourceName, project string) panelgroup.Option {
+func OverviewVMsMigrating(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Migrating VMs",
 		panel.Description("Number of virtual machines currently being migrated."),
 		statPanel.Chart(
@@ -218,7 +218,7 @@ func OverviewVMsMigrating(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusStatMigrating,
+			WithClusterLabel(overviewVMsByStatusStatMigrating, clusterLabelName),
 			query.SeriesNameFormat("Migrating"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -226,7 +226,7 @@ func OverviewVMsMigrating(datasourceName, project string) panelgroup.Option {
 }
 
 // OverviewClustersWarningHealth (0_5)
-func OverviewClustersWarningHealth(datasourceName string) panelgroup.Option {
+func OverviewClustersWarningHealth(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters in Warning Health",
 		panel.Description("Total number of clusters with warning level issues that impact the operator health, which are based on alerts and the operator conditions. This means there is a risk of core functionality loss for your clusters."),
 		statPanel.Chart(
@@ -240,25 +240,25 @@ func OverviewClustersWarningHealth(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			overviewClustersWarningHealth,
+			WithClusterLabel(overviewClustersWarningHealth, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewVMsStartedLast7Days (0_6)
-func OverviewVMsStartedLast7Days(datasourceName, project string) panelgroup.Option {
+func OverviewVMsStartedLast7Days(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Number of VMs Started in the Last 7 Days",
 		tablePanel.Table(),
 		mergePluginSpecFields(map[string]any{
 			"columnSettings": []any{
 				map[string]any{
-					"name":          "cluster",
+					"name":          clusterLabel(clusterLabelName),
 					"enableSorting": true,
 					"dataLink": map[string]any{
 						"openNewTab": true,
 						"title":      "Cluster Details",
-						"url":        clusterDetailsDashboardLinkURL(project),
+						"url":        clusterDetailsDashboardLinkURL(clusterLabelName, project),
 					},
 				},
 				map[string]any{"name": "timestamp", "hide": true},
@@ -266,19 +266,21 @@ func OverviewVMsStartedLast7Days(datasourceName, project string) panelgroup.Opti
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewVMsStartedLast7Days,
+			WithClusterLabel(overviewVMsStartedLast7Days, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-var overviewTableMergeJoinByCluster = []commonSdk.Transform{
-	{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
-	{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{"cluster"}}},
+func overviewTableMergeJoinByCluster(clusterLabelName string) []commonSdk.Transform {
+	return []commonSdk.Transform{
+		{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
+		{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{clusterLabel(clusterLabelName)}}},
+	}
 }
 
 // OverviewClustersByOperatorVersion (0_7)
-func OverviewClustersByOperatorVersion(datasourceName string) panelgroup.Option {
+func OverviewClustersByOperatorVersion(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters by Operator Version",
 		tablePanel.Table(
 			tablePanel.Transform([]commonSdk.Transform{
@@ -301,19 +303,19 @@ func OverviewClustersByOperatorVersion(datasourceName string) panelgroup.Option
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewClustersByOperatorVersionCounts,
+			WithClusterLabel(overviewClustersByOperatorVersionCounts, clusterLabelName),
 			query.SeriesNameFormat("{{version}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewClustersByOperatorVersionPercent,
+			WithClusterLabel(overviewClustersByOperatorVersionPercent, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewClustersByOpenShiftVersion (0_8)
-func OverviewClustersByOpenShiftVersion(datasourceName string) panelgroup.Option {
+func OverviewClustersByOpenShiftVersion(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters by OpenShift Version",
 		tablePanel.Table(
 			tablePanel.Transform([]commonSdk.Transform{
@@ -333,22 +335,22 @@ func OverviewClustersByOpenShiftVersion(datasourceName string) panelgroup.Option
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewClustersByOpenShiftVersionCounts,
+			WithClusterLabel(overviewClustersByOpenShiftVersionCounts, clusterLabelName),
 			query.SeriesNameFormat("{{openshiftVersion}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewClustersByOpenShiftVersionPercent,
+			WithClusterLabel(overviewClustersByOpenShiftVersionPercent, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewOperatorHealthByCluster (1_0)
-func OverviewOperatorHealthByCluster(datasourceName, project string) panelgroup.Option {
+func OverviewOperatorHealthByCluster(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Operator Health by Cluster",
 		tablePanel.Table(
-			tablePanel.Transform(overviewTableMergeJoinByCluster),
+			tablePanel.Transform(overviewTableMergeJoinByCluster(clusterLabelName)),
 		),
 		mergePluginSpecFields(map[string]any{
 			"columnSettings": []any{
@@ -361,11 +363,11 @@ func OverviewOperatorHealthByCluster(datasourceName, project string) panelgroup.
 					},
 				},
 				map[string]any{
-					"name": "cluster", "header": "Cluster", "enableSorting": true,
+					"name": clusterLabel(clusterLabelName), "header": "Cluster", "enableSorting": true,
 					"dataLink": map[string]any{
 						"openNewTab": true,
 						"title":      "Cluster Details",
-						"url":        clusterDetailsDashboardLinkURL(project),
+						"url":        clusterDetailsDashboardLinkURL(clusterLabelName, project),
 					},
 				},
 				map[string]any{"name": "openshiftVersion", "hide": true},
@@ -403,23 +405,23 @@ func OverviewOperatorHealthByCluster(datasourceName, project string) panelgroup.
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewOperatorHealthByClusterStatus,
+			WithClusterLabel(overviewOperatorHealthByClusterStatus, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewOperatorHealthByClusterCriticalAlerts,
+			WithClusterLabel(overviewOperatorHealthByClusterCriticalAlerts, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewOperatorHealthByClusterWarningAlerts,
+			WithClusterLabel(overviewOperatorHealthByClusterWarningAlerts, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewOperatorHealthByClusterRunningVMs,
+			WithClusterLabel(overviewOperatorHealthByClusterRunningVMs, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewOperatorHealthByClusterHCO,
+			WithClusterLabel(overviewOperatorHealthByClusterHCO, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -439,16 +441,16 @@ func overviewPieChartPlugin() panel.Option {
 }
 
 // OverviewRunningVMsByOS (2_0)
-func OverviewRunningVMsByOS(datasourceName string) panelgroup.Option {
+func OverviewRunningVMsByOS(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs by OS",
 		overviewPieChartPlugin(),
 		panel.AddQuery(query.PromQL(
-			overviewRunningVMsByOSKnown,
+			WithClusterLabel(overviewRunningVMsByOSKnown, clusterLabelName),
 			query.SeriesNameFormat("{{os}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewRunningVMsByOSUnknown,
+			WithClusterLabel(overviewRunningVMsByOSUnknown, clusterLabelName),
 			query.SeriesNameFormat("{{os}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -472,7 +474,7 @@ func overviewTSChartLegendLastMax() timeSeriesPanel.Legend {
 }
 
 // OverviewRunningVMsByClusterTop20 (2_1)
-func OverviewRunningVMsByClusterTop20(datasourceName string) panelgroup.Option {
+func OverviewRunningVMsByClusterTop20(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs by Cluster - Top 20",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(overviewTSChartLegendLast()),
@@ -494,15 +496,15 @@ func OverviewRunningVMsByClusterTop20(datasourceName string) panelgroup.Option {
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewRunningVMsByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewRunningVMsByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewVMsByStatusTimeSeries (2_2)
-func OverviewVMsByStatusTimeSeries(datasourceName string) panelgroup.Option {
+func OverviewVMsByStatusTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Machines by Status",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(overviewTSChartLegendLast()),
@@ -523,27 +525,27 @@ func OverviewVMsByStatusTimeSeries(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusTSRunning,
+			WithClusterLabel(overviewVMsByStatusTSRunning, clusterLabelName),
 			query.SeriesNameFormat("running"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusTSStarting,
+			WithClusterLabel(overviewVMsByStatusTSStarting, clusterLabelName),
 			query.SeriesNameFormat("starting"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusTSMigrating,
+			WithClusterLabel(overviewVMsByStatusTSMigrating, clusterLabelName),
 			query.SeriesNameFormat("migrating"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusTSError,
+			WithClusterLabel(overviewVMsByStatusTSError, clusterLabelName),
 			query.SeriesNameFormat("error"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			overviewVMsByStatusTSStopped,
+			WithClusterLabel(overviewVMsByStatusTSStopped, clusterLabelName),
 			query.SeriesNameFormat("stopped"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -551,7 +553,7 @@ func OverviewVMsByStatusTimeSeries(datasourceName string) panelgroup.Option {
 }
 
 // OverviewRunningVMsByNodeTop20 (2_3)
-func OverviewRunningVMsByNodeTop20(datasourceName string) panelgroup.Option {
+func OverviewRunningVMsByNodeTop20(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs by Node - Top 20",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(overviewTSChartLegendLast()),
@@ -572,15 +574,15 @@ func OverviewRunningVMsByNodeTop20(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewRunningVMsByNodeTop20,
-			query.SeriesNameFormat("{{cluster}} | {{node}}"),
+			WithClusterLabel(overviewRunningVMsByNodeTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}} | {{node}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewCPUUsageByCluster (3_0)
-func OverviewCPUUsageByCluster(datasourceName string) panelgroup.Option {
+func OverviewCPUUsageByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Usage by Cluster",
 		panel.Description("This panel displays the top 20 clusters based on their VMs CPU usage over the past 10 minutes. CPU usage is calculated as the total CPU time consumed by VMs. This provides insight into clusters with the highest CPU demand and helps identify potential resource bottlenecks."),
 		timeSeriesPanel.Chart(
@@ -598,15 +600,15 @@ func OverviewCPUUsageByCluster(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewCPUUsageByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewCPUUsageByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewCPUUsagePercentByCluster (3_1)
-func OverviewCPUUsagePercentByCluster(datasourceName string) panelgroup.Option {
+func OverviewCPUUsagePercentByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters by CPU Usage (%)",
 		panel.Description("This panel displays the top 20 clusters based on their CPU usage percentage for clusters running virtual machines (VMs) over the past 10 minutes. CPU usage is calculated as the total CPU time consumed by VMs divided by the total CPU capacity of all nodes in the cluster. This provides insight into how much of the cluster's overall CPU capacity is consumed by VM workloads."),
 		timeSeriesPanel.Chart(
@@ -623,15 +625,15 @@ func OverviewCPUUsagePercentByCluster(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewCPUUsagePercentByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewCPUUsagePercentByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewMemoryUsageByCluster (4_0)
-func OverviewMemoryUsageByCluster(datasourceName string) panelgroup.Option {
+func OverviewMemoryUsageByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage by Cluster",
 		panel.Description("Top 20 Clusters based on the VMs memory usage in the clusters"),
 		timeSeriesPanel.Chart(
@@ -649,15 +651,15 @@ func OverviewMemoryUsageByCluster(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewMemoryUsageByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewMemoryUsageByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewMemoryUsagePercentByCluster (4_1)
-func OverviewMemoryUsagePercentByCluster(datasourceName string) panelgroup.Option {
+func OverviewMemoryUsagePercentByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage by Cluster (%)",
 		panel.Description("Top 20 clusters based on VM memory usage as a percentage of total physical memory across all nodes in the cluster."),
 		timeSeriesPanel.Chart(
@@ -674,15 +676,15 @@ func OverviewMemoryUsagePercentByCluster(datasourceName string) panelgroup.Optio
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewMemoryUsagePercentByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewMemoryUsagePercentByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewNetworkReceivedByCluster (5_0)
-func OverviewNetworkReceivedByCluster(datasourceName string) panelgroup.Option {
+func OverviewNetworkReceivedByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters by Network Received Bytes",
 		panel.Description("This panel displays the top 20 clusters by network received bytes per second over the past 10 minutes. The query measures the rate of incoming network traffic, helping identify clusters with the highest network activity. Use this information to monitor and optimize resource usage for workloads with significant data reception."),
 		timeSeriesPanel.Chart(
@@ -699,15 +701,15 @@ func OverviewNetworkReceivedByCluster(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewNetworkReceivedBytesByClusterTop20,
-			query.SeriesNameFormat("{{cluster}} - Receive"),
+			WithClusterLabel(overviewNetworkReceivedBytesByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}} - Receive", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewNetworkTransmittedByCluster (5_1)
-func OverviewNetworkTransmittedByCluster(datasourceName string) panelgroup.Option {
+func OverviewNetworkTransmittedByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Clusters by Network Transmitted Bytes",
 		panel.Description("This panel displays the top 20 clusters by network transmitted bytes per second over the past 10 minutes. The query measures the rate of outgoing network traffic, helping identify clusters with the highest network activity. Use this information to monitor and optimize resource usage for workloads with significant data transmission."),
 		timeSeriesPanel.Chart(
@@ -724,15 +726,15 @@ func OverviewNetworkTransmittedByCluster(datasourceName string) panelgroup.Optio
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			overviewNetworkTransmittedBytesByClusterTop20,
-			query.SeriesNameFormat("{{cluster}} - Transmit"),
+			WithClusterLabel(overviewNetworkTransmittedBytesByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}} - Transmit", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewStorageTrafficByCluster (6_0)
-func OverviewStorageTrafficByCluster(datasourceName string) panelgroup.Option {
+func OverviewStorageTrafficByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Storage Traffic by Cluster",
 		panel.Description("This panel displays the top 20 clusters based on their combined storage traffic (read + write) over the past 10 minutes."),
 		timeSeriesPanel.Chart(
@@ -754,15 +756,15 @@ func OverviewStorageTrafficByCluster(datasourceName string) panelgroup.Option {
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewStorageTrafficByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewStorageTrafficByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // OverviewStorageIOPsByCluster (6_1)
-func OverviewStorageIOPsByCluster(datasourceName string) panelgroup.Option {
+func OverviewStorageIOPsByCluster(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Storage IOPS by Cluster",
 		panel.Description("This panel displays the top 20 clusters based on their combined storage IOPS (read + write) over the past 10 minutes."),
 		timeSeriesPanel.Chart(
@@ -784,8 +786,8 @@ func OverviewStorageIOPsByCluster(datasourceName string) panelgroup.Option {
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			overviewStorageIOPsByClusterTop20,
-			query.SeriesNameFormat("{{cluster}}"),
+			WithClusterLabel(overviewStorageIOPsByClusterTop20, clusterLabelName),
+			query.SeriesNameFormat(WithClusterLabel("{{cluster}}", clusterLabelName)),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -115,7 +115,7 @@ func singleClusterTSVisual(connectNulls bool) timeSeriesPanel.Visual {
 }
 
 // SingleClusterClusterName shows the selected cluster label on the hyperconverged health metric.
-func SingleClusterClusterName(datasourceName string) panelgroup.Option {
+func SingleClusterClusterName(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Cluster Name",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -123,17 +123,17 @@ func SingleClusterClusterName(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{
 			"colorMode":   "none",
-			"metricLabel": "cluster",
+			"metricLabel": clusterLabel(clusterLabelName),
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterClusterName,
+			WithClusterLabel(singleClusterClusterName, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOpenshiftVirtVersion shows the virt operator CSV version.
-func SingleClusterOpenshiftVirtVersion(datasourceName string) panelgroup.Option {
+func SingleClusterOpenshiftVirtVersion(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("OpenShift Virt Version",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -144,14 +144,14 @@ func SingleClusterOpenshiftVirtVersion(datasourceName string) panelgroup.Option
 			"metricLabel": "version",
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterOpenshiftVirtVersion,
+			WithClusterLabel(singleClusterOpenshiftVirtVersion, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterTotalNodes shows allocatable nodes exposing kubevirt resources.
-func SingleClusterTotalNodes(datasourceName string) panelgroup.Option {
+func SingleClusterTotalNodes(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Allocatable Nodes",
 		panel.Description("Total node count in OpenShift Virtualization clusters"),
 		statPanel.Chart(
@@ -161,14 +161,14 @@ func SingleClusterTotalNodes(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterTotalNodes,
+			WithClusterLabel(singleClusterTotalNodes, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterTotalVMs counts the total number of distinct VMs in the selected cluster.
-func SingleClusterTotalVMs(datasourceName, project string) panelgroup.Option {
+func SingleClusterTotalVMs(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Total VMs",
 		panel.Description("Total number of virtual machines in the selected cluster."),
 		statPanel.Chart(
@@ -185,14 +185,14 @@ func SingleClusterTotalVMs(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterTotalVMs,
+			WithClusterLabel(singleClusterTotalVMs, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterVMsRunningPercent shows the percentage of VMs currently running.
-func SingleClusterVMsRunningPercent(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsRunningPercent(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs Running (%)",
 		panel.Description("Percentage of virtual machines that are currently running out of all VMs in the cluster."),
 		statPanel.Chart(
@@ -206,7 +206,7 @@ func SingleClusterVMsRunningPercent(datasourceName, project string) panelgroup.O
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsRunningPercent,
+			WithClusterLabel(singleClusterVMsRunningPercent, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -215,7 +215,7 @@ func SingleClusterVMsRunningPercent(datasourceName, project string) panelgroup.O
 // SingleClusterVMsNotEvictable shows VMIs with evictionStrategy=None that will
 // block node drain entirely. A non-zero value requires admin action before any
 // node drain or update can complete.
-func SingleClusterVMsNotEvictable(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsNotEvictable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs Not Evictable",
 		panel.Description("Number of running virtual machines with eviction strategy set to None. These VMs will block node drain and cluster updates entirely."),
 		panel.AddLink(vmInventoryLinkURL(project),
@@ -234,14 +234,14 @@ func SingleClusterVMsNotEvictable(datasourceName, project string) panelgroup.Opt
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsNotEvictable,
+			WithClusterLabel(singleClusterVMsNotEvictable, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterVMsCreatedLast24h shows VMs created in the last 24 hours.
-func SingleClusterVMsCreatedLast24h(datasourceName string) panelgroup.Option {
+func SingleClusterVMsCreatedLast24h(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs Created (24h)",
 		panel.Description("Number of virtual machines created in the last 24 hours."),
 		statPanel.Chart(
@@ -250,14 +250,14 @@ func SingleClusterVMsCreatedLast24h(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsCreatedLast24h,
+			WithClusterLabel(singleClusterVMsCreatedLast24h, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterVirtualMachinesByStatus shows running / stopped / error / starting / migrating counts.
-func SingleClusterVMsRunning(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsRunning(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs",
 		panel.Description("Number of virtual machines currently running."),
 		statPanel.Chart(
@@ -270,14 +270,14 @@ func SingleClusterVMsRunning(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMStatusRunning,
+			WithClusterLabel(singleClusterVMStatusRunning, clusterLabelName),
 			query.SeriesNameFormat("Running"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func SingleClusterVMsInError(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsInError(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs in Error",
 		panel.Description("Number of virtual machines currently in an error state. Any value above zero requires attention."),
 		statPanel.Chart(
@@ -296,14 +296,14 @@ func SingleClusterVMsInError(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMStatusError,
+			WithClusterLabel(singleClusterVMStatusError, clusterLabelName),
 			query.SeriesNameFormat("Error"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func SingleClusterVMsStopped(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsStopped(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Stopped VMs",
 		panel.Description("Number of virtual machines currently stopped."),
 		statPanel.Chart(
@@ -316,14 +316,14 @@ func SingleClusterVMsStopped(datasourceName, project string) panelgroup.Option {
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMStatusStopped,
+			WithClusterLabel(singleClusterVMStatusStopped, clusterLabelName),
 			query.SeriesNameFormat("Stopped"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func SingleClusterVMsStarting(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsStarting(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Starting VMs",
 		panel.Description("Number of virtual machines currently starting up."),
 		statPanel.Chart(
@@ -336,14 +336,14 @@ func SingleClusterVMsStarting(datasourceName, project string) panelgroup.Option
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMStatusStarting,
+			WithClusterLabel(singleClusterVMStatusStarting, clusterLabelName),
 			query.SeriesNameFormat("Starting"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func SingleClusterVMsMigrating(datasourceName, project string) panelgroup.Option {
+func SingleClusterVMsMigrating(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Migrating VMs",
 		panel.Description("Number of virtual machines currently being migrated."),
 		statPanel.Chart(
@@ -356,7 +356,7 @@ func SingleClusterVMsMigrating(datasourceName, project string) panelgroup.Option
 			link.TargetBlank(true),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMStatusMigrating,
+			WithClusterLabel(singleClusterVMStatusMigrating, clusterLabelName),
 			query.SeriesNameFormat("Migrating"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -364,7 +364,7 @@ func SingleClusterVMsMigrating(datasourceName, project string) panelgroup.Option
 }
 
 // SingleClusterProvider shows cloud provider from ACM managed cluster labels.
-func SingleClusterProvider(datasourceName string) panelgroup.Option {
+func SingleClusterProvider(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Provider",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -375,14 +375,14 @@ func SingleClusterProvider(datasourceName string) panelgroup.Option {
 			"metricLabel": "cloud",
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterProviderByCloud,
+			WithClusterLabel(singleClusterProviderByCloud, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOpenshiftVersion shows OpenShift version from CSV metrics.
-func SingleClusterOpenshiftVersion(datasourceName string) panelgroup.Option {
+func SingleClusterOpenshiftVersion(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("OpenShift Version",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -393,14 +393,14 @@ func SingleClusterOpenshiftVersion(datasourceName string) panelgroup.Option {
 			"metricLabel": "version",
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterOpenshiftVersion,
+			WithClusterLabel(singleClusterOpenshiftVersion, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOperatorStatus maps hyperconverged operator health codes to OK / Warning / Critical.
-func SingleClusterOperatorStatus(datasourceName string) panelgroup.Option {
+func SingleClusterOperatorStatus(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Operator Status",
 		panel.Description("Inspect the Operator Conditions and the Alerts tab for additional details"),
 		statPanel.Chart(
@@ -412,14 +412,14 @@ func SingleClusterOperatorStatus(datasourceName string) panelgroup.Option {
 			"mappings":  singleClusterOperatorHealthMappings,
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterOperatorHealthStatus,
+			WithClusterLabel(singleClusterOperatorHealthStatus, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOperatorConditions maps HCO system health codes to OK / Warning / Critical.
-func SingleClusterOperatorConditions(datasourceName string) panelgroup.Option {
+func SingleClusterOperatorConditions(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Operator Conditions",
 		panel.Description("Status of HCO conditions - Check the HCO Conditions in the cluster"),
 		statPanel.Chart(
@@ -431,14 +431,14 @@ func SingleClusterOperatorConditions(datasourceName string) panelgroup.Option {
 			"mappings":  singleClusterOperatorHealthMappings,
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterHCOSystemHealthStatus,
+			WithClusterLabel(singleClusterHCOSystemHealthStatus, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterRunningVMsByOS is a pie chart of running guests grouped by OS name.
-func SingleClusterRunningVMsByOS(datasourceName string) panelgroup.Option {
+func SingleClusterRunningVMsByOS(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Running VMs by OS",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "PieChart",
@@ -450,12 +450,12 @@ func SingleClusterRunningVMsByOS(datasourceName string) panelgroup.Option {
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterRunningVMsByOS1,
+			WithClusterLabel(singleClusterRunningVMsByOS1, clusterLabelName),
 			query.SeriesNameFormat("{{os}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			singleClusterRunningVMsByOS2,
+			WithClusterLabel(singleClusterRunningVMsByOS2, clusterLabelName),
 			query.SeriesNameFormat("{{os}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -463,14 +463,14 @@ func SingleClusterRunningVMsByOS(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterRecentVMsStarted lists VMs that started most recently with a drill-down link.
-func SingleClusterRecentVMsStarted(datasourceName, project string) panelgroup.Option {
+func SingleClusterRecentVMsStarted(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Recent VMs Started",
 		tablePanel.Table(),
 		mergePluginSpecFields(map[string]any{
 			"columnSettings": singleClusterRecentVMsColumnSettings(project),
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterRecentVMsStarted,
+			WithClusterLabel(singleClusterRecentVMsStarted, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -478,7 +478,7 @@ func SingleClusterRecentVMsStarted(datasourceName, project string) panelgroup.Op
 }
 
 // SingleClusterVMsRunningByNode plots running VMI count per node (top series).
-func SingleClusterVMsRunningByNode(datasourceName string) panelgroup.Option {
+func SingleClusterVMsRunningByNode(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs Running by Node",
 		panel.Description("Top 20 nodes"),
 		timeSeriesPanel.Chart(
@@ -494,7 +494,7 @@ func SingleClusterVMsRunningByNode(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsRunningByNode,
+			WithClusterLabel(singleClusterVMsRunningByNode, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -502,7 +502,7 @@ func SingleClusterVMsRunningByNode(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterVMsByStatus plots aggregate VM counts by lifecycle phase.
-func SingleClusterVMsByStatus(datasourceName string) panelgroup.Option {
+func SingleClusterVMsByStatus(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by Status",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(singleClusterTSLegend(commonSdk.LastNumberCalculation)),
@@ -523,27 +523,27 @@ func SingleClusterVMsByStatus(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsByStatusStarting,
+			WithClusterLabel(singleClusterVMsByStatusStarting, clusterLabelName),
 			query.SeriesNameFormat("starting"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsByStatusRunning,
+			WithClusterLabel(singleClusterVMsByStatusRunning, clusterLabelName),
 			query.SeriesNameFormat("running"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsByStatusMigrating,
+			WithClusterLabel(singleClusterVMsByStatusMigrating, clusterLabelName),
 			query.SeriesNameFormat("migrating"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsByStatusError,
+			WithClusterLabel(singleClusterVMsByStatusError, clusterLabelName),
 			query.SeriesNameFormat("error"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsByStatusStopped,
+			WithClusterLabel(singleClusterVMsByStatusStopped, clusterLabelName),
 			query.SeriesNameFormat("stopped"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -551,7 +551,7 @@ func SingleClusterVMsByStatus(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesCPUUtilization shows node CPU utilization rate (recording rule).
-func SingleClusterNodesCPUUtilization(datasourceName string) panelgroup.Option {
+func SingleClusterNodesCPUUtilization(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.SecondsUnit)
 	return panelgroup.AddPanel("Nodes by CPU Utilization",
 		panel.Description("This panel displays the CPU Utilization Percentage for the top 20 nodes in the cluster, that are running virtual machines, over the past 1 minute. Node CPU Utilization reflects the rate of CPU usage relative to the node's total capacity, providing insight into the most resource-intensive nodes."),
@@ -567,7 +567,7 @@ func SingleClusterNodesCPUUtilization(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesCPUUtilizationRate1m,
+			WithClusterLabel(singleClusterNodesCPUUtilizationRate1m, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -575,7 +575,7 @@ func SingleClusterNodesCPUUtilization(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterVMsTotalCPUUsage shows CPU seconds rate per VM.
-func SingleClusterVMsTotalCPUUsage(datasourceName string) panelgroup.Option {
+func SingleClusterVMsTotalCPUUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.SecondsUnit)
 	return panelgroup.AddPanel("VMs by Total CPU Usage",
 		panel.Description("This panel displays the total CPU Usage for the top 20 VMs over the past 10 minutes. CPU Usage represents the rate of CPU time consumed by each VM, providing insight into the most resource-intensive workloads in the cluster during the recent period."),
@@ -591,7 +591,7 @@ func SingleClusterVMsTotalCPUUsage(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsTotalCPUUsage,
+			WithClusterLabel(singleClusterVMsTotalCPUUsage, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -599,7 +599,7 @@ func SingleClusterVMsTotalCPUUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesCPUUsagePercent shows non-idle CPU share per node.
-func SingleClusterNodesCPUUsagePercent(datasourceName string) panelgroup.Option {
+func SingleClusterNodesCPUUsagePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Nodes by CPU Usage (%)",
 		panel.Description("This panel shows the CPU Usage Percentage for the top 20 nodes in the cluster, that are running virtual machines, over the past 10 minutes. CPU Usage Percentage is calculated as the proportion of active CPU time (user, system, I/O wait) relative to the total available CPU time. It helps identify nodes with the highest workload demand and potential resource contention."),
 		timeSeriesPanel.Chart(
@@ -614,7 +614,7 @@ func SingleClusterNodesCPUUsagePercent(datasourceName string) panelgroup.Option
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesCPUUsagePercent,
+			WithClusterLabel(singleClusterNodesCPUUsagePercent, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -622,7 +622,7 @@ func SingleClusterNodesCPUUsagePercent(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterVMsCPUUsagePercent shows guest CPU usage vs requested capacity.
-func SingleClusterVMsCPUUsagePercent(datasourceName string) panelgroup.Option {
+func SingleClusterVMsCPUUsagePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by CPU Usage (%)",
 		panel.Description("This panel displays the CPU Usage Percentage for the top 20 VMs over the past 10 minutes. CPU Usage Percentage measures how much of the allocated CPU resources (cores, sockets, and threads) each VM is actively utilizing. It helps identify VMs with high or low CPU utilization relative to their allocated capacity."),
 		timeSeriesPanel.Chart(
@@ -637,7 +637,7 @@ func SingleClusterVMsCPUUsagePercent(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsCPUUsagePercent,
+			WithClusterLabel(singleClusterVMsCPUUsagePercent, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -645,7 +645,7 @@ func SingleClusterVMsCPUUsagePercent(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesCPUStealPercent shows steal time relative to total CPU.
-func SingleClusterNodesCPUStealPercent(datasourceName string) panelgroup.Option {
+func SingleClusterNodesCPUStealPercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Nodes by CPU Steal Time (%)",
 		panel.Description("This panel shows the CPU steal time percentage over time for the top 20 nodes in the cluster, that are running virtual machines, over the past 10 minutes.\nIt can indicate resource contention on virtualized nodes. High values suggest VMs are competing for CPU resources, potentially impacting performance."),
 		timeSeriesPanel.Chart(
@@ -660,7 +660,7 @@ func SingleClusterNodesCPUStealPercent(datasourceName string) panelgroup.Option
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesCPUStealPercent,
+			WithClusterLabel(singleClusterNodesCPUStealPercent, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -668,7 +668,7 @@ func SingleClusterNodesCPUStealPercent(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterVMsCPUReadyPercent shows vCPU delay vs requested CPU topology.
-func SingleClusterVMsCPUReadyPercent(datasourceName string) panelgroup.Option {
+func SingleClusterVMsCPUReadyPercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by CPU Ready (%)",
 		panel.Description("This panel shows the CPU Ready Percentage for the top 20 VMs over the past 10 minutes. CPU Ready indicates the proportion of time a VM's virtual CPUs were ready to execute but had to wait for physical CPU resources due to contention."),
 		timeSeriesPanel.Chart(
@@ -684,7 +684,7 @@ func SingleClusterVMsCPUReadyPercent(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsCPUReadyPercent,
+			WithClusterLabel(singleClusterVMsCPUReadyPercent, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -692,7 +692,7 @@ func SingleClusterVMsCPUReadyPercent(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesMemoryUsage shows estimated memory used in bytes per node.
-func SingleClusterNodesMemoryUsage(datasourceName string) panelgroup.Option {
+func SingleClusterNodesMemoryUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Nodes by Memory Usage",
 		panel.Description("This panel shows the Memory Usage for the top 20 nodes in the cluster that are running virtual machines. Memory usage is calculated as the proportion of memory utilized relative to the total available memory on each node. This helps identify resource-intensive nodes hosting virtual machines, enabling proactive resource management."),
 		timeSeriesPanel.Chart(
@@ -708,7 +708,7 @@ func SingleClusterNodesMemoryUsage(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesMemoryUsageBytes,
+			WithClusterLabel(singleClusterNodesMemoryUsageBytes, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -716,7 +716,7 @@ func SingleClusterNodesMemoryUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterVMsMemoryUsage shows guest memory used (available - unused - cached).
-func SingleClusterVMsMemoryUsage(datasourceName string) panelgroup.Option {
+func SingleClusterVMsMemoryUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by Memory Usage",
 		panel.Description("This panel displays the memory usage for the top 20 virtual machines (VMs) in the cluster. Memory usage is calculated as the allocated memory minus unused and cached memory. This provides insight into the most memory-intensive VMs, helping identify resource-heavy workloads and potential optimizations. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -731,7 +731,7 @@ func SingleClusterVMsMemoryUsage(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsMemoryUsageBytes,
+			WithClusterLabel(singleClusterVMsMemoryUsageBytes, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -739,7 +739,7 @@ func SingleClusterVMsMemoryUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesMemoryUsagePercent shows node memory utilization ratio.
-func SingleClusterNodesMemoryUsagePercent(datasourceName string) panelgroup.Option {
+func SingleClusterNodesMemoryUsagePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Nodes by Memory Usage (%)",
 		panel.Description("This panel shows the Memory Usage Percentage for the top 20 nodes in the cluster that are running virtual machines. Memory usage is calculated as the proportion of memory utilized relative to the total available memory on each node. This helps identify resource-intensive nodes hosting virtual machines, enabling proactive resource management."),
 		timeSeriesPanel.Chart(
@@ -754,7 +754,7 @@ func SingleClusterNodesMemoryUsagePercent(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesMemoryUsagePercent,
+			WithClusterLabel(singleClusterNodesMemoryUsagePercent, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -762,7 +762,7 @@ func SingleClusterNodesMemoryUsagePercent(datasourceName string) panelgroup.Opti
 }
 
 // SingleClusterVMsMemoryUsagePercent shows guest memory used vs requested memory.
-func SingleClusterVMsMemoryUsagePercent(datasourceName string) panelgroup.Option {
+func SingleClusterVMsMemoryUsagePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by Memory Usage (%)",
 		panel.Description("This panel displays the memory usage for the top 20 virtual machines (VMs) in the cluster. Memory usage is calculated as the allocated memory minus unused and cached memory. This provides insight into the most memory-intensive VMs, helping identify resource-heavy workloads and potential optimizations. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -777,7 +777,7 @@ func SingleClusterVMsMemoryUsagePercent(datasourceName string) panelgroup.Option
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsMemoryUsagePercent,
+			WithClusterLabel(singleClusterVMsMemoryUsagePercent, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -785,7 +785,7 @@ func SingleClusterVMsMemoryUsagePercent(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterNodesNetworkReceived shows receive throughput per node.
-func SingleClusterNodesNetworkReceived(datasourceName string) panelgroup.Option {
+func SingleClusterNodesNetworkReceived(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("Nodes by Network Received Bytes",
 		panel.Description("This panel displays the top 20 nodes running virtual machines (VMs) based on their total network received bytes over the past hour. It includes only nodes hosting VMs and excludes traffic from the loopback interface. This provides insight into the nodes with the highest incoming network traffic, helping identify workloads with significant data reception requirements."),
@@ -800,7 +800,7 @@ func SingleClusterNodesNetworkReceived(datasourceName string) panelgroup.Option
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesNetworkReceivedRate,
+			WithClusterLabel(singleClusterNodesNetworkReceivedRate, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -808,7 +808,7 @@ func SingleClusterNodesNetworkReceived(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterVMsNetworkReceived shows receive throughput per VM.
-func SingleClusterVMsNetworkReceived(datasourceName string) panelgroup.Option {
+func SingleClusterVMsNetworkReceived(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("VMs by Network Received Bytes",
 		panel.Description("This panel displays the top 20 virtual machines (VMs) in the cluster by network received bytes per second over the past 10 minutes. The query measures the rate of incoming network traffic, helping identify VMs with the highest network activity. Use this information to monitor and optimize resource usage for workloads with significant data reception."),
@@ -823,7 +823,7 @@ func SingleClusterVMsNetworkReceived(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsNetworkReceivedRate,
+			WithClusterLabel(singleClusterVMsNetworkReceivedRate, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -831,7 +831,7 @@ func SingleClusterVMsNetworkReceived(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesNetworkTransmitted shows transmit throughput per node.
-func SingleClusterNodesNetworkTransmitted(datasourceName string) panelgroup.Option {
+func SingleClusterNodesNetworkTransmitted(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("Nodes by Network Transmitted Bytes",
 		panel.Description("This panel displays the top 20 nodes running virtual machines (VMs) based on their total network transmitted bytes over the past hour. It includes only nodes hosting VMs and excludes traffic from the loopback interface. This provides insight into the nodes with the highest outgoing network traffic, helping identify workloads with significant data transmission requirements."),
@@ -846,7 +846,7 @@ func SingleClusterNodesNetworkTransmitted(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesNetworkTransmitRate,
+			WithClusterLabel(singleClusterNodesNetworkTransmitRate, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -854,7 +854,7 @@ func SingleClusterNodesNetworkTransmitted(datasourceName string) panelgroup.Opti
 }
 
 // SingleClusterVMsNetworkTransmitted shows transmit throughput per VM.
-func SingleClusterVMsNetworkTransmitted(datasourceName string) panelgroup.Option {
+func SingleClusterVMsNetworkTransmitted(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("VMs by Network Transmitted Bytes",
 		panel.Description("This panel displays the top 20 virtual machines (VMs) in the cluster by network transmitted bytes per second over the past 10 minutes. The query measures the rate of outgoing network traffic, helping identify VMs with the highest network activity. Use this information to monitor and optimize resource usage for workloads with significant data transmission."),
@@ -869,7 +869,7 @@ func SingleClusterVMsNetworkTransmitted(datasourceName string) panelgroup.Option
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsNetworkTransmitRate,
+			WithClusterLabel(singleClusterVMsNetworkTransmitRate, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -877,7 +877,7 @@ func SingleClusterVMsNetworkTransmitted(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterNodesVMStorageIOPS aggregates read+write IOPS per node.
-func SingleClusterNodesVMStorageIOPS(datasourceName string) panelgroup.Option {
+func SingleClusterNodesVMStorageIOPS(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Nodes by VM Storage IOPS",
 		panel.Description("This panel displays the top 20 nodes running virtual machines (VMs) based on their combined storage IOPS (read + write) over the past 10 minutes. The metric aggregates the total input/output operations per second for all VMs on each node, helping identify nodes with the highest storage activity and potential bottlenecks."),
 		timeSeriesPanel.Chart(
@@ -890,7 +890,7 @@ func SingleClusterNodesVMStorageIOPS(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesStorageIOPS,
+			WithClusterLabel(singleClusterNodesStorageIOPS, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -898,7 +898,7 @@ func SingleClusterNodesVMStorageIOPS(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterVMsStorageIOPS aggregates read+write IOPS per VM.
-func SingleClusterVMsStorageIOPS(datasourceName string) panelgroup.Option {
+func SingleClusterVMsStorageIOPS(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VMs by Storage IOPS",
 		panel.Description("This panel displays the top 20 virtual machines (VMs) based on their combined storage IOPS (read + write) over the past 10 minutes. The metric aggregates the total input/output operations per second for each VM, helping identify workloads with the highest storage activity and potential hotspots for performance optimization."),
 		timeSeriesPanel.Chart(
@@ -911,7 +911,7 @@ func SingleClusterVMsStorageIOPS(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsStorageIOPS,
+			WithClusterLabel(singleClusterVMsStorageIOPS, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -919,7 +919,7 @@ func SingleClusterVMsStorageIOPS(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterNodesVMStorageTraffic aggregates read+write bytes per second per node.
-func SingleClusterNodesVMStorageTraffic(datasourceName string) panelgroup.Option {
+func SingleClusterNodesVMStorageTraffic(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("Nodes by VM Storage Traffic",
 		panel.Description("This panel displays the top 20 nodes running virtual machines (VMs) based on their combined storage traffic (read + write) over the past 10 minutes. The metric aggregates the total input/output operations for all VMs on each node, helping identify nodes with the highest storage activity and potential bottlenecks."),
@@ -935,7 +935,7 @@ func SingleClusterNodesVMStorageTraffic(datasourceName string) panelgroup.Option
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterNodesStorageTraffic,
+			WithClusterLabel(singleClusterNodesStorageTraffic, clusterLabelName),
 			query.SeriesNameFormat("{{node}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -943,7 +943,7 @@ func SingleClusterNodesVMStorageTraffic(datasourceName string) panelgroup.Option
 }
 
 // SingleClusterVMsStorageTraffic aggregates read+write bytes per second per VM.
-func SingleClusterVMsStorageTraffic(datasourceName string) panelgroup.Option {
+func SingleClusterVMsStorageTraffic(datasourceName, clusterLabelName string) panelgroup.Option {
 	u := string(commonSdk.BytesDecPerSecondsUnit)
 	return panelgroup.AddPanel("VMs by Storage Traffic",
 		panel.Description("This panel displays the top 20 virtual machines (VMs) based on their combined storage traffic (read + write) over the past 10 minutes. The metric aggregates the total input/output operations for the last 10 minutes for each VM, helping identify workloads with the highest storage activity and potential hotspots for performance optimization."),
@@ -959,7 +959,7 @@ func SingleClusterVMsStorageTraffic(datasourceName string) panelgroup.Option {
 		),
 		mergeTimeSeriesVisual(map[string]any{"lineStyle": "solid"}),
 		panel.AddQuery(query.PromQL(
-			singleClusterVMsStorageTraffic,
+			WithClusterLabel(singleClusterVMsStorageTraffic, clusterLabelName),
 			query.SeriesNameFormat("{{namespace}} | {{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -967,7 +967,7 @@ func SingleClusterVMsStorageTraffic(datasourceName string) panelgroup.Option {
 }
 
 // SingleClusterCriticalSeverityAlerts counts firing critical kubevirt operator alerts.
-func SingleClusterCriticalSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleClusterCriticalSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Critical Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: critical."),
 		statPanel.Chart(
@@ -983,14 +983,14 @@ func SingleClusterCriticalSeverityAlerts(datasourceName string) panelgroup.Optio
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterAlertsCritical,
+			WithClusterLabel(singleClusterAlertsCritical, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterWarningSeverityAlerts counts firing warning alerts filtered by health impact.
-func SingleClusterWarningSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleClusterWarningSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Warning Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: warning."),
 		statPanel.Chart(
@@ -1006,14 +1006,14 @@ func SingleClusterWarningSeverityAlerts(datasourceName string) panelgroup.Option
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterAlertsWarning,
+			WithClusterLabel(singleClusterAlertsWarning, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterInfoSeverityAlerts counts firing info-level kubevirt alerts.
-func SingleClusterInfoSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleClusterInfoSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Info Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: info."),
 		statPanel.Chart(
@@ -1029,14 +1029,14 @@ func SingleClusterInfoSeverityAlerts(datasourceName string) panelgroup.Option {
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterAlertsInfo,
+			WithClusterLabel(singleClusterAlertsInfo, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOperatorHealthImpactAlertsTable lists firing alerts with operator health impact (excludes info).
-func SingleClusterOperatorHealthImpactAlertsTable(datasourceName string) panelgroup.Option {
+func SingleClusterOperatorHealthImpactAlertsTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Operator Health Impact Alerts",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "Table",
@@ -1061,21 +1061,21 @@ func SingleClusterOperatorHealthImpactAlertsTable(datasourceName string) panelgr
 					map[string]any{"name": "alertstate", "hide": true},
 					map[string]any{"name": "timestamp", "hide": true},
 					map[string]any{"name": "value", "hide": true},
-					map[string]any{"name": "cluster", "hide": true},
+					map[string]any{"name": clusterLabel(clusterLabelName), "hide": true},
 					map[string]any{"name": "name", "hide": true},
 					map[string]any{"name": "namespace", "hide": true},
 				},
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterOperatorHealthImpactAlerts,
+			WithClusterLabel(singleClusterOperatorHealthImpactAlerts, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleClusterOperatorCSVIssuesTable shows abnormal hyperconverged CSV rows by version/phase/reason.
-func SingleClusterOperatorCSVIssuesTable(datasourceName string) panelgroup.Option {
+func SingleClusterOperatorCSVIssuesTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Operator CSV Issues",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
@@ -1087,7 +1087,7 @@ func SingleClusterOperatorCSVIssuesTable(datasourceName string) panelgroup.Optio
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleClusterOperatorCSVAbnormal,
+			WithClusterLabel(singleClusterOperatorCSVAbnormal, clusterLabelName),
 			query.SeriesNameFormat("{{version}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -1095,7 +1095,7 @@ func SingleClusterOperatorCSVIssuesTable(datasourceName string) panelgroup.Optio
 }
 
 // SingleClusterAllAlertsTable lists all firing kubevirt alerts with severity and impact labels.
-func SingleClusterAllAlertsTable(datasourceName string) panelgroup.Option {
+func SingleClusterAllAlertsTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("All Alerts",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "Table",
@@ -1123,12 +1123,12 @@ func SingleClusterAllAlertsTable(datasourceName string) panelgroup.Option {
 					map[string]any{"name": "value", "header": "Total Alerts", "enableSorting": true},
 					map[string]any{"name": "timestamp", "hide": true},
 					map[string]any{"name": "alertstate", "hide": true},
-					map[string]any{"name": "cluster", "hide": true},
+					map[string]any{"name": clusterLabel(clusterLabelName), "hide": true},
 				},
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleClusterAllAlerts,
+			WithClusterLabel(singleClusterAllAlerts, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
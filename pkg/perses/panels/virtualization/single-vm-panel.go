@@ -47,7 +47,7 @@ func singleVMGaugeThresholdsCPUdelay() commonSdk.Thresholds {
 }
 
 // SingleVMStatus displays the current VM status as text (default black).
-func SingleVMStatus(datasourceName string) panelgroup.Option {
+func SingleVMStatus(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Status",
 		statPanel.Chart(
 			statPanel.Calculation("last-number"),
@@ -57,14 +57,14 @@ func SingleVMStatus(datasourceName string) panelgroup.Option {
 			"colorMode":   "none",
 		}),
 		panel.AddQuery(query.PromQL(
-			singleVMStatusQuery,
+			WithClusterLabel(singleVMStatusQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMCriticalSeverityAlerts counts firing critical alerts for the VM.
-func SingleVMCriticalSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleVMCriticalSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Critical Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: critical."),
 		statPanel.Chart(
@@ -77,14 +77,14 @@ func SingleVMCriticalSeverityAlerts(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			singleVMCriticalAlertsQuery,
+			WithClusterLabel(singleVMCriticalAlertsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMWarningSeverityAlerts counts firing warning alerts for the VM.
-func SingleVMWarningSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleVMWarningSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Warning Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: warning."),
 		statPanel.Chart(
@@ -97,14 +97,14 @@ func SingleVMWarningSeverityAlerts(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			singleVMWarningAlertsQuery,
+			WithClusterLabel(singleVMWarningAlertsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMInfoSeverityAlerts counts firing info alerts for the VM.
-func SingleVMInfoSeverityAlerts(datasourceName string) panelgroup.Option {
+func SingleVMInfoSeverityAlerts(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Info Severity Alerts",
 		panel.Description("Total number of alerts that are firing with the severity level: info."),
 		statPanel.Chart(
@@ -117,14 +117,14 @@ func SingleVMInfoSeverityAlerts(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "value"}),
 		panel.AddQuery(query.PromQL(
-			singleVMInfoAlertsQuery,
+			WithClusterLabel(singleVMInfoAlertsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMMemoryUsagePercentGauge shows memory usage vs requested memory.
-func SingleVMMemoryUsagePercentGauge(datasourceName string) panelgroup.Option {
+func SingleVMMemoryUsagePercentGauge(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage (%)",
 		panel.Description("This panel displays the VM memory usage Percentage. The memory usage is calculated as the allocated memory minus unused and cached memory. The data is based on the most recent metrics collected."),
 		gaugePanel.Chart(
@@ -136,7 +136,7 @@ func SingleVMMemoryUsagePercentGauge(datasourceName string) panelgroup.Option {
 			gaugePanel.Thresholds(singleVMGaugeThresholds802090()),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMMemoryUsagePercentGaugeQuery,
+			WithClusterLabel(singleVMMemoryUsagePercentGaugeQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -144,7 +144,7 @@ func SingleVMMemoryUsagePercentGauge(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMCPUUsagePercentGauge shows CPU usage vs allocated CPU.
-func SingleVMCPUUsagePercentGauge(datasourceName string) panelgroup.Option {
+func SingleVMCPUUsagePercentGauge(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Usage (%)",
 		panel.Description("This panel displays the VM CPU Usage Percentage. CPU Usage Percentage measures how much of the allocated CPU resources (cores, sockets, and threads) each VM is actively utilizing. It helps identify VMs with high or low CPU utilization relative to their allocated capacity."),
 		gaugePanel.Chart(
@@ -156,7 +156,7 @@ func SingleVMCPUUsagePercentGauge(datasourceName string) panelgroup.Option {
 			gaugePanel.Thresholds(singleVMGaugeThresholds802090()),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMCPUUsagePercentRatioQuery,
+			WithClusterLabel(singleVMCPUUsagePercentRatioQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -164,7 +164,7 @@ func SingleVMCPUUsagePercentGauge(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMVMInformationTable shows label-based VM fields (name, status, OS, etc.).
-func SingleVMVMInformationTable(datasourceName string) panelgroup.Option {
+func SingleVMVMInformationTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Information",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
@@ -181,18 +181,18 @@ func SingleVMVMInformationTable(datasourceName string) panelgroup.Option {
 			}),
 		),
 		mergePluginSpecFields(map[string]any{"defaultColumnHidden": true}),
-		panel.AddQuery(query.PromQL(singleVMVMInformationNameQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationStatusQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationGuestOSQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationGuestOSVersionQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationInstanceTypeQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationWorkloadQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationFlavorQuery, dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationNameQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationStatusQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationGuestOSQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationGuestOSVersionQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationInstanceTypeQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationWorkloadQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationFlavorQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
 	)
 }
 
 // SingleVMAllocatedResourcesTable shows numeric allocated resources (CPU, memory, disk).
-func SingleVMAllocatedResourcesTable(datasourceName string) panelgroup.Option {
+func SingleVMAllocatedResourcesTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Allocated Resources",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
@@ -208,14 +208,14 @@ func SingleVMAllocatedResourcesTable(datasourceName string) panelgroup.Option {
 			}),
 		),
 		mergePluginSpecFields(map[string]any{"defaultColumnHidden": true}),
-		panel.AddQuery(query.PromQL(singleVMVMInformationAllocatedCPUQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationAllocatedMemoryQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMVMInformationAllocatedDiskQuery, dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationAllocatedCPUQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationAllocatedMemoryQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMVMInformationAllocatedDiskQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
 	)
 }
 
 // SingleVMGeneralInformationTable merges namespace, node, pod, and related fields.
-func SingleVMGeneralInformationTable(datasourceName string) panelgroup.Option {
+func SingleVMGeneralInformationTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("General Information",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
@@ -232,17 +232,17 @@ func SingleVMGeneralInformationTable(datasourceName string) panelgroup.Option {
 			}),
 		),
 		mergePluginSpecFields(map[string]any{"defaultColumnHidden": true}),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationNamespaceQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationNodeQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationPodQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationEvictableQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationMachineTypeQuery, dashboards.AddQueryDataSource(datasourceName))),
-		panel.AddQuery(query.PromQL(singleVMGeneralInformationOutdatedQuery, dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationNamespaceQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationNodeQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationPodQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationEvictableQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationMachineTypeQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
+		panel.AddQuery(query.PromQL(WithClusterLabel(singleVMGeneralInformationOutdatedQuery, clusterLabelName), dashboards.AddQueryDataSource(datasourceName))),
 	)
 }
 
 // SingleVMFilesystemUsagePercentGauge shows max filesystem usage ratio.
-func SingleVMFilesystemUsagePercentGauge(datasourceName string) panelgroup.Option {
+func SingleVMFilesystemUsagePercentGauge(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("File System Usage (%)",
 		panel.Description("This panel displays the VM File System usage percentage for the disk that has the highest usage percentage."),
 		gaugePanel.Chart(
@@ -254,7 +254,7 @@ func SingleVMFilesystemUsagePercentGauge(datasourceName string) panelgroup.Optio
 			gaugePanel.Thresholds(singleVMGaugeThresholds802090()),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMFilesystemUsagePercentQuery,
+			WithClusterLabel(singleVMFilesystemUsagePercentQuery, clusterLabelName),
 			query.SeriesNameFormat("{{disk_name}} (highest)"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -262,7 +262,7 @@ func SingleVMFilesystemUsagePercentGauge(datasourceName string) panelgroup.Optio
 }
 
 // SingleVMCPUDelayPercentGauge shows CPU delay as a percent of allocated CPU.
-func SingleVMCPUDelayPercentGauge(datasourceName string) panelgroup.Option {
+func SingleVMCPUDelayPercentGauge(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Delay (%)",
 		panel.Description("This panel shows the VM CPU Delay Percentage. CPU Delay indicates the proportion of time a VM's virtual CPUs were ready to execute but had to wait for physical CPU resources due to contention."),
 		gaugePanel.Chart(
@@ -274,7 +274,7 @@ func SingleVMCPUDelayPercentGauge(datasourceName string) panelgroup.Option {
 			gaugePanel.Thresholds(singleVMGaugeThresholdsCPUdelay()),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMCPUDelayPercentRatioQuery,
+			WithClusterLabel(singleVMCPUDelayPercentRatioQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -282,7 +282,7 @@ func SingleVMCPUDelayPercentGauge(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMNetworkTable lists network addresses for the VM.
-func SingleVMNetworkTable(datasourceName string) panelgroup.Option {
+func SingleVMNetworkTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Network",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
@@ -290,26 +290,26 @@ func SingleVMNetworkTable(datasourceName string) panelgroup.Option {
 				{Name: "network_name", Header: "Network Name", EnableSorting: true},
 				{Name: "address", Header: "IP Address", EnableSorting: true},
 				{Name: "timestamp", Hide: true},
-				{Name: "cluster", Hide: true},
+				{Name: clusterLabel(clusterLabelName), Hide: true},
 				{Name: "name", Hide: true},
 				{Name: "namespace", Hide: true},
 				{Name: "value", Hide: true},
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMNetworkAddressesQuery,
+			WithClusterLabel(singleVMNetworkAddressesQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMSnapshotsTable lists VM snapshots with create timestamps.
-func SingleVMSnapshotsTable(datasourceName string) panelgroup.Option {
+func SingleVMSnapshotsTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Snapshots",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
 				{Name: "timestamp", Hide: true},
-				{Name: "cluster", Hide: true},
+				{Name: clusterLabel(clusterLabelName), Hide: true},
 				{Name: "clusterID", Hide: true},
 				{Name: "container", Hide: true},
 				{Name: "endpoint", Hide: true},
@@ -330,14 +330,14 @@ func SingleVMSnapshotsTable(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMSnapshotsQuery,
+			WithClusterLabel(singleVMSnapshotsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
 // SingleVMTotalCPUUsage plots CPU usage rate in seconds.
-func SingleVMTotalCPUUsage(datasourceName string) panelgroup.Option {
+func SingleVMTotalCPUUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total CPU Usage",
 		panel.Description("This panel displays the total VM CPU Usage. CPU Usage represents the rate of CPU time consumed by each VM, providing insight into the most resource-intensive workloads in the cluster during the recent period."),
 		timeSeriesPanel.Chart(
@@ -356,7 +356,7 @@ func SingleVMTotalCPUUsage(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMTotalCPUUsageQuery,
+			WithClusterLabel(singleVMTotalCPUUsageQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -364,7 +364,7 @@ func SingleVMTotalCPUUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMCPUUsagePercentTimeSeries plots CPU usage percentage over time.
-func SingleVMCPUUsagePercentTimeSeries(datasourceName string) panelgroup.Option {
+func SingleVMCPUUsagePercentTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Usage (%)",
 		panel.Description("This panel displays the VM CPU Usage Percentage. CPU Usage Percentage measures how much of the allocated CPU resources (cores, sockets, and threads) each VM is actively utilizing. It helps identify VMs with high or low CPU utilization relative to their allocated capacity."),
 		timeSeriesPanel.Chart(
@@ -383,7 +383,7 @@ func SingleVMCPUUsagePercentTimeSeries(datasourceName string) panelgroup.Option
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMCPUUsagePercentRatioQuery,
+			WithClusterLabel(singleVMCPUUsagePercentRatioQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -391,7 +391,7 @@ func SingleVMCPUUsagePercentTimeSeries(datasourceName string) panelgroup.Option
 }
 
 // SingleVMCPUReadyTime plots CPU ready / delay related time series (source dashboard uses seconds axis).
-func SingleVMCPUReadyTime(datasourceName string) panelgroup.Option {
+func SingleVMCPUReadyTime(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Ready Time (%)",
 		panel.Description("This panel shows the VM CPU Ready Time percentage. CPU Ready indicates the proportion of time a VM's virtual CPUs were ready to execute but had to wait for physical CPU resources due to contention."),
 		timeSeriesPanel.Chart(
@@ -415,7 +415,7 @@ func SingleVMCPUReadyTime(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMCPUReadyTimeQuery,
+			WithClusterLabel(singleVMCPUReadyTimeQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -423,7 +423,7 @@ func SingleVMCPUReadyTime(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMCPUDelayPercentTimeSeries plots vCPU delay as a percentage of allocation.
-func SingleVMCPUDelayPercentTimeSeries(datasourceName string) panelgroup.Option {
+func SingleVMCPUDelayPercentTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("CPU Delay (%)",
 		panel.Description("This panel shows the VM CPU Delay Percentage. CPU Delay indicates the proportion of time a VM's virtual CPUs were ready to execute but had to wait for physical CPU resources due to contention."),
 		timeSeriesPanel.Chart(
@@ -443,7 +443,7 @@ func SingleVMCPUDelayPercentTimeSeries(datasourceName string) panelgroup.Option
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMCPUDelayPercentRatioQuery,
+			WithClusterLabel(singleVMCPUDelayPercentRatioQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -451,7 +451,7 @@ func SingleVMCPUDelayPercentTimeSeries(datasourceName string) panelgroup.Option
 }
 
 // SingleVMMemoryUsage plots used memory (excl. unused/cache) in bytes.
-func SingleVMMemoryUsage(datasourceName string) panelgroup.Option {
+func SingleVMMemoryUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage",
 		panel.Description("This panel displays the VM memory usage. The memory usage is calculated as the allocated memory minus unused and cached memory. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -470,7 +470,7 @@ func SingleVMMemoryUsage(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMMemoryUsageBytesQuery,
+			WithClusterLabel(singleVMMemoryUsageBytesQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -478,7 +478,7 @@ func SingleVMMemoryUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMMemoryUsagePercentTimeSeries plots memory usage vs request.
-func SingleVMMemoryUsagePercentTimeSeries(datasourceName string) panelgroup.Option {
+func SingleVMMemoryUsagePercentTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Memory Usage (%)",
 		panel.Description("This panel displays the VM memory usage Percentage. The memory usage is calculated as the allocated memory minus unused and cached memory. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -502,7 +502,7 @@ func SingleVMMemoryUsagePercentTimeSeries(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMMemoryUsagePercentTimeSeriesQuery,
+			WithClusterLabel(singleVMMemoryUsagePercentTimeSeriesQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -510,7 +510,7 @@ func SingleVMMemoryUsagePercentTimeSeries(datasourceName string) panelgroup.Opti
 }
 
 // SingleVMNetworkTransmit plots transmit throughput (decbytes/sec).
-func SingleVMNetworkTransmit(datasourceName string) panelgroup.Option {
+func SingleVMNetworkTransmit(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Network Usage - Transmit",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(singleVMTimeSeriesLegend()),
@@ -528,7 +528,7 @@ func SingleVMNetworkTransmit(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMNetworkTransmitQuery,
+			WithClusterLabel(singleVMNetworkTransmitQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -536,7 +536,7 @@ func SingleVMNetworkTransmit(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMNetworkReceive plots receive throughput (decbytes/sec).
-func SingleVMNetworkReceive(datasourceName string) panelgroup.Option {
+func SingleVMNetworkReceive(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Network Usage - Receive",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(singleVMTimeSeriesLegend()),
@@ -554,7 +554,7 @@ func SingleVMNetworkReceive(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMNetworkReceiveQuery,
+			WithClusterLabel(singleVMNetworkReceiveQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -562,7 +562,7 @@ func SingleVMNetworkReceive(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMNetworkTransmitPacketsDropped plots dropped transmit packets rate.
-func SingleVMNetworkTransmitPacketsDropped(datasourceName string) panelgroup.Option {
+func SingleVMNetworkTransmitPacketsDropped(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Network Usage - Transmit Packets Dropped",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(singleVMTimeSeriesLegend()),
@@ -580,7 +580,7 @@ func SingleVMNetworkTransmitPacketsDropped(datasourceName string) panelgroup.Opt
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMNetworkTransmitPacketsDroppedQuery,
+			WithClusterLabel(singleVMNetworkTransmitPacketsDroppedQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -588,7 +588,7 @@ func SingleVMNetworkTransmitPacketsDropped(datasourceName string) panelgroup.Opt
 }
 
 // SingleVMNetworkReceivePacketsDropped plots dropped receive packets rate.
-func SingleVMNetworkReceivePacketsDropped(datasourceName string) panelgroup.Option {
+func SingleVMNetworkReceivePacketsDropped(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Network Usage - Receive Packets Dropped",
 		timeSeriesPanel.Chart(
 			timeSeriesPanel.WithLegend(singleVMTimeSeriesLegend()),
@@ -606,7 +606,7 @@ func SingleVMNetworkReceivePacketsDropped(datasourceName string) panelgroup.Opti
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMNetworkReceivePacketsDroppedQuery,
+			WithClusterLabel(singleVMNetworkReceivePacketsDroppedQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -614,7 +614,7 @@ func SingleVMNetworkReceivePacketsDropped(datasourceName string) panelgroup.Opti
 }
 
 // SingleVMStorageTraffic plots read+write bytes per second.
-func SingleVMStorageTraffic(datasourceName string) panelgroup.Option {
+func SingleVMStorageTraffic(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Storage Traffic",
 		panel.Description("This panel displays the VM storage traffic (read + write) over the past 10 minutes. The metric aggregates the total input/output operations, helping identify workloads with the highest storage activity and potential hotspots for performance optimization."),
 		timeSeriesPanel.Chart(
@@ -633,7 +633,7 @@ func SingleVMStorageTraffic(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMStorageTrafficQuery,
+			WithClusterLabel(singleVMStorageTrafficQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -641,7 +641,7 @@ func SingleVMStorageTraffic(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMStorageIOPs plots read+write IOPS.
-func SingleVMStorageIOPs(datasourceName string) panelgroup.Option {
+func SingleVMStorageIOPs(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Storage IOPS",
 		panel.Description("This panel displays the VM storage IOPS (read + write) over the past 10 minutes. The metric aggregates the total input/output operations per second, helping identify workloads with the highest storage activity and potential hotspots for performance optimization."),
 		timeSeriesPanel.Chart(
@@ -658,7 +658,7 @@ func SingleVMStorageIOPs(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMStorageIOPsQuery,
+			WithClusterLabel(singleVMStorageIOPsQuery, clusterLabelName),
 			query.SeriesNameFormat("{{name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -666,7 +666,7 @@ func SingleVMStorageIOPs(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMFilesystemUsage plots used bytes per disk.
-func SingleVMFilesystemUsage(datasourceName string) panelgroup.Option {
+func SingleVMFilesystemUsage(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("File System Usage",
 		panel.Description("This panel displays the VM file system usage. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -685,7 +685,7 @@ func SingleVMFilesystemUsage(datasourceName string) panelgroup.Option {
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMFilesystemUsedBytesQuery,
+			WithClusterLabel(singleVMFilesystemUsedBytesQuery, clusterLabelName),
 			query.SeriesNameFormat("{{disk_name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -693,7 +693,7 @@ func SingleVMFilesystemUsage(datasourceName string) panelgroup.Option {
 }
 
 // SingleVMFilesystemUsagePercentTimeSeries plots usage vs capacity per disk.
-func SingleVMFilesystemUsagePercentTimeSeries(datasourceName string) panelgroup.Option {
+func SingleVMFilesystemUsagePercentTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("File System Usage (%)",
 		panel.Description("This panel displays the VM file system usage Percentage. The data is based on the most recent metrics collected."),
 		timeSeriesPanel.Chart(
@@ -712,7 +712,7 @@ func SingleVMFilesystemUsagePercentTimeSeries(datasourceName string) panelgroup.
 			}),
 		),
 		panel.AddQuery(query.PromQL(
-			singleVMFilesystemUsagePercentQuery,
+			WithClusterLabel(singleVMFilesystemUsagePercentQuery, clusterLabelName),
 			query.SeriesNameFormat("{{disk_name}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -720,7 +720,7 @@ func SingleVMFilesystemUsagePercentTimeSeries(datasourceName string) panelgroup.
 }
 
 // SingleVMVMAlertsTable lists firing alerts for the VM.
-func SingleVMVMAlertsTable(datasourceName string) panelgroup.Option {
+func SingleVMVMAlertsTable(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("VM Alerts",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "Table",
@@ -749,12 +749,12 @@ func SingleVMVMAlertsTable(datasourceName string) panelgroup.Option {
 					map[string]any{"name": "name", "hide": true},
 					map[string]any{"name": "timestamp", "hide": true},
 					map[string]any{"name": "value", "hide": true},
-					map[string]any{"name": "cluster", "hide": true},
+					map[string]any{"name": clusterLabel(clusterLabelName), "hide": true},
 				},
 			},
 		}),
 		panel.AddQuery(query.PromQL(
-			singleVMVMAlertsTableQuery,
+			WithClusterLabel(singleVMVMAlertsTableQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
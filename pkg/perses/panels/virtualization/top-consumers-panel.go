@@ -37,22 +37,22 @@ func topConsumersTimeSeriesVisual() timeSeriesPanel.Visual {
 func buildTopConsumersTable(
 	title, description, valueHeader string,
 	valueFmt commonSdk.Format,
-	promQL, datasourceName, project string,
+	promQL, datasourceName, clusterLabelName, project string,
 ) panelgroup.Option {
 	return panelgroup.AddPanel(title,
 		panel.Description(description),
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
 				{Name: "timestamp", Hide: true},
-				{Name: "cluster", Header: "Cluster", EnableSorting: true},
+				{Name: clusterLabel(clusterLabelName), Header: "Cluster", EnableSorting: true},
 				{Name: "namespace", Header: "Namespace", EnableSorting: true},
 				{Name: "name", Header: "Virtual Machine", EnableSorting: true},
 				{Name: "value", Header: valueHeader, EnableSorting: true, Format: &valueFmt},
 			}),
 		),
-		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(project))),
+		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(clusterLabelName, project))),
 		panel.AddQuery(query.PromQL(
-			promQL,
+			WithClusterLabel(promQL, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -63,7 +63,7 @@ func buildTopConsumersTable(
 func buildTopConsumersTimeSeries(
 	title, description string,
 	yAxisFmt commonSdk.Format,
-	promQL, datasourceName string,
+	promQL, datasourceName, clusterLabelName string,
 ) panelgroup.Option {
 	return panelgroup.AddPanel(title,
 		panel.Description(description),
@@ -76,7 +76,7 @@ func buildTopConsumersTimeSeries(
 			timeSeriesPanel.WithVisual(topConsumersTimeSeriesVisual()),
 		),
 		panel.AddQuery(query.PromQL(
-			promQL,
+			WithClusterLabel(promQL, clusterLabelName),
 			query.SeriesNameFormat("{{name}} / {{namespace}}"),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
@@ -85,137 +85,137 @@ func buildTopConsumersTimeSeries(
 
 // --- Table panels (Top Consumers) ---
 
-func TopConsumersMemoryTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersMemoryTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of Memory",
 		"Virtual machines with the highest memory consumption.",
 		"Avg Memory Usage",
 		commonSdk.Format{Unit: &dashboards.BytesUnit},
-		topConsumersMemoryTableQuery, datasourceName, project,
+		topConsumersMemoryTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersCPUTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersCPUTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of CPU",
 		"Virtual machines with the highest CPU usage in cores. A value of 2.0 means two full CPU cores are in use.",
 		"CPU Usage (cores)",
 		commonSdk.Format{Unit: &dashboards.DecimalUnit, DecimalPlaces: 3},
-		topConsumersCPUTableQuery, datasourceName, project,
+		topConsumersCPUTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersStorageTrafficTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersStorageTrafficTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of Storage Traffic",
 		"Virtual machines with the highest storage traffic (read + write).",
 		"Storage Traffic",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersStorageTrafficTableQuery, datasourceName, project,
+		topConsumersStorageTrafficTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersStorageIOPSTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersStorageIOPSTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of Storage IOPS",
 		"Virtual machines with the highest storage IOPS (read + write).",
 		"Storage IOPS",
 		commonSdk.Format{Unit: &opsPerSecUnit},
-		topConsumersStorageIOPSTableQuery, datasourceName, project,
+		topConsumersStorageIOPSTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersNetworkTrafficTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersNetworkTrafficTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of Network Traffic",
 		"Virtual machines with the highest network traffic (receive + transmit).",
 		"Network Traffic",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersNetworkTrafficTableQuery, datasourceName, project,
+		topConsumersNetworkTrafficTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersVCPUWaitTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersVCPUWaitTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of vCPU Wait",
 		"Virtual machines with the highest vCPU wait time, indicating CPU contention.",
 		"vCPU Wait Time",
 		commonSdk.Format{Unit: &dashboards.SecondsUnit},
-		topConsumersVCPUWaitTableQuery, datasourceName, project,
+		topConsumersVCPUWaitTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
-func TopConsumersMemorySwapTable(datasourceName, project string) panelgroup.Option {
+func TopConsumersMemorySwapTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return buildTopConsumersTable(
 		"Top Consumers of Memory Swap Traffic",
 		"Virtual machines with the highest memory swap traffic (in + out). High swap activity may indicate memory pressure.",
 		"Avg Memory Swap Traffic",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersMemorySwapTableQuery, datasourceName, project,
+		topConsumersMemorySwapTableQuery, datasourceName, clusterLabelName, project,
 	)
 }
 
 // --- Time-series panels (Top Consumers Over Time) ---
 
-func TopConsumersMemoryTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersMemoryTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"Memory Usage Over Time",
 		"Top virtual machines by memory consumption over time.",
 		commonSdk.Format{Unit: &dashboards.BytesUnit},
-		topConsumersMemoryTimeSeriesQuery, datasourceName,
+		topConsumersMemoryTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersCPUTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersCPUTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"CPU Usage Over Time",
 		"Top virtual machines by CPU usage in cores over time. A value of 2.0 means two full CPU cores are in use.",
 		commonSdk.Format{Unit: &dashboards.DecimalUnit},
-		topConsumersCPUTimeSeriesQuery, datasourceName,
+		topConsumersCPUTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersStorageTrafficTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersStorageTrafficTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"Storage Traffic Over Time",
 		"Top virtual machines by storage traffic (read + write) over time.",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersStorageTrafficTimeSeriesQuery, datasourceName,
+		topConsumersStorageTrafficTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersStorageIOPSTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersStorageIOPSTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"Storage IOPS Over Time",
 		"Top virtual machines by storage IOPS (read + write) over time.",
 		commonSdk.Format{Unit: &opsPerSecUnit},
-		topConsumersStorageIOPSTimeSeriesQuery, datasourceName,
+		topConsumersStorageIOPSTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersNetworkTrafficTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersNetworkTrafficTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"Network Traffic Over Time",
 		"Top virtual machines by network traffic (receive + transmit) over time.",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersNetworkTrafficTimeSeriesQuery, datasourceName,
+		topConsumersNetworkTrafficTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersVCPUWaitTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersVCPUWaitTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"vCPU Wait Over Time",
 		"Top virtual machines by vCPU wait time over time, indicating CPU contention.",
 		commonSdk.Format{Unit: &dashboards.SecondsUnit},
-		topConsumersVCPUWaitTimeSeriesQuery, datasourceName,
+		topConsumersVCPUWaitTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
 
-func TopConsumersMemorySwapTimeSeries(datasourceName string) panelgroup.Option {
+func TopConsumersMemorySwapTimeSeries(datasourceName, clusterLabelName string) panelgroup.Option {
 	return buildTopConsumersTimeSeries(
 		"Memory Swap Traffic Over Time",
 		"Top virtual machines by memory swap traffic (in + out) over time. High swap activity may indicate memory pressure.",
 		commonSdk.Format{Unit: &decBytesPerSecUnit},
-		topConsumersMemorySwapTimeSeriesQuery, datasourceName,
+		topConsumersMemorySwapTimeSeriesQuery, datasourceName, clusterLabelName,
 	)
 }
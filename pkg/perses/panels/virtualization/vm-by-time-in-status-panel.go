@@ -10,7 +10,7 @@ import (
 	tablePanel "github.com/perses/plugins/table/sdk/go"
 )
 
-func TotalAllocatedCPU(datasourceName string) panelgroup.Option {
+func TotalAllocatedCPU(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Allocated CPU",
 		panel.Description("The total CPUs of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -22,13 +22,13 @@ func TotalAllocatedCPU(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTotalAllocatedCPUStatQuery,
+			WithClusterLabel(vmByTimeInStatusTotalAllocatedCPUStatQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalAllocatedMemory(datasourceName string) panelgroup.Option {
+func TotalAllocatedMemory(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Allocated Memory",
 		panel.Description("The total Memory of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -40,13 +40,13 @@ func TotalAllocatedMemory(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTotalAllocatedMemoryStatQuery,
+			WithClusterLabel(vmByTimeInStatusTotalAllocatedMemoryStatQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalAllocatedDisk(datasourceName string) panelgroup.Option {
+func TotalAllocatedDisk(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Allocated Disk",
 		panel.Description("The total disk size of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -58,18 +58,18 @@ func TotalAllocatedDisk(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTotalAllocatedDiskStatQuery,
+			WithClusterLabel(vmByTimeInStatusTotalAllocatedDiskStatQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TimeInStatusTable(datasourceName, project string) panelgroup.Option {
+func TimeInStatusTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Machines List by Time In Status",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
 				{Name: "timestamp", Hide: true},
-				{Name: "cluster", Header: "Cluster", EnableSorting: true},
+				{Name: clusterLabel(clusterLabelName), Header: "Cluster", EnableSorting: true},
 				{Name: "clusterID", Hide: true},
 				{Name: "container", Hide: true},
 				{Name: "endpoint", Hide: true},
@@ -115,32 +115,32 @@ func TimeInStatusTable(datasourceName, project string) panelgroup.Option {
 			}),
 			tablePanel.Transform([]commonSdk.Transform{
 				{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
-				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{"cluster", "name", "namespace"}}},
+				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{clusterLabel(clusterLabelName), "name", "namespace"}}},
 			}),
 		),
-		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(project))),
+		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(clusterLabelName, project))),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableDiskQuery,
+			WithClusterLabel(vmByTimeInStatusTableDiskQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableTimeInStatusQuery,
+			WithClusterLabel(vmByTimeInStatusTableTimeInStatusQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableTimeSinceLastMigrationQuery,
+			WithClusterLabel(vmByTimeInStatusTableTimeSinceLastMigrationQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableMigrationEndMsQuery,
+			WithClusterLabel(vmByTimeInStatusTableMigrationEndMsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableMemoryQuery,
+			WithClusterLabel(vmByTimeInStatusTableMemoryQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			vmByTimeInStatusTableCPUQuery,
+			WithClusterLabel(vmByTimeInStatusTableCPUQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -9,14 +9,14 @@ import (
 	"github.com/perses/plugins/prometheus/sdk/go/query"
 )
 
-func VMInventoryTable(datasourceName, project string) panelgroup.Option {
+func VMInventoryTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Machines Inventory",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "Table",
 			Spec: map[string]any{
 				"columnSettings": []any{
 					map[string]any{"name": "timestamp", "hide": true},
-					map[string]any{"name": "cluster", "header": "Cluster", "enableSorting": true},
+					map[string]any{"name": clusterLabel(clusterLabelName), "header": "Cluster", "enableSorting": true},
 					map[string]any{"name": "namespace", "header": "Namespace", "enableSorting": true},
 					map[string]any{"name": "name", "header": "VM Name", "enableSorting": true},
 					map[string]any{"name": "status", "header": "Status", "enableSorting": true},
@@ -81,11 +81,11 @@ func VMInventoryTable(datasourceName, project string) panelgroup.Option {
 				},
 				"transforms": []any{
 					map[string]any{"kind": string(commonSdk.MergeSeriesKind), "spec": map[string]any{}},
-					map[string]any{"kind": string(commonSdk.JoinByColumValueKind), "spec": map[string]any{"columns": []string{"cluster", "name", "namespace"}}},
+					map[string]any{"kind": string(commonSdk.JoinByColumValueKind), "spec": map[string]any{"columns": []string{clusterLabel(clusterLabelName), "name", "namespace"}}},
 				},
 			},
 		}),
-		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(project))),
+		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(clusterLabelName, project))),
 		// Column "value #N" → query N mapping (positional; keep in sync with columns above):
 		//   value #1  → (hidden) Query 1: VM info series value
 		//   value #2  → CPU            Query 2: inventoryCPUQuery
@@ -102,67 +102,67 @@ func VMInventoryTable(datasourceName, project string) panelgroup.Option {
 		//   value #13 → (hidden)       Query 13: inventoryLiveMigratableQuery (label column: live_migratable)
 		// Query 1: VM info with status
 		panel.AddQuery(query.PromQL(
-			inventoryInfoQuery,
+			WithClusterLabel(inventoryInfoQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 2: CPU (cores * sockets * threads)
 		panel.AddQuery(query.PromQL(
-			inventoryCPUQuery,
+			WithClusterLabel(inventoryCPUQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 3: Memory
 		panel.AddQuery(query.PromQL(
-			inventoryMemoryQuery,
+			WithClusterLabel(inventoryMemoryQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 4: Guest Agent indicator
 		panel.AddQuery(query.PromQL(
-			inventoryGuestAgentQuery,
+			WithClusterLabel(inventoryGuestAgentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 5: Create Date
 		panel.AddQuery(query.PromQL(
-			inventoryCreateDateQuery,
+			WithClusterLabel(inventoryCreateDateQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 6: Disk
 		panel.AddQuery(query.PromQL(
-			inventoryDiskQuery,
+			WithClusterLabel(inventoryDiskQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 7: Flavor
 		panel.AddQuery(query.PromQL(
-			inventoryFlavorQuery,
+			WithClusterLabel(inventoryFlavorQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 8: Workload
 		panel.AddQuery(query.PromQL(
-			inventoryWorkloadQuery,
+			WithClusterLabel(inventoryWorkloadQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 9: Instance Type
 		panel.AddQuery(query.PromQL(
-			inventoryInstanceTypeQuery,
+			WithClusterLabel(inventoryInstanceTypeQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 10: Preference
 		panel.AddQuery(query.PromQL(
-			inventoryPreferenceQuery,
+			WithClusterLabel(inventoryPreferenceQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 11: Guest OS info
 		panel.AddQuery(query.PromQL(
-			inventoryGuestOSQuery,
+			WithClusterLabel(inventoryGuestOSQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 12: Evictable/Outdated
 		panel.AddQuery(query.PromQL(
-			inventoryEvictableOutdatedQuery,
+			WithClusterLabel(inventoryEvictableOutdatedQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		// Query 13: Live Migratable (derived from kubevirt_vmi_non_evictable)
 		panel.AddQuery(query.PromQL(
-			inventoryLiveMigratableQuery,
+			WithClusterLabel(inventoryLiveMigratableQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
@@ -16,7 +16,7 @@ var (
 	hoursFormatUnit         = string(commonSdk.HoursUnit)
 )
 
-func TotalUptimePercent(datasourceName string) panelgroup.Option {
+func TotalUptimePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Uptime %",
 		panel.Description("The total uptime % of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -28,13 +28,13 @@ func TotalUptimePercent(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelUptimePercentQuery,
+			WithClusterLabel(serviceLevelUptimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalPlannedDowntimePercent(datasourceName string) panelgroup.Option {
+func TotalPlannedDowntimePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Planned Downtime %",
 		panel.Description("The total planned downtime % of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -46,13 +46,13 @@ func TotalPlannedDowntimePercent(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelPlannedDowntimePercentQuery,
+			WithClusterLabel(serviceLevelPlannedDowntimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalUnplannedDowntimePercent(datasourceName string) panelgroup.Option {
+func TotalUnplannedDowntimePercent(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Unplanned Downtime %",
 		panel.Description("The total unplanned downtime % of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -64,13 +64,13 @@ func TotalUnplannedDowntimePercent(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelUnplannedDowntimePercentQuery,
+			WithClusterLabel(serviceLevelUnplannedDowntimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalUptimeHours(datasourceName string) panelgroup.Option {
+func TotalUptimeHours(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Uptime (Hours)",
 		panel.Description("The total uptime hours of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -82,13 +82,13 @@ func TotalUptimeHours(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelUptimeHoursQuery,
+			WithClusterLabel(serviceLevelUptimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalPlannedDowntimeHours(datasourceName string) panelgroup.Option {
+func TotalPlannedDowntimeHours(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Planned Downtime (Hours)",
 		panel.Description("The total planned downtime hours of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -100,13 +100,13 @@ func TotalPlannedDowntimeHours(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelPlannedDowntimeHoursQuery,
+			WithClusterLabel(serviceLevelPlannedDowntimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func TotalUnplannedDowntimeHours(datasourceName string) panelgroup.Option {
+func TotalUnplannedDowntimeHours(datasourceName, clusterLabelName string) panelgroup.Option {
 	return panelgroup.AddPanel("Total Unplanned Downtime (Hours)",
 		panel.Description("The total unplanned downtime hours of the VMs that are listed in the dashboard"),
 		statPanel.Chart(
@@ -118,18 +118,18 @@ func TotalUnplannedDowntimeHours(datasourceName string) panelgroup.Option {
 		),
 		mergePluginSpecFields(map[string]any{"colorMode": "none"}),
 		panel.AddQuery(query.PromQL(
-			serviceLevelUnplannedDowntimeHoursQuery,
+			WithClusterLabel(serviceLevelUnplannedDowntimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
 }
 
-func ServiceLevelTable(datasourceName, project string) panelgroup.Option {
+func ServiceLevelTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Machines Service Level Summary",
 		tablePanel.Table(
 			tablePanel.WithColumnSettings([]tablePanel.ColumnSettings{
 				{Name: "timestamp", Hide: true},
-				{Name: "cluster", Header: "Cluster", EnableSorting: true},
+				{Name: clusterLabel(clusterLabelName), Header: "Cluster", EnableSorting: true},
 				{Name: "namespace", Header: "Namespace", EnableSorting: true},
 				{Name: "name", Header: "VM Name", EnableSorting: true},
 				{Name: "status_group", Header: "Status", EnableSorting: true},
@@ -181,44 +181,44 @@ func ServiceLevelTable(datasourceName, project string) panelgroup.Option {
 			}),
 			tablePanel.Transform([]commonSdk.Transform{
 				{Kind: commonSdk.MergeSeriesKind, Spec: commonSdk.MergeSeriesSpec{}},
-				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{"cluster", "name", "namespace"}}},
+				{Kind: commonSdk.JoinByColumValueKind, Spec: commonSdk.JoinByColumnValueSpec{Columns: []string{clusterLabel(clusterLabelName), "name", "namespace"}}},
 			}),
 		),
-		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(project))),
+		addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(clusterLabelName, project))),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableVMInfoQuery,
+			WithClusterLabel(serviceLevelTableVMInfoQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTablePlannedDowntimeHoursQuery,
+			WithClusterLabel(serviceLevelTablePlannedDowntimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTablePlannedDowntimePercentQuery,
+			WithClusterLabel(serviceLevelTablePlannedDowntimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableUnplannedDowntimeHoursQuery,
+			WithClusterLabel(serviceLevelTableUnplannedDowntimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableUnplannedDowntimePercentQuery,
+			WithClusterLabel(serviceLevelTableUnplannedDowntimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableCreateDateQuery,
+			WithClusterLabel(serviceLevelTableCreateDateQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableUptimeHoursQuery,
+			WithClusterLabel(serviceLevelTableUptimeHoursQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableUptimePercentQuery,
+			WithClusterLabel(serviceLevelTableUptimePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			serviceLevelTableErrorStatusQuery,
+			WithClusterLabel(serviceLevelTableErrorStatusQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)
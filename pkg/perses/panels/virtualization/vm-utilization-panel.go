@@ -11,8 +11,8 @@ import (
 	"github.com/perses/plugins/prometheus/sdk/go/query"
 )
 
-func utilizationVMNameDataLink(project string) panel.Option {
-	return addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(project)))
+func utilizationVMNameDataLink(clusterLabelName, project string) panel.Option {
+	return addColumnDataLink("name", tableDataLink("Virtual Machine Details", vmDetailsDashboardLinkByValueURL(clusterLabelName, project)))
 }
 
 func addColumnDataLink(columnName string, dataLink map[string]any) panel.Option {
@@ -45,14 +45,14 @@ func addColumnDataLink(columnName string, dataLink map[string]any) panel.Option
 	}
 }
 
-func VMUtilizationTable(datasourceName, project string) panelgroup.Option {
+func VMUtilizationTable(datasourceName, clusterLabelName, project string) panelgroup.Option {
 	return panelgroup.AddPanel("Virtual Machines Utilization",
 		panel.Plugin(apicommon.Plugin{
 			Kind: "Table",
 			Spec: map[string]any{
 				"columnSettings": []any{
 					map[string]any{"name": "timestamp", "hide": true},
-					map[string]any{"name": "cluster", "header": "Cluster", "enableSorting": true},
+					map[string]any{"name": clusterLabel(clusterLabelName), "header": "Cluster", "enableSorting": true},
 					map[string]any{"name": "namespace", "header": "Namespace", "enableSorting": true},
 					map[string]any{"name": "name", "header": "VM Name", "enableSorting": true},
 					map[string]any{"name": "status", "header": "Status", "enableSorting": true},
@@ -104,81 +104,81 @@ func VMUtilizationTable(datasourceName, project string) panelgroup.Option {
 				},
 				"transforms": []any{
 					map[string]any{"kind": string(commonSdk.MergeSeriesKind), "spec": map[string]any{}},
-					map[string]any{"kind": string(commonSdk.JoinByColumValueKind), "spec": map[string]any{"columns": []string{"cluster", "name", "namespace"}}},
+					map[string]any{"kind": string(commonSdk.JoinByColumValueKind), "spec": map[string]any{"columns": []string{clusterLabel(clusterLabelName), "name", "namespace"}}},
 				},
 			},
 		}),
-		utilizationVMNameDataLink(project),
+		utilizationVMNameDataLink(clusterLabelName, project),
 		panel.AddQuery(query.PromQL(
-			utilizationInfoQuery,
+			WithClusterLabel(utilizationInfoQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationCPUUsageQuery,
+			WithClusterLabel(utilizationCPUUsageQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationAllocatedVCPUQuery,
+			WithClusterLabel(utilizationAllocatedVCPUQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationCPUUsagePercentQuery,
+			WithClusterLabel(utilizationCPUUsagePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationMemoryUsageQuery,
+			WithClusterLabel(utilizationMemoryUsageQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationAllocatedMemoryQuery,
+			WithClusterLabel(utilizationAllocatedMemoryQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationMemoryUsagePercentQuery,
+			WithClusterLabel(utilizationMemoryUsagePercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationNetworkTrafficQuery,
+			WithClusterLabel(utilizationNetworkTrafficQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationStorageTrafficQuery,
+			WithClusterLabel(utilizationStorageTrafficQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationStorageIOPsQuery,
+			WithClusterLabel(utilizationStorageIOPsQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationCPUDelayQuery,
+			WithClusterLabel(utilizationCPUDelayQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationCPUDelayPercentQuery,
+			WithClusterLabel(utilizationCPUDelayPercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationCPUIOWaitQuery,
+			WithClusterLabel(utilizationCPUIOWaitQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationMemorySwapQuery,
+			WithClusterLabel(utilizationMemorySwapQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationFSCapacityQuery,
+			WithClusterLabel(utilizationFSCapacityQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationFSUsageQuery,
+			WithClusterLabel(utilizationFSUsageQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationFSUsageMaxPercentQuery,
+			WithClusterLabel(utilizationFSUsageMaxPercentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 		panel.AddQuery(query.PromQL(
-			utilizationGuestAgentQuery,
+			WithClusterLabel(utilizationGuestAgentQuery, clusterLabelName),
 			dashboards.AddQueryDataSource(datasourceName),
 		)),
 	)